@@ -2,40 +2,102 @@ package main
 
 import (
     "log"
+    "log/slog"
+    "os"
 
     "github.com/labstack/echo/v4"
     "github.com/labstack/echo/v4/middleware"
+    "estimate-backend/internal/infrastructure/memory"
+    "estimate-backend/internal/infrastructure/notifier"
     "estimate-backend/internal/interface/controller"
     "estimate-backend/internal/usecase"
-    // TODO: Add repository implementations
 )
 
 func main() {
+    logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
     // Initialize Echo
     e := echo.New()
+    // The API is served without a proxy, so X-Forwarded-For and X-Real-IP are not trusted for the client IP
+    e.IPExtractor = echo.ExtractIPDirect()
 
     // Middleware
-    e.Use(middleware.Logger())
+    e.Use(controller.RequestID())
+    e.Use(controller.RequestLogger(logger))
     e.Use(middleware.Recover())
-    e.Use(middleware.CORS())
+    e.Use(middleware.CORSWithConfig(controller.CORSConfigFromEnv()))
+    e.Use(controller.RateLimit(controller.RateLimitConfigFromEnv()))
+    e.Use(controller.ValidationErrorHandler())
+    e.Validator = controller.NewRequestValidator()
 
-    // TODO: Initialize repositories
-    // For now, we'll use mock repositories
+    // Initialize repositories
+    // For now, we'll use in-memory repositories
+    processRepo := memory.NewInMemoryProcessRepository()
+    cocomoRepo := memory.NewInMemoryCOCOMORepository()
+    estimateRepo := memory.NewInMemoryEstimateRepository()
+    factorRepo := memory.NewInMemoryFactorRepository()
+    subscriptionRepo := memory.NewInMemorySubscriptionRepository()
+    taskRepo := memory.NewInMemoryTaskRepository()
+    auditRepo := memory.NewInMemoryAuditRepository()
+    templateRepo := memory.NewInMemoryTemplateRepository()
 
     // Initialize use cases
-    processUseCase := usecase.NewProcessUseCase(nil) // TODO: Add process repository
-    estimateUseCase := usecase.NewEstimateUseCase(nil, nil, nil, nil, nil) // TODO: Add repositories
-    cocomoUseCase := usecase.NewCOCOMOUseCase(nil) // TODO: Add COCOMO repository
+    configUseCase := usecase.NewConfigUseCase()
+    auditUseCase := usecase.NewAuditUseCase(auditRepo)
+    processUseCase := usecase.NewProcessUseCase(processRepo)
+    processUseCase.SetAuditLog(auditUseCase)
+    factorUseCase := usecase.NewFactorUseCase(factorRepo)
+    factorUseCase.SetAuditLog(auditUseCase)
+    taskUseCase := usecase.NewTaskUseCase(taskRepo, processRepo, factorRepo)
+    estimateUseCase := usecase.NewEstimateUseCase(estimateRepo, processRepo, factorRepo, taskRepo, cocomoRepo, configUseCase)
+    estimateUseCase.SetAuditLog(auditUseCase)
+    estimateUseCase.SetLogger(logger)
+    // Drift alerts are POSTed to the subscribers' webhooks in the background
+    estimateUseCase.SetDriftNotification(subscriptionRepo, notifier.NewWebhookNotifier())
+    templateUseCase := usecase.NewTemplateUseCase(templateRepo, processRepo, factorRepo, estimateUseCase)
+    cocomoUseCase := usecase.NewCOCOMOUseCase(cocomoRepo, configUseCase)
+    ucpUseCase := usecase.NewUCPUseCase(configUseCase)
+
+    // Seed the default processes, factors and COCOMO II models, scale factors and cost drivers
+    if err := processUseCase.InitializeDefaultProcesses(); err != nil {
+        log.Fatal(err)
+    }
+    if err := factorUseCase.InitializeDefaultFactors(); err != nil {
+        log.Fatal(err)
+    }
+    if err := cocomoUseCase.InitializeDefaultModel(); err != nil {
+        log.Fatal(err)
+    }
+    if err := cocomoUseCase.InitializeScaleFactors(); err != nil {
+        log.Fatal(err)
+    }
+    if err := cocomoUseCase.InitializeCostDrivers(); err != nil {
+        log.Fatal(err)
+    }
 
     // Initialize controllers
     processController := controller.NewProcessController(processUseCase)
+    factorController := controller.NewFactorController(factorUseCase)
+    taskController := controller.NewTaskController(taskUseCase)
     estimateController := controller.NewEstimateController(estimateUseCase)
+    templateController := controller.NewTemplateController(templateUseCase)
     cocomoController := controller.NewCOCOMOController(cocomoUseCase)
+    ucpController := controller.NewUCPController(ucpUseCase)
+    configController := controller.NewConfigController(configUseCase)
+    auditController := controller.NewAuditController(auditUseCase)
+    openAPIController := controller.NewOpenAPIController(e)
 
     // Register routes
     processController.RegisterRoutes(e)
+    factorController.RegisterRoutes(e)
+    taskController.RegisterRoutes(e)
     estimateController.RegisterRoutes(e)
+    templateController.RegisterRoutes(e)
     cocomoController.RegisterRoutes(e)
+    ucpController.RegisterRoutes(e)
+    configController.RegisterRoutes(e)
+    auditController.RegisterRoutes(e)
+    openAPIController.RegisterRoutes(e)
 
     // Start server
     log.Fatal(e.Start(":8080"))
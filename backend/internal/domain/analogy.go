@@ -0,0 +1,125 @@
+package domain
+
+import (
+    "math"
+    "sort"
+)
+
+// ProjectAttributes represents the characteristics used to find similar past projects
+type ProjectAttributes struct {
+    SizeKSLOC      float64
+    FunctionCount  float64 // Number of features or screens
+    Complexity     float64 // 1-5 scale
+    TeamExperience float64 // 1-5 scale
+    Integrations   float64 // Number of external system integrations
+}
+
+// values returns the attributes in the order of analogyWeights
+func (a ProjectAttributes) values() []float64 {
+    return []float64{a.SizeKSLOC, a.FunctionCount, a.Complexity, a.TeamExperience, a.Integrations}
+}
+
+// analogyWeights holds the weight of each attribute in the distance, in the order of values
+var analogyWeights = []float64{0.3, 0.25, 0.2, 0.15, 0.1}
+
+// Analog represents a past estimate selected as an analogy
+type Analog struct {
+    EstimateID  string
+    ProjectName string
+    ActualHours float64
+    Distance    float64
+    Weight      float64 // Normalized weight in the resulting estimate
+}
+
+// AnalogyEstimate represents an estimate derived from the actuals of similar past projects
+type AnalogyEstimate struct {
+    EstimatedHours float64
+    Analogs        []Analog
+}
+
+// HasActuals reports whether the estimate has recorded actual hours and can serve as an analog
+func (e *Estimate) HasActuals() bool {
+    return e.ActualHours > 0
+}
+
+// EstimateByAnalogy selects the k candidates with actuals closest to the target attributes and
+// returns the inverse-distance weighted average of their actual hours.
+// Exact matches take all the weight.
+func EstimateByAnalogy(target ProjectAttributes, candidates []*Estimate, k int) *AnalogyEstimate {
+    var completed []*Estimate
+    for _, c := range candidates {
+        if c.HasActuals() {
+            completed = append(completed, c)
+        }
+    }
+    if len(completed) == 0 || k <= 0 {
+        return &AnalogyEstimate{}
+    }
+
+    // Normalize each attribute by its range so that attributes with large units don't dominate
+    targetValues := target.values()
+    ranges := make([]float64, len(targetValues))
+    for i, tv := range targetValues {
+        low, high := tv, tv
+        for _, c := range completed {
+            v := c.Attributes.values()[i]
+            low = math.Min(low, v)
+            high = math.Max(high, v)
+        }
+        ranges[i] = high - low
+    }
+
+    analogs := make([]Analog, 0, len(completed))
+    for _, c := range completed {
+        var sum float64
+        for i, v := range c.Attributes.values() {
+            if ranges[i] == 0 {
+                continue
+            }
+            diff := (v - targetValues[i]) / ranges[i]
+            sum += analogyWeights[i] * diff * diff
+        }
+        analogs = append(analogs, Analog{
+            EstimateID:  c.ID,
+            ProjectName: c.ProjectName,
+            ActualHours: c.ActualHours,
+            Distance:    math.Sqrt(sum),
+        })
+    }
+
+    sort.SliceStable(analogs, func(i, j int) bool {
+        return analogs[i].Distance < analogs[j].Distance
+    })
+    if k < len(analogs) {
+        analogs = analogs[:k]
+    }
+
+    // Exact matches take all the weight, otherwise weight by inverse distance
+    exact := 0
+    for _, a := range analogs {
+        if a.Distance == 0 {
+            exact++
+        }
+    }
+
+    var totalWeight float64
+    for i, a := range analogs {
+        switch {
+        case exact > 0 && a.Distance == 0:
+            analogs[i].Weight = 1
+        case exact > 0:
+            analogs[i].Weight = 0
+        default:
+            analogs[i].Weight = 1 / a.Distance
+        }
+        totalWeight += analogs[i].Weight
+    }
+
+    result := &AnalogyEstimate{Analogs: analogs}
+    for i := range analogs {
+        analogs[i].Weight /= totalWeight
+        result.EstimatedHours += analogs[i].Weight * analogs[i].ActualHours
+    }
+
+    return result
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+// pastProject returns a completed estimate with the given size and actual hours
+func pastProject(id string, sizeKSLOC, actualHours float64) *Estimate {
+    return &Estimate{
+        ID:          id,
+        ActualHours: actualHours,
+        Attributes:  ProjectAttributes{SizeKSLOC: sizeKSLOC, FunctionCount: 20, Complexity: 3, TeamExperience: 3},
+    }
+}
+
+func TestEstimateByAnalogyExactMatchTakesAllWeight(t *testing.T) {
+    candidates := []*Estimate{
+        pastProject("small", 10, 800),
+        pastProject("exact", 30, 2400),
+        pastProject("large", 80, 7000),
+    }
+
+    result := EstimateByAnalogy(candidates[1].Attributes, candidates, 3)
+
+    if result.EstimatedHours != 2400 {
+        t.Errorf("EstimatedHours = %v, want the exact analog's 2400", result.EstimatedHours)
+    }
+    if result.Analogs[0].EstimateID != "exact" || result.Analogs[0].Weight != 1 {
+        t.Errorf("first analog = %+v, want exact with all the weight", result.Analogs[0])
+    }
+}
+
+func TestEstimateByAnalogySelectsKNearest(t *testing.T) {
+    candidates := []*Estimate{
+        pastProject("far", 100, 9000),
+        pastProject("near", 22, 1800),
+        pastProject("nearer", 18, 1500),
+        {ID: "no-actuals", Attributes: ProjectAttributes{SizeKSLOC: 20, FunctionCount: 20, Complexity: 3, TeamExperience: 3}},
+    }
+    target := ProjectAttributes{SizeKSLOC: 20, FunctionCount: 20, Complexity: 3, TeamExperience: 3}
+
+    result := EstimateByAnalogy(target, candidates, 2)
+
+    if len(result.Analogs) != 2 {
+        t.Fatalf("got %d analogs, want 2", len(result.Analogs))
+    }
+    for _, analog := range result.Analogs {
+        if analog.EstimateID != "near" && analog.EstimateID != "nearer" {
+            t.Errorf("analog %s selected, want the two nearest completed projects", analog.EstimateID)
+        }
+    }
+    if result.EstimatedHours < 1500 || result.EstimatedHours > 1800 {
+        t.Errorf("EstimatedHours = %v, want between the analogs' 1500 and 1800", result.EstimatedHours)
+    }
+    if sum := result.Analogs[0].Weight + result.Analogs[1].Weight; !approxEqual(sum, 1, 1e-9) {
+        t.Errorf("weights sum to %v, want 1", sum)
+    }
+}
+
+func TestEstimateByAnalogyWithoutActuals(t *testing.T) {
+    result := EstimateByAnalogy(ProjectAttributes{SizeKSLOC: 20}, []*Estimate{{ID: "open"}}, 3)
+    if len(result.Analogs) != 0 || result.EstimatedHours != 0 {
+        t.Errorf("result = %+v, want empty without completed projects", result)
+    }
+}
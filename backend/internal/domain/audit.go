@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// AuditEntityType identifies the kind of entity an audit log entry refers to
+type AuditEntityType string
+
+const (
+    AuditEntityEstimate AuditEntityType = "estimate"
+    AuditEntityFactor   AuditEntityType = "factor"
+    AuditEntityProcess  AuditEntityType = "process"
+    AuditEntityActivity AuditEntityType = "activity"
+)
+
+// AuditAction identifies the kind of change recorded by an audit log entry
+type AuditAction string
+
+const (
+    AuditActionCreated  AuditAction = "created"
+    AuditActionUpdated  AuditAction = "updated"
+    AuditActionDeleted  AuditAction = "deleted"
+    AuditActionRestored AuditAction = "restored"
+)
+
+// AuditLog records a single change to an entity: who made it, and a summary of the
+// relevant fields before and after. Before is nil for creations, After for deletions.
+type AuditLog struct {
+    ID         string
+    Actor      string
+    EntityType AuditEntityType
+    EntityID   string
+    Action     AuditAction
+    Before     map[string]interface{}
+    After      map[string]interface{}
+    Timestamp  time.Time
+}
+
+// AuditRepository defines the interface for audit log persistence.
+// Entries are append-only.
+type AuditRepository interface {
+    Save(entry *AuditLog) error
+    FindByEntityID(entityID string) ([]*AuditLog, error)
+    FindAll() ([]*AuditLog, error)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+    "errors"
+    "math"
+)
+
+// CalibrationPoint represents the size and actual effort of a completed project
+type CalibrationPoint struct {
+    Size         float64 // KSLOC
+    ActualEffort float64 // Person-months
+}
+
+// Calibration represents COCOMO coefficients fitted to completed projects
+type Calibration struct {
+    A        float64
+    B        float64 // Fitted exponent, including the scale factors of the projects
+    BaseB    float64 // Base exponent of a model reproducing the fit: B less the scale factor contributions
+    RSquared float64 // Coefficient of determination of the fit in log space
+    Points   int
+}
+
+// ErrInsufficientCalibrationData is returned when the points can't determine a fit
+var ErrInsufficientCalibrationData = errors.New("calibration needs at least two projects of different sizes with positive size and effort")
+
+// Calibrate fits PM = A * Size^B to the points by least squares regression of ln(PM) on ln(Size).
+// scaleFactors are the rated scale factors the projects shared; their contribution is taken out
+// of the fitted exponent, so a model with BaseB reproduces the fit for estimates rated the same.
+// The points should come from projects with nominal cost drivers.
+func Calibrate(points []CalibrationPoint, scaleFactors []ScaleFactor) (*Calibration, error) {
+    n := float64(len(points))
+    if len(points) < 2 {
+        return nil, ErrInsufficientCalibrationData
+    }
+
+    var sumX, sumY float64
+    for _, p := range points {
+        if p.Size <= 0 || p.ActualEffort <= 0 {
+            return nil, ErrInsufficientCalibrationData
+        }
+        sumX += math.Log(p.Size)
+        sumY += math.Log(p.ActualEffort)
+    }
+    meanX, meanY := sumX/n, sumY/n
+
+    var sxx, sxy, syy float64
+    for _, p := range points {
+        dx := math.Log(p.Size) - meanX
+        dy := math.Log(p.ActualEffort) - meanY
+        sxx += dx * dx
+        sxy += dx * dy
+        syy += dy * dy
+    }
+    if sxx == 0 {
+        return nil, ErrInsufficientCalibrationData
+    }
+
+    b := sxy / sxx
+    calibration := &Calibration{
+        A:        math.Exp(meanY - b*meanX),
+        B:        b,
+        BaseB:    b - scaleFactorExponent(scaleFactors),
+        RSquared: 1, // All points share the same effort, which the fit reproduces exactly
+        Points:   len(points),
+    }
+    if syy > 0 {
+        calibration.RSquared = sxy * sxy / (sxx * syy)
+    }
+    return calibration, nil
+}
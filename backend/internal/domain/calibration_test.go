@@ -0,0 +1,77 @@
+package domain
+
+import (
+    "errors"
+    "math"
+    "testing"
+)
+
+// syntheticPoints generates the effort of PM = a * Size^b for each size, scaled by the noise factors
+func syntheticPoints(a, b float64, sizes []float64, noise []float64) []CalibrationPoint {
+    points := make([]CalibrationPoint, len(sizes))
+    for i, size := range sizes {
+        effort := a * math.Pow(size, b)
+        if noise != nil {
+            effort *= noise[i]
+        }
+        points[i] = CalibrationPoint{Size: size, ActualEffort: effort}
+    }
+    return points
+}
+
+func TestCalibrateRecoversCoefficients(t *testing.T) {
+    points := syntheticPoints(2.94, 1.1, []float64{5, 12, 30, 75, 150}, nil)
+
+    calibration, err := Calibrate(points, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !approxEqual(calibration.A, 2.94, 1e-9) || !approxEqual(calibration.B, 1.1, 1e-9) {
+        t.Errorf("A = %v, B = %v, want 2.94 and 1.1", calibration.A, calibration.B)
+    }
+    if !approxEqual(calibration.RSquared, 1, 1e-9) || calibration.Points != 5 {
+        t.Errorf("RSquared = %v over %d points, want a perfect fit over 5", calibration.RSquared, calibration.Points)
+    }
+}
+
+func TestCalibrateWithNoise(t *testing.T) {
+    points := syntheticPoints(2.94, 1.1, []float64{5, 12, 30, 75, 150, 300}, []float64{1.05, 0.95, 1.02, 0.97, 1.04, 0.98})
+
+    calibration, err := Calibrate(points, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !approxEqual(calibration.A, 2.94, 0.3) || !approxEqual(calibration.B, 1.1, 0.03) {
+        t.Errorf("A = %v, B = %v, want about 2.94 and 1.1", calibration.A, calibration.B)
+    }
+    if calibration.RSquared >= 1 || calibration.RSquared < 0.99 {
+        t.Errorf("RSquared = %v, want a close but imperfect fit", calibration.RSquared)
+    }
+}
+
+func TestCalibrateTakesOutScaleFactors(t *testing.T) {
+    scaleFactors := nominalEstimate(0).ScaleFactors
+    points := syntheticPoints(2.94, 1.1, []float64{10, 100}, nil)
+
+    calibration, err := Calibrate(points, scaleFactors)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if want := 1.1 - scaleFactorExponent(scaleFactors); !approxEqual(calibration.BaseB, want, 1e-9) {
+        t.Errorf("BaseB = %v, want %v", calibration.BaseB, want)
+    }
+}
+
+func TestCalibrateRejectsInsufficientData(t *testing.T) {
+    tests := [][]CalibrationPoint{
+        nil,
+        {{Size: 10, ActualEffort: 30}},
+        {{Size: 10, ActualEffort: 30}, {Size: 10, ActualEffort: 40}},
+        {{Size: 10, ActualEffort: 30}, {Size: 0, ActualEffort: 40}},
+    }
+    for _, points := range tests {
+        if _, err := Calibrate(points, nil); !errors.Is(err, ErrInsufficientCalibrationData) {
+            t.Errorf("%v: got %v, want ErrInsufficientCalibrationData", points, err)
+        }
+    }
+}
@@ -1,8 +1,14 @@
 package domain
 
+import (
+    "fmt"
+    "math"
+)
+
 // COCOMOModel represents the COCOMO II estimation model configuration
 type COCOMOModel struct {
     ID          string
+    OwnerID     string // Organization the model belongs to; empty for the built-in models shared by all
     Name        string
     Description string
     // Base coefficients for effort equation: PM = A * Size^B * EM
@@ -10,6 +16,17 @@ type COCOMOModel struct {
     B           float64 // Scale factor
 }
 
+// IDs of the built-in COCOMO II models
+const (
+    ModelEarlyDesignID      = "early_design"
+    ModelPostArchitectureID = "post_architecture"
+)
+
+// VisibleTo reports whether an organization may use the model: its own models and the built-in ones
+func (m *COCOMOModel) VisibleTo(ownerID string) bool {
+    return m.OwnerID == "" || m.OwnerID == ownerID
+}
+
 // ScaleFactorType represents different types of COCOMO II scale factors
 type ScaleFactorType string
 
@@ -22,14 +39,41 @@ const (
     ScaleFactorPMAT ScaleFactorType = "process_maturity"     // プロセス成熟度
 )
 
+const (
+    // MinRating and MaxRating bound the ratings of scale factors and cost drivers (Very Low to Extra High)
+    MinRating = 0.0
+    MaxRating = 5.0
+)
+
+// IsValidRating reports whether a scale factor or cost driver rating is within range
+func IsValidRating(rating float64) bool {
+    return rating >= MinRating && rating <= MaxRating
+}
+
 // ScaleFactor represents a COCOMO II scale factor
 type ScaleFactor struct {
     ID          string
     Type        ScaleFactorType
     Name        string
     Description string
-    Rating      float64 // Very Low (0) to Extra High (5)
-    Weight      float64 // Impact on the exponential scale factor
+    Rating      float64 // Very Low (0) to Extra High (5), a higher rating lowers the exponent
+    Weight      float64 // Scale factor value of one rating step below Extra High
+}
+
+// scaleFactorExponentScale converts the sum of the scale factor values into the exponent: B = B0 + 0.01 * Σ SF
+const scaleFactorExponentScale = 0.01
+
+// ExponentContribution returns what the scale factor at its rating adds to the effort exponent B.
+// As in COCOMO II the value falls with the rating to 0 at Extra High, so a better rated factor
+// means less effort: SF = Weight * (5 - Rating).
+func (sf *ScaleFactor) ExponentContribution() float64 {
+    return scaleFactorExponentScale * sf.Weight * (MaxRating - sf.Rating)
+}
+
+// effortMultiplier returns the factor the scale factor's exponent contribution multiplies
+// the effort by at the given size, comparable to a cost driver's effort multiplier
+func (sf *ScaleFactor) effortMultiplier(size float64) float64 {
+    return pow(size, sf.ExponentContribution())
 }
 
 // CostDriverType represents different types of COCOMO II cost drivers
@@ -70,6 +114,32 @@ type CostDriver struct {
     Description string
     Rating      float64 // Very Low (0) to Extra High (5)
     Value       float64 // Effort multiplier value
+    RatingValues []float64 // Optional effort multipliers per rating level, Very Low to Extra High
+}
+
+// ValueAt returns the effort multiplier at a rating, interpolating between rating levels.
+// Without rating values the driver keeps its fixed Value.
+func (cd *CostDriver) ValueAt(rating float64) float64 {
+    if len(cd.RatingValues) == 0 {
+        return cd.Value
+    }
+    if rating <= 0 {
+        return cd.RatingValues[0]
+    }
+    last := len(cd.RatingValues) - 1
+    if rating >= float64(last) {
+        return cd.RatingValues[last]
+    }
+
+    lower := int(rating)
+    fraction := rating - float64(lower)
+    return cd.RatingValues[lower] + (cd.RatingValues[lower+1]-cd.RatingValues[lower])*fraction
+}
+
+// SetRating sets the rating and the effort multiplier that goes with it
+func (cd *CostDriver) SetRating(rating float64) {
+    cd.Rating = rating
+    cd.Value = cd.ValueAt(rating)
 }
 
 // COCOMOEstimate represents a COCOMO II based estimation
@@ -79,6 +149,12 @@ type COCOMOEstimate struct {
     Model        *COCOMOModel
     ScaleFactors []ScaleFactor
     CostDrivers  []CostDriver
+    ReuseComponents []ReuseComponent // Adapted or reused code added to the size
+    REVL         float64       // Requirements evolution and volatility in percent, inflates the size
+    TargetDurationTM float64   // Optional requested schedule in months, checked against the schedule floor
+    PlannedTeamSize  float64   // Optional staffing plan, see TeamOverheadConfig
+    TeamOverhead     float64   // Effort multiplier for the communication overhead of the planned team; 0 means none
+    Uncertainty  *MonteCarloDistribution // Optional distributions for RunMonteCarlo
     // Calculated values
     ExponentB    float64  // Calculated from scale factors
     EffortPM     float64  // Person-Months
@@ -88,48 +164,219 @@ type COCOMOEstimate struct {
 
 // CalculateEffort calculates the effort in person-months using COCOMO II
 func (e *COCOMOEstimate) CalculateEffort() {
+    e.calculate(nil)
+}
+
+// calculate performs the COCOMO II calculation, recording its steps in trace unless it is nil
+func (e *COCOMOEstimate) calculate(trace *CalculationTrace) {
     // Calculate the exponential scale factor (B)
-    e.ExponentB = e.Model.B
-    for _, sf := range e.ScaleFactors {
-        e.ExponentB += sf.Weight * sf.Rating
-    }
+    e.ExponentB = exponentB(e.Model, e.ScaleFactors)
 
     // Calculate the effort multiplier (EM)
+    em := effortMultiplier(e.CostDrivers)
+    overhead := e.teamOverhead()
+
+    // Calculate effort: PM = A * Size^B * EM * team overhead
+    e.EffortPM = e.Model.A * pow(e.EffectiveSize(), e.ExponentB) * em * overhead
+
+    // Calculate duration: TDEV = C * (PM)^D
+    e.DurationTM = nominalDuration(e.EffortPM, e.ExponentB)
+    nominalDurationTM := e.DurationTM
+
+    e.applyScheduleConstraint(em * overhead)
+
+    // Calculate average team size
+    e.TeamSize = averageStaff(e.EffortPM, e.DurationTM)
+
+    if trace != nil {
+        trace.record(e, em, nominalDurationTM)
+    }
+}
+
+// teamOverhead returns the team overhead multiplier, 1.0 when the estimate has none
+func (e *COCOMOEstimate) teamOverhead() float64 {
+    if e.TeamOverhead <= 0 {
+        return 1.0
+    }
+    return e.TeamOverhead
+}
+
+// exponentB calculates the exponential scale factor from the model's base exponent and the scale factor ratings
+func exponentB(model *COCOMOModel, scaleFactors []ScaleFactor) float64 {
+    return model.B + scaleFactorExponent(scaleFactors)
+}
+
+// scaleFactorExponent sums the exponent contributions of the scale factors
+func scaleFactorExponent(scaleFactors []ScaleFactor) float64 {
+    sum := 0.0
+    for i := range scaleFactors {
+        sum += scaleFactors[i].ExponentContribution()
+    }
+    return sum
+}
+
+// effortMultiplier calculates the product of the cost driver values
+func effortMultiplier(costDrivers []CostDriver) float64 {
     em := 1.0
-    for _, cd := range e.CostDrivers {
+    for _, cd := range costDrivers {
         em *= cd.Value
     }
+    return em
+}
 
-    // Calculate effort: PM = A * Size^B * EM
-    e.EffortPM = e.Model.A * pow(e.ProjectSize, e.ExponentB) * em
+const (
+    // MinSchedulePercent is the strongest schedule compression COCOMO II allows (75% of nominal)
+    MinSchedulePercent = 0.75
+)
 
-    // Calculate duration: TDEV = C * (PM)^D
-    // where C and D are empirically derived constants
-    c := 3.67
-    d := 0.28 + 0.2 * (e.ExponentB - 1.01)
-    e.DurationTM = c * pow(e.EffortPM, d)
+// scheduleByRating maps SCED ratings (Very Low, Low, Nominal) to the required schedule as a ratio of nominal
+var scheduleByRating = []float64{0.75, 0.85, 1.0}
 
-    // Calculate average team size
-    e.TeamSize = e.EffortPM / e.DurationTM
+// SchedulePercent returns the required schedule as a ratio of the nominal schedule,
+// derived from the SCED rating. Ratings at or above nominal return 1.0.
+func (e *COCOMOEstimate) SchedulePercent() float64 {
+    for _, cd := range e.CostDrivers {
+        if cd.Type != CostDriverSCED {
+            continue
+        }
+        if cd.Rating <= 0 {
+            return scheduleByRating[0]
+        }
+        if cd.Rating >= float64(len(scheduleByRating)-1) {
+            return 1.0
+        }
+
+        // Interpolate between the neighbouring rating levels
+        lower := int(cd.Rating)
+        fraction := cd.Rating - float64(lower)
+        return scheduleByRating[lower] + (scheduleByRating[lower+1]-scheduleByRating[lower])*fraction
+    }
+    return 1.0
 }
 
-// Helper function for power calculation
-func pow(base, exp float64) float64 {
-    result := 1.0
-    for i := 0; i < int(exp); i++ {
-        result *= base
+// applyScheduleConstraint compresses the duration when SCED requires a schedule shorter than nominal.
+// COCOMO II computes TDEV from the effort without SCED and scales it by SCED%, bounded at 75%.
+// The SCED multiplier itself stays in the effort, so the same work is redistributed over a
+// shorter calendar with a larger team. Schedule expansion (SCED above nominal) is not applied.
+func (e *COCOMOEstimate) applyScheduleConstraint(em float64) {
+    percent := e.SchedulePercent()
+    if percent >= 1.0 {
+        return
     }
-    return result
+    if percent < MinSchedulePercent {
+        percent = MinSchedulePercent
+    }
+
+    // Effort with a nominal schedule (excluding the SCED multiplier)
+    nominalEM := withoutSCED(em, e.CostDrivers)
+    nominalEffort := e.Model.A * pow(e.EffectiveSize(), e.ExponentB) * nominalEM
+
+    e.DurationTM = nominalDuration(nominalEffort, e.ExponentB) * percent
+}
+
+// withoutSCED removes the SCED multiplier from a product of effort multipliers
+func withoutSCED(em float64, costDrivers []CostDriver) float64 {
+    for _, cd := range costDrivers {
+        if cd.Type == CostDriverSCED && cd.Value > 0 {
+            em /= cd.Value
+        }
+    }
+    return em
+}
+
+// durationCoefficient is the C of the COCOMO II schedule equation TDEV = C * PM^D
+const durationCoefficient = 3.67
+
+// durationExponent returns the D of the schedule equation, derived from the effort exponent
+func durationExponent(exponentB float64) float64 {
+    return 0.28 + 0.2*(exponentB-1.01)
+}
+
+// nominalDuration calculates the uncompressed schedule in months for an effort in person-months
+func nominalDuration(effortPM, exponentB float64) float64 {
+    return durationCoefficient * pow(effortPM, durationExponent(exponentB))
+}
+
+// ReuseComponent represents adapted or reused code in the COCOMO II reuse model
+type ReuseComponent struct {
+    Name        string
+    AdaptedSize float64 // Size of the adapted code, same unit as ProjectSize
+    DM          float64 // Percent of design modified (0-100)
+    CM          float64 // Percent of code modified (0-100)
+    IM          float64 // Percent of integration and test effort required (0-100)
+    AA          float64 // Assessment and assimilation increment (0-8)
+    SU          float64 // Software understanding increment (10-50)
+    UNFM        float64 // Programmer unfamiliarity with the software (0-1)
+}
+
+// Validate checks that the size is not negative and each adaptation parameter is within its range
+func (r ReuseComponent) Validate() error {
+    if r.AdaptedSize < 0 {
+        return fmt.Errorf("adapted size of reuse component %q must not be negative", r.Name)
+    }
+    for _, param := range []struct {
+        name      string
+        value     float64
+        low, high float64
+    }{
+        {"DM", r.DM, 0, 100},
+        {"CM", r.CM, 0, 100},
+        {"IM", r.IM, 0, 100},
+        {"AA", r.AA, 0, 8},
+        {"SU", r.SU, 10, 50},
+        {"UNFM", r.UNFM, 0, 1},
+    } {
+        if !(param.value >= param.low && param.value <= param.high) {
+            return fmt.Errorf("%s of reuse component %q must be between %g and %g", param.name, r.Name, param.low, param.high)
+        }
+    }
+    return nil
+}
+
+// EquivalentSLOC calculates the equivalent new size of the adapted code
+func (r *ReuseComponent) EquivalentSLOC() float64 {
+    // Adaptation adjustment factor
+    aaf := 0.4*r.DM + 0.3*r.CM + 0.3*r.IM
+
+    // Adaptation adjustment modifier
+    var aam float64
+    if aaf <= 50 {
+        aam = (r.AA + aaf*(1+0.02*r.SU*r.UNFM)) / 100
+    } else {
+        aam = (r.AA + aaf + r.SU*r.UNFM) / 100
+    }
+
+    return r.AdaptedSize * aam
+}
+
+// EffectiveSize returns the new size plus the equivalent size of all reused components,
+// adjusted for requirements volatility: Size = (New + Equivalent) * (1 + REVL/100)
+func (e *COCOMOEstimate) EffectiveSize() float64 {
+    size := e.ProjectSize
+    for _, rc := range e.ReuseComponents {
+        size += rc.EquivalentSLOC()
+    }
+    return size * (1 + e.REVL/100)
+}
+
+// Helper function for power calculation; exponents are fractional, so it must not truncate them
+func pow(base, exp float64) float64 {
+    return math.Pow(base, exp)
 }
 
 // COCOMORepository defines the interface for COCOMO II model persistence
 type COCOMORepository interface {
     SaveModel(model *COCOMOModel) error
     FindModelByID(id string) (*COCOMOModel, error)
+    FindModelsByOwner(ownerID string) ([]*COCOMOModel, error) // The owner's models and the built-in ones
     SaveEstimate(estimate *COCOMOEstimate) error
     FindEstimateByID(id string) (*COCOMOEstimate, error)
+    FindAllEstimates() ([]*COCOMOEstimate, error)
+    DeleteEstimate(id string) error
     SaveScaleFactor(factor *ScaleFactor) error
     FindScaleFactorByID(id string) (*ScaleFactor, error)
+    FindAllScaleFactors() ([]*ScaleFactor, error)
     SaveCostDriver(driver *CostDriver) error
     FindCostDriverByID(id string) (*CostDriver, error)
+    FindAllCostDrivers() ([]*CostDriver, error)
 }
\ No newline at end of file
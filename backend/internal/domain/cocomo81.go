@@ -0,0 +1,221 @@
+package domain
+
+import "math"
+
+// COCOMO81Level represents the level of detail of the original COCOMO model
+type COCOMO81Level string
+
+const (
+    COCOMO81Basic        COCOMO81Level = "basic"        // Size only
+    COCOMO81Intermediate COCOMO81Level = "intermediate" // Size and the 15 cost drivers
+    COCOMO81Detailed     COCOMO81Level = "detailed"     // Intermediate with effort multipliers per phase
+)
+
+// COCOMO81Mode represents the development mode of the original COCOMO model
+type COCOMO81Mode string
+
+const (
+    COCOMO81Organic      COCOMO81Mode = "organic"      // Small team, familiar environment
+    COCOMO81Semidetached COCOMO81Mode = "semidetached" // Mixed experience and constraints
+    COCOMO81Embedded     COCOMO81Mode = "embedded"     // Tight hardware, software and operational constraints
+)
+
+// COCOMO81Model holds the coefficients of the original COCOMO equations:
+// PM = A * KDSI^B * EAF and TDEV = C * PM^D
+type COCOMO81Model struct {
+    Level COCOMO81Level
+    Mode  COCOMO81Mode
+    A     float64
+    B     float64
+    C     float64
+    D     float64
+}
+
+// cocomo81Coefficients holds A and B per level and mode; C and D only depend on the mode.
+// Detailed COCOMO uses the intermediate coefficients.
+var cocomo81Coefficients = map[COCOMO81Level]map[COCOMO81Mode][2]float64{
+    COCOMO81Basic: {
+        COCOMO81Organic:      {2.4, 1.05},
+        COCOMO81Semidetached: {3.0, 1.12},
+        COCOMO81Embedded:     {3.6, 1.20},
+    },
+    COCOMO81Intermediate: {
+        COCOMO81Organic:      {3.2, 1.05},
+        COCOMO81Semidetached: {3.0, 1.12},
+        COCOMO81Embedded:     {2.8, 1.20},
+    },
+}
+
+var cocomo81Schedule = map[COCOMO81Mode][2]float64{
+    COCOMO81Organic:      {2.5, 0.38},
+    COCOMO81Semidetached: {2.5, 0.35},
+    COCOMO81Embedded:     {2.5, 0.32},
+}
+
+// COCOMO81ModelFor returns the coefficients of a level and mode
+func COCOMO81ModelFor(level COCOMO81Level, mode COCOMO81Mode) (COCOMO81Model, bool) {
+    coefficientLevel := level
+    if level == COCOMO81Detailed {
+        coefficientLevel = COCOMO81Intermediate
+    }
+    ab, ok := cocomo81Coefficients[coefficientLevel][mode]
+    if !ok {
+        return COCOMO81Model{}, false
+    }
+    cd := cocomo81Schedule[mode]
+    return COCOMO81Model{Level: level, Mode: mode, A: ab[0], B: ab[1], C: cd[0], D: cd[1]}, true
+}
+
+// cocomo81Multipliers holds the effort multipliers of the intermediate cost drivers by rating
+var cocomo81Multipliers = map[string]map[string]float64{
+    // Product attributes
+    "RELY": {"very_low": 0.75, "low": 0.88, "nominal": 1.00, "high": 1.15, "very_high": 1.40},
+    "DATA": {"low": 0.94, "nominal": 1.00, "high": 1.08, "very_high": 1.16},
+    "CPLX": {"very_low": 0.70, "low": 0.85, "nominal": 1.00, "high": 1.15, "very_high": 1.30, "extra_high": 1.65},
+    // Computer attributes
+    "TIME": {"nominal": 1.00, "high": 1.11, "very_high": 1.30, "extra_high": 1.66},
+    "STOR": {"nominal": 1.00, "high": 1.06, "very_high": 1.21, "extra_high": 1.56},
+    "VIRT": {"low": 0.87, "nominal": 1.00, "high": 1.15, "very_high": 1.30},
+    "TURN": {"low": 0.87, "nominal": 1.00, "high": 1.07, "very_high": 1.15},
+    // Personnel attributes
+    "ACAP": {"very_low": 1.46, "low": 1.19, "nominal": 1.00, "high": 0.86, "very_high": 0.71},
+    "AEXP": {"very_low": 1.29, "low": 1.13, "nominal": 1.00, "high": 0.91, "very_high": 0.82},
+    "PCAP": {"very_low": 1.42, "low": 1.17, "nominal": 1.00, "high": 0.86, "very_high": 0.70},
+    "VEXP": {"very_low": 1.21, "low": 1.10, "nominal": 1.00, "high": 0.90},
+    "LEXP": {"very_low": 1.14, "low": 1.07, "nominal": 1.00, "high": 0.95},
+    // Project attributes
+    "MODP": {"very_low": 1.24, "low": 1.10, "nominal": 1.00, "high": 0.91, "very_high": 0.82},
+    "TOOL": {"very_low": 1.24, "low": 1.10, "nominal": 1.00, "high": 0.91, "very_high": 0.83},
+    "SCED": {"very_low": 1.23, "low": 1.08, "nominal": 1.00, "high": 1.04, "very_high": 1.10},
+}
+
+// COCOMO81Multiplier returns the effort multiplier of a cost driver rating,
+// e.g. ("RELY", "high") -> 1.15. ok is false for unknown drivers and undefined ratings.
+func COCOMO81Multiplier(driver, rating string) (multiplier float64, ok bool) {
+    multiplier, ok = cocomo81Multipliers[driver][rating]
+    return multiplier, ok
+}
+
+// Phase codes of the original COCOMO phase distribution
+const (
+    COCOMO81PhaseProductDesign   = "product_design"
+    COCOMO81PhaseProgramming     = "programming" // Detailed design, code and unit test
+    COCOMO81PhaseIntegrationTest = "integration_test"
+)
+
+// cocomo81PhaseSizes are the sizes (KDSI) at which the phase distribution is tabulated
+var cocomo81PhaseSizes = []float64{2, 8, 32, 128, 512}
+
+// cocomo81PhaseDistribution holds the share of effort of product design, programming and
+// integration & test at each of cocomo81PhaseSizes. Organic projects stop at 128 KDSI.
+var cocomo81PhaseDistribution = map[COCOMO81Mode][][3]float64{
+    COCOMO81Organic: {
+        {0.16, 0.68, 0.16}, {0.16, 0.65, 0.19}, {0.16, 0.62, 0.22}, {0.16, 0.59, 0.25},
+    },
+    COCOMO81Semidetached: {
+        {0.17, 0.64, 0.19}, {0.17, 0.61, 0.22}, {0.17, 0.58, 0.25}, {0.17, 0.55, 0.28}, {0.17, 0.52, 0.31},
+    },
+    COCOMO81Embedded: {
+        {0.18, 0.60, 0.22}, {0.18, 0.57, 0.25}, {0.18, 0.54, 0.28}, {0.18, 0.51, 0.31}, {0.18, 0.48, 0.34},
+    },
+}
+
+// COCOMO81PhaseShares returns the share of effort of each phase for a project size,
+// interpolating the published distribution on a logarithmic size scale
+func COCOMO81PhaseShares(mode COCOMO81Mode, kdsi float64) map[string]float64 {
+    table := cocomo81PhaseDistribution[mode]
+    if len(table) == 0 {
+        return nil
+    }
+    shares := table[0]
+    switch {
+    case kdsi >= cocomo81PhaseSizes[len(table)-1]:
+        shares = table[len(table)-1]
+    case kdsi > cocomo81PhaseSizes[0]:
+        for i := 1; i < len(table); i++ {
+            if kdsi <= cocomo81PhaseSizes[i] {
+                t := math.Log(kdsi/cocomo81PhaseSizes[i-1]) / math.Log(cocomo81PhaseSizes[i]/cocomo81PhaseSizes[i-1])
+                for j := range shares {
+                    shares[j] = table[i-1][j] + (table[i][j]-table[i-1][j])*t
+                }
+                break
+            }
+        }
+    }
+    return map[string]float64{
+        COCOMO81PhaseProductDesign:   shares[0],
+        COCOMO81PhaseProgramming:     shares[1],
+        COCOMO81PhaseIntegrationTest: shares[2],
+    }
+}
+
+// COCOMO81Phase represents the effort of a phase in detailed COCOMO
+type COCOMO81Phase struct {
+    Phase            string
+    Share            float64 // Share of the nominal effort
+    EffortMultiplier float64 // Phase EAF
+    EffortPM         float64
+}
+
+// COCOMO81Estimate represents an estimate using the original COCOMO (1981) model
+type COCOMO81Estimate struct {
+    KDSI        float64           // Thousands of delivered source instructions
+    Model       COCOMO81Model
+    CostDrivers map[string]string // Driver -> rating, ignored by basic COCOMO; missing drivers are nominal
+    // Per-phase EAF of detailed COCOMO; phases without one use the EAF of CostDrivers
+    PhaseMultipliers map[string]float64
+    // Calculated values
+    NominalEffortPM float64 // A * KDSI^B
+    EAF             float64 // Effort adjustment factor, the product of the cost driver multipliers
+    EffortPM        float64
+    DurationTM      float64
+    AverageStaff    float64
+    Productivity    float64 // DSI per person-month
+    Phases          []COCOMO81Phase // Detailed COCOMO only
+}
+
+// Calculate calculates effort, schedule and staffing.
+// CostDrivers must only hold ratings accepted by COCOMO81Multiplier.
+func (e *COCOMO81Estimate) Calculate() {
+    e.NominalEffortPM = e.Model.A * math.Pow(e.KDSI, e.Model.B)
+
+    e.EAF = 1.0
+    if e.Model.Level != COCOMO81Basic {
+        for driver, rating := range e.CostDrivers {
+            if m, ok := COCOMO81Multiplier(driver, rating); ok {
+                e.EAF *= m
+            }
+        }
+    }
+
+    e.Phases = nil
+    if e.Model.Level == COCOMO81Detailed {
+        e.EffortPM = 0
+        shares := COCOMO81PhaseShares(e.Model.Mode, e.KDSI)
+        for _, phase := range []string{COCOMO81PhaseProductDesign, COCOMO81PhaseProgramming, COCOMO81PhaseIntegrationTest} {
+            multiplier, ok := e.PhaseMultipliers[phase]
+            if !ok {
+                multiplier = e.EAF
+            }
+            effort := e.NominalEffortPM * shares[phase] * multiplier
+            e.Phases = append(e.Phases, COCOMO81Phase{
+                Phase:            phase,
+                Share:            shares[phase],
+                EffortMultiplier: multiplier,
+                EffortPM:         effort,
+            })
+            e.EffortPM += effort
+        }
+    } else {
+        e.EffortPM = e.NominalEffortPM * e.EAF
+    }
+
+    e.DurationTM = 0
+    e.AverageStaff = 0
+    e.Productivity = 0
+    if e.EffortPM > 0 {
+        e.DurationTM = e.Model.C * math.Pow(e.EffortPM, e.Model.D)
+        e.AverageStaff = averageStaff(e.EffortPM, e.DurationTM)
+        e.Productivity = e.KDSI * 1000 / e.EffortPM
+    }
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "testing"
+
+// cocomo81Estimate calculates an estimate of the given level, mode and size
+func cocomo81Estimate(t *testing.T, level COCOMO81Level, mode COCOMO81Mode, kdsi float64, drivers map[string]string) *COCOMO81Estimate {
+    t.Helper()
+    model, ok := COCOMO81ModelFor(level, mode)
+    if !ok {
+        t.Fatalf("no model for %s %s", level, mode)
+    }
+    estimate := &COCOMO81Estimate{KDSI: kdsi, Model: model, CostDrivers: drivers}
+    estimate.Calculate()
+    return estimate
+}
+
+func TestCOCOMO81BasicPublishedExamples(t *testing.T) {
+    // Basic COCOMO examples from Boehm, Software Engineering Economics (1981)
+    tests := []struct {
+        mode     COCOMO81Mode
+        kdsi     float64
+        effort   float64
+        duration float64
+    }{
+        {COCOMO81Organic, 32, 91, 14},
+        {COCOMO81Semidetached, 32, 146, 14},
+        {COCOMO81Embedded, 128, 1216, 24},
+    }
+    for _, tt := range tests {
+        estimate := cocomo81Estimate(t, COCOMO81Basic, tt.mode, tt.kdsi, nil)
+        if !approxEqual(estimate.EffortPM, tt.effort, 1) {
+            t.Errorf("%s %v KDSI: EffortPM = %v, want about %v", tt.mode, tt.kdsi, estimate.EffortPM, tt.effort)
+        }
+        if !approxEqual(estimate.DurationTM, tt.duration, 0.5) {
+            t.Errorf("%s %v KDSI: DurationTM = %v, want about %v", tt.mode, tt.kdsi, estimate.DurationTM, tt.duration)
+        }
+    }
+
+    // 32 KDSI over 91 person-months is about 352 DSI per person-month
+    organic := cocomo81Estimate(t, COCOMO81Basic, COCOMO81Organic, 32, nil)
+    if !approxEqual(organic.Productivity, 352, 3) {
+        t.Errorf("Productivity = %v, want about 352", organic.Productivity)
+    }
+}
+
+func TestCOCOMO81IntermediateAppliesCostDrivers(t *testing.T) {
+    // The intermediate embedded example of 10 KDSI has a nominal effort of 44 person-months
+    nominal := cocomo81Estimate(t, COCOMO81Intermediate, COCOMO81Embedded, 10, nil)
+    if !approxEqual(nominal.NominalEffortPM, 44, 0.5) || nominal.EAF != 1 {
+        t.Errorf("NominalEffortPM = %v, EAF = %v, want about 44 and 1", nominal.NominalEffortPM, nominal.EAF)
+    }
+
+    rated := cocomo81Estimate(t, COCOMO81Intermediate, COCOMO81Embedded, 10, map[string]string{"RELY": "high", "ACAP": "high"})
+    if want := 1.15 * 0.86; !approxEqual(rated.EAF, want, 1e-9) {
+        t.Errorf("EAF = %v, want %v", rated.EAF, want)
+    }
+    if !approxEqual(rated.EffortPM, rated.NominalEffortPM*rated.EAF, 1e-9) {
+        t.Errorf("EffortPM = %v, want the nominal effort times the EAF", rated.EffortPM)
+    }
+
+    // Basic COCOMO ignores the cost drivers
+    basic := cocomo81Estimate(t, COCOMO81Basic, COCOMO81Embedded, 10, map[string]string{"RELY": "high"})
+    if basic.EAF != 1 {
+        t.Errorf("basic EAF = %v, want 1", basic.EAF)
+    }
+}
+
+func TestCOCOMO81DetailedSumsPhases(t *testing.T) {
+    estimate := cocomo81Estimate(t, COCOMO81Detailed, COCOMO81Organic, 32, nil)
+    if len(estimate.Phases) != 3 {
+        t.Fatalf("got %d phases, want 3", len(estimate.Phases))
+    }
+    sum := 0.0
+    for _, phase := range estimate.Phases {
+        sum += phase.EffortPM
+    }
+    if !approxEqual(sum, estimate.EffortPM, 1e-9) || !approxEqual(sum, estimate.NominalEffortPM, 1e-9) {
+        t.Errorf("phases sum to %v, want the effort %v at nominal ratings", sum, estimate.NominalEffortPM)
+    }
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+    "math"
+    "sort"
+)
+
+// FactorContribution represents a scale factor or cost driver rated differently by two COCOMO II estimates.
+// A factor rated by only one estimate counts as unrated (no exponent, multiplier 1.0) on the other side.
+type FactorContribution struct {
+    Kind        string  // FactorKindScaleFactor or FactorKindCostDriver
+    ID          string
+    Name        string  // Message key of a built-in factor, or the stored name
+    Rating1     float64
+    Rating2     float64
+    Value1      float64 // Effort multiplier of a cost driver, or weight × rating added to the exponent by a scale factor
+    Value2      float64
+    EffortRatio float64 // Factor by which this change alone multiplies the effort
+    EffortPM    float64 // Share of the effort difference attributed to this change
+}
+
+// COCOMOComparison represents the difference between two COCOMO II estimates, each value of estimate 2
+// minus that of estimate 1.
+//
+// The effort difference is attributed by taking the logarithm of PM = A × Size^B × ΠEM × team overhead:
+// ln(PM2/PM1) splits exactly into a term per differing factor, one for the model and size and one for
+// the team overhead, and each term gets
+// its proportional share of the difference, so the shares sum to EffortDiff.
+type COCOMOComparison struct {
+    EffortPM1      float64
+    EffortPM2      float64
+    EffortDiff     float64
+    PercentChange  float64 // Of the effort, relative to EffortPM1; 0 when EffortPM1 is 0
+    DurationTM1    float64
+    DurationTM2    float64
+    DurationDiff   float64
+    TeamSize1      float64
+    TeamSize2      float64
+    TeamSizeDiff   float64
+    ExponentB1     float64
+    ExponentB2     float64
+    ExponentBDiff  float64
+    SizeEffortPM   float64 // Share of the effort difference from the size and the model coefficients
+    TeamOverheadEffortPM float64 // Share of the effort difference from the overhead of the planned teams
+    Factors        []FactorContribution // Ordered by the size of their effect, largest first
+}
+
+// CompareCOCOMO compares two calculated COCOMO II estimates and attributes the effort difference
+// to the size and the factors that differ
+func CompareCOCOMO(e1, e2 *COCOMOEstimate) *COCOMOComparison {
+    comparison := &COCOMOComparison{
+        EffortPM1:     e1.EffortPM,
+        EffortPM2:     e2.EffortPM,
+        EffortDiff:    e2.EffortPM - e1.EffortPM,
+        DurationTM1:   e1.DurationTM,
+        DurationTM2:   e2.DurationTM,
+        DurationDiff:  e2.DurationTM - e1.DurationTM,
+        TeamSize1:     e1.TeamSize,
+        TeamSize2:     e2.TeamSize,
+        TeamSizeDiff:  e2.TeamSize - e1.TeamSize,
+        ExponentB1:    e1.ExponentB,
+        ExponentB2:    e2.ExponentB,
+        ExponentBDiff: e2.ExponentB - e1.ExponentB,
+        Factors:       []FactorContribution{},
+    }
+    if e1.EffortPM != 0 {
+        comparison.PercentChange = comparison.EffortDiff / e1.EffortPM * 100
+    }
+
+    // Log terms of the effort ratio; the shares are only defined when both efforts are positive
+    logSize1 := math.Log(e1.EffectiveSize())
+    logSize2 := math.Log(e2.EffectiveSize())
+    terms := make(map[int]float64)
+    sizeTerm := math.Log(e2.Model.A/e1.Model.A) + e1.ExponentB*(logSize2-logSize1) + (e2.Model.B-e1.Model.B)*logSize2
+    overheadTerm := math.Log(e2.teamOverhead() / e1.teamOverhead())
+
+    for _, pair := range pairScaleFactors(e1.ScaleFactors, e2.ScaleFactors) {
+        value1, value2 := pair[0].ExponentContribution(), pair[1].ExponentContribution()
+        if value1 == value2 {
+            continue
+        }
+        sf := pair[0]
+        if sf.Type == "" && sf.Name == "" {
+            sf = pair[1]
+        }
+        terms[len(comparison.Factors)] = (value2 - value1) * logSize2
+        comparison.Factors = append(comparison.Factors, FactorContribution{
+            Kind: FactorKindScaleFactor, ID: sf.ID, Name: sf.nameKey(),
+            Rating1: pair[0].Rating, Rating2: pair[1].Rating,
+            Value1: value1, Value2: value2,
+        })
+    }
+    for _, pair := range pairCostDrivers(e1.CostDrivers, e2.CostDrivers) {
+        if pair[0].Value == pair[1].Value {
+            continue
+        }
+        cd := pair[0]
+        if cd.Type == "" && cd.Name == "" {
+            cd = pair[1]
+        }
+        if pair[0].Value > 0 && pair[1].Value > 0 {
+            terms[len(comparison.Factors)] = math.Log(pair[1].Value / pair[0].Value)
+        }
+        comparison.Factors = append(comparison.Factors, FactorContribution{
+            Kind: FactorKindCostDriver, ID: cd.ID, Name: cd.nameKey(),
+            Rating1: pair[0].Rating, Rating2: pair[1].Rating,
+            Value1: pair[0].Value, Value2: pair[1].Value,
+        })
+    }
+
+    total := 0.0
+    if e1.EffortPM > 0 && e2.EffortPM > 0 {
+        total = math.Log(e2.EffortPM / e1.EffortPM)
+    }
+    share := func(term float64) float64 {
+        if total == 0 || math.IsNaN(term) || math.IsInf(term, 0) {
+            return 0
+        }
+        return comparison.EffortDiff * term / total
+    }
+    comparison.SizeEffortPM = share(sizeTerm)
+    comparison.TeamOverheadEffortPM = share(overheadTerm)
+    for i := range comparison.Factors {
+        term, ok := terms[i]
+        if !ok {
+            continue
+        }
+        comparison.Factors[i].EffortRatio = math.Exp(term)
+        comparison.Factors[i].EffortPM = share(term)
+    }
+
+    sort.SliceStable(comparison.Factors, func(i, j int) bool {
+        return math.Abs(comparison.Factors[i].EffortRatio-1) > math.Abs(comparison.Factors[j].EffortRatio-1)
+    })
+    return comparison
+}
+
+// pairScaleFactors pairs the scale factors of two estimates by ID, in the order of the first estimate
+// followed by those only rated by the second. A missing side is the zero ScaleFactor.
+func pairScaleFactors(factors1, factors2 []ScaleFactor) [][2]ScaleFactor {
+    var pairs [][2]ScaleFactor
+    index := make(map[string]int)
+    for _, sf := range factors1 {
+        index[sf.ID] = len(pairs)
+        pairs = append(pairs, [2]ScaleFactor{sf, {}})
+    }
+    for _, sf := range factors2 {
+        if i, ok := index[sf.ID]; ok {
+            pairs[i][1] = sf
+            continue
+        }
+        pairs = append(pairs, [2]ScaleFactor{{}, sf})
+    }
+    return pairs
+}
+
+// pairCostDrivers pairs the cost drivers of two estimates by ID like pairScaleFactors.
+// A missing side is an unrated driver with a multiplier of 1.0.
+func pairCostDrivers(drivers1, drivers2 []CostDriver) [][2]CostDriver {
+    var pairs [][2]CostDriver
+    index := make(map[string]int)
+    for _, cd := range drivers1 {
+        index[cd.ID] = len(pairs)
+        pairs = append(pairs, [2]CostDriver{cd, {Value: 1}})
+    }
+    for _, cd := range drivers2 {
+        if i, ok := index[cd.ID]; ok {
+            pairs[i][1] = cd
+            continue
+        }
+        pairs = append(pairs, [2]CostDriver{{Value: 1}, cd})
+    }
+    return pairs
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "testing"
+
+func TestCompareCOCOMOAttributesDeltaToCPLX(t *testing.T) {
+    nominal := nominalEstimate(50)
+    nominal.CalculateEffort()
+    highCPLX := nominalEstimate(50)
+    highCPLX.rate(CostDriverCPLX, 4) // 1.34
+    highCPLX.CalculateEffort()
+
+    comparison := CompareCOCOMO(nominal, highCPLX)
+
+    if !approxEqual(comparison.EffortDiff, nominal.EffortPM*0.34, 1e-9) || !approxEqual(comparison.PercentChange, 34, 1e-9) {
+        t.Errorf("effort diff %v (%v%%), want 34%% of %v", comparison.EffortDiff, comparison.PercentChange, nominal.EffortPM)
+    }
+    if comparison.ExponentBDiff != 0 || comparison.DurationDiff <= 0 || comparison.TeamSizeDiff <= 0 {
+        t.Errorf("exponent diff %v, duration diff %v, team diff %v, want the same exponent and a longer, larger project",
+            comparison.ExponentBDiff, comparison.DurationDiff, comparison.TeamSizeDiff)
+    }
+
+    if len(comparison.Factors) != 1 {
+        t.Fatalf("got %d differing factors, want only CPLX", len(comparison.Factors))
+    }
+    cplx := comparison.Factors[0]
+    if cplx.Kind != FactorKindCostDriver || cplx.Name != CostDriverCPLX.NameKey() || cplx.Rating1 != nominalRating || cplx.Rating2 != 4 {
+        t.Errorf("factor %+v, want CPLX from Nominal to Very High", cplx)
+    }
+    if cplx.Value1 != 1.0 || cplx.Value2 != 1.34 || !approxEqual(cplx.EffortRatio, 1.34, 1e-9) {
+        t.Errorf("CPLX values %v → %v with ratio %v, want 1.0 → 1.34", cplx.Value1, cplx.Value2, cplx.EffortRatio)
+    }
+    if !approxEqual(cplx.EffortPM, comparison.EffortDiff, 1e-9) || !approxEqual(comparison.SizeEffortPM, 0, 1e-9) {
+        t.Errorf("CPLX accounts for %v of %v PM with %v from the size, want all of it", cplx.EffortPM, comparison.EffortDiff, comparison.SizeEffortPM)
+    }
+}
@@ -4,16 +4,21 @@ package domain
 type COCOMODetailedResult struct {
     // Basic project information
     ProjectSize     float64 // KSLOC
+    AdjustedSize    float64 // KSLOC including reused code and requirements volatility (REVL)
     ModelType       string  // Early Design or Post-Architecture
     
     // Effort estimation
-    BaseEffort      float64 // Person-months without adjustments
-    AdjustedEffort  float64 // Person-months after applying all factors
+    ExponentB       float64 // Size exponent derived from the scale factors
+    EffortMultiplier float64 // Product of all cost driver multipliers (EM)
+    BaseEffort      float64 // Person-months before effort multipliers: A * Size^B
+    TeamOverhead    float64 // Communication overhead of the planned team, 1.0 without one
+    AdjustedEffort  float64 // Person-months after applying all factors: BaseEffort * EM * TeamOverhead
     EffortRange     struct {
-        Optimistic  float64 // -20% of nominal
+        Optimistic  float64 // Nominal × UncertaintyBand.EffortLow
         Nominal     float64 // Calculated effort
-        Pessimistic float64 // +20% of nominal
+        Pessimistic float64 // Nominal × UncertaintyBand.EffortHigh
     }
+    UncertaintyBand UncertaintyBand // Multipliers of the ranges, wider for earlier lifecycle phases
     
     // Schedule estimation
     Duration        float64 // Calendar months
@@ -31,20 +36,29 @@ type COCOMODetailedResult struct {
         Maximum     float64
     }
     
-    // Cost estimation (if hourly rate is provided)
+    // Cost estimation (if an hourly rate or rate card is provided)
     CostEstimate    struct {
-        HourlyRate  float64
-        TotalCost   float64
-        CostRange   struct {
+        Currency    string
+        HourlyRate  float64 // Blended rate across all phases
+        TotalCost   float64 // Labor cost, the sum of the phase costs
+        CostBreakdown       // Labor cost with overhead and tax applied
+        CostRange   struct { // Grand totals, including overhead and tax
             Minimum float64
             Nominal float64
             Maximum float64
         }
     }
     
-    // Breakdown by phase (typical distribution for the selected process)
+    // Simulated percentiles (if a Monte Carlo simulation was run)
+    MonteCarlo      *MonteCarloResult
+    
+    // Breakdown by phase (distribution of the selected phase profile)
+    PhaseProfile    string
     PhaseDistribution []PhaseEffort
     
+    // Breakdown by role, split from the phases by the role mix
+    RoleDistribution []RoleEffort
+    
     // Factor analysis
     ScaleFactorAnalysis  []FactorAnalysis
     CostDriverAnalysis   []FactorAnalysis
@@ -52,15 +66,27 @@ type COCOMODetailedResult struct {
     // Risk assessment
     RiskLevel       string  // Low, Medium, High
     RiskFactors     []RiskFactor
+    RiskScore       float64 // 0-100, weighted from the impacts of the risk factors
+    RiskBand        string  // Low, Medium, High, derived from the risk score
+    RiskBreakdown   []RiskContribution // Share of the risk score per category
+    
+    // Requested values that can't be met, such as a duration below the schedule floor
+    Warnings        []ValidationWarning
+
+    // Factors ranked by the absolute elasticity of the effort to their rating, the top levers first
+    SensitivityRanking []FactorElasticity
 }
 
 // PhaseEffort represents effort distribution for a development phase
 type PhaseEffort struct {
-    Phase           string  // Plans and Requirements, Product Design, Programming, etc.
+    Code            string  // Stable phase identifier, see PhaseRequirements etc.
+    Phase           string  // Message key of the display name, see PhaseNameKey
     PercentEffort   float64 // Percentage of total effort
     Effort          float64 // Person-months for this phase
     Duration        float64 // Calendar months for this phase
     AverageStaff    float64 // Average staff size for this phase
+    HourlyRate      float64 // Rate from the rate card, 0 when not costed
+    Cost            float64 // Effort * monthly hours * HourlyRate
 }
 
 // FactorAnalysis represents the impact analysis of a COCOMO II factor
@@ -68,11 +94,12 @@ type FactorAnalysis struct {
     Name            string
     Rating          float64 // Current rating value
     Impact          float64 // Multiplier or additive impact
-    Sensitivity     float64 // How much the estimate changes with this factor
+    Sensitivity     float64 // Elasticity of the effort to the factor's rating, see ratingElasticity
     Recommendation  string  // Optional recommendation for improvement
 }
 
-// RiskFactor represents a project risk identified through COCOMO II analysis
+// RiskFactor represents a project risk identified through COCOMO II analysis.
+// Name, Description and Mitigation hold message keys, except the stored names of custom factors.
 type RiskFactor struct {
     Category    string  // Technical, Cost, Schedule, or Process
     Name        string
@@ -83,26 +110,35 @@ type RiskFactor struct {
 }
 
 // GenerateDetailedResult generates a detailed COCOMO II estimation result
-func (e *COCOMOEstimate) GenerateDetailedResult(hourlyRate float64) *COCOMODetailedResult {
+// Effort and duration are distributed across the phases of the profile, a profile without phases
+// being the default one. Each phase is costed at its rate from the rate card and split across roles
+// by the role mix; a nil mix uses DefaultRoleMix.
+func (e *COCOMOEstimate) GenerateDetailedResult(rates RateCard, roles RoleMix, phases PhaseProfile, config EstimationConfig) *COCOMODetailedResult {
     result := &COCOMODetailedResult{
         ProjectSize: e.ProjectSize,
+        AdjustedSize: e.EffectiveSize(),
         ModelType:   e.Model.Name,
     }
     
     // Calculate base and adjusted effort
-    result.BaseEffort = e.Model.A * pow(e.ProjectSize, e.Model.B)
+    result.ExponentB = e.ExponentB
+    result.EffortMultiplier = effortMultiplier(e.CostDrivers)
+    result.BaseEffort = e.Model.A * pow(e.EffectiveSize(), e.ExponentB)
+    result.TeamOverhead = e.teamOverhead()
     result.AdjustedEffort = e.EffortPM
     
-    // Calculate effort range
+    // Calculate effort range from the cone of uncertainty of the model's lifecycle phase
+    band := e.UncertaintyBand()
+    result.UncertaintyBand = band
     result.EffortRange.Nominal = e.EffortPM
-    result.EffortRange.Optimistic = e.EffortPM * 0.8  // -20%
-    result.EffortRange.Pessimistic = e.EffortPM * 1.2 // +20%
+    result.EffortRange.Optimistic = e.EffortPM * band.EffortLow
+    result.EffortRange.Pessimistic = e.EffortPM * band.EffortHigh
     
     // Calculate duration and range
     result.Duration = e.DurationTM
     result.DurationRange.Nominal = e.DurationTM
-    result.DurationRange.Optimistic = e.DurationTM * 0.85  // -15%
-    result.DurationRange.Pessimistic = e.DurationTM * 1.15 // +15%
+    result.DurationRange.Optimistic = e.DurationTM * band.DurationLow
+    result.DurationRange.Pessimistic = e.DurationTM * band.DurationHigh
     
     // Calculate team size ranges
     result.TeamSize = e.TeamSize
@@ -110,183 +146,251 @@ func (e *COCOMOEstimate) GenerateDetailedResult(hourlyRate float64) *COCOMODetai
     result.TeamSizeRange.Minimum = e.TeamSize * 0.7  // -30%
     result.TeamSizeRange.Maximum = e.TeamSize * 1.3  // +30%
     
-    // Calculate cost if hourly rate is provided
-    if hourlyRate > 0 {
-        monthlyHours := 160.0 // Assuming 160 working hours per month
-        totalCost := e.EffortPM * monthlyHours * hourlyRate
+    // Calculate phase distribution from the phase profile
+    if len(phases.Phases) == 0 {
+        phases = DefaultPhaseProfile()
+    }
+    result.PhaseProfile = phases.Name
+    durationShares := phases.normalizedDurations()
+    for i, share := range phases.Phases {
+        effort := e.EffortPM * share.Effort
+        duration := e.DurationTM * durationShares[i]
+        result.PhaseDistribution = append(result.PhaseDistribution, PhaseEffort{
+            Code:          share.Code,
+            Phase:         PhaseNameKey(share.Code),
+            PercentEffort: share.Effort,
+            Effort:        effort,
+            Duration:      duration,
+            AverageStaff:  averageStaff(effort, duration),
+        })
+    }
+    
+    // Calculate cost per phase if an hourly rate or rate card is provided
+    if rates.IsSet() {
+        totalCost := 0.0
+        for i := range result.PhaseDistribution {
+            phase := &result.PhaseDistribution[i]
+            phase.HourlyRate = rates.RateFor(phase.Code)
+            phase.Cost = phase.Effort * config.MonthlyHours() * phase.HourlyRate
+            totalCost += phase.Cost
+        }
         
-        result.CostEstimate.HourlyRate = hourlyRate
+        result.CostEstimate.Currency = rates.Currency
+        if hours := e.EffortPM * config.MonthlyHours(); hours > 0 {
+            result.CostEstimate.HourlyRate = totalCost / hours
+        } else {
+            result.CostEstimate.HourlyRate = rates.DefaultRate
+        }
         result.CostEstimate.TotalCost = totalCost
-        result.CostEstimate.CostRange.Nominal = totalCost
-        result.CostEstimate.CostRange.Minimum = totalCost * 0.8  // -20%
-        result.CostEstimate.CostRange.Maximum = totalCost * 1.2  // +20%
+        result.CostEstimate.CostBreakdown = rates.Breakdown(totalCost)
+        result.CostEstimate.CostRange.Nominal = result.CostEstimate.GrandTotal
+        result.CostEstimate.CostRange.Minimum = rates.Breakdown(totalCost * band.EffortLow).GrandTotal
+        result.CostEstimate.CostRange.Maximum = rates.Breakdown(totalCost * band.EffortHigh).GrandTotal
     }
     
-    // Calculate phase distribution (typical distribution for software projects)
-    result.PhaseDistribution = []PhaseEffort{
-        {
-            Phase:         "要件定義・計画",
-            PercentEffort: 0.08,
-            Effort:        e.EffortPM * 0.08,
-            Duration:      e.DurationTM * 0.15,
-            AverageStaff:  (e.EffortPM * 0.08) / (e.DurationTM * 0.15),
-        },
-        {
-            Phase:         "システム設計",
-            PercentEffort: 0.18,
-            Effort:        e.EffortPM * 0.18,
-            Duration:      e.DurationTM * 0.25,
-            AverageStaff:  (e.EffortPM * 0.18) / (e.DurationTM * 0.25),
-        },
-        {
-            Phase:         "詳細設計",
-            PercentEffort: 0.25,
-            Effort:        e.EffortPM * 0.25,
-            Duration:      e.DurationTM * 0.35,
-            AverageStaff:  (e.EffortPM * 0.25) / (e.DurationTM * 0.35),
-        },
-        {
-            Phase:         "実装・単体テスト",
-            PercentEffort: 0.26,
-            Effort:        e.EffortPM * 0.26,
-            Duration:      e.DurationTM * 0.45,
-            AverageStaff:  (e.EffortPM * 0.26) / (e.DurationTM * 0.45),
-        },
-        {
-            Phase:         "結合テスト",
-            PercentEffort: 0.15,
-            Effort:        e.EffortPM * 0.15,
-            Duration:      e.DurationTM * 0.25,
-            AverageStaff:  (e.EffortPM * 0.15) / (e.DurationTM * 0.25),
-        },
-        {
-            Phase:         "システムテスト",
-            PercentEffort: 0.08,
-            Effort:        e.EffortPM * 0.08,
-            Duration:      e.DurationTM * 0.15,
-            AverageStaff:  (e.EffortPM * 0.08) / (e.DurationTM * 0.15),
-        },
+    // Split the phases across roles
+    if roles == nil {
+        roles = DefaultRoleMix()
     }
+    result.DistributeRoles(roles)
     
     // Analyze scale factors
-    for _, sf := range e.ScaleFactors {
+    for i, sf := range e.ScaleFactors {
         analysis := FactorAnalysis{
-            Name:   sf.Name,
+            Name:   sf.nameKey(),
             Rating: sf.Rating,
-            Impact: sf.Weight * sf.Rating,
+            Impact: sf.ExponentContribution(),
         }
         
         // Calculate sensitivity
-        sensitivity := (sf.Weight * 0.5) / e.EffortPM // Impact of 0.5 rating change
-        analysis.Sensitivity = sensitivity
+        analysis.Sensitivity = e.ratingElasticity(sf.Rating, func(c *COCOMOEstimate, rating float64) {
+            c.ScaleFactors[i].Rating = rating
+        })
         
-        // Add recommendations based on rating
-        if sf.Rating > 3.5 {
-            analysis.Recommendation = "この要因の改善により工数を削減できる可能性があります"
-        }
+        // Add recommendations based on the factor and its rating
+        analysis.Recommendation = sf.recommendation()
         
         result.ScaleFactorAnalysis = append(result.ScaleFactorAnalysis, analysis)
     }
     
     // Analyze cost drivers
-    for _, cd := range e.CostDrivers {
+    for i, cd := range e.CostDrivers {
         analysis := FactorAnalysis{
-            Name:   cd.Name,
+            Name:   cd.nameKey(),
             Rating: cd.Rating,
             Impact: cd.Value,
         }
         
         // Calculate sensitivity
-        baseValue := cd.Value
-        increasedValue := baseValue * 1.1 // 10% increase
-        sensitivity := (increasedValue - baseValue) / baseValue
-        analysis.Sensitivity = sensitivity
+        analysis.Sensitivity = e.ratingElasticity(cd.Rating, func(c *COCOMOEstimate, rating float64) {
+            c.CostDrivers[i].SetRating(rating)
+        })
         
-        // Add recommendations based on rating and impact
-        if cd.Value > 1.2 {
-            analysis.Recommendation = "この要因の最適化により工数を削減できる可能性があります"
-        }
+        // Add recommendations based on the driver, its rating and impact
+        analysis.Recommendation = cd.recommendation()
         
         result.CostDriverAnalysis = append(result.CostDriverAnalysis, analysis)
     }
     
+    result.SensitivityRanking = rankElasticities(result.ScaleFactorAnalysis, result.CostDriverAnalysis)
+    
     // Assess overall project risk
-    result.RiskLevel = e.assessRiskLevel()
-    result.RiskFactors = e.identifyRiskFactors()
+    risk := config.RiskThresholds()
+    result.RiskLevel = e.assessRiskLevel(risk)
+    result.RiskFactors = e.identifyRiskFactors(risk)
+    result.RiskScore, result.RiskBreakdown = riskScore(result.RiskFactors)
+    result.RiskBand = risk.Band(result.RiskScore)
+    
+    // Warn about a requested duration below the schedule floor
+    if warning := e.CheckScheduleFloor(); warning != nil {
+        result.Warnings = append(result.Warnings, ValidationWarning{
+            Field:   "targetDuration",
+            Message: MsgWarningScheduleFloor,
+            Limit:   warning.MinimumDurationTM,
+        })
+    }
     
     return result
 }
 
+// averageStaff divides effort by duration, returning 0 staff when the duration is not positive
+func averageStaff(effortPM, durationTM float64) float64 {
+    if durationTM <= 0 {
+        return 0
+    }
+    return effortPM / durationTM
+}
+
 // assessRiskLevel determines the overall project risk level
-func (e *COCOMOEstimate) assessRiskLevel() string {
+func (e *COCOMOEstimate) assessRiskLevel(config RiskConfig) string {
     // Count high-rated scale factors and cost drivers
     highRiskCount := 0
     
     for _, sf := range e.ScaleFactors {
-        if sf.Rating > 4.0 {
+        if sf.Rating < config.ScaleFactorRating {
             highRiskCount++
         }
     }
     
     for _, cd := range e.CostDrivers {
-        if cd.Value > 1.3 {
+        if cd.Value > config.CostDriverValue {
             highRiskCount++
         }
     }
     
-    if highRiskCount >= 3 {
+    if highRiskCount >= config.HighRiskCount {
         return "High"
-    } else if highRiskCount >= 1 {
+    } else if highRiskCount >= config.MediumRiskCount {
         return "Medium"
     }
     return "Low"
 }
 
 // identifyRiskFactors identifies specific project risk factors
-func (e *COCOMOEstimate) identifyRiskFactors() []RiskFactor {
+func (e *COCOMOEstimate) identifyRiskFactors(config RiskConfig) []RiskFactor {
     var risks []RiskFactor
     
     // Analyze scale factors for risks
     for _, sf := range e.ScaleFactors {
-        if sf.Rating > 4.0 {
+        if sf.Rating < config.ScaleFactorRating {
             risk := RiskFactor{
                 Category:    "Process",
-                Name:        sf.Name,
+                Name:        sf.nameKey(),
                 Level:      "High",
-                Impact:     sf.Weight * sf.Rating,
-                Description: "高いスケールファクター値による影響",
-                Mitigation: "プロセスの改善とリスク軽減策の実施を検討",
+                Impact:     sf.effortMultiplier(e.EffectiveSize()),
+                Description: MsgRiskScaleFactorDescription,
+                Mitigation: MsgRiskScaleFactorMitigation,
             }
             risks = append(risks, risk)
         }
     }
     
-    // Analyze cost drivers for risks
+    // Analyze cost drivers for risks; SCED is reported as a schedule risk below
     for _, cd := range e.CostDrivers {
-        if cd.Value > 1.3 {
+        if cd.Type != CostDriverSCED && cd.Value > config.CostDriverValue {
             risk := RiskFactor{
                 Category:    "Technical",
-                Name:        cd.Name,
+                Name:        cd.nameKey(),
                 Level:      "High",
                 Impact:     cd.Value,
-                Description: "高いコストドライバー値による影響",
-                Mitigation: "技術的な対策と改善策の実施を検討",
+                Description: MsgRiskCostDriverDescription,
+                Mitigation: MsgRiskCostDriverMitigation,
             }
             risks = append(risks, risk)
         }
     }
     
     // Add size-related risks
-    if e.ProjectSize > 100 { // Large project
+    if e.ProjectSize > config.LargeProjectSize { // Large project
         risks = append(risks, RiskFactor{
             Category:    "Technical",
-            Name:        "大規模プロジェクト",
+            Name:        MsgRiskLargeProjectName,
             Level:      "Medium",
             Impact:     1.3,
-            Description: "プロジェクト規模が大きいことによる複雑性の増加",
-            Mitigation: "モジュール化とインクリメンタル開発の採用を検討",
+            Description: MsgRiskLargeProjectDescription,
+            Mitigation: MsgRiskLargeProjectMitigation,
+        })
+    }
+    
+    // Add schedule risks when SCED compresses the schedule
+    if percent := e.SchedulePercent(); percent < 1.0 {
+        level := "Medium"
+        if percent <= scheduleByRating[1] {
+            level = "High"
+        }
+        risks = append(risks, RiskFactor{
+            Category:    "Schedule",
+            Name:        MsgRiskScheduleCompressionName,
+            Level:       level,
+            Impact:      e.scheduleMultiplier(),
+            Description: MsgRiskScheduleCompressionDescription,
+            Mitigation:  MsgRiskScheduleCompressionMitigation,
+        })
+    }
+    
+    // A requested duration below the schedule floor is infeasible at any staffing
+    if warning := e.CheckScheduleFloor(); warning != nil {
+        risks = append(risks, RiskFactor{
+            Category:    "Schedule",
+            Name:        MsgRiskScheduleFloorName,
+            Level:       "High",
+            Impact:      warning.MinimumDurationTM / warning.RequestedDurationTM,
+            Description: MsgRiskScheduleFloorDescription,
+            Mitigation:  MsgRiskScheduleFloorMitigation,
+        })
+    }
+    
+    // Add cost risks for large efforts and teams
+    if e.EffortPM > config.LargeEffortPM {
+        risks = append(risks, RiskFactor{
+            Category:    "Cost",
+            Name:        MsgRiskLargeEffortName,
+            Level:       "High",
+            Impact:      e.EffortPM / config.LargeEffortPM,
+            Description: MsgRiskLargeEffortDescription,
+            Mitigation:  MsgRiskLargeEffortMitigation,
+        })
+    }
+    if e.TeamSize > config.LargeTeamSize {
+        risks = append(risks, RiskFactor{
+            Category:    "Cost",
+            Name:        MsgRiskLargeTeamName,
+            Level:       "Medium",
+            Impact:      e.TeamSize / config.LargeTeamSize,
+            Description: MsgRiskLargeTeamDescription,
+            Mitigation:  MsgRiskLargeTeamMitigation,
         })
     }
     
     return risks
+}
+
+// scheduleMultiplier returns the SCED effort multiplier, 1.0 when the estimate has no SCED driver
+func (e *COCOMOEstimate) scheduleMultiplier() float64 {
+    for _, cd := range e.CostDrivers {
+        if cd.Type == CostDriverSCED {
+            return cd.Value
+        }
+    }
+    return 1.0
 }
\ No newline at end of file
@@ -0,0 +1,124 @@
+package domain
+
+import (
+    "encoding/json"
+    "testing"
+)
+
+func TestDetailedResultExposesIntermediateValues(t *testing.T) {
+    estimate := nominalEstimate(50)
+    estimate.rate(CostDriverRELY, 4)
+    estimate.rate(CostDriverACAP, 1)
+    estimate.rate(CostDriverSCED, 1)
+    estimate.CalculateEffort()
+
+    result := estimate.GenerateDetailedResult(RateCard{}, nil, PhaseProfile{}, DefaultEstimationConfig())
+
+    if result.ExponentB != estimate.ExponentB || result.ExponentB <= testModel().B {
+        t.Errorf("ExponentB = %v, want the estimate's %v above the model B", result.ExponentB, estimate.ExponentB)
+    }
+    if result.EffortMultiplier == 1 {
+        t.Error("EffortMultiplier = 1, want the product of the non-nominal drivers")
+    }
+    if want := result.BaseEffort * result.EffortMultiplier * result.TeamOverhead; !approxEqual(result.AdjustedEffort, want, 1e-9) {
+        t.Errorf("AdjustedEffort = %v, want BaseEffort × EM = %v", result.AdjustedEffort, want)
+    }
+}
+
+func TestDetailedResultOfZeroDurationIsFinite(t *testing.T) {
+    empty := nominalEstimate(0)
+    empty.CalculateEffort()
+
+    // A zero duration must not divide the phase efforts even when there is effort to staff
+    unscheduled := nominalEstimate(50)
+    unscheduled.CalculateEffort()
+    unscheduled.DurationTM = 0
+
+    for _, estimate := range []*COCOMOEstimate{empty, unscheduled} {
+        result := estimate.GenerateDetailedResult(RateCard{DefaultRate: 5000}, nil, PhaseProfile{}, DefaultEstimationConfig())
+        if _, err := json.Marshal(result); err != nil {
+            t.Fatalf("size %v: %v", estimate.ProjectSize, err)
+        }
+        for _, phase := range result.PhaseDistribution {
+            if phase.AverageStaff != 0 {
+                t.Errorf("size %v, phase %s: AverageStaff = %v, want 0 without duration", estimate.ProjectSize, phase.Code, phase.AverageStaff)
+            }
+        }
+    }
+}
+
+func TestRiskLevelDependsOnRiskConfig(t *testing.T) {
+    estimate := nominalEstimate(20)
+    estimate.rate(CostDriverCPLX, 4) // 1.34
+    estimate.rate(CostDriverSTOR, 5) // 1.46
+    estimate.rate(CostDriverTIME, 4) // 1.29
+    estimate.CalculateEffort()
+
+    strict := DefaultRiskConfig()
+    strict.CostDriverValue = 1.2
+    lenient := DefaultRiskConfig()
+    lenient.MediumRiskCount = 5
+    lenient.HighRiskCount = 10
+
+    tests := []struct {
+        name   string
+        config RiskConfig
+        level  string
+        risks  int
+    }{
+        {"default", DefaultRiskConfig(), "Medium", 2},
+        {"strict", strict, "High", 3},
+        {"lenient", lenient, "Low", 2},
+    }
+    for _, tt := range tests {
+        config := DefaultEstimationConfig()
+        config.Risk = tt.config
+        result := estimate.GenerateDetailedResult(RateCard{}, nil, PhaseProfile{}, config)
+        if result.RiskLevel != tt.level {
+            t.Errorf("%s: risk level %s, want %s", tt.name, result.RiskLevel, tt.level)
+        }
+        if len(result.RiskFactors) != tt.risks {
+            t.Errorf("%s: %d risk factors, want %d", tt.name, len(result.RiskFactors), tt.risks)
+        }
+    }
+}
+
+func TestCompressedScheduleIsAScheduleRisk(t *testing.T) {
+    tests := []struct {
+        sced  float64
+        level string // Empty when no schedule risk is expected
+    }{
+        {nominalRating, ""},
+        {1.5, "Medium"}, // Between 85% and 100% of nominal
+        {1, "High"},     // 85% of nominal
+        {0, "High"},     // 75% of nominal
+    }
+    for _, tt := range tests {
+        estimate := nominalEstimate(20)
+        estimate.rate(CostDriverSCED, tt.sced)
+        estimate.CalculateEffort()
+
+        var schedule []RiskFactor
+        for _, risk := range estimate.identifyRiskFactors(DefaultRiskConfig()) {
+            if risk.Category == "Schedule" {
+                schedule = append(schedule, risk)
+            }
+        }
+        if tt.level == "" {
+            if len(schedule) != 0 {
+                t.Errorf("SCED %v: schedule risks %v, want none", tt.sced, schedule)
+            }
+            continue
+        }
+        if len(schedule) != 1 {
+            t.Fatalf("SCED %v: %d schedule risks, want 1", tt.sced, len(schedule))
+        }
+        risk := schedule[0]
+        if risk.Level != tt.level || risk.Name != MsgRiskScheduleCompressionName || risk.Mitigation != MsgRiskScheduleCompressionMitigation {
+            t.Errorf("SCED %v: risk %+v, want a %s schedule compression risk with its mitigation", tt.sced, risk, tt.level)
+        }
+        if risk.Impact != estimate.scheduleMultiplier() || risk.Impact <= 1 {
+            t.Errorf("SCED %v: impact %v, want the SCED multiplier above 1", tt.sced, risk.Impact)
+        }
+    }
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+    "math"
+    "testing"
+)
+
+// nominalRating is the rating of the Nominal level
+const nominalRating = 2.0
+
+// testModel returns the built-in Post-Architecture coefficients
+func testModel() *COCOMOModel {
+    return &COCOMOModel{ID: ModelPostArchitectureID, Name: "Post-Architecture", A: 2.45, B: 0.91}
+}
+
+// nominalEstimate returns an estimate of the given size with every scale factor and cost driver at Nominal
+func nominalEstimate(size float64) *COCOMOEstimate {
+    estimate := &COCOMOEstimate{ProjectSize: size, Model: testModel()}
+    for _, t := range ScaleFactorTypes {
+        sf := NewScaleFactor(t)
+        sf.Rating = nominalRating
+        estimate.ScaleFactors = append(estimate.ScaleFactors, sf)
+    }
+    for _, t := range CostDriverTypes {
+        cd := NewCostDriver(t)
+        cd.SetRating(nominalRating)
+        estimate.CostDrivers = append(estimate.CostDrivers, cd)
+    }
+    return estimate
+}
+
+// rate sets the rating of a cost driver of the estimate
+func (e *COCOMOEstimate) rate(t CostDriverType, rating float64) {
+    for i := range e.CostDrivers {
+        if e.CostDrivers[i].Type == t {
+            e.CostDrivers[i].SetRating(rating)
+        }
+    }
+}
+
+func approxEqual(a, b, tolerance float64) bool {
+    return math.Abs(a-b) <= tolerance
+}
+
+func TestNominalExponentB(t *testing.T) {
+    estimate := nominalEstimate(100)
+    // COCOMO II.2000: B = 0.91 + 0.01 * 18.97 at nominal scale factors
+    if b := exponentB(estimate.Model, estimate.ScaleFactors); !approxEqual(b, 1.0997, 1e-9) {
+        t.Errorf("nominal B = %v, want 1.0997", b)
+    }
+
+    // Extra High removes the scale factors from the exponent, Very Low adds the most
+    for i := range estimate.ScaleFactors {
+        estimate.ScaleFactors[i].Rating = MaxRating
+    }
+    if b := exponentB(estimate.Model, estimate.ScaleFactors); !approxEqual(b, estimate.Model.B, 1e-9) {
+        t.Errorf("B at Extra High = %v, want the model's %v", b, estimate.Model.B)
+    }
+    for i := range estimate.ScaleFactors {
+        estimate.ScaleFactors[i].Rating = MinRating
+    }
+    if b := exponentB(estimate.Model, estimate.ScaleFactors); b <= 1.0997 {
+        t.Errorf("B at Very Low = %v, want above the nominal 1.0997", b)
+    }
+}
+
+func TestEffectiveSizeOfPureNewCode(t *testing.T) {
+    estimate := nominalEstimate(50)
+    if size := estimate.EffectiveSize(); size != 50 {
+        t.Errorf("EffectiveSize = %v, want the raw size 50 without reused code", size)
+    }
+
+    // Adapted code that is redesigned, recoded and retested in full counts as new code
+    rewritten := ReuseComponent{AdaptedSize: 20, DM: 100, CM: 100, IM: 100}
+    if size := rewritten.EquivalentSLOC(); !approxEqual(size, 20, 1e-9) {
+        t.Errorf("EquivalentSLOC = %v, want 20 for fully rewritten code", size)
+    }
+}
+
+func TestEquivalentSLOCOfReusedCodeWithLowUnderstanding(t *testing.T) {
+    // Reused unmodified but integrated and retested, by programmers who neither understand nor know it
+    reused := ReuseComponent{AdaptedSize: 100, DM: 0, CM: 0, IM: 100, AA: 8, SU: 50, UNFM: 1}
+
+    // AAF = 0.3 * 100 = 30; AAM = (8 + 30 * (1 + 0.02 * 50 * 1)) / 100 = 0.68
+    if size := reused.EquivalentSLOC(); !approxEqual(size, 68, 1e-9) {
+        t.Errorf("EquivalentSLOC = %v, want 68", size)
+    }
+
+    understood := reused
+    understood.SU = 10
+    understood.UNFM = 0
+    if reused.EquivalentSLOC() <= understood.EquivalentSLOC() {
+        t.Errorf("low understanding: %v, want more than the %v of well understood code", reused.EquivalentSLOC(), understood.EquivalentSLOC())
+    }
+}
+
+func TestReuseComponentValidate(t *testing.T) {
+    valid := ReuseComponent{Name: "lib", AdaptedSize: 10, DM: 10, CM: 20, IM: 30, AA: 4, SU: 30, UNFM: 0.4}
+    if err := valid.Validate(); err != nil {
+        t.Fatalf("got %v, want a valid component", err)
+    }
+
+    tests := []struct {
+        name   string
+        modify func(*ReuseComponent)
+    }{
+        {"negative size", func(r *ReuseComponent) { r.AdaptedSize = -1 }},
+        {"negative DM", func(r *ReuseComponent) { r.DM = -100 }},
+        {"CM above 100", func(r *ReuseComponent) { r.CM = 101 }},
+        {"negative IM", func(r *ReuseComponent) { r.IM = -1 }},
+        {"AA above 8", func(r *ReuseComponent) { r.AA = 9 }},
+        {"SU below 10", func(r *ReuseComponent) { r.SU = 5 }},
+        {"SU above 50", func(r *ReuseComponent) { r.SU = 51 }},
+        {"UNFM above 1", func(r *ReuseComponent) { r.UNFM = 1.5 }},
+        {"NaN DM", func(r *ReuseComponent) { r.DM = math.NaN() }},
+    }
+    for _, tt := range tests {
+        component := valid
+        tt.modify(&component)
+        if err := component.Validate(); err == nil {
+            t.Errorf("%s: got no error", tt.name)
+        }
+    }
+}
+
+func TestCalculateEffortIncludesEquivalentSize(t *testing.T) {
+    withReuse := nominalEstimate(40)
+    withReuse.ReuseComponents = []ReuseComponent{{AdaptedSize: 100, IM: 100, AA: 8, SU: 50, UNFM: 1}}
+    withReuse.CalculateEffort()
+
+    newOnly := nominalEstimate(40 + 68)
+    newOnly.CalculateEffort()
+
+    if !approxEqual(withReuse.EffortPM, newOnly.EffortPM, 1e-9) {
+        t.Errorf("EffortPM = %v, want %v as for the new plus equivalent size", withReuse.EffortPM, newOnly.EffortPM)
+    }
+}
+
+func TestScheduleConstraintCompressesDuration(t *testing.T) {
+    nominal := nominalEstimate(100)
+    nominal.CalculateEffort()
+
+    // SCED Low requires 85% of the nominal schedule
+    compressed := nominalEstimate(100)
+    compressed.rate(CostDriverSCED, 1)
+    compressed.CalculateEffort()
+
+    if percent := compressed.SchedulePercent(); percent != 0.85 {
+        t.Fatalf("SchedulePercent = %v, want 0.85", percent)
+    }
+    if want := nominal.DurationTM * 0.85; !approxEqual(compressed.DurationTM, want, 1e-9) {
+        t.Errorf("DurationTM = %v, want 85%% of the nominal %v", compressed.DurationTM, nominal.DurationTM)
+    }
+    // The compression costs effort, spread over the shorter schedule by a larger team
+    if want := nominal.EffortPM * 1.14; !approxEqual(compressed.EffortPM, want, 1e-9) {
+        t.Errorf("EffortPM = %v, want %v with the SCED multiplier 1.14", compressed.EffortPM, want)
+    }
+    if compressed.TeamSize <= nominal.TeamSize {
+        t.Errorf("TeamSize = %v, want more than the nominal %v", compressed.TeamSize, nominal.TeamSize)
+    }
+}
+
+func TestScheduleConstraintIsBoundedAt75Percent(t *testing.T) {
+    nominal := nominalEstimate(100)
+    nominal.CalculateEffort()
+
+    veryLow := nominalEstimate(100)
+    veryLow.rate(CostDriverSCED, 0)
+    veryLow.CalculateEffort()
+
+    if want := nominal.DurationTM * MinSchedulePercent; !approxEqual(veryLow.DurationTM, want, 1e-9) {
+        t.Errorf("DurationTM = %v, want 75%% of the nominal %v", veryLow.DurationTM, nominal.DurationTM)
+    }
+}
+
+func TestScheduleExpansionKeepsNominalDuration(t *testing.T) {
+    nominal := nominalEstimate(100)
+    nominal.CalculateEffort()
+
+    stretched := nominalEstimate(100)
+    stretched.rate(CostDriverSCED, 4)
+    stretched.CalculateEffort()
+
+    if stretched.DurationTM != nominal.DurationTM {
+        t.Errorf("DurationTM = %v, want the nominal %v", stretched.DurationTM, nominal.DurationTM)
+    }
+}
@@ -0,0 +1,121 @@
+package domain
+
+// ProcessDelta represents the hour difference of a single process between two estimates.
+// A process missing from one estimate counts as 0 hours on that side.
+type ProcessDelta struct {
+    ProcessID   string
+    ProcessName string
+    Hours1      float64
+    Hours2      float64
+    HoursDiff   float64 // Hours2 - Hours1
+    InEstimate1 bool
+    InEstimate2 bool
+}
+
+// FactorDifference represents a global factor that is applied differently by two estimates
+type FactorDifference struct {
+    FactorID    string
+    Name        string
+    Impact1     float64 // 0 when the factor isn't applied by estimate 1
+    Impact2     float64 // 0 when the factor isn't applied by estimate 2
+    InEstimate1 bool
+    InEstimate2 bool
+}
+
+// EstimateComparison represents the result of comparing two estimates
+type EstimateComparison struct {
+    EstimateID1       string
+    EstimateID2       string
+    TotalHours1       float64
+    TotalHours2       float64
+    TotalHoursDiff    float64 // TotalHours2 - TotalHours1
+    PercentChange     float64 // Relative to TotalHours1; 0 when TotalHours1 is 0
+    Processes         []ProcessDelta
+    FactorDifferences []FactorDifference
+}
+
+// CompareEstimates compares two estimates process by process and lists the global factors that differ.
+// Processes are listed in the order of estimate 1, followed by those only present in estimate 2.
+func CompareEstimates(e1, e2 *Estimate) *EstimateComparison {
+    comparison := &EstimateComparison{
+        EstimateID1:       e1.ID,
+        EstimateID2:       e2.ID,
+        TotalHours1:       e1.TotalHours,
+        TotalHours2:       e2.TotalHours,
+        TotalHoursDiff:    e2.TotalHours - e1.TotalHours,
+        Processes:         []ProcessDelta{},
+        FactorDifferences: []FactorDifference{},
+    }
+    if e1.TotalHours != 0 {
+        comparison.PercentChange = comparison.TotalHoursDiff / e1.TotalHours * 100
+    }
+
+    index := make(map[string]int)
+    for _, pe := range e1.ProcessEstimates {
+        id, name := processKey(pe)
+        index[id] = len(comparison.Processes)
+        comparison.Processes = append(comparison.Processes, ProcessDelta{
+            ProcessID:   id,
+            ProcessName: name,
+            Hours1:      pe.TotalHours,
+            InEstimate1: true,
+        })
+    }
+    for _, pe := range e2.ProcessEstimates {
+        id, name := processKey(pe)
+        i, ok := index[id]
+        if !ok {
+            i = len(comparison.Processes)
+            index[id] = i
+            comparison.Processes = append(comparison.Processes, ProcessDelta{ProcessID: id, ProcessName: name})
+        }
+        comparison.Processes[i].Hours2 = pe.TotalHours
+        comparison.Processes[i].InEstimate2 = true
+    }
+    for i := range comparison.Processes {
+        delta := &comparison.Processes[i]
+        delta.HoursDiff = delta.Hours2 - delta.Hours1
+    }
+
+    comparison.FactorDifferences = compareFactors(e1.GlobalFactors, e2.GlobalFactors)
+    return comparison
+}
+
+// processKey returns the ID and name of the process of a process estimate
+func processKey(pe ProcessEstimate) (string, string) {
+    if pe.Process == nil {
+        return "", ""
+    }
+    return pe.Process.ID, pe.Process.Name
+}
+
+// compareFactors lists the factors applied by only one side or with a different impact
+func compareFactors(factors1, factors2 []Factor) []FactorDifference {
+    byID2 := make(map[string]Factor, len(factors2))
+    for _, f := range factors2 {
+        byID2[f.ID] = f
+    }
+
+    differences := []FactorDifference{}
+    seen := make(map[string]bool, len(factors1))
+    for _, f1 := range factors1 {
+        seen[f1.ID] = true
+        f2, ok := byID2[f1.ID]
+        if ok && f1.Impact == f2.Impact {
+            continue
+        }
+        diff := FactorDifference{FactorID: f1.ID, Name: f1.Name, Impact1: f1.Impact, InEstimate1: true}
+        if ok {
+            diff.Impact2 = f2.Impact
+            diff.InEstimate2 = true
+        }
+        differences = append(differences, diff)
+    }
+    for _, f2 := range factors2 {
+        if seen[f2.ID] {
+            continue
+        }
+        differences = append(differences, FactorDifference{FactorID: f2.ID, Name: f2.Name, Impact2: f2.Impact, InEstimate2: true})
+    }
+    return differences
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "testing"
+
+func TestCompareEstimatesWithPartlySharedProcesses(t *testing.T) {
+    design := &Process{ID: "design", Name: "基本設計"}
+    implementation := &Process{ID: "implementation", Name: "実装"}
+    testProcess := &Process{ID: "testing", Name: "テスト"}
+    security := Factor{ID: "security", Name: "セキュリティ要件厳格", Impact: 1.2}
+    veteran := Factor{ID: "veteran", Name: "熟練チーム", Impact: 0.8}
+    shared := Factor{ID: "shared", Name: "システム間連携多数", Impact: 1.1}
+
+    e1 := &Estimate{
+        ID:         "e1",
+        TotalHours: 300,
+        ProcessEstimates: []ProcessEstimate{
+            {Process: design, TotalHours: 100},
+            {Process: implementation, TotalHours: 200},
+        },
+        GlobalFactors: []Factor{shared, security},
+    }
+    e2 := &Estimate{
+        ID:         "e2",
+        TotalHours: 450,
+        ProcessEstimates: []ProcessEstimate{
+            {Process: implementation, TotalHours: 250},
+            {Process: testProcess, TotalHours: 200},
+        },
+        GlobalFactors: []Factor{shared, veteran},
+    }
+
+    comparison := CompareEstimates(e1, e2)
+
+    if comparison.TotalHoursDiff != 150 || comparison.PercentChange != 50 {
+        t.Errorf("diff = %v hours, %v%%, want 150 hours, 50%%", comparison.TotalHoursDiff, comparison.PercentChange)
+    }
+
+    want := []ProcessDelta{
+        {ProcessID: "design", ProcessName: "基本設計", Hours1: 100, HoursDiff: -100, InEstimate1: true},
+        {ProcessID: "implementation", ProcessName: "実装", Hours1: 200, Hours2: 250, HoursDiff: 50, InEstimate1: true, InEstimate2: true},
+        {ProcessID: "testing", ProcessName: "テスト", Hours2: 200, HoursDiff: 200, InEstimate2: true},
+    }
+    if len(comparison.Processes) != len(want) {
+        t.Fatalf("got %d process deltas, want %d", len(comparison.Processes), len(want))
+    }
+    for i := range want {
+        if comparison.Processes[i] != want[i] {
+            t.Errorf("process %d = %+v, want %+v", i, comparison.Processes[i], want[i])
+        }
+    }
+
+    // The shared factor with the same impact is not a difference
+    if len(comparison.FactorDifferences) != 2 {
+        t.Fatalf("factor differences = %+v, want security and veteran", comparison.FactorDifferences)
+    }
+    if d := comparison.FactorDifferences[0]; d.FactorID != "security" || !d.InEstimate1 || d.InEstimate2 {
+        t.Errorf("first difference = %+v, want security only in estimate 1", d)
+    }
+    if d := comparison.FactorDifferences[1]; d.FactorID != "veteran" || d.InEstimate1 || !d.InEstimate2 {
+        t.Errorf("second difference = %+v, want veteran only in estimate 2", d)
+    }
+}
+
+func TestCompareEstimatesChangedFactorImpact(t *testing.T) {
+    e1 := &Estimate{GlobalFactors: []Factor{{ID: "f", Impact: 1.2}}}
+    e2 := &Estimate{GlobalFactors: []Factor{{ID: "f", Impact: 1.4}}}
+
+    differences := CompareEstimates(e1, e2).FactorDifferences
+    if len(differences) != 1 || differences[0].Impact1 != 1.2 || differences[0].Impact2 != 1.4 {
+        t.Errorf("differences = %+v, want the changed impact", differences)
+    }
+    if CompareEstimates(e1, e2).PercentChange != 0 {
+        t.Error("PercentChange is not 0 for a zero-hour estimate")
+    }
+}
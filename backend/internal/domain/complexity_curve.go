@@ -0,0 +1,37 @@
+package domain
+
+import "math"
+
+// ComplexityCurve represents how the hours of a task grow with its complexity (1-5)
+type ComplexityCurve string
+
+const (
+    ComplexityCurveLinear      ComplexityCurve = "linear"      // 0.8 + 0.2 × complexity
+    ComplexityCurveExponential ComplexityCurve = "exponential" // 1.5^(complexity - 3), 1.0 at complexity 3, for work whose effort compounds
+    ComplexityCurveFlat        ComplexityCurve = "flat"        // 1.0, for work that hardly depends on complexity
+)
+
+// exponentialComplexityBase is the growth per complexity step of the exponential curve
+const exponentialComplexityBase = 1.5
+
+// IsValid reports whether the curve is known; empty selects the default
+func (c ComplexityCurve) IsValid() bool {
+    switch c {
+    case "", ComplexityCurveLinear, ComplexityCurveExponential, ComplexityCurveFlat:
+        return true
+    }
+    return false
+}
+
+// Multiplier returns the factor applied to the base hours of a task of the given complexity.
+// The linear curve is the default.
+func (c ComplexityCurve) Multiplier(complexity int) float64 {
+    switch c {
+    case ComplexityCurveExponential:
+        return math.Pow(exponentialComplexityBase, float64(complexity-3))
+    case ComplexityCurveFlat:
+        return 1.0
+    default:
+        return 0.8 + float64(complexity)*0.2
+    }
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "testing"
+
+func TestComplexityCurvesAtComplexityFive(t *testing.T) {
+    task := Task{Complexity: 5, Scale: 1}
+
+    linear := task.CalculateBaseHours(Activity{BaseHours: 40, ComplexityCurve: ComplexityCurveLinear}, 0)
+    exponential := task.CalculateBaseHours(Activity{BaseHours: 40, ComplexityCurve: ComplexityCurveExponential}, 0)
+
+    // 40 × (0.8 + 5×0.2) and 40 × 1.5²
+    if !approxEqual(linear, 72, 1e-9) {
+        t.Errorf("linear = %v, want 72", linear)
+    }
+    if !approxEqual(exponential, 90, 1e-9) {
+        t.Errorf("exponential = %v, want 90", exponential)
+    }
+    if def := task.CalculateBaseHours(Activity{BaseHours: 40}, 0); def != linear {
+        t.Errorf("default curve gives %v, want the linear %v", def, linear)
+    }
+}
+
+func TestActivityCurveOverridesProcessCurve(t *testing.T) {
+    process := &Process{ComplexityCurve: ComplexityCurveExponential}
+
+    if curve := process.CurveFor(Activity{}); curve != ComplexityCurveExponential {
+        t.Errorf("curve = %s, want the process's exponential curve", curve)
+    }
+    if curve := process.CurveFor(Activity{ComplexityCurve: ComplexityCurveFlat}); curve != ComplexityCurveFlat {
+        t.Errorf("curve = %s, want the activity's flat curve", curve)
+    }
+    if ComplexityCurve("quadratic").IsValid() {
+        t.Error("unknown curve reported valid")
+    }
+}
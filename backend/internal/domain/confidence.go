@@ -0,0 +1,47 @@
+package domain
+
+// PlanningProcesses are the early processes whose deliverables narrow the cone of uncertainty, in order
+var PlanningProcesses = []ProcessCategory{
+    ProcessRequirementDefinition,
+    ProcessFunctionalSpec,
+    ProcessBasicDesign,
+    ProcessDetailedDesign,
+}
+
+// coneOfUncertainty holds the upper range multiplier at each planning milestone:
+// initial concept, then after each of the PlanningProcesses is complete
+var coneOfUncertainty = []float64{4.0, 2.0, 1.5, 1.25, 1.1}
+
+// PlanningMaturityConfidence derives a confidence level (0-1) from the completion ratio (0-1)
+// of each planning process, given in the order of PlanningProcesses
+func PlanningMaturityConfidence(completion []float64) float64 {
+    multiplier := coneOfUncertainty[0]
+
+    for i, ratio := range completion {
+        if i+1 >= len(coneOfUncertainty) {
+            break
+        }
+        if ratio < 0 {
+            ratio = 0
+        }
+
+        // The cone narrows along the current phase until it is complete
+        if ratio < 1 {
+            multiplier = coneOfUncertainty[i] - (coneOfUncertainty[i]-coneOfUncertainty[i+1])*ratio
+            break
+        }
+        multiplier = coneOfUncertainty[i+1]
+    }
+
+    return 1 / multiplier
+}
+
+// IsDeliverableCompleted reports whether the deliverable has been marked as completed
+func (e *Estimate) IsDeliverableCompleted(deliverable string) bool {
+    for _, d := range e.CompletedDeliverables {
+        if d == deliverable {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestPlanningMaturityConfidenceFollowsCone(t *testing.T) {
+    tests := []struct {
+        name       string
+        completion []float64
+        want       float64
+    }{
+        {"nothing done", []float64{0, 0, 0, 0}, 1 / 4.0},
+        {"half the requirements", []float64{0.5, 0, 0, 0}, 1 / 3.0},
+        {"requirements done", []float64{1, 0, 0, 0}, 1 / 2.0},
+        {"specification done", []float64{1, 1, 0, 0}, 1 / 1.5},
+        {"all planning done", []float64{1, 1, 1, 1}, 1 / 1.1},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := PlanningMaturityConfidence(tt.completion); !approxEqual(got, tt.want, 1e-9) {
+                t.Errorf("PlanningMaturityConfidence(%v) = %v, want %v", tt.completion, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestPlanningMaturityConfidenceWaitsForEarlierPhases(t *testing.T) {
+    // Later deliverables do not narrow the cone while the requirements are still open
+    open := PlanningMaturityConfidence([]float64{0, 1, 1, 1})
+    if want := PlanningMaturityConfidence([]float64{0, 0, 0, 0}); open != want {
+        t.Errorf("confidence = %v, want %v while the requirements are open", open, want)
+    }
+}
@@ -0,0 +1,94 @@
+package domain
+
+// DefaultHoursPerPersonMonth is the number of working hours in a person-month unless configured otherwise
+const DefaultHoursPerPersonMonth = 160.0
+
+// EstimationConfig holds the organization-wide settings used when converting between hours and person-months
+type EstimationConfig struct {
+    HoursPerPersonMonth float64 // Working hours in a person-month; varies by country and contract
+    Risk                RiskConfig
+    TeamOverhead        TeamOverheadConfig
+}
+
+// TeamOverheadConfig holds the communication overhead added to the effort of large planned teams.
+// Above the threshold the overhead grows with the number of communication channels, n(n-1)/2:
+// multiplier = 1 + Rate × (channels(planned) / channels(Threshold) - 1).
+type TeamOverheadConfig struct {
+    Threshold float64 // Planned team sizes above this add overhead; at least 2
+    Rate      float64 // Extra effort per multiple of the channels at the threshold, e.g. 0.05 for 5%
+}
+
+// DefaultTeamOverheadConfig returns the team overhead used unless configured otherwise
+func DefaultTeamOverheadConfig() TeamOverheadConfig {
+    return TeamOverheadConfig{Threshold: 10, Rate: 0.05}
+}
+
+// Multiplier returns the effort multiplier of a planned team size, 1.0 up to the threshold
+func (c TeamOverheadConfig) Multiplier(plannedTeamSize float64) float64 {
+    if c.Threshold < 2 || plannedTeamSize <= c.Threshold {
+        return 1.0
+    }
+    channels := func(n float64) float64 { return n * (n - 1) / 2 }
+    return 1 + c.Rate*(channels(plannedTeamSize)/channels(c.Threshold)-1)
+}
+
+// RiskConfig holds the thresholds used to assess the risk of a COCOMO II estimate
+type RiskConfig struct {
+    ScaleFactorRating float64 // Scale factors rated below this are high risks
+    CostDriverValue   float64 // Cost drivers with an effort multiplier above this are high risks
+    HighRiskCount     int     // Number of high risks from which the project is High risk
+    MediumRiskCount   int     // Number of high risks from which the project is Medium risk
+    LargeProjectSize  float64 // Projects larger than this carry a size risk
+    LargeEffortPM     float64 // Estimates above this effort carry a cost risk
+    LargeTeamSize     float64 // Estimates above this average staff carry a cost risk
+    MediumRiskScore   float64 // Risk score from which the project is in the Medium band
+    HighRiskScore     float64 // Risk score from which the project is in the High band
+}
+
+// DefaultRiskConfig returns the risk thresholds used unless configured otherwise
+func DefaultRiskConfig() RiskConfig {
+    return RiskConfig{
+        ScaleFactorRating: 1.0,
+        CostDriverValue:   1.3,
+        HighRiskCount:     3,
+        MediumRiskCount:   1,
+        LargeProjectSize:  100,
+        LargeEffortPM:     500,
+        LargeTeamSize:     20,
+        MediumRiskScore:   20,
+        HighRiskScore:     50,
+    }
+}
+
+// DefaultEstimationConfig returns the configuration used when nothing else is set
+func DefaultEstimationConfig() EstimationConfig {
+    return EstimationConfig{
+        HoursPerPersonMonth: DefaultHoursPerPersonMonth,
+        Risk:                DefaultRiskConfig(),
+        TeamOverhead:        DefaultTeamOverheadConfig(),
+    }
+}
+
+// MonthlyHours returns the hours per person-month, falling back to the default when unset
+func (c EstimationConfig) MonthlyHours() float64 {
+    if c.HoursPerPersonMonth <= 0 {
+        return DefaultHoursPerPersonMonth
+    }
+    return c.HoursPerPersonMonth
+}
+
+// RiskThresholds returns the risk thresholds, falling back to the defaults when unset
+func (c EstimationConfig) RiskThresholds() RiskConfig {
+    if c.Risk == (RiskConfig{}) {
+        return DefaultRiskConfig()
+    }
+    return c.Risk
+}
+
+// TeamOverheadSettings returns the team overhead, falling back to the default when unset
+func (c EstimationConfig) TeamOverheadSettings() TeamOverheadConfig {
+    if c.TeamOverhead == (TeamOverheadConfig{}) {
+        return DefaultTeamOverheadConfig()
+    }
+    return c.TeamOverhead
+}
@@ -0,0 +1,67 @@
+package domain
+
+import "testing"
+
+func TestMonthlyHoursFallsBackToDefault(t *testing.T) {
+    if hours := (EstimationConfig{}).MonthlyHours(); hours != DefaultHoursPerPersonMonth {
+        t.Errorf("MonthlyHours = %v, want the default %v when unset", hours, DefaultHoursPerPersonMonth)
+    }
+    if hours := (EstimationConfig{HoursPerPersonMonth: 140}).MonthlyHours(); hours != 140 {
+        t.Errorf("MonthlyHours = %v, want the configured 140", hours)
+    }
+}
+
+func TestHoursPerPersonMonthScalesPersonMonths(t *testing.T) {
+    implementation := &Process{ID: "implementation", Category: ProcessImplementation, Activities: []Activity{{ID: "a1", BaseHours: 10}}}
+    repo := newProcessStore(implementation)
+    estimate := &Estimate{ProcessEstimates: []ProcessEstimate{
+        {Process: implementation, Tasks: []Task{{ActivityID: "a1", Optimistic: 700, MostLikely: 700, Pessimistic: 700}}},
+    }}
+
+    standard, err := estimate.calculateActivityBased(repo, EstimationConfig{HoursPerPersonMonth: 160})
+    if err != nil {
+        t.Fatal(err)
+    }
+    shorter, err := estimate.calculateActivityBased(repo, EstimationConfig{HoursPerPersonMonth: 140})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if shorter.TotalHours != standard.TotalHours {
+        t.Errorf("TotalHours = %v, want the unchanged %v", shorter.TotalHours, standard.TotalHours)
+    }
+    if want := standard.PersonMonths * 160 / 140; !approxEqual(shorter.PersonMonths, want, 1e-9) {
+        t.Errorf("PersonMonths = %v, want %v at 140 hours per person-month", shorter.PersonMonths, want)
+    }
+
+    // The COCOMO II effort converts to proportionally fewer hours
+    cocomo := nominalEstimate(50)
+    cocomo.CalculateEffort()
+    withCOCOMO := &Estimate{COCOMOEstimate: cocomo}
+    if hours := withCOCOMO.calculateCOCOMOBased(EstimationConfig{HoursPerPersonMonth: 140}).TotalHours; !approxEqual(hours, cocomo.EffortPM*140, 1e-9) {
+        t.Errorf("COCOMO TotalHours = %v, want %v", hours, cocomo.EffortPM*140)
+    }
+}
+
+func TestTeamOverheadMultiplier(t *testing.T) {
+    config := DefaultTeamOverheadConfig()
+
+    tests := []struct {
+        planned float64
+        want    float64
+    }{
+        {0, 1.0},
+        {config.Threshold, 1.0},
+        // 190 channels are 190/45 of those at 10, so 5% × (190/45 - 1)
+        {20, 1 + 0.05*(190.0/45-1)},
+        {40, 1 + 0.05*(780.0/45-1)},
+    }
+    for _, tt := range tests {
+        if got := config.Multiplier(tt.planned); !approxEqual(got, tt.want, 1e-9) {
+            t.Errorf("planned team %v: multiplier %v, want %v", tt.planned, got, tt.want)
+        }
+    }
+    if got := (TeamOverheadConfig{Threshold: 1, Rate: 0.05}).Multiplier(40); got != 1.0 {
+        t.Errorf("threshold below 2: multiplier %v, want 1.0", got)
+    }
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+    "errors"
+    "fmt"
+)
+
+// ErrInvalidDependencies is returned when task dependencies refer to unknown tasks or form a cycle
+var ErrInvalidDependencies = errors.New("invalid task dependencies")
+
+// CriticalPathResult represents the longest chain of dependent tasks and the resulting minimum duration
+type CriticalPathResult struct {
+    Path          []string // Task IDs from the first to the last task of the chain
+    DurationHours float64  // Minimum calendar duration assuming unlimited parallelism
+}
+
+// CriticalPath calculates the longest chain of dependent tasks, where each task starts once all
+// the tasks it depends on are finished. Independent tasks run in parallel, so without any
+// dependencies the duration is that of the longest single task.
+// Ties are broken in favour of the task listed first.
+func CriticalPath(tasks []Task, hours map[string]float64) (path []string, durationHours float64, err error) {
+    index := make(map[string]int, len(tasks))
+    for i, task := range tasks {
+        index[task.ID] = i
+    }
+
+    // Count the unfinished predecessors of each task
+    pending := make([]int, len(tasks))
+    successors := make([][]int, len(tasks))
+    for i, task := range tasks {
+        for _, dep := range task.Dependencies {
+            j, ok := index[dep]
+            if !ok {
+                return nil, 0, fmt.Errorf("task %s depends on unknown task %s: %w", task.ID, dep, ErrInvalidDependencies)
+            }
+            pending[i]++
+            successors[j] = append(successors[j], i)
+        }
+    }
+
+    // Process the tasks in topological order, tracking the earliest finish of each task
+    finish := make([]float64, len(tasks))
+    previous := make([]int, len(tasks))
+    var queue []int
+    for i := range tasks {
+        previous[i] = -1
+        if pending[i] == 0 {
+            queue = append(queue, i)
+        }
+    }
+
+    processed := 0
+    last := -1
+    for len(queue) > 0 {
+        i := queue[0]
+        queue = queue[1:]
+        processed++
+
+        finish[i] += hours[tasks[i].ID]
+        if last == -1 || finish[i] > finish[last] {
+            last = i
+        }
+
+        for _, j := range successors[i] {
+            if previous[j] == -1 || finish[i] > finish[j] {
+                finish[j] = finish[i]
+                previous[j] = i
+            }
+            pending[j]--
+            if pending[j] == 0 {
+                queue = append(queue, j)
+            }
+        }
+    }
+    if processed < len(tasks) {
+        return nil, 0, fmt.Errorf("dependency cycle: %w", ErrInvalidDependencies)
+    }
+    if last == -1 {
+        return []string{}, 0, nil
+    }
+
+    for i := last; i != -1; i = previous[i] {
+        path = append([]string{tasks[i].ID}, path...)
+    }
+    return path, finish[last], nil
+}
+
+// TaskHours calculates the hours of every task including global factors and accessibility,
+// consistent with the process totals of the activity-based calculation
+func (e *Estimate) TaskHours(processRepo ProcessRepository) (map[string]float64, error) {
+    result := make(map[string]float64)
+
+    for _, pe := range e.ProcessEstimates {
+        process, err := processRepo.FindByID(pe.Process.ID)
+        if err != nil {
+            return nil, err
+        }
+
+        for _, task := range pe.Tasks {
+            hours, _ := task.CalculateHours(process, e.hoursPerStoryPoint())
+            for _, factor := range e.factorsFor(process.Category) {
+                hours = factor.Apply(hours)
+            }
+            if IsUIProcess(process.Category) {
+                hours *= e.AccessibilityLevel.Multiplier()
+            }
+            result[task.ID] = hours
+        }
+    }
+
+    return result, nil
+}
+
+// CalculateCriticalPath calculates the critical path across all tasks of the estimate
+func (e *Estimate) CalculateCriticalPath(processRepo ProcessRepository) (*CriticalPathResult, error) {
+    hours, err := e.TaskHours(processRepo)
+    if err != nil {
+        return nil, err
+    }
+
+    var tasks []Task
+    for _, pe := range e.ProcessEstimates {
+        tasks = append(tasks, pe.Tasks...)
+    }
+
+    path, duration, err := CriticalPath(tasks, hours)
+    if err != nil {
+        return nil, err
+    }
+    return &CriticalPathResult{Path: path, DurationHours: duration}, nil
+}
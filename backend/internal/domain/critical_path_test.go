@@ -0,0 +1,69 @@
+package domain
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+)
+
+func TestCriticalPathWithoutDependencies(t *testing.T) {
+    tasks := []Task{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+    hours := map[string]float64{"a": 10, "b": 30, "c": 20}
+
+    path, duration, err := CriticalPath(tasks, hours)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // Everything runs in parallel, so only the longest task counts
+    if !reflect.DeepEqual(path, []string{"b"}) || duration != 30 {
+        t.Errorf("path = %v, duration = %v, want [b] and 30", path, duration)
+    }
+}
+
+func TestCriticalPathOfSerialChain(t *testing.T) {
+    tasks := []Task{
+        {ID: "c", Dependencies: []string{"b"}},
+        {ID: "b", Dependencies: []string{"a"}},
+        {ID: "a"},
+    }
+    hours := map[string]float64{"a": 10, "b": 30, "c": 20}
+
+    path, duration, err := CriticalPath(tasks, hours)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !reflect.DeepEqual(path, []string{"a", "b", "c"}) || duration != 60 {
+        t.Errorf("path = %v, duration = %v, want [a b c] and the sum 60", path, duration)
+    }
+}
+
+func TestCriticalPathOfDiamond(t *testing.T) {
+    // a fans out to b and c, which both lead to d; the longer branch is critical
+    tasks := []Task{
+        {ID: "a"},
+        {ID: "b", Dependencies: []string{"a"}},
+        {ID: "c", Dependencies: []string{"a"}},
+        {ID: "d", Dependencies: []string{"b", "c"}},
+    }
+    hours := map[string]float64{"a": 5, "b": 10, "c": 25, "d": 5}
+
+    path, duration, err := CriticalPath(tasks, hours)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !reflect.DeepEqual(path, []string{"a", "c", "d"}) || duration != 35 {
+        t.Errorf("path = %v, duration = %v, want [a c d] and 35", path, duration)
+    }
+}
+
+func TestCriticalPathRejectsInvalidDependencies(t *testing.T) {
+    cycle := []Task{{ID: "a", Dependencies: []string{"b"}}, {ID: "b", Dependencies: []string{"a"}}}
+    if _, _, err := CriticalPath(cycle, nil); !errors.Is(err, ErrInvalidDependencies) {
+        t.Errorf("got %v, want ErrInvalidDependencies for a cycle", err)
+    }
+
+    unknown := []Task{{ID: "a", Dependencies: []string{"missing"}}}
+    if _, _, err := CriticalPath(unknown, nil); !errors.Is(err, ErrInvalidDependencies) {
+        t.Errorf("got %v, want ErrInvalidDependencies for an unknown task", err)
+    }
+}
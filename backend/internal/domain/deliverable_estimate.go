@@ -0,0 +1,76 @@
+package domain
+
+import "math"
+
+// DeliverableBaseHours returns the base hours of each deliverable of the activity for deliverable-based
+// estimation. Deliverables with hours in DeliverableHours use them; the others share what is left of
+// BaseHours evenly, so an activity without deliverable hours sums to its BaseHours.
+func (a Activity) DeliverableBaseHours() map[string]float64 {
+    hours := make(map[string]float64, len(a.Deliverables))
+    remaining := a.BaseHours
+    var unweighted []string
+    for _, deliverable := range a.Deliverables {
+        if h, ok := a.DeliverableHours[deliverable]; ok {
+            hours[deliverable] = h
+            remaining -= h
+        } else {
+            unweighted = append(unweighted, deliverable)
+        }
+    }
+    if len(unweighted) > 0 {
+        share := math.Max(0, remaining) / float64(len(unweighted))
+        for _, deliverable := range unweighted {
+            hours[deliverable] = share
+        }
+    }
+    return hours
+}
+
+// deliverableConfidence is the default confidence of deliverable-based estimation, which counts
+// outputs without the complexity and scale of the tasks
+const deliverableConfidence = 0.6
+
+// calculateDeliverableBased sums the deliverable hours of the activities the tasks select, each
+// selected activity counted once, and applies the process and global factors to each process total.
+// Activities without deliverables add nothing.
+func (e *Estimate) calculateDeliverableBased(processRepo ProcessRepository, config EstimationConfig) (*CalculationResult, error) {
+    var projectTotal float64
+    for i, pe := range e.ProcessEstimates {
+        process, err := processRepo.FindByID(pe.Process.ID)
+        if err != nil {
+            return nil, err
+        }
+
+        var processTotal float64
+        selected := make(map[string]bool)
+        for _, task := range pe.Tasks {
+            activity := process.Activity(task.ActivityID)
+            if activity == nil || selected[activity.ID] {
+                continue
+            }
+            selected[activity.ID] = true
+            for _, hours := range activity.DeliverableBaseHours() {
+                processTotal += hours
+            }
+        }
+
+        for _, factor := range e.factorsFor(process.Category) {
+            processTotal = factor.Apply(processTotal)
+        }
+
+        e.ProcessEstimates[i].DeliverableHours = processTotal
+        projectTotal += processTotal
+    }
+
+    personMonths := projectTotal / config.MonthlyHours()
+    teamSize, duration := StaffingForEffort(personMonths)
+
+    return &CalculationResult{
+        Method:         CalculationMethodDeliverable,
+        TotalHours:     projectTotal,
+        PersonMonths:   personMonths,
+        TeamSize:       teamSize,
+        DurationMonths: duration,
+        Confidence:     deliverableConfidence,
+    }, nil
+}
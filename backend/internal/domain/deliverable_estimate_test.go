@@ -0,0 +1,76 @@
+package domain
+
+import "testing"
+
+// deliverableEstimate returns a deliverable-based estimate of one task per activity of the process;
+// on a flat complexity curve each task takes the base hours of its activity
+func deliverableEstimate(process *Process) *Estimate {
+    tasks := make([]Task, len(process.Activities))
+    for i, activity := range process.Activities {
+        tasks[i] = Task{ActivityID: activity.ID, Complexity: 3, Scale: 1}
+    }
+    return &Estimate{
+        ProcessEstimates: []ProcessEstimate{{Process: process, Tasks: tasks}},
+        GlobalFactors:    []Factor{{Impact: 1.2}},
+        DeliverableBased: true,
+    }
+}
+
+func TestDeliverableBasedMatchesActivityBasedWithoutDeliverableHours(t *testing.T) {
+    process := &Process{ID: "design", Category: ProcessBasicDesign, ComplexityCurve: ComplexityCurveFlat, Activities: []Activity{
+        {ID: "a1", BaseHours: 10, Deliverables: []string{"spec", "diagram"}},
+        {ID: "a2", BaseHours: 20, Deliverables: []string{"review"}},
+    }}
+    estimate := deliverableEstimate(process)
+
+    results, err := estimate.CalculateResults(newProcessStore(process), DefaultEstimationConfig())
+    if err != nil {
+        t.Fatal(err)
+    }
+    // The deliverables share the base hours of their activity
+    if !approxEqual(results.Deliverable.TotalHours, results.Activity.TotalHours, 1e-9) {
+        t.Errorf("deliverable-based %v hours, want the activity-based %v", results.Deliverable.TotalHours, results.Activity.TotalHours)
+    }
+    if want := 30 * 1.2; !approxEqual(results.Deliverable.TotalHours, want, 1e-9) {
+        t.Errorf("deliverable-based %v hours, want %v with the global factor", results.Deliverable.TotalHours, want)
+    }
+    if results.Deliverable.Method != CalculationMethodDeliverable {
+        t.Errorf("method %q, want %q", results.Deliverable.Method, CalculationMethodDeliverable)
+    }
+}
+
+func TestDeliverableBasedDiffersFromActivityBasedWithDeliverableHours(t *testing.T) {
+    process := &Process{ID: "implementation", Category: ProcessImplementation, ComplexityCurve: ComplexityCurveFlat, Activities: []Activity{
+        {ID: "a1", BaseHours: 10, Deliverables: []string{"spec", "diagram"}},
+        {ID: "a2", BaseHours: 20, Deliverables: []string{"code", "review"}, DeliverableHours: map[string]float64{"code": 30}},
+    }}
+    estimate := deliverableEstimate(process)
+    // A second task on a1 adds activity hours but no deliverables
+    estimate.ProcessEstimates[0].Tasks = append(estimate.ProcessEstimates[0].Tasks, Task{ActivityID: "a1", Complexity: 3, Scale: 1})
+
+    results, err := estimate.CalculateResults(newProcessStore(process), DefaultEstimationConfig())
+    if err != nil {
+        t.Fatal(err)
+    }
+    // Activity-based: a1 twice and a2, 40 hours. Deliverable-based: a1's 10 hours once, code's 30
+    // and nothing left of a2's base hours for the review, 40 hours.
+    if want := 40 * 1.2; !approxEqual(results.Activity.TotalHours, want, 1e-9) {
+        t.Errorf("activity-based %v hours, want %v", results.Activity.TotalHours, want)
+    }
+    if want := 40 * 1.2; !approxEqual(results.Deliverable.TotalHours, want, 1e-9) {
+        t.Errorf("deliverable-based %v hours, want %v", results.Deliverable.TotalHours, want)
+    }
+
+    // Without the duplicate task the methods diverge by the deliverable hours above the activity's base hours
+    estimate.ProcessEstimates[0].Tasks = estimate.ProcessEstimates[0].Tasks[:2]
+    results, err = estimate.CalculateResults(newProcessStore(process), DefaultEstimationConfig())
+    if err != nil {
+        t.Fatal(err)
+    }
+    if got, want := results.Deliverable.TotalHours-results.Activity.TotalHours, 10*1.2; !approxEqual(got, want, 1e-9) {
+        t.Errorf("deliverable-based exceeds activity-based by %v hours, want %v", got, want)
+    }
+    if got := estimate.ProcessEstimates[0].DeliverableHours; !approxEqual(got, results.Deliverable.TotalHours, 1e-9) {
+        t.Errorf("process deliverable hours %v, want the process total %v", got, results.Deliverable.TotalHours)
+    }
+}
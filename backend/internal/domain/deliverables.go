@@ -0,0 +1,97 @@
+package domain
+
+// DeliverableItem represents a deliverable on the checklist of an estimate
+type DeliverableItem struct {
+    Name       string
+    Activities []string // Names of the selected activities that produce it
+    Completed  bool
+}
+
+// ProcessDeliverables represents the deliverables of one process of an estimate
+type ProcessDeliverables struct {
+    ProcessID    string
+    ProcessName  string
+    Category     ProcessCategory
+    Deliverables []DeliverableItem
+}
+
+// DeliverableChecklist represents the deliverables of the activities selected by an estimate
+type DeliverableChecklist struct {
+    EstimateID string
+    Processes  []ProcessDeliverables // In the order of the process estimates; processes without deliverables are omitted
+    Total      int
+    Completed  int
+}
+
+// DeliverableChecklist lists the deliverables of the activities the tasks of the estimate select,
+// using the current definitions of the processes. Each deliverable is listed once, under the first
+// process that produces it, however many tasks or activities select it.
+func (e *Estimate) DeliverableChecklist(processRepo ProcessRepository) (*DeliverableChecklist, error) {
+    checklist := &DeliverableChecklist{EstimateID: e.ID, Processes: []ProcessDeliverables{}}
+
+    // Position of each listed deliverable: process index and item index
+    listed := make(map[string][2]int)
+    for _, pe := range e.ProcessEstimates {
+        if pe.Process == nil {
+            continue
+        }
+        process, err := processRepo.FindByID(pe.Process.ID)
+        if err != nil {
+            return nil, err
+        }
+
+        group := ProcessDeliverables{ProcessID: process.ID, ProcessName: process.Name, Category: process.Category}
+        groupIndex := len(checklist.Processes)
+        selected := make(map[string]bool)
+        for _, task := range pe.Tasks {
+            activity := process.Activity(task.ActivityID)
+            if activity == nil || selected[activity.ID] {
+                continue
+            }
+            selected[activity.ID] = true
+
+            for _, deliverable := range activity.Deliverables {
+                if at, ok := listed[deliverable]; ok {
+                    var item *DeliverableItem
+                    if at[0] == groupIndex {
+                        item = &group.Deliverables[at[1]]
+                    } else {
+                        item = &checklist.Processes[at[0]].Deliverables[at[1]]
+                    }
+                    item.Activities = appendUnique(item.Activities, activity.Name)
+                    continue
+                }
+                listed[deliverable] = [2]int{groupIndex, len(group.Deliverables)}
+                group.Deliverables = append(group.Deliverables, DeliverableItem{
+                    Name:       deliverable,
+                    Activities: []string{activity.Name},
+                    Completed:  e.IsDeliverableCompleted(deliverable),
+                })
+            }
+        }
+
+        if len(group.Deliverables) > 0 {
+            checklist.Processes = append(checklist.Processes, group)
+        }
+    }
+
+    for _, group := range checklist.Processes {
+        for _, item := range group.Deliverables {
+            checklist.Total++
+            if item.Completed {
+                checklist.Completed++
+            }
+        }
+    }
+    return checklist, nil
+}
+
+// appendUnique appends s unless values already contains it
+func appendUnique(values []string, s string) []string {
+    for _, v := range values {
+        if v == s {
+            return values
+        }
+    }
+    return append(values, s)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestDeliverableChecklistListsEachDeliverableOnce(t *testing.T) {
+    design := &Process{ID: "design", Name: "Design", Category: ProcessBasicDesign, Activities: []Activity{
+        {ID: "a1", Name: "Screens", Deliverables: []string{"spec", "diagram"}},
+        {ID: "a2", Name: "Batches", Deliverables: []string{"spec", "review"}},
+    }}
+    implementation := &Process{ID: "implementation", Name: "Implementation", Category: ProcessImplementation, Activities: []Activity{
+        {ID: "a3", Name: "Coding", Deliverables: []string{"code", "diagram"}},
+        {ID: "a4", Name: "Unused", Deliverables: []string{"unused"}},
+    }}
+    estimate := &Estimate{
+        ID: "e1",
+        ProcessEstimates: []ProcessEstimate{
+            {Process: design, Tasks: []Task{{ActivityID: "a1"}, {ActivityID: "a1"}, {ActivityID: "a2"}}},
+            {Process: implementation, Tasks: []Task{{ActivityID: "a3"}}},
+        },
+        CompletedDeliverables: []string{"code"},
+    }
+
+    checklist, err := estimate.DeliverableChecklist(newProcessStore(design, implementation))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    want := []ProcessDeliverables{
+        {ProcessID: "design", ProcessName: "Design", Category: ProcessBasicDesign, Deliverables: []DeliverableItem{
+            {Name: "spec", Activities: []string{"Screens", "Batches"}},
+            {Name: "diagram", Activities: []string{"Screens", "Coding"}},
+            {Name: "review", Activities: []string{"Batches"}},
+        }},
+        {ProcessID: "implementation", ProcessName: "Implementation", Category: ProcessImplementation, Deliverables: []DeliverableItem{
+            {Name: "code", Activities: []string{"Coding"}, Completed: true},
+        }},
+    }
+    if !reflect.DeepEqual(checklist.Processes, want) {
+        t.Errorf("checklist\n%+v\nwant\n%+v", checklist.Processes, want)
+    }
+    if checklist.Total != 4 || checklist.Completed != 1 {
+        t.Errorf("total %d with %d completed, want 4 with 1", checklist.Total, checklist.Completed)
+    }
+}
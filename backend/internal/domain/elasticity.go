@@ -0,0 +1,70 @@
+package domain
+
+import (
+    "math"
+    "sort"
+)
+
+// elasticityStep is the rating perturbation of the elasticities as a share of the rating range,
+// so every factor is moved by the same relative amount (half a rating level)
+const elasticityStep = 0.1
+
+// Kinds of the factors in a sensitivity ranking
+const (
+    FactorKindScaleFactor = "scale_factor"
+    FactorKindCostDriver  = "cost_driver"
+)
+
+// FactorElasticity represents a factor in the sensitivity ranking of a detailed result
+type FactorElasticity struct {
+    Kind       string  // FactorKindScaleFactor or FactorKindCostDriver
+    Name       string  // Message key of a built-in factor, or the stored name
+    Rating     float64
+    Elasticity float64 // %Δeffort / %Δrating; negative when a higher rating lowers the effort
+}
+
+// ratingElasticity returns %Δeffort / %Δrating for one factor, the rating change taken relative to
+// the rating range. The effort is recalculated with the rating moved elasticityStep down and up
+// (within the range) by setRating, which sets the factor on a copy of the estimate.
+// Degenerate estimates without effort have an elasticity of 0.
+func (e *COCOMOEstimate) ratingElasticity(rating float64, setRating func(c *COCOMOEstimate, rating float64)) float64 {
+    if e.EffortPM <= 0 {
+        return 0
+    }
+
+    delta := elasticityStep * (MaxRating - MinRating)
+    low := math.Max(rating-delta, MinRating)
+    high := math.Min(rating+delta, MaxRating)
+    if high <= low {
+        return 0
+    }
+
+    effortAt := func(rating float64) float64 {
+        c := *e
+        c.ScaleFactors = append([]ScaleFactor(nil), e.ScaleFactors...)
+        c.CostDrivers = append([]CostDriver(nil), e.CostDrivers...)
+        setRating(&c, rating)
+        c.CalculateEffort()
+        return c.EffortPM
+    }
+
+    effortChange := (effortAt(high) - effortAt(low)) / e.EffortPM
+    ratingChange := (high - low) / (MaxRating - MinRating)
+    return effortChange / ratingChange
+}
+
+// rankElasticities ranks the analyzed factors by the absolute value of their elasticity
+func rankElasticities(scaleFactors, costDrivers []FactorAnalysis) []FactorElasticity {
+    var ranking []FactorElasticity
+    for _, a := range scaleFactors {
+        ranking = append(ranking, FactorElasticity{Kind: FactorKindScaleFactor, Name: a.Name, Rating: a.Rating, Elasticity: a.Sensitivity})
+    }
+    for _, a := range costDrivers {
+        ranking = append(ranking, FactorElasticity{Kind: FactorKindCostDriver, Name: a.Name, Rating: a.Rating, Elasticity: a.Sensitivity})
+    }
+
+    sort.SliceStable(ranking, func(i, j int) bool {
+        return math.Abs(ranking[i].Elasticity) > math.Abs(ranking[j].Elasticity)
+    })
+    return ranking
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+    "math"
+    "testing"
+)
+
+func TestSensitivityOfZeroEffortIsFinite(t *testing.T) {
+    estimate := nominalEstimate(0)
+    estimate.CalculateEffort()
+    if estimate.EffortPM != 0 {
+        t.Fatalf("EffortPM = %v, want 0 for an empty project", estimate.EffortPM)
+    }
+
+    result := estimate.GenerateDetailedResult(RateCard{}, nil, PhaseProfile{}, DefaultEstimationConfig())
+
+    for _, analyses := range [][]FactorAnalysis{result.ScaleFactorAnalysis, result.CostDriverAnalysis} {
+        for _, analysis := range analyses {
+            if analysis.Sensitivity != 0 {
+                t.Errorf("%s: sensitivity %v, want 0 without effort", analysis.Name, analysis.Sensitivity)
+            }
+        }
+    }
+    for _, ranked := range result.SensitivityRanking {
+        if math.IsNaN(ranked.Elasticity) || math.IsInf(ranked.Elasticity, 0) {
+            t.Errorf("%s: elasticity %v, want a finite value", ranked.Name, ranked.Elasticity)
+        }
+    }
+}
+
+func TestSensitivityIsDimensionless(t *testing.T) {
+    // An elasticity compares relative changes, so it must not depend on the model's calibration constant
+    small := nominalEstimate(50)
+    small.CalculateEffort()
+    scaled := nominalEstimate(50)
+    scaled.Model.A *= 10
+    scaled.CalculateEffort()
+
+    smallResult := small.GenerateDetailedResult(RateCard{}, nil, PhaseProfile{}, DefaultEstimationConfig())
+    scaledResult := scaled.GenerateDetailedResult(RateCard{}, nil, PhaseProfile{}, DefaultEstimationConfig())
+    for i, analysis := range smallResult.ScaleFactorAnalysis {
+        if got := scaledResult.ScaleFactorAnalysis[i].Sensitivity; !approxEqual(got, analysis.Sensitivity, 1e-9) {
+            t.Errorf("%s: sensitivity %v with 10× the effort, want %v", analysis.Name, got, analysis.Sensitivity)
+        }
+    }
+}
+
+func TestWidestMultiplierRangeRanksHighest(t *testing.T) {
+    estimate := nominalEstimate(50)
+    estimate.CostDrivers = nil
+    for _, driver := range []struct {
+        name   string
+        values []float64
+    }{
+        {"narrow", []float64{0.90, 0.95, 1.00, 1.05, 1.10}},
+        {"wide", []float64{0.60, 0.80, 1.00, 1.20, 1.40}},
+        {"medium", []float64{0.80, 0.90, 1.00, 1.10, 1.20}},
+    } {
+        cd := CostDriver{Type: CostDriverType(driver.name), Name: driver.name, RatingValues: driver.values}
+        cd.SetRating(nominalRating)
+        estimate.CostDrivers = append(estimate.CostDrivers, cd)
+    }
+    estimate.CalculateEffort()
+
+    result := estimate.GenerateDetailedResult(RateCard{}, nil, PhaseProfile{}, DefaultEstimationConfig())
+
+    var order []string
+    for _, ranked := range result.SensitivityRanking {
+        if ranked.Kind == FactorKindCostDriver {
+            order = append(order, ranked.Name)
+        }
+    }
+    if len(order) != 3 || order[0] != "wide" || order[1] != "medium" || order[2] != "narrow" {
+        t.Errorf("cost drivers ranked %v, want wide, medium, narrow", order)
+    }
+    if top := result.SensitivityRanking[0]; top.Name != "wide" {
+        t.Errorf("top lever %s, want the widest range", top.Name)
+    }
+    for i := 1; i < len(result.SensitivityRanking); i++ {
+        if math.Abs(result.SensitivityRanking[i].Elasticity) > math.Abs(result.SensitivityRanking[i-1].Elasticity) {
+            t.Errorf("ranking not ordered by absolute elasticity at %d", i)
+        }
+    }
+}
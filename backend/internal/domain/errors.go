@@ -0,0 +1,9 @@
+package domain
+
+import "errors"
+
+// ErrNotFound is returned by repositories when the requested entity does not exist
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict is returned by repositories when an update is based on an outdated version of the entity
+var ErrConflict = errors.New("conflict")
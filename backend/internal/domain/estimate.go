@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+    "math"
+    "time"
+)
 
 // EstimateStatus represents the status of an estimate
 type EstimateStatus string
@@ -11,12 +14,40 @@ const (
     EstimateStatusApproved  EstimateStatus = "approved"
 )
 
+// IsValid reports whether the status is a known estimate status
+func (s EstimateStatus) IsValid() bool {
+    switch s {
+    case EstimateStatusDraft, EstimateStatusCompleted, EstimateStatusApproved:
+        return true
+    }
+    return false
+}
+
+// statusTransitions lists the statuses each status may move to.
+// A completed estimate may be sent back to draft for rework; an approved estimate is final.
+var statusTransitions = map[EstimateStatus][]EstimateStatus{
+    EstimateStatusDraft:     {EstimateStatusCompleted},
+    EstimateStatusCompleted: {EstimateStatusApproved, EstimateStatusDraft},
+}
+
+// CanTransitionTo reports whether an estimate may move from status s to next
+func (s EstimateStatus) CanTransitionTo(next EstimateStatus) bool {
+    for _, allowed := range statusTransitions[s] {
+        if allowed == next {
+            return true
+        }
+    }
+    return false
+}
+
 // ProcessEstimate represents estimation details for a specific process
 type ProcessEstimate struct {
     Process     *Process
     Tasks       []Task
     BaseHours   float64
+    AccessibilityHours float64 // Extra hours for accessibility requirements, included in TotalHours
     TotalHours  float64  // After applying factors
+    DeliverableHours float64 // Deliverable-based hours after applying factors, 0 unless the estimate is deliverable-based
 }
 
 // Estimate represents a work effort estimation for the entire project
@@ -26,12 +57,29 @@ type Estimate struct {
     ProjectName     string
     ProcessEstimates []ProcessEstimate
     GlobalFactors   []Factor        // Factors that apply to the entire project
+    ProcessFactors  map[ProcessCategory][]Factor // Factors that apply to a single process, before the global factors
     COCOMOEstimate  *COCOMOEstimate // COCOMO II based estimation
+    UseCasePoints   *UseCasePoints  // Use case points based estimation
+    StoryPoints     *StoryPointEstimate // Converts the story points of tasks into hours and sprints
+    AdditionalEfforts []AdditionalEffort // Separate lines added on top of the calculated total
+    CompletedDeliverables []string       // Names of the deliverables already completed
+    Attributes      ProjectAttributes    // Characteristics used for analogy-based estimation
+    ActualHours     float64              // Recorded actual hours once the project is finished
+    AccessibilityLevel    AccessibilityLevel // Required accessibility conformance
+    LocalizationLanguages []string           // Languages the product is localized into
+    DeliverableBased      bool               // Also estimates from the deliverables of the selected activities
+    Reconciliation        ReconciliationStrategy // How the method results are combined into TotalHours
     TotalHours      float64
+    ActivityResult  *CalculationResult // Activity-based result behind TotalHours
+    COCOMOResult    *CalculationResult // COCOMO II based result behind TotalHours, nil without COCOMO data
+    UCPResult       *CalculationResult // Use case points based result behind TotalHours, nil without use case data
+    DeliverableResult *CalculationResult // Deliverable-based result behind TotalHours, nil unless DeliverableBased
+    Version         int // Incremented on every save; earlier versions stay retrievable as snapshots
     Status          EstimateStatus
     CreatedBy       string
     CreatedAt       time.Time
     UpdatedAt       time.Time
+    DeletedAt       *time.Time // Set when the estimate is soft-deleted
     Notes           string
 }
 
@@ -41,6 +89,9 @@ type CalculationMethod string
 const (
     CalculationMethodActivity CalculationMethod = "activity_based"
     CalculationMethodCOCOMO  CalculationMethod = "cocomo_based"
+    CalculationMethodAnalogy CalculationMethod = "analogy_based"
+    CalculationMethodUCP     CalculationMethod = "use_case_points"
+    CalculationMethodDeliverable CalculationMethod = "deliverable_based"
 )
 
 // CalculationResult represents the result of effort calculation
@@ -51,31 +102,112 @@ type CalculationResult struct {
     TeamSize        float64
     DurationMonths  float64
     Confidence      float64  // 0-1, representing estimation confidence
+    StdDevHours     float64  // Standard deviation aggregated from three-point task estimates
+    Interval        ConfidenceInterval
 }
 
-// CalculateTotalHours calculates the total estimated hours using both activity-based and COCOMO II methods
-func (e *Estimate) CalculateTotalHours(processRepo ProcessRepository) error {
-    // Calculate activity-based estimation
-    activityResult, err := e.calculateActivityBased(processRepo)
+// ConfidenceInterval represents the range in which the total hours fall with the given probability
+type ConfidenceInterval struct {
+    Probability float64
+    Low         float64
+    High        float64
+}
+
+// confidenceZ is the z-score of the reported two-sided 90% confidence interval
+const confidenceZ = 1.645
+
+// CalculateTotalHours calculates the total estimated hours using the activity-based, COCOMO II,
+// use case points and deliverable-based methods
+func (e *Estimate) CalculateTotalHours(processRepo ProcessRepository, config EstimationConfig) error {
+    results, err := e.CalculateResults(processRepo, config)
     if err != nil {
         return err
     }
 
+    // Combine and reconcile estimates
+    e.reconcileEstimates(results)
+
+    return nil
+}
+
+// MethodResults holds the result of each estimation method; methods the estimate has no data for are nil
+type MethodResults struct {
+    Activity    *CalculationResult
+    COCOMO      *CalculationResult
+    UCP         *CalculationResult
+    Deliverable *CalculationResult
+}
+
+// all returns the results in reconciliation order, including the nil ones
+func (r MethodResults) all() []*CalculationResult {
+    return []*CalculationResult{r.Activity, r.COCOMO, r.UCP, r.Deliverable}
+}
+
+// methodResults returns the results behind the calculated total hours
+func (e *Estimate) methodResults() MethodResults {
+    return MethodResults{
+        Activity:    e.ActivityResult,
+        COCOMO:      e.COCOMOResult,
+        UCP:         e.UCPResult,
+        Deliverable: e.DeliverableResult,
+    }
+}
+
+// Results returns the method results behind the calculated total hours in reconciliation order,
+// leaving out the methods the estimate has no data for
+func (e *Estimate) Results() []*CalculationResult {
+    var results []*CalculationResult
+    for _, result := range e.methodResults().all() {
+        if result != nil {
+            results = append(results, result)
+        }
+    }
+    return results
+}
+
+// CalculateResults calculates the activity-based and, if available, the COCOMO II, use case points
+// and deliverable-based results without reconciling them
+func (e *Estimate) CalculateResults(processRepo ProcessRepository, config EstimationConfig) (results MethodResults, err error) {
+    // Story points flow into the activity-based calculation through the tasks
+    if e.StoryPoints != nil {
+        e.StoryPoints.Calculate(e.TotalStoryPoints())
+    }
+
+    // Calculate activity-based estimation
+    results.Activity, err = e.calculateActivityBased(processRepo, config)
+    if err != nil {
+        return MethodResults{}, err
+    }
+
     // Calculate COCOMO II based estimation if available
-    var cocomoResult *CalculationResult
     if e.COCOMOEstimate != nil {
-        cocomoResult = e.calculateCOCOMOBased()
+        results.COCOMO = e.calculateCOCOMOBased(config)
     }
 
-    // Combine and reconcile estimates
-    e.reconcileEstimates(activityResult, cocomoResult)
+    // Calculate use case points based estimation if available
+    if e.UseCasePoints != nil {
+        e.UseCasePoints.Calculate()
+        results.UCP = e.UseCasePoints.ToCalculationResult(config)
+    }
 
-    return nil
+    // Calculate deliverable-based estimation if requested
+    for i := range e.ProcessEstimates {
+        e.ProcessEstimates[i].DeliverableHours = 0
+    }
+    if e.DeliverableBased {
+        results.Deliverable, err = e.calculateDeliverableBased(processRepo, config)
+        if err != nil {
+            return MethodResults{}, err
+        }
+    }
+
+    return results, nil
 }
 
 // calculateActivityBased performs the traditional activity-based calculation
-func (e *Estimate) calculateActivityBased(processRepo ProcessRepository) (*CalculationResult, error) {
+func (e *Estimate) calculateActivityBased(processRepo ProcessRepository, config EstimationConfig) (*CalculationResult, error) {
     var projectTotal float64
+    var projectVariance float64
 
     // Calculate hours for each process
     for i, pe := range e.ProcessEstimates {
@@ -85,57 +217,99 @@ func (e *Estimate) calculateActivityBased(processRepo ProcessRepository) (*Calcu
         }
 
         var processTotal float64
+        var processVariance float64
         // Calculate base hours for each task in the process
         for _, task := range pe.Tasks {
-            // Find the corresponding activity
-            var activity Activity
-            for _, a := range process.Activities {
-                if a.ID == task.ActivityID {
-                    activity = a
-                    break
-                }
-            }
-            
-            baseHours := task.CalculateBaseHours(activity)
-            
-            // Apply task-specific factors
-            for _, factor := range task.CustomFactors {
-                baseHours = factor.Apply(baseHours)
-            }
-            
+            baseHours, stdDev := task.CalculateHours(process, e.hoursPerStoryPoint())
             processTotal += baseHours
+            processVariance += stdDev * stdDev
         }
 
-        // Store the base hours before applying global factors
+        // Store the base hours before applying process and global factors
         e.ProcessEstimates[i].BaseHours = processTotal
         
-        // Apply global factors to the process total
-        for _, factor := range e.GlobalFactors {
+        // Apply the process factors, then the global factors, to the process total
+        for _, factor := range e.factorsFor(process.Category) {
             processTotal = factor.Apply(processTotal)
+            processVariance = factor.Apply(factor.Apply(processVariance))
+        }
+
+        // Apply accessibility requirements to UI-related processes
+        e.ProcessEstimates[i].AccessibilityHours = 0
+        if IsUIProcess(process.Category) {
+            multiplier := e.AccessibilityLevel.Multiplier()
+            e.ProcessEstimates[i].AccessibilityHours = processTotal * (multiplier - 1)
+            processTotal *= multiplier
+            processVariance *= multiplier * multiplier
         }
         
         e.ProcessEstimates[i].TotalHours = processTotal
         projectTotal += processTotal
+        projectVariance += processVariance
     }
 
+    // Task variances are independent, so they sum across tasks and processes
+    stdDev := math.Sqrt(projectVariance)
+
+    personMonths := projectTotal / config.MonthlyHours()
+    teamSize, duration := StaffingForEffort(personMonths)
+
     return &CalculationResult{
         Method:         CalculationMethodActivity,
         TotalHours:    projectTotal,
-        PersonMonths:   projectTotal / 160.0, // Assuming 160 working hours per month
-        TeamSize:       5.0,                  // Default team size, should be adjusted based on project scale
-        DurationMonths: (projectTotal / 160.0) / 5.0,
+        StdDevHours:    stdDev,
+        Interval: ConfidenceInterval{
+            Probability: 0.9,
+            Low:         math.Max(0, projectTotal-confidenceZ*stdDev),
+            High:        projectTotal + confidenceZ*stdDev,
+        },
+        PersonMonths:   personMonths,
+        TeamSize:       teamSize,
+        DurationMonths: duration,
         Confidence:     0.8,                  // Default confidence level for activity-based estimation
     }, nil
 }
 
+// EffortByWorkType calculates the activity-based hours per work type, including global factors.
+// The buckets sum to the activity-based total.
+func (e *Estimate) EffortByWorkType(processRepo ProcessRepository) (map[WorkType]float64, error) {
+    result := make(map[WorkType]float64)
+
+    for _, pe := range e.ProcessEstimates {
+        process, err := processRepo.FindByID(pe.Process.ID)
+        if err != nil {
+            return nil, err
+        }
+
+        for _, task := range pe.Tasks {
+            hours, _ := task.CalculateHours(process, e.hoursPerStoryPoint())
+
+            // Process and global factors are multiplicative, so applying them per task matches the process total
+            for _, factor := range e.factorsFor(process.Category) {
+                hours = factor.Apply(hours)
+            }
+
+            result[task.EffectiveWorkType()] += hours
+        }
+    }
+
+    return result, nil
+}
+
+// factorsFor returns the factors applied to the total of a process: its own factors followed by the global factors
+func (e *Estimate) factorsFor(category ProcessCategory) []Factor {
+    factors := append([]Factor(nil), e.ProcessFactors[category]...)
+    return append(factors, e.GlobalFactors...)
+}
+
 // calculateCOCOMOBased performs the COCOMO II based calculation
-func (e *Estimate) calculateCOCOMOBased() *CalculationResult {
+func (e *Estimate) calculateCOCOMOBased(config EstimationConfig) *CalculationResult {
     // Recalculate COCOMO II estimate
     e.COCOMOEstimate.CalculateEffort()
 
     return &CalculationResult{
         Method:         CalculationMethodCOCOMO,
-        TotalHours:    e.COCOMOEstimate.EffortPM * 160.0, // Convert person-months to hours
+        TotalHours:    e.COCOMOEstimate.EffortPM * config.MonthlyHours(), // Convert person-months to hours
         PersonMonths:   e.COCOMOEstimate.EffortPM,
         TeamSize:       e.COCOMOEstimate.TeamSize,
         DurationMonths: e.COCOMOEstimate.DurationTM,
@@ -143,22 +317,26 @@ func (e *Estimate) calculateCOCOMOBased() *CalculationResult {
     }
 }
 
-// reconcileEstimates combines activity-based and COCOMO II estimates
-func (e *Estimate) reconcileEstimates(activityResult, cocomoResult *CalculationResult) {
-    if cocomoResult == nil {
-        // Use only activity-based estimation
-        e.TotalHours = activityResult.TotalHours
-        return
-    }
+// reconcileEstimates combines the activity-based, COCOMO II, use case points and deliverable-based
+// estimates, weighted as the reconciliation strategy says. Missing results are left out; when the
+// strategy selects none of the results, they are weighted by confidence.
+func (e *Estimate) reconcileEstimates(results MethodResults) {
+    // Keep the individual results so the gap between the methods stays visible
+    e.ActivityResult = results.Activity
+    e.COCOMOResult = results.COCOMO
+    e.UCPResult = results.UCP
+    e.DeliverableResult = results.Deliverable
 
-    // Calculate weighted average based on confidence levels
-    totalConfidence := activityResult.Confidence + cocomoResult.Confidence
-    activityWeight := activityResult.Confidence / totalConfidence
-    cocomoWeight := cocomoResult.Confidence / totalConfidence
+    shares := e.Reconciliation.reconciliationShares(results)
+    e.TotalHours = 0
+    for _, result := range results.all() {
+        if result != nil {
+            e.TotalHours += result.TotalHours * shares[result.Method]
+        }
+    }
 
-    // Combine estimates
-    e.TotalHours = (activityResult.TotalHours * activityWeight) +
-                   (cocomoResult.TotalHours * cocomoWeight)
+    // Additional effort lines are not covered by either method
+    e.TotalHours += e.AdditionalHours()
 }
 
 // EstimateRepository defines the interface for estimate persistence
@@ -166,6 +344,10 @@ type EstimateRepository interface {
     Save(estimate *Estimate) error
     FindByID(id string) (*Estimate, error)
     FindByProjectID(projectID string) ([]*Estimate, error)
-    Update(estimate *Estimate) error
-    Delete(id string) error
+    FindAll() ([]*Estimate, error)
+    Update(estimate *Estimate) error // Fails with ErrConflict unless estimate.Version is the stored version
+    Delete(id string) error  // Soft-deletes; deleted estimates are hidden from the finders until restored
+    Restore(id string) error // Undoes Delete
+    FindVersions(id string) ([]*Estimate, error)
+    FindVersion(id string, version int) (*Estimate, error)
 }
\ No newline at end of file
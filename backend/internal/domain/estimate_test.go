@@ -0,0 +1,55 @@
+package domain
+
+import "testing"
+
+func TestEstimateStatusTransitions(t *testing.T) {
+    tests := []struct {
+        from, to EstimateStatus
+        allowed  bool
+    }{
+        {EstimateStatusDraft, EstimateStatusCompleted, true},
+        {EstimateStatusCompleted, EstimateStatusApproved, true},
+        {EstimateStatusCompleted, EstimateStatusDraft, true},
+        {EstimateStatusDraft, EstimateStatusApproved, false},
+        {EstimateStatusApproved, EstimateStatusDraft, false},
+        {EstimateStatusApproved, EstimateStatusCompleted, false},
+        {EstimateStatusDraft, EstimateStatusDraft, false},
+    }
+    for _, tt := range tests {
+        if got := tt.from.CanTransitionTo(tt.to); got != tt.allowed {
+            t.Errorf("%s -> %s: allowed = %v, want %v", tt.from, tt.to, got, tt.allowed)
+        }
+    }
+}
+
+func TestProcessFactorAffectsOnlyItsProcess(t *testing.T) {
+    design := &Process{ID: "design", Category: ProcessBasicDesign, Activities: []Activity{{ID: "a1", BaseHours: 10}}}
+    testProcess := &Process{ID: "testing", Category: ProcessTesting, Activities: []Activity{{ID: "a2", BaseHours: 10}}}
+    repo := newProcessStore(design, testProcess)
+    newEstimate := func() *Estimate {
+        return &Estimate{
+            ProcessEstimates: []ProcessEstimate{
+                {Process: design, Tasks: []Task{{ActivityID: "a1", Complexity: 3, Scale: 1}}},
+                {Process: testProcess, Tasks: []Task{{ActivityID: "a2", Complexity: 3, Scale: 1}}},
+            },
+            GlobalFactors: []Factor{{Impact: 1.1}},
+        }
+    }
+
+    plain := newEstimate()
+    if _, err := plain.calculateActivityBased(repo, DefaultEstimationConfig()); err != nil {
+        t.Fatal(err)
+    }
+    buffered := newEstimate()
+    buffered.ProcessFactors = map[ProcessCategory][]Factor{ProcessTesting: {{Impact: 1.5}}}
+    if _, err := buffered.calculateActivityBased(repo, DefaultEstimationConfig()); err != nil {
+        t.Fatal(err)
+    }
+
+    if got, want := buffered.ProcessEstimates[0].TotalHours, plain.ProcessEstimates[0].TotalHours; got != want {
+        t.Errorf("design total %v, want %v unaffected by the testing factor", got, want)
+    }
+    if got, want := buffered.ProcessEstimates[1].TotalHours, plain.ProcessEstimates[1].TotalHours*1.5; !approxEqual(got, want, 1e-9) {
+        t.Errorf("testing total %v, want %v with the factor applied", got, want)
+    }
+}
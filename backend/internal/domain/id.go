@@ -0,0 +1,20 @@
+package domain
+
+import (
+    "crypto/rand"
+    "fmt"
+)
+
+// NewID generates a random UUID (version 4) used as the ID of new entities.
+// Repositories call it on Save when the entity has no ID yet.
+func NewID() string {
+    var b [16]byte
+    if _, err := rand.Read(b[:]); err != nil {
+        panic(fmt.Sprintf("failed to generate ID: %v", err))
+    }
+
+    b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+    b[8] = (b[8] & 0x3f) | 0x80 // Variant RFC 4122
+
+    return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
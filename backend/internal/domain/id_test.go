@@ -0,0 +1,22 @@
+package domain
+
+import (
+    "regexp"
+    "testing"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewIDIsUniqueUUIDv4(t *testing.T) {
+    seen := make(map[string]bool)
+    for i := 0; i < 1000; i++ {
+        id := NewID()
+        if !uuidV4.MatchString(id) {
+            t.Fatalf("NewID() = %q, want a UUID v4", id)
+        }
+        if seen[id] {
+            t.Fatalf("NewID() returned %q twice", id)
+        }
+        seen[id] = true
+    }
+}
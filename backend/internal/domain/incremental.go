@@ -0,0 +1,67 @@
+package domain
+
+import "fmt"
+
+// DefaultIncrementAdaptation describes how much of the previously delivered code each increment
+// reworks: 10% of the code modified and 20% of it re-integrated and re-tested
+var DefaultIncrementAdaptation = ReuseComponent{CM: 10, IM: 20, SU: 30, UNFM: 0.4}
+
+// IncrementResult represents the effort and schedule of a single increment
+type IncrementResult struct {
+    Increment      int     // 1-based index
+    NewSize        float64 // Size delivered by this increment
+    CarriedSize    float64 // Size delivered by the previous increments
+    EquivalentSize float64 // New size plus the equivalent size of the reworked carried code
+    EffortPM       float64
+    DurationTM     float64
+}
+
+// IncrementalEstimate represents COCOMO II incremental development, where each increment
+// builds on and partly reworks the code delivered by the previous ones
+type IncrementalEstimate struct {
+    Increments   []float64      // Size of each increment, same unit as COCOMOEstimate.ProjectSize
+    Adaptation   ReuseComponent // Rework of the carried code; AdaptedSize is ignored
+    Model        *COCOMOModel
+    ScaleFactors []ScaleFactor
+    CostDrivers  []CostDriver
+    // Calculated values
+    Results         []IncrementResult
+    TotalEffortPM   float64
+    TotalDurationTM float64 // Increments are assumed to run one after another
+}
+
+// Calculate calculates every increment as a COCOMO II estimate whose reused code is the carried code
+func (ie *IncrementalEstimate) Calculate() {
+    ie.Results = make([]IncrementResult, 0, len(ie.Increments))
+    ie.TotalEffortPM = 0
+    ie.TotalDurationTM = 0
+
+    carried := 0.0
+    for i, size := range ie.Increments {
+        estimate := &COCOMOEstimate{
+            ProjectSize:  size,
+            Model:        ie.Model,
+            ScaleFactors: ie.ScaleFactors,
+            CostDrivers:  ie.CostDrivers,
+        }
+        if carried > 0 {
+            rework := ie.Adaptation
+            rework.Name = fmt.Sprintf("increments 1-%d", i)
+            rework.AdaptedSize = carried
+            estimate.ReuseComponents = []ReuseComponent{rework}
+        }
+        estimate.CalculateEffort()
+
+        ie.Results = append(ie.Results, IncrementResult{
+            Increment:      i + 1,
+            NewSize:        size,
+            CarriedSize:    carried,
+            EquivalentSize: estimate.EffectiveSize(),
+            EffortPM:       estimate.EffortPM,
+            DurationTM:     estimate.DurationTM,
+        })
+        ie.TotalEffortPM += estimate.EffortPM
+        ie.TotalDurationTM += estimate.DurationTM
+        carried += size
+    }
+}
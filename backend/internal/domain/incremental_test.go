@@ -0,0 +1,49 @@
+package domain
+
+import "testing"
+
+// nominalIncremental returns an incremental estimate of the given increments with every rating at Nominal
+func nominalIncremental(increments ...float64) *IncrementalEstimate {
+    nominal := nominalEstimate(0)
+    return &IncrementalEstimate{
+        Increments:   increments,
+        Adaptation:   DefaultIncrementAdaptation,
+        Model:        nominal.Model,
+        ScaleFactors: nominal.ScaleFactors,
+        CostDrivers:  nominal.CostDrivers,
+    }
+}
+
+func TestIncrementsCostMoreThanMonolith(t *testing.T) {
+    incremental := nominalIncremental(30, 30, 30)
+    incremental.Calculate()
+
+    monolith := nominalEstimate(90)
+    monolith.CalculateEffort()
+
+    if incremental.TotalEffortPM <= monolith.EffortPM {
+        t.Errorf("TotalEffortPM = %v, want more than the monolithic %v because of the rework", incremental.TotalEffortPM, monolith.EffortPM)
+    }
+}
+
+func TestIncrementsCarryThePreviousCode(t *testing.T) {
+    incremental := nominalIncremental(30, 30, 30)
+    incremental.Calculate()
+
+    if len(incremental.Results) != 3 {
+        t.Fatalf("got %d results, want 3", len(incremental.Results))
+    }
+    sum := 0.0
+    for i, result := range incremental.Results {
+        if want := 30 * float64(i); result.CarriedSize != want {
+            t.Errorf("increment %d: CarriedSize = %v, want %v", result.Increment, result.CarriedSize, want)
+        }
+        if i > 0 && result.EffortPM <= incremental.Results[i-1].EffortPM {
+            t.Errorf("increment %d: EffortPM = %v, want more than the previous %v with more code to rework", result.Increment, result.EffortPM, incremental.Results[i-1].EffortPM)
+        }
+        sum += result.EffortPM
+    }
+    if !approxEqual(incremental.TotalEffortPM, sum, 1e-9) {
+        t.Errorf("TotalEffortPM = %v, want the sum %v of the increments", incremental.TotalEffortPM, sum)
+    }
+}
@@ -0,0 +1,39 @@
+package domain
+
+// MaintenanceEstimate represents the annual maintenance effort of an existing system
+// using the COCOMO II maintenance model
+type MaintenanceEstimate struct {
+    BaseSize            float64 // Size of the existing system, same unit as COCOMOEstimate.ProjectSize
+    AnnualChangeTraffic float64 // ACT = (added + modified) / total size per year, 0 to 1
+    Model               *COCOMOModel
+    ScaleFactors        []ScaleFactor
+    CostDrivers         []CostDriver
+    // Calculated values
+    MaintainedSize float64 // BaseSize * ACT
+    ExponentB      float64
+    EffortPM       float64 // Person-Months per year
+    AverageStaff   float64 // Full-time staff needed over the year
+}
+
+// CalculateEffort calculates the annual maintenance effort: PM_maint = A * (Size * ACT)^B * ΠEM.
+// SCED is excluded from the effort multipliers since maintenance has no development schedule to compress.
+func (m *MaintenanceEstimate) CalculateEffort() {
+    m.ExponentB = exponentB(m.Model, m.ScaleFactors)
+    m.MaintainedSize = m.BaseSize * m.AnnualChangeTraffic
+
+    if m.MaintainedSize <= 0 {
+        m.EffortPM = 0
+        m.AverageStaff = 0
+        return
+    }
+
+    var drivers []CostDriver
+    for _, cd := range m.CostDrivers {
+        if cd.Type != CostDriverSCED {
+            drivers = append(drivers, cd)
+        }
+    }
+
+    m.EffortPM = m.Model.A * pow(m.MaintainedSize, m.ExponentB) * effortMultiplier(drivers)
+    m.AverageStaff = m.EffortPM / 12
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "testing"
+
+// nominalMaintenance returns a maintenance estimate of the given system with every rating at Nominal
+func nominalMaintenance(baseSize, act float64) *MaintenanceEstimate {
+    nominal := nominalEstimate(baseSize)
+    return &MaintenanceEstimate{
+        BaseSize:            baseSize,
+        AnnualChangeTraffic: act,
+        Model:               nominal.Model,
+        ScaleFactors:        nominal.ScaleFactors,
+        CostDrivers:         nominal.CostDrivers,
+    }
+}
+
+func TestMaintenanceWithoutChangeTrafficIsZero(t *testing.T) {
+    maintenance := nominalMaintenance(100, 0)
+    maintenance.CalculateEffort()
+
+    if maintenance.EffortPM != 0 || maintenance.AverageStaff != 0 {
+        t.Errorf("EffortPM = %v, AverageStaff = %v, want 0 without change traffic", maintenance.EffortPM, maintenance.AverageStaff)
+    }
+}
+
+func TestMaintenanceOfEverythingMatchesRedevelopment(t *testing.T) {
+    maintenance := nominalMaintenance(100, 1)
+    maintenance.CalculateEffort()
+
+    development := nominalEstimate(100)
+    development.CalculateEffort()
+
+    if !approxEqual(maintenance.EffortPM, development.EffortPM, 1e-9) {
+        t.Errorf("EffortPM = %v, want the redevelopment effort %v when the whole system changes", maintenance.EffortPM, development.EffortPM)
+    }
+    if !approxEqual(maintenance.AverageStaff, maintenance.EffortPM/12, 1e-9) {
+        t.Errorf("AverageStaff = %v, want the yearly effort spread over 12 months", maintenance.AverageStaff)
+    }
+}
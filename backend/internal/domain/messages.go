@@ -0,0 +1,86 @@
+package domain
+
+// Message keys of the texts the domain generates. The interface layer resolves them into the
+// language of the client; see the i18n package for the catalogs.
+const (
+    MsgRecommendScaleFactor = "recommendation.scale_factor"
+    MsgRecommendCostDriver  = "recommendation.cost_driver"
+
+    MsgRiskScaleFactorDescription = "risk.scale_factor.description"
+    MsgRiskScaleFactorMitigation  = "risk.scale_factor.mitigation"
+    MsgRiskCostDriverDescription  = "risk.cost_driver.description"
+    MsgRiskCostDriverMitigation   = "risk.cost_driver.mitigation"
+
+    MsgRiskLargeProjectName        = "risk.large_project.name"
+    MsgRiskLargeProjectDescription = "risk.large_project.description"
+    MsgRiskLargeProjectMitigation  = "risk.large_project.mitigation"
+
+    MsgRiskScheduleCompressionName        = "risk.schedule_compression.name"
+    MsgRiskScheduleCompressionDescription = "risk.schedule_compression.description"
+    MsgRiskScheduleCompressionMitigation  = "risk.schedule_compression.mitigation"
+
+    MsgRiskScheduleFloorName        = "risk.schedule_floor.name"
+    MsgRiskScheduleFloorDescription = "risk.schedule_floor.description"
+    MsgRiskScheduleFloorMitigation  = "risk.schedule_floor.mitigation"
+
+    MsgWarningScheduleFloor = "warning.schedule_floor"
+
+    MsgRiskLargeEffortName        = "risk.large_effort.name"
+    MsgRiskLargeEffortDescription = "risk.large_effort.description"
+    MsgRiskLargeEffortMitigation  = "risk.large_effort.mitigation"
+
+    MsgRiskLargeTeamName        = "risk.large_team.name"
+    MsgRiskLargeTeamDescription = "risk.large_team.description"
+    MsgRiskLargeTeamMitigation  = "risk.large_team.mitigation"
+)
+
+// NameKey returns the message key of the scale factor's name
+func (t ScaleFactorType) NameKey() string {
+    return "scale_factor." + string(t) + ".name"
+}
+
+// DescriptionKey returns the message key of the scale factor's description
+func (t ScaleFactorType) DescriptionKey() string {
+    return "scale_factor." + string(t) + ".description"
+}
+
+// GuideKey returns the message key of the scale factor's guide at a rating level, e.g. "nominal"
+func (t ScaleFactorType) GuideKey(level string) string {
+    return "scale_factor." + string(t) + ".guide." + level
+}
+
+// NameKey returns the message key of the cost driver's name
+func (t CostDriverType) NameKey() string {
+    return "cost_driver." + string(t) + ".name"
+}
+
+// DescriptionKey returns the message key of the cost driver's description
+func (t CostDriverType) DescriptionKey() string {
+    return "cost_driver." + string(t) + ".description"
+}
+
+// GuideKey returns the message key of the cost driver's guide at a rating level, e.g. "nominal"
+func (t CostDriverType) GuideKey(level string) string {
+    return "cost_driver." + string(t) + ".guide." + level
+}
+
+// PhaseNameKey returns the message key of the display name of a phase code
+func PhaseNameKey(code string) string {
+    return "phase." + code
+}
+
+// nameKey returns the message key of a built-in scale factor's name, or the stored name of another
+func (sf *ScaleFactor) nameKey() string {
+    if _, ok := scaleFactorSpecs[sf.Type]; ok {
+        return sf.Type.NameKey()
+    }
+    return sf.Name
+}
+
+// nameKey returns the message key of a built-in cost driver's name, or the stored name of another
+func (cd *CostDriver) nameKey() string {
+    if _, ok := costDriverSpecs[cd.Type]; ok {
+        return cd.Type.NameKey()
+    }
+    return cd.Name
+}
@@ -0,0 +1,83 @@
+package domain
+
+import "math"
+
+// MigrationSpec represents the sizing inputs for a data-migration sub-project
+type MigrationSpec struct {
+    SourceTables             int     // Number of source tables to migrate
+    RecordVolume             float64 // Total number of records across all tables
+    TransformationComplexity int     // 1-5 scale
+}
+
+// MigrationEffort represents the calculated effort of a data-migration sub-project
+type MigrationEffort struct {
+    Spec                 MigrationSpec
+    TableHours           float64 // Mapping, scripting and verification per table
+    VolumeHours          float64 // Loading, reconciliation and rehearsal driven by volume
+    ComplexityMultiplier float64
+    TotalHours           float64
+}
+
+// AdditionalEffort represents a separate effort line added on top of the calculated estimate
+type AdditionalEffort struct {
+    Category string // e.g. "migration"
+    Name     string
+    Hours    float64
+}
+
+const (
+    // AdditionalEffortMigration is the category of the data-migration effort line
+    AdditionalEffortMigration = "migration"
+
+    migrationHoursPerTable = 6.0  // Mapping definition, script and verification per table
+    migrationVolumeHours   = 16.0 // Hours per order of magnitude above 10,000 records
+)
+
+// CalculateMigrationEffort calculates the effort of a data-migration sub-project
+func CalculateMigrationEffort(spec MigrationSpec) *MigrationEffort {
+    result := &MigrationEffort{Spec: spec}
+
+    result.TableHours = float64(spec.SourceTables) * migrationHoursPerTable
+
+    // Volume effort grows logarithmically: each order of magnitude adds a rehearsal cycle
+    if spec.RecordVolume > 0 {
+        result.VolumeHours = migrationVolumeHours * math.Log10(1+spec.RecordVolume/10000)
+    }
+
+    // Complexity 3 is considered normal (multiplier 1.0), same scale as Task
+    result.ComplexityMultiplier = 0.4 + (float64(spec.TransformationComplexity) * 0.2)
+
+    result.TotalHours = (result.TableHours + result.VolumeHours) * result.ComplexityMultiplier
+
+    return result
+}
+
+// AdditionalHours returns the sum of all additional effort lines
+func (e *Estimate) AdditionalHours() float64 {
+    var total float64
+    for _, line := range e.AdditionalEfforts {
+        total += line.Hours
+    }
+    return total
+}
+
+// SetAdditionalEffort adds or replaces the additional effort line of the given category
+func (e *Estimate) SetAdditionalEffort(line AdditionalEffort) {
+    for i, existing := range e.AdditionalEfforts {
+        if existing.Category == line.Category {
+            e.AdditionalEfforts[i] = line
+            return
+        }
+    }
+    e.AdditionalEfforts = append(e.AdditionalEfforts, line)
+}
+
+// RemoveAdditionalEffort removes the additional effort line of the given category, if any
+func (e *Estimate) RemoveAdditionalEffort(category string) {
+    for i, existing := range e.AdditionalEfforts {
+        if existing.Category == category {
+            e.AdditionalEfforts = append(e.AdditionalEfforts[:i], e.AdditionalEfforts[i+1:]...)
+            return
+        }
+    }
+}
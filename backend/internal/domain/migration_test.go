@@ -0,0 +1,48 @@
+package domain
+
+import "testing"
+
+func TestCalculateMigrationEffortGrowsWithTables(t *testing.T) {
+    small := CalculateMigrationEffort(MigrationSpec{SourceTables: 10, RecordVolume: 50000, TransformationComplexity: 3})
+    large := CalculateMigrationEffort(MigrationSpec{SourceTables: 40, RecordVolume: 50000, TransformationComplexity: 3})
+
+    if large.TotalHours <= small.TotalHours {
+        t.Errorf("40 tables: %v hours, want more than the %v hours of 10 tables", large.TotalHours, small.TotalHours)
+    }
+}
+
+func TestCalculateMigrationEffortGrowsWithComplexity(t *testing.T) {
+    var previous float64
+    for complexity := 1; complexity <= 5; complexity++ {
+        effort := CalculateMigrationEffort(MigrationSpec{SourceTables: 10, RecordVolume: 50000, TransformationComplexity: complexity})
+        if effort.TotalHours <= previous {
+            t.Errorf("complexity %d: %v hours, want more than %v", complexity, effort.TotalHours, previous)
+        }
+        previous = effort.TotalHours
+    }
+}
+
+func TestCalculateMigrationEffortNominalComplexity(t *testing.T) {
+    effort := CalculateMigrationEffort(MigrationSpec{SourceTables: 10, TransformationComplexity: 3})
+
+    if effort.ComplexityMultiplier != 1.0 {
+        t.Errorf("ComplexityMultiplier = %v, want 1.0 at complexity 3", effort.ComplexityMultiplier)
+    }
+    if effort.VolumeHours != 0 {
+        t.Errorf("VolumeHours = %v, want 0 without records", effort.VolumeHours)
+    }
+    if want := 10 * migrationHoursPerTable; effort.TotalHours != want {
+        t.Errorf("TotalHours = %v, want %v", effort.TotalHours, want)
+    }
+}
+
+func TestAdditionalEffortIsAddedToTotal(t *testing.T) {
+    estimate := &Estimate{}
+    estimate.SetAdditionalEffort(AdditionalEffort{Category: AdditionalEffortMigration, Hours: 40})
+    estimate.SetAdditionalEffort(AdditionalEffort{Category: "other", Hours: 10})
+    estimate.reconcileEstimates(MethodResults{Activity: &CalculationResult{Method: CalculationMethodActivity, TotalHours: 100, Confidence: 0.8}})
+
+    if estimate.TotalHours != 150 {
+        t.Errorf("TotalHours = %v, want 150", estimate.TotalHours)
+    }
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+    "context"
+    "math"
+    "math/rand"
+    "runtime"
+    "sort"
+    "sync"
+    "sync/atomic"
+)
+
+// MaxMonteCarloIterations is the upper bound of iterations accepted for a single simulation
+const MaxMonteCarloIterations = 1000000
+
+// MonteCarloDistribution configures the triangular distributions used to perturb an estimate
+type MonteCarloDistribution struct {
+    SizeLow      float64 // Lowest size as a ratio of the estimated size
+    SizeHigh     float64 // Highest size as a ratio of the estimated size
+    DriverSpread float64 // Relative spread of each cost driver value (e.g. 0.1 = ±10%)
+}
+
+// DefaultMonteCarloDistribution reflects that size is more often under- than over-estimated
+var DefaultMonteCarloDistribution = MonteCarloDistribution{
+    SizeLow:      0.8,
+    SizeHigh:     1.5,
+    DriverSpread: 0.1,
+}
+
+// Percentiles represents the P10/P50/P90 values of a simulated distribution
+type Percentiles struct {
+    P10 float64
+    P50 float64
+    P90 float64
+}
+
+// MonteCarloResult represents the outcome of a Monte Carlo simulation
+type MonteCarloResult struct {
+    Iterations int
+    Seed       int64
+    Effort     Percentiles // Person-months
+    Duration   Percentiles // Calendar months
+}
+
+// monteCarloChunkSize is the number of iterations simulated with one random source.
+// Chunks are the unit of work of the workers, so the result doesn't depend on their number.
+const monteCarloChunkSize = 4096
+
+// RunMonteCarlo simulates the estimate by perturbing the size and each cost driver
+// and returns the percentile distribution of effort and duration.
+// The iterations are split across one worker per CPU. The same seed always produces the same result.
+func (e *COCOMOEstimate) RunMonteCarlo(iterations int, seed int64) *MonteCarloResult {
+    result, _ := e.runMonteCarlo(context.Background(), iterations, seed, runtime.NumCPU(), nil)
+    return result
+}
+
+// RunMonteCarloContext runs the simulation like RunMonteCarlo, calling progress with the number of
+// completed iterations as chunks of them finish. progress may be called from several goroutines at once.
+// It stops early with the error of ctx when ctx is done.
+func (e *COCOMOEstimate) RunMonteCarloContext(ctx context.Context, iterations int, seed int64, progress func(completed int)) (*MonteCarloResult, error) {
+    return e.runMonteCarlo(ctx, iterations, seed, runtime.NumCPU(), progress)
+}
+
+// runMonteCarlo runs the simulation on the given number of workers.
+// Each chunk of iterations draws from its own random source seeded from the seed and the chunk index,
+// and writes to its own range of the samples, so any number of workers yields identical percentiles.
+func (e *COCOMOEstimate) runMonteCarlo(ctx context.Context, iterations int, seed int64, workers int, progress func(completed int)) (*MonteCarloResult, error) {
+    dist := DefaultMonteCarloDistribution
+    if e.Uncertainty != nil {
+        dist = *e.Uncertainty
+    }
+
+    efforts := make([]float64, iterations)
+    durations := make([]float64, iterations)
+    chunks := (iterations + monteCarloChunkSize - 1) / monteCarloChunkSize
+    if workers > chunks {
+        workers = chunks
+    }
+    if workers < 1 {
+        workers = 1
+    }
+
+    next := make(chan int)
+    var completed atomic.Int64
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for chunk := range next {
+                start := chunk * monteCarloChunkSize
+                end := min(start+monteCarloChunkSize, iterations)
+                e.simulateChunk(dist, chunkSeed(seed, chunk), efforts[start:end], durations[start:end])
+                done := completed.Add(int64(end - start))
+                if progress != nil {
+                    progress(int(done))
+                }
+            }
+        }()
+    }
+feed:
+    for chunk := 0; chunk < chunks; chunk++ {
+        select {
+        case next <- chunk:
+        case <-ctx.Done():
+            break feed
+        }
+    }
+    close(next)
+    wg.Wait()
+    if err := ctx.Err(); err != nil {
+        return nil, err
+    }
+
+    return &MonteCarloResult{
+        Iterations: iterations,
+        Seed:       seed,
+        Effort:     percentilesOf(efforts),
+        Duration:   percentilesOf(durations),
+    }, nil
+}
+
+// simulateChunk fills efforts and durations with one trial each, drawn from a random source of its own
+func (e *COCOMOEstimate) simulateChunk(dist MonteCarloDistribution, seed int64, efforts, durations []float64) {
+    rng := rand.New(rand.NewSource(seed))
+    for i := range efforts {
+        trial := *e
+        trial.ProjectSize = e.ProjectSize * triangular(rng, dist.SizeLow, 1.0, dist.SizeHigh)
+
+        trial.CostDrivers = make([]CostDriver, len(e.CostDrivers))
+        for j, cd := range e.CostDrivers {
+            cd.Value *= triangular(rng, 1-dist.DriverSpread, 1.0, 1+dist.DriverSpread)
+            trial.CostDrivers[j] = cd
+        }
+
+        trial.CalculateEffort()
+        efforts[i] = trial.EffortPM
+        durations[i] = trial.DurationTM
+    }
+}
+
+// chunkSeed derives the seed of a chunk; the first chunk uses the seed itself, so runs of up to
+// monteCarloChunkSize iterations draw the same samples as a single random source would
+func chunkSeed(seed int64, chunk int) int64 {
+    const golden = 0x9E3779B97F4A7C15 // Spreads consecutive chunk indexes across the seed space
+    return int64(uint64(seed) + uint64(chunk)*golden)
+}
+
+// triangular samples a triangular distribution with the given minimum, mode and maximum
+func triangular(rng *rand.Rand, low, mode, high float64) float64 {
+    if high <= low {
+        return mode
+    }
+
+    u := rng.Float64()
+    split := (mode - low) / (high - low)
+    if u < split {
+        return low + math.Sqrt(u*(high-low)*(mode-low))
+    }
+    return high - math.Sqrt((1-u)*(high-low)*(high-mode))
+}
+
+// percentilesOf calculates the P10/P50/P90 values using the nearest-rank method
+func percentilesOf(values []float64) Percentiles {
+    if len(values) == 0 {
+        return Percentiles{}
+    }
+
+    sorted := append([]float64(nil), values...)
+    sort.Float64s(sorted)
+
+    rank := func(p float64) float64 {
+        idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+        if idx < 0 {
+            idx = 0
+        }
+        return sorted[idx]
+    }
+
+    return Percentiles{
+        P10: rank(0.10),
+        P50: rank(0.50),
+        P90: rank(0.90),
+    }
+}
+
+// ApplyMonteCarlo replaces the fixed optimistic/pessimistic multipliers with simulated percentiles
+func (r *COCOMODetailedResult) ApplyMonteCarlo(mc *MonteCarloResult) {
+    r.MonteCarlo = mc
+
+    r.EffortRange.Optimistic = mc.Effort.P10
+    r.EffortRange.Pessimistic = mc.Effort.P90
+    r.DurationRange.Optimistic = mc.Duration.P10
+    r.DurationRange.Pessimistic = mc.Duration.P90
+}
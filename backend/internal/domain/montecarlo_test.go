@@ -0,0 +1,156 @@
+package domain
+
+import (
+    "context"
+    "errors"
+    "runtime"
+    "sync/atomic"
+    "testing"
+)
+
+func TestRunMonteCarloIsStableForASeed(t *testing.T) {
+    estimate := nominalEstimate(100)
+    result := estimate.RunMonteCarlo(10000, 42)
+
+    // Recorded from a previous run; a change means the same seed no longer reproduces the same simulation
+    want := MonteCarloResult{
+        Iterations: 10000,
+        Seed:       42,
+        Effort:     Percentiles{P10: 317.7598970778113, P50: 420.0428082553044, P90: 563.90397968196},
+        Duration:   Percentiles{P10: 20.424031235207874, P50: 22.194744276718186, P90: 24.230374663785607},
+    }
+    assertPercentiles(t, "effort", result.Effort, want.Effort)
+    assertPercentiles(t, "duration", result.Duration, want.Duration)
+    if result.Iterations != want.Iterations || result.Seed != want.Seed {
+        t.Errorf("Iterations, Seed = %d, %d, want %d, %d", result.Iterations, result.Seed, want.Iterations, want.Seed)
+    }
+
+    again := estimate.RunMonteCarlo(10000, 42)
+    if *again != *result {
+        t.Errorf("second run = %+v, want %+v", *again, *result)
+    }
+}
+
+func TestRunMonteCarloBracketsNominal(t *testing.T) {
+    estimate := nominalEstimate(100)
+    estimate.CalculateEffort()
+    result := estimate.RunMonteCarlo(5000, 7)
+
+    if !(result.Effort.P10 < estimate.EffortPM && estimate.EffortPM < result.Effort.P90) {
+        t.Errorf("effort P10-P90 = %v-%v, want it to contain the nominal %v", result.Effort.P10, result.Effort.P90, estimate.EffortPM)
+    }
+    if !(result.Duration.P10 < estimate.DurationTM && estimate.DurationTM < result.Duration.P90) {
+        t.Errorf("duration P10-P90 = %v-%v, want it to contain the nominal %v", result.Duration.P10, result.Duration.P90, estimate.DurationTM)
+    }
+}
+
+func TestRunMonteCarloWithoutUncertainty(t *testing.T) {
+    estimate := nominalEstimate(100)
+    estimate.Uncertainty = &MonteCarloDistribution{SizeLow: 1, SizeHigh: 1, DriverSpread: 0}
+    estimate.CalculateEffort()
+    result := estimate.RunMonteCarlo(100, 1)
+
+    effort, duration := estimate.EffortPM, estimate.DurationTM
+    assertPercentiles(t, "effort", result.Effort, Percentiles{P10: effort, P50: effort, P90: effort})
+    assertPercentiles(t, "duration", result.Duration, Percentiles{P10: duration, P50: duration, P90: duration})
+}
+
+func TestRunMonteCarloIsIndependentOfWorkers(t *testing.T) {
+    estimate := nominalEstimate(100)
+    // Several chunks and a partial last one
+    iterations := 3*monteCarloChunkSize + 17
+
+    serial, err := estimate.runMonteCarlo(context.Background(), iterations, 42, 1, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, workers := range []int{2, 3, 8} {
+        parallel, err := estimate.runMonteCarlo(context.Background(), iterations, 42, workers, nil)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if *parallel != *serial {
+            t.Errorf("%d workers: %+v, want the serial %+v", workers, *parallel, *serial)
+        }
+    }
+}
+
+func TestRunMonteCarloContextReportsProgress(t *testing.T) {
+    estimate := nominalEstimate(100)
+    iterations := 2*monteCarloChunkSize + 1
+
+    var calls, last atomic.Int64
+    _, err := estimate.RunMonteCarloContext(context.Background(), iterations, 1, func(completed int) {
+        calls.Add(1)
+        for {
+            previous := last.Load()
+            if int64(completed) <= previous || last.CompareAndSwap(previous, int64(completed)) {
+                break
+            }
+        }
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    if calls.Load() != 3 || last.Load() != int64(iterations) {
+        t.Errorf("%d progress calls up to %d iterations, want 3 up to %d", calls.Load(), last.Load(), iterations)
+    }
+}
+
+func TestRunMonteCarloContextStopsWhenCancelled(t *testing.T) {
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if _, err := nominalEstimate(100).RunMonteCarloContext(ctx, MaxMonteCarloIterations, 1, nil); !errors.Is(err, context.Canceled) {
+        t.Errorf("got %v, want context.Canceled", err)
+    }
+}
+
+func TestApplyMonteCarloReplacesFixedRange(t *testing.T) {
+    result := &COCOMODetailedResult{}
+    mc := &MonteCarloResult{
+        Effort:   Percentiles{P10: 80, P50: 100, P90: 140},
+        Duration: Percentiles{P10: 10, P50: 12, P90: 15},
+    }
+    result.ApplyMonteCarlo(mc)
+
+    if result.EffortRange.Optimistic != 80 || result.EffortRange.Pessimistic != 140 {
+        t.Errorf("EffortRange = %+v, want P10 80 and P90 140", result.EffortRange)
+    }
+    if result.DurationRange.Optimistic != 10 || result.DurationRange.Pessimistic != 15 {
+        t.Errorf("DurationRange = %+v, want P10 10 and P90 15", result.DurationRange)
+    }
+    if result.MonteCarlo != mc {
+        t.Error("MonteCarlo is not set")
+    }
+}
+
+func TestPercentilesOfUsesNearestRank(t *testing.T) {
+    values := []float64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+    if got, want := percentilesOf(values), (Percentiles{P10: 1, P50: 5, P90: 9}); got != want {
+        t.Errorf("percentilesOf = %+v, want %+v", got, want)
+    }
+    if got := percentilesOf(nil); got != (Percentiles{}) {
+        t.Errorf("percentilesOf(nil) = %+v, want zeros", got)
+    }
+}
+
+func assertPercentiles(t *testing.T, name string, got, want Percentiles) {
+    t.Helper()
+    if !approxEqual(got.P10, want.P10, 1e-9) || !approxEqual(got.P50, want.P50, 1e-9) || !approxEqual(got.P90, want.P90, 1e-9) {
+        t.Errorf("%s percentiles = %+v, want %+v", name, got, want)
+    }
+}
+
+func BenchmarkRunMonteCarlo(b *testing.B) {
+    estimate := nominalEstimate(100)
+    for _, bm := range []struct {
+        name    string
+        workers int
+    }{{"serial", 1}, {"parallel", runtime.NumCPU()}} {
+        b.Run(bm.name, func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                estimate.runMonteCarlo(context.Background(), 100000, int64(i), bm.workers, nil)
+            }
+        })
+    }
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+    "fmt"
+    "math"
+    "sort"
+)
+
+// PhaseShare represents the share of a phase in the effort and duration of a project
+type PhaseShare struct {
+    Code     string  // Phase code, see PhaseRequirements etc.
+    Effort   float64 // Share of the total effort
+    Duration float64 // Relative weight in the total duration, normalized over the phases of the profile
+}
+
+// PhaseProfile represents how a development methodology distributes effort and duration across phases
+type PhaseProfile struct {
+    Name   string
+    Phases []PhaseShare // In lifecycle order
+}
+
+// Names of the built-in phase profiles
+const (
+    PhaseProfileWaterfall = "waterfall"
+    PhaseProfileIterative = "iterative"
+)
+
+// phaseShareTolerance is the rounding tolerance of the sum of the effort shares of a profile
+const phaseShareTolerance = 0.001
+
+// phaseProfiles holds the built-in profiles by name
+var phaseProfiles = map[string]PhaseProfile{
+    // Sequential phases with a long design up front
+    PhaseProfileWaterfall: {
+        Name: PhaseProfileWaterfall,
+        Phases: []PhaseShare{
+            {Code: PhaseRequirements, Effort: 0.08, Duration: 0.15},
+            {Code: PhaseSystemDesign, Effort: 0.18, Duration: 0.25},
+            {Code: PhaseDetailedDesign, Effort: 0.25, Duration: 0.35},
+            {Code: PhaseImplementation, Effort: 0.26, Duration: 0.45},
+            {Code: PhaseIntegrationTest, Effort: 0.15, Duration: 0.25},
+            {Code: PhaseSystemTest, Effort: 0.08, Duration: 0.15},
+        },
+    },
+    // Short iterations where design, implementation and testing run side by side
+    PhaseProfileIterative: {
+        Name: PhaseProfileIterative,
+        Phases: []PhaseShare{
+            {Code: PhaseRequirements, Effort: 0.06, Duration: 0.10},
+            {Code: PhaseSystemDesign, Effort: 0.10, Duration: 0.15},
+            {Code: PhaseDetailedDesign, Effort: 0.14, Duration: 0.20},
+            {Code: PhaseImplementation, Effort: 0.40, Duration: 0.60},
+            {Code: PhaseIntegrationTest, Effort: 0.20, Duration: 0.35},
+            {Code: PhaseSystemTest, Effort: 0.10, Duration: 0.15},
+        },
+    },
+}
+
+// DefaultPhaseProfile returns the waterfall profile
+func DefaultPhaseProfile() PhaseProfile {
+    profile, _ := PhaseProfileByName(PhaseProfileWaterfall)
+    return profile
+}
+
+// PhaseProfileByName returns a copy of a built-in profile
+func PhaseProfileByName(name string) (PhaseProfile, bool) {
+    profile, ok := phaseProfiles[name]
+    if !ok {
+        return PhaseProfile{}, false
+    }
+    profile.Phases = append([]PhaseShare(nil), profile.Phases...)
+    return profile, true
+}
+
+// PhaseProfiles returns the built-in profiles sorted by name
+func PhaseProfiles() []PhaseProfile {
+    profiles := make([]PhaseProfile, 0, len(phaseProfiles))
+    for name := range phaseProfiles {
+        profile, _ := PhaseProfileByName(name)
+        profiles = append(profiles, profile)
+    }
+    sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+    return profiles
+}
+
+// normalizedDurations returns the duration shares of the phases scaled to sum to 1, so that the
+// phase durations add up to the project duration. Without duration weights the effort shares are used.
+func (p PhaseProfile) normalizedDurations() []float64 {
+    var total float64
+    for _, phase := range p.Phases {
+        total += phase.Duration
+    }
+
+    shares := make([]float64, len(p.Phases))
+    for i, phase := range p.Phases {
+        if total > 0 {
+            shares[i] = phase.Duration / total
+        } else {
+            shares[i] = phase.Effort
+        }
+    }
+    return shares
+}
+
+// Validate checks that the phases are known and unique, the shares non-negative and the effort shares sum to 1
+func (p PhaseProfile) Validate() error {
+    if len(p.Phases) == 0 {
+        return fmt.Errorf("phase profile %q has no phases", p.Name)
+    }
+
+    seen := make(map[string]bool)
+    var totalEffort float64
+    for _, phase := range p.Phases {
+        if !isPhaseCode(phase.Code) {
+            return fmt.Errorf("unknown phase %q in phase profile", phase.Code)
+        }
+        if seen[phase.Code] {
+            return fmt.Errorf("phase %s appears more than once in phase profile", phase.Code)
+        }
+        seen[phase.Code] = true
+        if phase.Effort < 0 || phase.Duration < 0 {
+            return fmt.Errorf("shares of phase %s must not be negative", phase.Code)
+        }
+        totalEffort += phase.Effort
+    }
+    if math.Abs(totalEffort-1) > phaseShareTolerance {
+        return fmt.Errorf("effort shares of the phases must sum to 100%%, got %.1f%%", totalEffort*100)
+    }
+    return nil
+}
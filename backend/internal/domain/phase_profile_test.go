@@ -0,0 +1,82 @@
+package domain
+
+import "testing"
+
+func TestBuiltInPhaseProfilesSumToOne(t *testing.T) {
+    for _, profile := range PhaseProfiles() {
+        if err := profile.Validate(); err != nil {
+            t.Errorf("%s: %v", profile.Name, err)
+        }
+        total := 0.0
+        for _, phase := range profile.Phases {
+            total += phase.Effort
+        }
+        if !approxEqual(total, 1, phaseShareTolerance) {
+            t.Errorf("%s: effort shares sum to %v, want 1", profile.Name, total)
+        }
+    }
+
+    if profile := DefaultPhaseProfile(); profile.Name != PhaseProfileWaterfall || len(profile.Phases) != len(PhaseCodes) {
+        t.Errorf("default profile %s has %d phases, want waterfall with all %d", profile.Name, len(profile.Phases), len(PhaseCodes))
+    }
+}
+
+func TestPhaseProfileRejectsInvalidShares(t *testing.T) {
+    tests := []struct {
+        name    string
+        profile PhaseProfile
+    }{
+        {"effort below 100%", PhaseProfile{Phases: []PhaseShare{{Code: PhaseImplementation, Effort: 0.6}, {Code: PhaseSystemTest, Effort: 0.3}}}},
+        {"effort above 100%", PhaseProfile{Phases: []PhaseShare{{Code: PhaseImplementation, Effort: 0.8}, {Code: PhaseSystemTest, Effort: 0.3}}}},
+        {"no phases", PhaseProfile{Name: "empty"}},
+        {"unknown phase", PhaseProfile{Phases: []PhaseShare{{Code: "deployment", Effort: 1}}}},
+        {"repeated phase", PhaseProfile{Phases: []PhaseShare{{Code: PhaseImplementation, Effort: 0.5}, {Code: PhaseImplementation, Effort: 0.5}}}},
+        {"negative duration", PhaseProfile{Phases: []PhaseShare{{Code: PhaseImplementation, Effort: 1, Duration: -1}}}},
+    }
+    for _, tt := range tests {
+        if err := tt.profile.Validate(); err == nil {
+            t.Errorf("%s: got no error", tt.name)
+        }
+    }
+}
+
+func TestPhaseProfileByNameReturnsCopy(t *testing.T) {
+    profile, ok := PhaseProfileByName(PhaseProfileIterative)
+    if !ok {
+        t.Fatal("iterative profile not found")
+    }
+    profile.Phases[0].Effort = 1
+
+    again, _ := PhaseProfileByName(PhaseProfileIterative)
+    if again.Phases[0].Effort == 1 {
+        t.Error("changing a returned profile changed the built-in one")
+    }
+    if _, ok := PhaseProfileByName("spiral"); ok {
+        t.Error("found an unknown profile")
+    }
+}
+
+func TestPhaseDurationsSumToProjectDuration(t *testing.T) {
+    estimate := nominalEstimate(50)
+    estimate.CalculateEffort()
+
+    custom := PhaseProfile{Name: "custom", Phases: []PhaseShare{
+        {Code: PhaseSystemDesign, Effort: 0.3},
+        {Code: PhaseImplementation, Effort: 0.7},
+    }}
+    for _, profile := range append(PhaseProfiles(), custom) {
+        result := estimate.GenerateDetailedResult(RateCard{}, nil, profile, DefaultEstimationConfig())
+
+        var duration, effort float64
+        for _, phase := range result.PhaseDistribution {
+            duration += phase.Duration
+            effort += phase.Effort
+        }
+        if !approxEqual(duration, estimate.DurationTM, 1e-9) {
+            t.Errorf("%s: phase durations sum to %v, want the project duration %v", profile.Name, duration, estimate.DurationTM)
+        }
+        if !approxEqual(effort, estimate.EffortPM, 1e-9) {
+            t.Errorf("%s: phase efforts sum to %v, want the project effort %v", profile.Name, effort, estimate.EffortPM)
+        }
+    }
+}
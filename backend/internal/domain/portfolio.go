@@ -0,0 +1,36 @@
+package domain
+
+// PortfolioSummary rolls up the detailed COCOMO II results of the candidate projects of a portfolio
+type PortfolioSummary struct {
+    ProjectCount    int
+    TotalEffortPM   float64
+    TotalCost       float64 // Sum of the grand totals including overhead and tax, regardless of currency
+    MaxDurationTM   float64 // Longest schedule, the duration of the portfolio when the projects run in parallel
+    PeakTeamSize    float64 // Sum of the average team sizes, the staff needed to run the projects in parallel
+    RiskScore       float64 // Mean risk score weighted by effort, so large projects weigh more (0-100)
+    RiskBand        string  // Low, Medium, High, derived from the risk score
+    HighRiskCount   int     // Projects whose own risk band is High
+}
+
+// NewPortfolioSummary aggregates detailed results, deriving the risk band with the given thresholds
+func NewPortfolioSummary(results []*COCOMODetailedResult, risk RiskConfig) *PortfolioSummary {
+    summary := &PortfolioSummary{ProjectCount: len(results)}
+    weightedRisk := 0.0
+    for _, result := range results {
+        summary.TotalEffortPM += result.AdjustedEffort
+        summary.TotalCost += result.CostEstimate.GrandTotal
+        if result.Duration > summary.MaxDurationTM {
+            summary.MaxDurationTM = result.Duration
+        }
+        summary.PeakTeamSize += result.TeamSize
+        weightedRisk += result.RiskScore * result.AdjustedEffort
+        if result.RiskBand == "High" {
+            summary.HighRiskCount++
+        }
+    }
+    if summary.TotalEffortPM > 0 {
+        summary.RiskScore = weightedRisk / summary.TotalEffortPM
+    }
+    summary.RiskBand = risk.Band(summary.RiskScore)
+    return summary
+}
@@ -13,6 +13,22 @@ const (
     ProcessDelivery           ProcessCategory = "delivery"
 )
 
+// ProcessCategories lists the process categories in their natural order
+var ProcessCategories = []ProcessCategory{
+    ProcessRequirementDefinition, ProcessFunctionalSpec, ProcessBasicDesign, ProcessDetailedDesign,
+    ProcessImplementation, ProcessTesting, ProcessDelivery,
+}
+
+// IsValid reports whether the category is one of the known process categories
+func (c ProcessCategory) IsValid() bool {
+    for _, category := range ProcessCategories {
+        if c == category {
+            return true
+        }
+    }
+    return false
+}
+
 // Process represents a development process category and its standard activities
 type Process struct {
     ID          string
@@ -21,6 +37,7 @@ type Process struct {
     Description string
     Activities  []Activity
     Order       int // For maintaining the natural order of processes
+    ComplexityCurve ComplexityCurve // Default curve of the activities in this category, linear when empty
 }
 
 // Activity represents a standard activity within a process
@@ -30,6 +47,36 @@ type Activity struct {
     Description string
     BaseHours   float64    // Standard base hours for this activity
     Deliverables []string  // Expected deliverables from this activity
+    DeliverableHours map[string]float64 // Optional base hours per deliverable for deliverable-based estimation
+    ComplexityCurve ComplexityCurve // Overrides the curve of the process when set
+}
+
+// HasActivity reports whether the process contains the activity
+func (p *Process) HasActivity(activityID string) bool {
+    for _, a := range p.Activities {
+        if a.ID == activityID {
+            return true
+        }
+    }
+    return false
+}
+
+// Activity returns the activity of the process with the given ID, or nil when it isn't part of the process
+func (p *Process) Activity(activityID string) *Activity {
+    for i := range p.Activities {
+        if p.Activities[i].ID == activityID {
+            return &p.Activities[i]
+        }
+    }
+    return nil
+}
+
+// CurveFor returns the complexity curve of an activity, falling back to the curve of the process
+func (p *Process) CurveFor(activity Activity) ComplexityCurve {
+    if activity.ComplexityCurve != "" {
+        return activity.ComplexityCurve
+    }
+    return p.ComplexityCurve
 }
 
 // ProcessRepository defines the interface for process persistence
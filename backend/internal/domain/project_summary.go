@@ -0,0 +1,36 @@
+package domain
+
+// ProjectSummary aggregates all estimates of a project
+type ProjectSummary struct {
+    ProjectID         string
+    EstimateCount     int
+    TotalHours        float64
+    HourlyRate        float64
+    TotalCost         float64 // TotalHours × HourlyRate
+    AverageConfidence float64 // Mean planning maturity confidence (0-1), 0 without estimates
+    CountByStatus     map[EstimateStatus]int
+}
+
+// NewProjectSummary creates an empty summary, with every status counted as 0
+func NewProjectSummary(projectID string, hourlyRate float64) *ProjectSummary {
+    return &ProjectSummary{
+        ProjectID:  projectID,
+        HourlyRate: hourlyRate,
+        CountByStatus: map[EstimateStatus]int{
+            EstimateStatusDraft:     0,
+            EstimateStatusCompleted: 0,
+            EstimateStatusApproved:  0,
+        },
+    }
+}
+
+// Add adds an estimate and its planning maturity confidence to the summary
+func (s *ProjectSummary) Add(estimate *Estimate, confidence float64) {
+    totalConfidence := s.AverageConfidence * float64(s.EstimateCount)
+
+    s.EstimateCount++
+    s.TotalHours += estimate.TotalHours
+    s.TotalCost = s.TotalHours * s.HourlyRate
+    s.AverageConfidence = (totalConfidence + confidence) / float64(s.EstimateCount)
+    s.CountByStatus[estimate.Status]++
+}
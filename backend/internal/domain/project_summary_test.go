@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestProjectSummaryOfMixedStatuses(t *testing.T) {
+    summary := NewProjectSummary("project-1", 5000)
+    summary.Add(&Estimate{TotalHours: 100, Status: EstimateStatusDraft}, 0.5)
+    summary.Add(&Estimate{TotalHours: 200, Status: EstimateStatusApproved}, 0.7)
+    summary.Add(&Estimate{TotalHours: 300, Status: EstimateStatusApproved}, 0.9)
+
+    if summary.EstimateCount != 3 || summary.TotalHours != 600 {
+        t.Errorf("%d estimates, %v hours, want 3 and 600", summary.EstimateCount, summary.TotalHours)
+    }
+    if summary.TotalCost != 600*5000 {
+        t.Errorf("TotalCost = %v, want %v", summary.TotalCost, 600*5000)
+    }
+    if !approxEqual(summary.AverageConfidence, 0.7, 1e-9) {
+        t.Errorf("AverageConfidence = %v, want 0.7", summary.AverageConfidence)
+    }
+    want := map[EstimateStatus]int{EstimateStatusDraft: 1, EstimateStatusCompleted: 0, EstimateStatusApproved: 2}
+    for status, count := range want {
+        if summary.CountByStatus[status] != count {
+            t.Errorf("%s: %d estimates, want %d", status, summary.CountByStatus[status], count)
+        }
+    }
+}
@@ -0,0 +1,91 @@
+package domain
+
+import "sort"
+
+// Codes identifying the phases of COCOMODetailedResult.PhaseDistribution, used as rate card keys
+const (
+    PhaseRequirements    = "requirements"
+    PhaseSystemDesign    = "system_design"
+    PhaseDetailedDesign  = "detailed_design"
+    PhaseImplementation  = "implementation"
+    PhaseIntegrationTest = "integration_test"
+    PhaseSystemTest      = "system_test"
+)
+
+// PhaseCodes lists the phase codes in the order of the phase distribution
+var PhaseCodes = []string{
+    PhaseRequirements,
+    PhaseSystemDesign,
+    PhaseDetailedDesign,
+    PhaseImplementation,
+    PhaseIntegrationTest,
+    PhaseSystemTest,
+}
+
+// RateCard holds the hourly rates used to cost an estimate. Phases missing from PhaseRates
+// are billed at DefaultRate, so a card without phase rates is a flat rate.
+type RateCard struct {
+    Currency     string             // ISO 4217 code, e.g. JPY; informational only
+    DefaultRate  float64
+    PhaseRates   map[string]float64 // Phase code -> hourly rate
+    OverheadRate float64            // Added on top of labor cost, e.g. 0.3 for 1.3x
+    TaxRate      float64            // Applied to labor cost plus overhead, e.g. 0.1 for 10%
+}
+
+// CostBreakdown splits a cost into labor, overhead and tax
+type CostBreakdown struct {
+    Subtotal   float64 // Labor cost
+    Overhead   float64 // Subtotal * OverheadRate
+    Tax        float64 // (Subtotal + Overhead) * TaxRate
+    GrandTotal float64 // Subtotal * (1 + OverheadRate) * (1 + TaxRate)
+}
+
+// Breakdown applies the overhead and tax rates of the card to a labor cost
+func (rc RateCard) Breakdown(subtotal float64) CostBreakdown {
+    overhead := subtotal * rc.OverheadRate
+    tax := (subtotal + overhead) * rc.TaxRate
+    return CostBreakdown{
+        Subtotal:   subtotal,
+        Overhead:   overhead,
+        Tax:        tax,
+        GrandTotal: subtotal + overhead + tax,
+    }
+}
+
+// FlatRateCard returns a rate card billing every phase at the same hourly rate
+func FlatRateCard(hourlyRate float64) RateCard {
+    return RateCard{DefaultRate: hourlyRate}
+}
+
+// RateFor returns the hourly rate of a phase
+func (rc RateCard) RateFor(phase string) float64 {
+    if rate, ok := rc.PhaseRates[phase]; ok {
+        return rate
+    }
+    return rc.DefaultRate
+}
+
+// UnknownPhases returns the keys of PhaseRates that aren't phase codes
+func (rc RateCard) UnknownPhases() []string {
+    var unknown []string
+    for phase := range rc.PhaseRates {
+        if !isPhaseCode(phase) {
+            unknown = append(unknown, phase)
+        }
+    }
+    sort.Strings(unknown)
+    return unknown
+}
+
+// IsSet reports whether the card has any rate, i.e. whether an estimate should be costed
+func (rc RateCard) IsSet() bool {
+    if rc.DefaultRate > 0 {
+        return true
+    }
+    for _, rate := range rc.PhaseRates {
+        if rate > 0 {
+            return true
+        }
+    }
+    return false
+}
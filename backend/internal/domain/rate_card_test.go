@@ -0,0 +1,89 @@
+package domain
+
+import "testing"
+
+// costedResult returns the detailed result of a nominal 50 KSLOC estimate costed with the rate card
+func costedResult(rates RateCard) *COCOMODetailedResult {
+    estimate := nominalEstimate(50)
+    estimate.CalculateEffort()
+    return estimate.GenerateDetailedResult(rates, nil, PhaseProfile{}, DefaultEstimationConfig())
+}
+
+func TestRateCardAgainstFlatRate(t *testing.T) {
+    flat := costedResult(FlatRateCard(5000))
+    if want := flat.AdjustedEffort * DefaultHoursPerPersonMonth * 5000; !approxEqual(flat.CostEstimate.TotalCost, want, 1e-6) {
+        t.Errorf("flat TotalCost = %v, want %v", flat.CostEstimate.TotalCost, want)
+    }
+    if flat.CostEstimate.HourlyRate != 5000 {
+        t.Errorf("flat HourlyRate = %v, want 5000", flat.CostEstimate.HourlyRate)
+    }
+
+    card := costedResult(RateCard{Currency: "JPY", DefaultRate: 5000, PhaseRates: map[string]float64{PhaseImplementation: 8000}})
+    var implementation PhaseEffort
+    for _, phase := range card.PhaseDistribution {
+        if phase.Code == PhaseImplementation {
+            implementation = phase
+        } else if phase.HourlyRate != 5000 {
+            t.Errorf("%s: HourlyRate = %v, want the default 5000", phase.Code, phase.HourlyRate)
+        }
+    }
+    if implementation.HourlyRate != 8000 {
+        t.Fatalf("implementation HourlyRate = %v, want 8000", implementation.HourlyRate)
+    }
+
+    // Only the implementation hours are billed at the higher rate
+    extra := implementation.Effort * DefaultHoursPerPersonMonth * 3000
+    if want := flat.CostEstimate.TotalCost + extra; !approxEqual(card.CostEstimate.TotalCost, want, 1e-6) {
+        t.Errorf("rate card TotalCost = %v, want %v", card.CostEstimate.TotalCost, want)
+    }
+    if rate := card.CostEstimate.HourlyRate; rate <= 5000 || rate >= 8000 {
+        t.Errorf("blended HourlyRate = %v, want between 5000 and 8000", rate)
+    }
+    if card.CostEstimate.Currency != "JPY" {
+        t.Errorf("Currency = %q, want JPY", card.CostEstimate.Currency)
+    }
+}
+
+func TestRateCardWithoutRatesIsNotCosted(t *testing.T) {
+    result := costedResult(RateCard{})
+    if result.CostEstimate.TotalCost != 0 {
+        t.Errorf("TotalCost = %v, want 0 without rates", result.CostEstimate.TotalCost)
+    }
+}
+
+func TestBreakdownAppliesOverheadThenTax(t *testing.T) {
+    rates := RateCard{DefaultRate: 5000, OverheadRate: 0.3, TaxRate: 0.1}
+    breakdown := rates.Breakdown(1000)
+
+    if breakdown.Subtotal != 1000 || !approxEqual(breakdown.Overhead, 300, 1e-9) || !approxEqual(breakdown.Tax, 130, 1e-9) {
+        t.Errorf("breakdown = %+v, want 1000 + 300 overhead + 130 tax", breakdown)
+    }
+    if want := 1000 * 1.3 * 1.1; !approxEqual(breakdown.GrandTotal, want, 1e-9) {
+        t.Errorf("GrandTotal = %v, want subtotal × (1 + overhead) × (1 + tax) = %v", breakdown.GrandTotal, want)
+    }
+}
+
+func TestCostRangeIncludesOverheadAndTax(t *testing.T) {
+    rates := RateCard{DefaultRate: 5000, OverheadRate: 0.3, TaxRate: 0.1}
+    result := costedResult(rates)
+    cost := result.CostEstimate
+
+    if want := cost.Subtotal * 1.3 * 1.1; !approxEqual(cost.GrandTotal, want, 1e-6) {
+        t.Errorf("GrandTotal = %v, want %v", cost.GrandTotal, want)
+    }
+    if cost.Subtotal != cost.TotalCost {
+        t.Errorf("Subtotal = %v, want the labor cost %v", cost.Subtotal, cost.TotalCost)
+    }
+
+    // Each end of the range is the labor cost at that effort with the same rates applied
+    band := result.UncertaintyBand
+    if cost.CostRange.Nominal != cost.GrandTotal {
+        t.Errorf("nominal cost = %v, want the grand total %v", cost.CostRange.Nominal, cost.GrandTotal)
+    }
+    if want := cost.Subtotal * band.EffortLow * 1.3 * 1.1; !approxEqual(cost.CostRange.Minimum, want, 1e-6) {
+        t.Errorf("minimum cost = %v, want %v", cost.CostRange.Minimum, want)
+    }
+    if want := cost.Subtotal * band.EffortHigh * 1.3 * 1.1; !approxEqual(cost.CostRange.Maximum, want, 1e-6) {
+        t.Errorf("maximum cost = %v, want %v", cost.CostRange.Maximum, want)
+    }
+}
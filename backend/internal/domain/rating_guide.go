@@ -0,0 +1,225 @@
+package domain
+
+// RatingLevels names the rating levels in order; a rating is the index of its level
+var RatingLevels = []string{"very_low", "low", "nominal", "high", "very_high", "extra_high"}
+
+// RatingLevel describes a rating level of a scale factor or cost driver
+type RatingLevel struct {
+    Level       string  // very_low ... extra_high
+    Rating      float64 // Numeric rating, 0 (Very Low) to 5 (Extra High)
+    Description string
+    Value       float64 // Effort multiplier of a cost driver, exponent contribution of a scale factor
+}
+
+// factorSpec holds the built-in definition of a scale factor or cost driver.
+// Guide and Values are indexed by rating; an empty guide entry marks a level the model leaves undefined.
+type factorSpec struct {
+    Name        string
+    Description string
+    Weight      float64    // Scale factors only, the COCOMO II.2000 Nominal value over its three steps to Extra High
+    Values      [6]float64 // Cost drivers only; undefined levels repeat the nearest defined multiplier
+    Guide       [6]string
+}
+
+// ScaleFactorTypes lists the scale factor types in their canonical order
+var ScaleFactorTypes = []ScaleFactorType{
+    ScaleFactorPREC, ScaleFactorFLEX, ScaleFactorRESL, ScaleFactorTEAM, ScaleFactorPMAT,
+}
+
+var scaleFactorSpecs = map[ScaleFactorType]factorSpec{
+    ScaleFactorPREC: {
+        Name: "先例性", Description: "類似プロジェクトの経験度", Weight: 3.72 / 3,
+        Guide: [6]string{"全く新しい開発", "大部分が新規", "類似経験あり", "ほぼ同様の開発経験あり", "ほぼ同一の開発", "完全に熟知した開発"},
+    },
+    ScaleFactorFLEX: {
+        Name: "開発の柔軟性", Description: "開発プロセスの柔軟性", Weight: 3.04 / 3,
+        Guide: [6]string{"厳格な制約あり", "一部柔軟性あり", "ある程度柔軟", "大部分が柔軟", "完全に柔軟", "一般的な目標のみ"},
+    },
+    ScaleFactorRESL: {
+        Name: "アーキテクチャ/リスク対応", Description: "リスク管理とアーキテクチャ対応の程度", Weight: 4.24 / 3,
+        Guide: [6]string{"リスク対応なし", "一部のリスクに対応", "半分程度のリスクに対応", "大部分のリスクに対応", "ほぼ全てのリスクに対応", "全てのリスクに対応"},
+    },
+    ScaleFactorTEAM: {
+        Name: "チーム凝集性", Description: "チームの協力度と一貫性", Weight: 3.29 / 3,
+        Guide: [6]string{"協力関係が非常に困難", "協力関係がやや困難", "基本的に協力的", "非常に協力的", "高度に協力的", "完全に一体化"},
+    },
+    ScaleFactorPMAT: {
+        Name: "プロセス成熟度", Description: "組織のプロセス成熟度", Weight: 4.68 / 3,
+        Guide: [6]string{"CMMIレベル1（下位）", "CMMIレベル1（上位）", "CMMIレベル2", "CMMIレベル3", "CMMIレベル4", "CMMIレベル5"},
+    },
+}
+
+// CostDriverTypes lists the cost driver types in their canonical order
+var CostDriverTypes = []CostDriverType{
+    CostDriverRELY, CostDriverDATA, CostDriverCPLX, CostDriverREUS, CostDriverDOCU,
+    CostDriverTIME, CostDriverSTOR, CostDriverPVOL,
+    CostDriverACAP, CostDriverPCAP, CostDriverPCON, CostDriverAPEX, CostDriverPLEX, CostDriverLTEX,
+    CostDriverTOOL, CostDriverSITE, CostDriverSCED,
+}
+
+// costDriverSpecs holds the COCOMO II.2000 Post-Architecture multipliers
+var costDriverSpecs = map[CostDriverType]factorSpec{
+    // Product Factors
+    CostDriverRELY: {
+        Name: "要求される信頼性", Description: "システム障害による影響の大きさ",
+        Values: [6]float64{0.82, 0.92, 1.00, 1.10, 1.26, 1.26},
+        Guide:  [6]string{"軽微な不便", "軽度の損失", "中程度の損失", "大きな損失", "人命に関わる", ""},
+    },
+    CostDriverDATA: {
+        Name: "データベース規模", Description: "テストデータベースサイズ/プログラムサイズの比",
+        Values: [6]float64{0.90, 0.90, 1.00, 1.14, 1.28, 1.28},
+        Guide:  [6]string{"10未満（低と同じ扱い）", "10未満", "10〜100", "100〜1000", "1000以上", ""},
+    },
+    CostDriverCPLX: {
+        Name: "製品の複雑さ", Description: "制御操作、演算処理、デバイス処理、データ管理、UI管理の複雑さ",
+        Values: [6]float64{0.73, 0.87, 1.00, 1.17, 1.34, 1.74},
+        Guide:  [6]string{"単純な処理", "やや複雑", "中程度", "複雑", "非常に複雑", "非常に高度な処理"},
+    },
+    CostDriverREUS: {
+        Name: "要求される再利用性", Description: "他で再利用するための開発の範囲",
+        Values: [6]float64{0.95, 0.95, 1.00, 1.07, 1.15, 1.24},
+        Guide:  [6]string{"再利用なし（低と同じ扱い）", "再利用なし", "プロジェクト内", "プログラム内", "製品ライン内", "複数の製品ライン"},
+    },
+    CostDriverDOCU: {
+        Name: "ドキュメント化", Description: "ライフサイクルニーズに対するドキュメントの適合度",
+        Values: [6]float64{0.81, 0.91, 1.00, 1.11, 1.23, 1.23},
+        Guide:  [6]string{"多くのニーズが未対応", "一部のニーズが未対応", "ニーズに適合", "ニーズに対して過剰", "ニーズに対して非常に過剰", ""},
+    },
+    // Platform Factors
+    CostDriverTIME: {
+        Name: "実行時間制約", Description: "使用可能な実行時間の制約",
+        Values: [6]float64{1.00, 1.00, 1.00, 1.11, 1.29, 1.63},
+        Guide:  [6]string{"実行時間の50%以下（公称と同じ扱い）", "実行時間の50%以下（公称と同じ扱い）", "実行時間の50%以下", "70%", "85%", "95%"},
+    },
+    CostDriverSTOR: {
+        Name: "主記憶制約", Description: "主記憶の制約",
+        Values: [6]float64{1.00, 1.00, 1.00, 1.05, 1.17, 1.46},
+        Guide:  [6]string{"主記憶の50%以下（公称と同じ扱い）", "主記憶の50%以下（公称と同じ扱い）", "主記憶の50%以下", "70%", "85%", "95%"},
+    },
+    CostDriverPVOL: {
+        Name: "プラットフォーム揮発性", Description: "ハードウェア・OS等のプラットフォームの変更頻度",
+        Values: [6]float64{0.87, 0.87, 1.00, 1.15, 1.30, 1.30},
+        Guide:  [6]string{"大きな変更は12か月ごと（低と同じ扱い）", "大きな変更は12か月ごと", "大きな変更は6か月ごと", "大きな変更は2か月ごと", "大きな変更は2週間ごと", ""},
+    },
+    // Personnel Factors
+    CostDriverACAP: {
+        Name: "アナリスト能力", Description: "分析担当者の能力と経験",
+        Values: [6]float64{1.42, 1.19, 1.00, 0.85, 0.71, 0.71},
+        Guide:  [6]string{"15パーセンタイル", "35パーセンタイル", "55パーセンタイル", "75パーセンタイル", "90パーセンタイル", ""},
+    },
+    CostDriverPCAP: {
+        Name: "プログラマ能力", Description: "プログラマの能力と経験",
+        Values: [6]float64{1.34, 1.15, 1.00, 0.88, 0.76, 0.76},
+        Guide:  [6]string{"15パーセンタイル", "35パーセンタイル", "55パーセンタイル", "75パーセンタイル", "90パーセンタイル", ""},
+    },
+    CostDriverPCON: {
+        Name: "要員の継続性", Description: "プロジェクト期間中の要員の交代率",
+        Values: [6]float64{1.29, 1.12, 1.00, 0.90, 0.81, 0.81},
+        Guide:  [6]string{"年48%の交代", "年24%の交代", "年12%の交代", "年6%の交代", "年3%の交代", ""},
+    },
+    CostDriverAPEX: {
+        Name: "アプリケーション経験", Description: "対象分野のアプリケーション開発経験",
+        Values: [6]float64{1.22, 1.10, 1.00, 0.88, 0.81, 0.81},
+        Guide:  [6]string{"2か月以下", "6か月", "1年", "3年", "6年", ""},
+    },
+    CostDriverPLEX: {
+        Name: "プラットフォーム経験", Description: "使用するプラットフォームの経験",
+        Values: [6]float64{1.19, 1.09, 1.00, 0.91, 0.85, 0.85},
+        Guide:  [6]string{"2か月以下", "6か月", "1年", "3年", "6年", ""},
+    },
+    CostDriverLTEX: {
+        Name: "言語・ツール経験", Description: "使用する言語とツールの経験",
+        Values: [6]float64{1.20, 1.09, 1.00, 0.91, 0.84, 0.84},
+        Guide:  [6]string{"2か月以下", "6か月", "1年", "3年", "6年", ""},
+    },
+    // Project Factors
+    CostDriverTOOL: {
+        Name: "ツール使用", Description: "使用するツールの成熟度と機能",
+        Values: [6]float64{1.17, 1.09, 1.00, 0.90, 0.78, 0.78},
+        Guide:  [6]string{"編集・コンパイル・デバッグのみ", "基本的なツール", "基本的なライフサイクルツール", "成熟したライフサイクルツール", "統合された先進的なツール", ""},
+    },
+    CostDriverSITE: {
+        Name: "開発拠点の分散", Description: "開発チームの地理的分散と通信手段",
+        Values: [6]float64{1.22, 1.09, 1.00, 0.93, 0.86, 0.80},
+        Guide:  [6]string{"国際的に分散・電話とメール", "複数都市・個別の電話とFAX", "同一都市・ナローバンド", "同一都市・ワイドバンド", "同一建物・対話型マルチメディア", "同一フロア・完全な対話型マルチメディア"},
+    },
+    CostDriverSCED: {
+        Name: "要求される開発工期", Description: "標準工期に対する要求工期の短縮度",
+        Values: [6]float64{1.43, 1.14, 1.00, 1.00, 1.00, 1.00},
+        Guide:  [6]string{"標準工期の75%", "85%", "100%", "130%", "160%", ""},
+    },
+}
+
+// RatingGuide returns the levels of the scale factor with the exponent contribution of each
+func (t ScaleFactorType) RatingGuide() []RatingLevel {
+    spec := scaleFactorSpecs[t]
+    sf := NewScaleFactor(t)
+    var levels []RatingLevel
+    for i, description := range spec.Guide {
+        if description == "" {
+            continue
+        }
+        sf.Rating = float64(i)
+        levels = append(levels, RatingLevel{
+            Level:       RatingLevels[i],
+            Rating:      float64(i),
+            Description: description,
+            Value:       sf.ExponentContribution(),
+        })
+    }
+    return levels
+}
+
+// RatingGuide returns the levels the model defines for the cost driver with their effort multipliers
+func (t CostDriverType) RatingGuide() []RatingLevel {
+    spec := costDriverSpecs[t]
+    var levels []RatingLevel
+    for i, description := range spec.Guide {
+        if description == "" {
+            continue
+        }
+        levels = append(levels, RatingLevel{
+            Level:       RatingLevels[i],
+            Rating:      float64(i),
+            Description: description,
+            Value:       spec.Values[i],
+        })
+    }
+    return levels
+}
+
+// Weight returns the scale factor value of one rating step; see ScaleFactor.ExponentContribution
+func (t ScaleFactorType) Weight() float64 {
+    return scaleFactorSpecs[t].Weight
+}
+
+// RatingValues returns the effort multipliers of the cost driver, Very Low to Extra High
+func (t CostDriverType) RatingValues() []float64 {
+    values := costDriverSpecs[t].Values
+    return values[:]
+}
+
+// NewScaleFactor returns the built-in definition of a scale factor, identified by its type
+func NewScaleFactor(t ScaleFactorType) ScaleFactor {
+    spec := scaleFactorSpecs[t]
+    return ScaleFactor{
+        ID:          string(t),
+        Type:        t,
+        Name:        spec.Name,
+        Description: spec.Description,
+        Weight:      t.Weight(),
+    }
+}
+
+// NewCostDriver returns the built-in definition of a cost driver at nominal, identified by its type
+func NewCostDriver(t CostDriverType) CostDriver {
+    spec := costDriverSpecs[t]
+    return CostDriver{
+        ID:           string(t),
+        Type:         t,
+        Name:         spec.Name,
+        Description:  spec.Description,
+        Value:        1.0, // Nominal value
+        RatingValues: t.RatingValues(),
+    }
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestCostDriverRatingGuidesAreComplete(t *testing.T) {
+    for _, ty := range CostDriverTypes {
+        guide := ty.RatingGuide()
+        if len(guide) != 5 && len(guide) != 6 {
+            t.Errorf("%s: guide has %d levels, want 5 or 6", ty, len(guide))
+            continue
+        }
+        values := ty.RatingValues()
+        for i, level := range guide {
+            if level.Level != RatingLevels[i] || level.Rating != float64(i) {
+                t.Errorf("%s: level %d is %s rated %v, want %s", ty, i, level.Level, level.Rating, RatingLevels[i])
+            }
+            if level.Description == "" {
+                t.Errorf("%s %s: missing description", ty, level.Level)
+            }
+            if level.Value != values[i] {
+                t.Errorf("%s %s: guide multiplier %v, calculations use %v", ty, level.Level, level.Value, values[i])
+            }
+        }
+        if guide[2].Value != 1.0 {
+            t.Errorf("%s: Nominal multiplier %v, want 1.0", ty, guide[2].Value)
+        }
+    }
+}
+
+func TestScaleFactorRatingGuidesAreComplete(t *testing.T) {
+    for _, ty := range ScaleFactorTypes {
+        guide := ty.RatingGuide()
+        if len(guide) != 5 && len(guide) != 6 {
+            t.Errorf("%s: guide has %d levels, want 5 or 6", ty, len(guide))
+            continue
+        }
+        for i, level := range guide {
+            if level.Level != RatingLevels[i] || level.Description == "" {
+                t.Errorf("%s: level %d is %q with description %q, want %s", ty, i, level.Level, level.Description, RatingLevels[i])
+            }
+            if want := scaleFactorExponentScale * ty.Weight() * (MaxRating - float64(i)); !approxEqual(level.Value, want, 1e-9) {
+                t.Errorf("%s %s: value %v, want %v", ty, level.Level, level.Value, want)
+            }
+        }
+    }
+}
@@ -0,0 +1,103 @@
+package domain
+
+// Thresholds of the generic recommendations, used when no targeted rule applies
+const (
+    genericScaleFactorRating = 1.5
+    genericCostDriverValue   = 1.2
+)
+
+// Conditions of the recommendation rules, part of the message keys
+const (
+    RecommendWhenLow  = "low"
+    RecommendWhenHigh = "high"
+)
+
+// recommendationRule recommends a targeted action when a factor is rated within [MinRating, MaxRating]
+type recommendationRule struct {
+    MinRating float64
+    MaxRating float64
+    When      string // RecommendWhenLow or RecommendWhenHigh
+}
+
+var (
+    ruleLow      = recommendationRule{MinRating: 0, MaxRating: 1, When: RecommendWhenLow}
+    ruleHigh     = recommendationRule{MinRating: 3, MaxRating: 5, When: RecommendWhenHigh}
+    ruleVeryHigh = recommendationRule{MinRating: 4, MaxRating: 5, When: RecommendWhenHigh}
+)
+
+// scaleFactorRules targets the lower levels, which describe the unfavourable situations and add
+// the most to the exponent
+var scaleFactorRules = map[ScaleFactorType][]recommendationRule{
+    ScaleFactorPREC: {ruleLow},
+    ScaleFactorFLEX: {ruleLow},
+    ScaleFactorRESL: {ruleLow},
+    ScaleFactorTEAM: {ruleLow},
+    ScaleFactorPMAT: {ruleLow},
+}
+
+// costDriverRules targets the levels whose multipliers raise the effort
+var costDriverRules = map[CostDriverType][]recommendationRule{
+    CostDriverRELY: {ruleHigh},
+    CostDriverDATA: {ruleHigh},
+    CostDriverCPLX: {ruleVeryHigh},
+    CostDriverREUS: {ruleVeryHigh},
+    CostDriverDOCU: {ruleVeryHigh},
+    CostDriverTIME: {ruleVeryHigh},
+    CostDriverSTOR: {ruleVeryHigh},
+    CostDriverPVOL: {ruleHigh},
+    CostDriverACAP: {ruleLow},
+    CostDriverPCAP: {ruleLow},
+    CostDriverPCON: {ruleLow},
+    CostDriverAPEX: {ruleLow},
+    CostDriverPLEX: {ruleLow},
+    CostDriverLTEX: {ruleLow},
+    CostDriverTOOL: {ruleLow},
+    CostDriverSITE: {ruleLow},
+    CostDriverSCED: {ruleLow},
+}
+
+// RecommendationKey returns the message key of the scale factor's recommendation for a rule condition
+func (t ScaleFactorType) RecommendationKey(when string) string {
+    return "recommendation." + string(t) + "." + when
+}
+
+// RecommendationKey returns the message key of the cost driver's recommendation for a rule condition
+func (t CostDriverType) RecommendationKey(when string) string {
+    return "recommendation." + string(t) + "." + when
+}
+
+// matchRule returns the first rule covering the rating
+func matchRule(rules []recommendationRule, rating float64) (recommendationRule, bool) {
+    for _, rule := range rules {
+        if rating >= rule.MinRating && rating <= rule.MaxRating {
+            return rule, true
+        }
+    }
+    return recommendationRule{}, false
+}
+
+// recommendation returns the message key of the targeted recommendation for the scale factor's
+// rating, the generic one for a low rating, or an empty string
+func (sf *ScaleFactor) recommendation() string {
+    if rule, ok := matchRule(scaleFactorRules[sf.Type], sf.Rating); ok {
+        return sf.Type.RecommendationKey(rule.When)
+    }
+    if sf.Rating < genericScaleFactorRating {
+        return MsgRecommendScaleFactor
+    }
+    return ""
+}
+
+// recommendation returns the message key of the targeted recommendation for the cost driver's
+// rating when its multiplier raises the effort, the generic one for a high multiplier, or an empty string
+func (cd *CostDriver) recommendation() string {
+    if cd.Value > 1.0 {
+        if rule, ok := matchRule(costDriverRules[cd.Type], cd.Rating); ok {
+            return cd.Type.RecommendationKey(rule.When)
+        }
+    }
+    if cd.Value > genericCostDriverValue {
+        return MsgRecommendCostDriver
+    }
+    return ""
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+// analysisOf returns the cost driver analysis of a driver type
+func analysisOf(t *testing.T, result *COCOMODetailedResult, driver CostDriverType) FactorAnalysis {
+    t.Helper()
+    for _, analysis := range result.CostDriverAnalysis {
+        if analysis.Name == driver.NameKey() {
+            return analysis
+        }
+    }
+    t.Fatalf("no analysis of %s", driver)
+    return FactorAnalysis{}
+}
+
+func TestLowAnalystCapabilityRecommendsImprovingIt(t *testing.T) {
+    estimate := nominalEstimate(50)
+    estimate.rate(CostDriverACAP, 0)
+    estimate.CalculateEffort()
+
+    result := estimate.GenerateDetailedResult(RateCard{}, nil, PhaseProfile{}, DefaultEstimationConfig())
+
+    want := CostDriverACAP.RecommendationKey(RecommendWhenLow)
+    if got := analysisOf(t, result, CostDriverACAP).Recommendation; got != want {
+        t.Errorf("ACAP recommendation %q, want %q", got, want)
+    }
+    if got := analysisOf(t, result, CostDriverPCAP).Recommendation; got != "" {
+        t.Errorf("nominal PCAP recommendation %q, want none", got)
+    }
+}
+
+func TestRecommendationRules(t *testing.T) {
+    tests := []struct {
+        driver CostDriverType
+        rating float64
+        want   string
+    }{
+        {CostDriverACAP, 1, CostDriverACAP.RecommendationKey(RecommendWhenLow)},
+        {CostDriverACAP, 4, ""}, // Favourable ratings need no action
+        {CostDriverCPLX, 3, ""}, // 1.17 is below the generic threshold and outside the rule
+        {CostDriverCPLX, 5, CostDriverCPLX.RecommendationKey(RecommendWhenHigh)},
+        {CostDriverRELY, 3, CostDriverRELY.RecommendationKey(RecommendWhenHigh)},
+    }
+    for _, tt := range tests {
+        cd := NewCostDriver(tt.driver)
+        cd.SetRating(tt.rating)
+        if got := cd.recommendation(); got != tt.want {
+            t.Errorf("%s rated %v: recommendation %q, want %q", tt.driver, tt.rating, got, tt.want)
+        }
+    }
+
+    sf := NewScaleFactor(ScaleFactorPMAT)
+    sf.Rating = 0
+    if got, want := sf.recommendation(), ScaleFactorPMAT.RecommendationKey(RecommendWhenLow); got != want {
+        t.Errorf("low PMAT recommendation %q, want %q", got, want)
+    }
+}
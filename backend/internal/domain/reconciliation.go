@@ -0,0 +1,153 @@
+package domain
+
+import (
+    "errors"
+    "fmt"
+)
+
+// ReconciliationMode selects how the results of the estimation methods are combined into the total
+type ReconciliationMode string
+
+const (
+    ReconciliationWeighted     ReconciliationMode = "weighted"      // Weighted by the confidence of each method
+    ReconciliationActivityOnly ReconciliationMode = "activity_only" // The activity-based result alone
+    ReconciliationCOCOMOOnly   ReconciliationMode = "cocomo_only"   // The COCOMO II based result alone
+    ReconciliationCustom       ReconciliationMode = "custom"        // Weighted by the weights of the strategy
+)
+
+// ReconciliationStrategy overrides the confidence weighting of the estimation methods.
+// The zero value is the weighted mode.
+type ReconciliationStrategy struct {
+    Mode    ReconciliationMode
+    Weights map[CalculationMethod]float64 // Custom mode only; relative, e.g. 70 and 30 or 0.7 and 0.3
+}
+
+// reconciledMethods are the methods reconcileEstimates can combine
+var reconciledMethods = []CalculationMethod{
+    CalculationMethodActivity,
+    CalculationMethodCOCOMO,
+    CalculationMethodUCP,
+    CalculationMethodDeliverable,
+}
+
+// Validate checks the mode and the custom weights
+func (s ReconciliationStrategy) Validate() error {
+    switch s.Mode {
+    case "", ReconciliationWeighted, ReconciliationActivityOnly, ReconciliationCOCOMOOnly:
+        if len(s.Weights) > 0 {
+            return errors.New("reconciliation weights are only used by the custom mode")
+        }
+        return nil
+    case ReconciliationCustom:
+    default:
+        return fmt.Errorf("unknown reconciliation mode %s", s.Mode)
+    }
+
+    total := 0.0
+    for method, weight := range s.Weights {
+        if !isReconciledMethod(method) {
+            return fmt.Errorf("unknown reconciliation method %s", method)
+        }
+        if weight < 0 {
+            return fmt.Errorf("reconciliation weight of %s must not be negative", method)
+        }
+        total += weight
+    }
+    if total == 0 {
+        return errors.New("custom reconciliation needs a positive weight")
+    }
+    return nil
+}
+
+// ValidateReconciliation checks that the reconciliation strategy of the estimate selects
+// at least one method the estimate has data for
+func (e *Estimate) ValidateReconciliation() error {
+    if err := e.Reconciliation.Validate(); err != nil {
+        return err
+    }
+    switch e.Reconciliation.Mode {
+    case ReconciliationCOCOMOOnly:
+        if e.COCOMOEstimate == nil {
+            return errors.New("cocomo_only reconciliation requires COCOMO II data")
+        }
+    case ReconciliationCustom:
+        available := map[CalculationMethod]bool{
+            CalculationMethodActivity:    true,
+            CalculationMethodCOCOMO:      e.COCOMOEstimate != nil,
+            CalculationMethodUCP:         e.UseCasePoints != nil,
+            CalculationMethodDeliverable: e.DeliverableBased,
+        }
+        for method, weight := range e.Reconciliation.Weights {
+            if weight > 0 && available[method] {
+                return nil
+            }
+        }
+        return errors.New("custom reconciliation weights only select methods without data")
+    }
+    return nil
+}
+
+// shares returns the share (0-1) of each present result in the reconciled total,
+// or false when the strategy gives every result a weight of 0
+func (s ReconciliationStrategy) shares(results MethodResults) (map[CalculationMethod]float64, bool) {
+    weights := make(map[CalculationMethod]float64)
+    total := 0.0
+    for _, result := range results.all() {
+        if result == nil {
+            continue
+        }
+        weights[result.Method] = s.weight(result)
+        total += weights[result.Method]
+    }
+    if total == 0 {
+        return nil, false
+    }
+    for method := range weights {
+        weights[method] /= total
+    }
+    return weights, true
+}
+
+// reconciliationShares returns the shares of the results under the strategy,
+// falling back to the confidence weighting when the strategy selects none of them
+func (s ReconciliationStrategy) reconciliationShares(results MethodResults) map[CalculationMethod]float64 {
+    if shares, ok := s.shares(results); ok {
+        return shares
+    }
+    shares, _ := ReconciliationStrategy{}.shares(results)
+    return shares
+}
+
+// ReconciliationShares returns the share (0-1) of each method result in the calculated total hours
+func (e *Estimate) ReconciliationShares() map[CalculationMethod]float64 {
+    return e.Reconciliation.reconciliationShares(e.methodResults())
+}
+
+// weight returns the weight of a method result in the reconciled total
+func (s ReconciliationStrategy) weight(result *CalculationResult) float64 {
+    switch s.Mode {
+    case ReconciliationActivityOnly:
+        return boolWeight(result.Method == CalculationMethodActivity)
+    case ReconciliationCOCOMOOnly:
+        return boolWeight(result.Method == CalculationMethodCOCOMO)
+    case ReconciliationCustom:
+        return s.Weights[result.Method]
+    }
+    return result.Confidence
+}
+
+func boolWeight(selected bool) float64 {
+    if selected {
+        return 1
+    }
+    return 0
+}
+
+func isReconciledMethod(method CalculationMethod) bool {
+    for _, m := range reconciledMethods {
+        if m == method {
+            return true
+        }
+    }
+    return false
+}
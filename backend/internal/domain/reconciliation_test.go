@@ -0,0 +1,95 @@
+package domain
+
+import "testing"
+
+// reconciliationResults returns activity-based and COCOMO II results of 1000 and 2000 hours
+// with the default confidences
+func reconciliationResults() MethodResults {
+    return MethodResults{
+        Activity: &CalculationResult{Method: CalculationMethodActivity, TotalHours: 1000, Confidence: 0.8},
+        COCOMO:   &CalculationResult{Method: CalculationMethodCOCOMO, TotalHours: 2000, Confidence: 0.85},
+    }
+}
+
+func TestReconciliationStrategies(t *testing.T) {
+    tests := []struct {
+        name     string
+        strategy ReconciliationStrategy
+        want     float64
+    }{
+        {"default", ReconciliationStrategy{}, (1000*0.8 + 2000*0.85) / (0.8 + 0.85)},
+        {"weighted", ReconciliationStrategy{Mode: ReconciliationWeighted}, (1000*0.8 + 2000*0.85) / (0.8 + 0.85)},
+        {"activity only", ReconciliationStrategy{Mode: ReconciliationActivityOnly}, 1000},
+        {"COCOMO only", ReconciliationStrategy{Mode: ReconciliationCOCOMOOnly}, 2000},
+        {"custom 70/30", ReconciliationStrategy{Mode: ReconciliationCustom, Weights: map[CalculationMethod]float64{
+            CalculationMethodActivity: 70, CalculationMethodCOCOMO: 30,
+        }}, 1000*0.7 + 2000*0.3},
+        {"custom 0.7/0.3", ReconciliationStrategy{Mode: ReconciliationCustom, Weights: map[CalculationMethod]float64{
+            CalculationMethodActivity: 0.7, CalculationMethodCOCOMO: 0.3,
+        }}, 1000*0.7 + 2000*0.3},
+    }
+    for _, tt := range tests {
+        estimate := &Estimate{Reconciliation: tt.strategy, AdditionalEfforts: []AdditionalEffort{{Hours: 50}}}
+        estimate.reconcileEstimates(reconciliationResults())
+        if want := tt.want + 50; !approxEqual(estimate.TotalHours, want, 1e-9) {
+            t.Errorf("%s: %v hours, want %v", tt.name, estimate.TotalHours, want)
+        }
+    }
+}
+
+func TestReconciliationFallsBackToConfidenceWithoutSelectedResults(t *testing.T) {
+    results := reconciliationResults()
+    results.COCOMO = nil
+
+    estimate := &Estimate{Reconciliation: ReconciliationStrategy{Mode: ReconciliationCOCOMOOnly}}
+    estimate.reconcileEstimates(results)
+    if estimate.TotalHours != 1000 {
+        t.Errorf("%v hours, want the activity-based 1000 without a COCOMO II result", estimate.TotalHours)
+    }
+}
+
+func TestReconciliationShares(t *testing.T) {
+    estimate := &Estimate{Reconciliation: ReconciliationStrategy{Mode: ReconciliationCustom, Weights: map[CalculationMethod]float64{
+        CalculationMethodActivity: 70, CalculationMethodCOCOMO: 30, CalculationMethodUCP: 50,
+    }}}
+    estimate.reconcileEstimates(reconciliationResults())
+
+    // The UCP weight is left out without a use case points result
+    shares := estimate.ReconciliationShares()
+    if len(shares) != 2 || !approxEqual(shares[CalculationMethodActivity], 0.7, 1e-9) || !approxEqual(shares[CalculationMethodCOCOMO], 0.3, 1e-9) {
+        t.Errorf("shares %v, want 0.7 activity-based and 0.3 COCOMO II", shares)
+    }
+}
+
+func TestValidateReconciliation(t *testing.T) {
+    custom := func(weights map[CalculationMethod]float64) ReconciliationStrategy {
+        return ReconciliationStrategy{Mode: ReconciliationCustom, Weights: weights}
+    }
+    tests := []struct {
+        name     string
+        strategy ReconciliationStrategy
+        cocomo   bool
+        valid    bool
+    }{
+        {"default", ReconciliationStrategy{}, false, true},
+        {"activity only", ReconciliationStrategy{Mode: ReconciliationActivityOnly}, false, true},
+        {"COCOMO only with COCOMO II data", ReconciliationStrategy{Mode: ReconciliationCOCOMOOnly}, true, true},
+        {"COCOMO only without COCOMO II data", ReconciliationStrategy{Mode: ReconciliationCOCOMOOnly}, false, false},
+        {"unknown mode", ReconciliationStrategy{Mode: "median"}, false, false},
+        {"weights outside custom", ReconciliationStrategy{Mode: ReconciliationWeighted, Weights: map[CalculationMethod]float64{CalculationMethodActivity: 1}}, false, false},
+        {"custom", custom(map[CalculationMethod]float64{CalculationMethodActivity: 70, CalculationMethodCOCOMO: 30}), true, true},
+        {"custom negative weight", custom(map[CalculationMethod]float64{CalculationMethodActivity: -1, CalculationMethodCOCOMO: 2}), true, false},
+        {"custom unknown method", custom(map[CalculationMethod]float64{"guess": 1}), true, false},
+        {"custom zero weights", custom(map[CalculationMethod]float64{CalculationMethodActivity: 0}), true, false},
+        {"custom selecting only missing data", custom(map[CalculationMethod]float64{CalculationMethodCOCOMO: 1}), false, false},
+    }
+    for _, tt := range tests {
+        estimate := &Estimate{Reconciliation: tt.strategy}
+        if tt.cocomo {
+            estimate.COCOMOEstimate = &COCOMOEstimate{}
+        }
+        if err := estimate.ValidateReconciliation(); (err == nil) != tt.valid {
+            t.Errorf("%s: got %v, want valid %v", tt.name, err, tt.valid)
+        }
+    }
+}
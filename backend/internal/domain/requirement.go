@@ -0,0 +1,62 @@
+package domain
+
+// AccessibilityLevel represents the WCAG conformance level required for the product
+type AccessibilityLevel string
+
+const (
+    AccessibilityNone AccessibilityLevel = ""
+    AccessibilityA    AccessibilityLevel = "A"
+    AccessibilityAA   AccessibilityLevel = "AA"
+    AccessibilityAAA  AccessibilityLevel = "AAA"
+)
+
+// accessibilityMultipliers holds the multiplier applied to UI-related processes per level
+var accessibilityMultipliers = map[AccessibilityLevel]float64{
+    AccessibilityNone: 1.0,
+    AccessibilityA:    1.1,
+    AccessibilityAA:   1.2,
+    AccessibilityAAA:  1.35,
+}
+
+// uiProcesses are the processes whose hours grow with accessibility requirements
+var uiProcesses = map[ProcessCategory]bool{
+    ProcessFunctionalSpec: true, // Screen design
+    ProcessImplementation: true, // Frontend implementation
+    ProcessTesting:        true, // Accessibility testing
+}
+
+const (
+    // AdditionalEffortLocalization is the category of the localization effort line
+    AdditionalEffortLocalization = "localization"
+
+    // LocalizationHoursPerLanguage is the translation, layout and review effort per language
+    LocalizationHoursPerLanguage = 40.0
+)
+
+// IsValid reports whether the level is a known accessibility level
+func (l AccessibilityLevel) IsValid() bool {
+    _, ok := accessibilityMultipliers[l]
+    return ok
+}
+
+// Multiplier returns the multiplier applied to UI-related processes
+func (l AccessibilityLevel) Multiplier() float64 {
+    if m, ok := accessibilityMultipliers[l]; ok {
+        return m
+    }
+    return 1.0
+}
+
+// IsUIProcess reports whether the process category is affected by accessibility requirements
+func IsUIProcess(category ProcessCategory) bool {
+    return uiProcesses[category]
+}
+
+// LocalizationEffort returns the effort line for localizing into the given languages
+func LocalizationEffort(languages []string) AdditionalEffort {
+    return AdditionalEffort{
+        Category: AdditionalEffortLocalization,
+        Name:     "多言語対応",
+        Hours:    float64(len(languages)) * LocalizationHoursPerLanguage,
+    }
+}
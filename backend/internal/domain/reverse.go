@@ -0,0 +1,85 @@
+package domain
+
+import "errors"
+
+// ErrInfeasibleTarget is returned when no positive size can be delivered within the target schedule and staffing
+var ErrInfeasibleTarget = errors.New("the target schedule and staffing can't deliver any new code")
+
+// ReverseConstraint identifies which target limits the affordable size
+type ReverseConstraint string
+
+const (
+    // ReverseConstraintStaffing means the team can't spend more effort by the deadline (team size × duration)
+    ReverseConstraintStaffing ReverseConstraint = "staffing"
+    // ReverseConstraintSchedule means a larger project's schedule, compressed as far as SCED allows, would miss the deadline
+    ReverseConstraintSchedule ReverseConstraint = "schedule"
+)
+
+// ReverseEstimate represents the largest size achievable with a fixed deadline and team size
+type ReverseEstimate struct {
+    TargetDurationTM float64
+    TeamSize         float64
+    StaffingEffortPM float64           // Effort the team can spend by the deadline
+    ScheduleEffortPM float64           // Largest effort whose schedule fits the deadline
+    Constraint       ReverseConstraint // The binding one of the two limits
+    ProjectSize      float64           // Solved size of new code, same unit as the model's size
+    Estimate         *COCOMOEstimate   // Forward estimate at the solved size
+}
+
+// SolveSize solves the COCOMO II equations backward for the project size.
+// base supplies the model, ratings, reused code and REVL; its ProjectSize is ignored.
+//
+// The affordable effort is the smaller of team size × target duration and the effort E whose
+// schedule C × E^D × SCED% equals the target duration. The effective size then follows from
+// PM = A × Size^B × EM; removing the REVL inflation and the equivalent size of reused code leaves
+// the new code, which must be positive.
+func SolveSize(base COCOMOEstimate, targetDurationTM, teamSize float64) (*ReverseEstimate, error) {
+    exponent := exponentB(base.Model, base.ScaleFactors)
+    if exponent <= 0 || durationExponent(exponent) <= 0 {
+        return nil, ErrInfeasibleTarget
+    }
+
+    // SCED stays in the effort but the schedule is derived from the effort without it
+    em := effortMultiplier(base.CostDrivers) * base.teamOverhead()
+    nominalEM := withoutSCED(em, base.CostDrivers)
+    percent := base.SchedulePercent()
+    if percent >= 1.0 {
+        // Schedule expansion is not applied, so the schedule comes from the full effort
+        percent = 1.0
+        nominalEM = em
+    } else if percent < MinSchedulePercent {
+        percent = MinSchedulePercent
+    }
+
+    result := &ReverseEstimate{
+        TargetDurationTM: targetDurationTM,
+        TeamSize:         teamSize,
+        StaffingEffortPM: teamSize * targetDurationTM,
+    }
+    nominalScheduleEffort := pow(targetDurationTM/(durationCoefficient*percent), 1/durationExponent(exponent))
+    result.ScheduleEffortPM = nominalScheduleEffort * em / nominalEM
+
+    result.Constraint = ReverseConstraintStaffing
+    effort := result.StaffingEffortPM
+    if result.ScheduleEffortPM < effort {
+        result.Constraint = ReverseConstraintSchedule
+        effort = result.ScheduleEffortPM
+    }
+
+    effectiveSize := pow(effort/(base.Model.A*em), 1/exponent)
+    size := effectiveSize / (1 + base.REVL/100)
+    for _, rc := range base.ReuseComponents {
+        size -= rc.EquivalentSLOC()
+    }
+    if size <= 0 {
+        return nil, ErrInfeasibleTarget
+    }
+    result.ProjectSize = size
+
+    estimate := base
+    estimate.ProjectSize = size
+    estimate.CalculateEffort()
+    result.Estimate = &estimate
+
+    return result, nil
+}
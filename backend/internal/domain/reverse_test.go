@@ -0,0 +1,64 @@
+package domain
+
+import (
+    "errors"
+    "testing"
+)
+
+func TestSolveSizeRoundTripsForwardEstimate(t *testing.T) {
+    for _, sced := range []float64{nominalRating, 1} {
+        forward := nominalEstimate(50)
+        forward.REVL = 10
+        forward.rate(CostDriverSCED, sced)
+        forward.CalculateEffort()
+
+        base := *nominalEstimate(0)
+        base.REVL = 10
+        base.rate(CostDriverSCED, sced)
+        reverse, err := SolveSize(base, forward.DurationTM, forward.EffortPM/forward.DurationTM)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if !approxEqual(reverse.ProjectSize, 50, 1e-6) {
+            t.Errorf("SCED %v: solved size %v, want the forward size 50", sced, reverse.ProjectSize)
+        }
+        if !approxEqual(reverse.Estimate.EffortPM, forward.EffortPM, 1e-6) || !approxEqual(reverse.Estimate.DurationTM, forward.DurationTM, 1e-6) {
+            t.Errorf("SCED %v: forward estimate of the solved size %v PM over %v months, want %v over %v",
+                sced, reverse.Estimate.EffortPM, reverse.Estimate.DurationTM, forward.EffortPM, forward.DurationTM)
+        }
+    }
+}
+
+func TestSolveSizeReportsBindingConstraint(t *testing.T) {
+    forward := nominalEstimate(50)
+    forward.CalculateEffort()
+    team := forward.EffortPM / forward.DurationTM
+
+    // Half the team can spend only half the effort by the deadline
+    reverse, err := SolveSize(*nominalEstimate(0), forward.DurationTM, team/2)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if reverse.Constraint != ReverseConstraintStaffing || reverse.ProjectSize >= 50 {
+        t.Errorf("constraint %s with size %v, want staffing below 50", reverse.Constraint, reverse.ProjectSize)
+    }
+
+    // Twice the team can't compress a larger project's schedule into the deadline
+    reverse, err = SolveSize(*nominalEstimate(0), forward.DurationTM, team*2)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if reverse.Constraint != ReverseConstraintSchedule || !approxEqual(reverse.ProjectSize, 50, 1e-6) {
+        t.Errorf("constraint %s with size %v, want schedule at 50", reverse.Constraint, reverse.ProjectSize)
+    }
+}
+
+func TestSolveSizeInfeasibleTarget(t *testing.T) {
+    base := *nominalEstimate(0)
+    base.ReuseComponents = []ReuseComponent{{AdaptedSize: 1000, DM: 50, CM: 50, IM: 50, SU: 30, UNFM: 0.4}}
+
+    if _, err := SolveSize(base, 3, 2); !errors.Is(err, ErrInfeasibleTarget) {
+        t.Errorf("got %v, want ErrInfeasibleTarget when the reused code alone exceeds the affordable size", err)
+    }
+}
@@ -0,0 +1,76 @@
+package domain
+
+import "math"
+
+// MaxRiskScore is the upper bound of the risk score
+const MaxRiskScore = 100.0
+
+// riskScoreScale sets how fast the score saturates: risk points of this size score about 63
+const riskScoreScale = 2.0
+
+// RiskCategories lists the risk categories in their canonical order
+var RiskCategories = []string{"Technical", "Process", "Schedule", "Cost"}
+
+// riskCategoryWeights weights the risk categories in the risk score
+var riskCategoryWeights = map[string]float64{
+    "Technical": 1.0,
+    "Process":   1.0,
+    "Schedule":  1.2,
+    "Cost":      0.8,
+}
+
+// riskLevelWeights weights the levels of the risk factors in the risk score
+var riskLevelWeights = map[string]float64{
+    "Low":    0.25,
+    "Medium": 0.5,
+    "High":   1.0,
+}
+
+// RiskContribution represents the share of the risk score coming from one category
+type RiskContribution struct {
+    Category string
+    Factors  int     // Number of risk factors in the category
+    Score    float64 // Points of the risk score; the contributions add up to the score
+}
+
+// riskScore combines the risk factors into a score from 0 to MaxRiskScore.
+// Each factor scores its category and level weights times its impact normalized to 0-1 as
+// impact / (1 + impact), so that multipliers and exponent contributions are comparable. The sum
+// saturates towards MaxRiskScore, and every additional factor raises the score.
+func riskScore(factors []RiskFactor) (float64, []RiskContribution) {
+    points := make(map[string]float64)
+    counts := make(map[string]int)
+    total := 0.0
+    for _, f := range factors {
+        impact := math.Max(f.Impact, 0)
+        p := riskCategoryWeights[f.Category] * riskLevelWeights[f.Level] * impact / (1 + impact)
+        points[f.Category] += p
+        counts[f.Category]++
+        total += p
+    }
+
+    score := MaxRiskScore * (1 - math.Exp(-total/riskScoreScale))
+
+    var breakdown []RiskContribution
+    for _, category := range RiskCategories {
+        if counts[category] == 0 {
+            continue
+        }
+        contribution := RiskContribution{Category: category, Factors: counts[category]}
+        if total > 0 {
+            contribution.Score = score * points[category] / total
+        }
+        breakdown = append(breakdown, contribution)
+    }
+    return score, breakdown
+}
+
+// Band returns the Low, Medium or High band of a risk score
+func (c RiskConfig) Band(score float64) string {
+    if score >= c.HighRiskScore {
+        return "High"
+    } else if score >= c.MediumRiskScore {
+        return "Medium"
+    }
+    return "Low"
+}
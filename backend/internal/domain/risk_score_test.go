@@ -0,0 +1,52 @@
+package domain
+
+import "testing"
+
+func TestRiskScoreRisesWithEachHighRatedDriver(t *testing.T) {
+    estimate := nominalEstimate(20)
+    drivers := []struct {
+        driver CostDriverType
+        rating float64
+    }{
+        {CostDriverCPLX, 5},
+        {CostDriverTIME, 5},
+        {CostDriverSTOR, 5},
+        {CostDriverACAP, 0},
+        {CostDriverPCAP, 0},
+    }
+
+    previous := -1.0
+    for i := 0; i <= len(drivers); i++ {
+        if i > 0 {
+            estimate.rate(drivers[i-1].driver, drivers[i-1].rating)
+        }
+        estimate.CalculateEffort()
+        result := estimate.GenerateDetailedResult(RateCard{}, nil, PhaseProfile{}, DefaultEstimationConfig())
+
+        if result.RiskScore <= previous || result.RiskScore > MaxRiskScore {
+            t.Errorf("%d high-rated drivers: score %v, want above %v and at most %v", i, result.RiskScore, previous, MaxRiskScore)
+        }
+        previous = result.RiskScore
+
+        sum := 0.0
+        for _, contribution := range result.RiskBreakdown {
+            sum += contribution.Score
+        }
+        if !approxEqual(sum, result.RiskScore, 1e-9) {
+            t.Errorf("%d high-rated drivers: contributions sum to %v, want the score %v", i, sum, result.RiskScore)
+        }
+        if band := DefaultRiskConfig().Band(result.RiskScore); result.RiskBand != band {
+            t.Errorf("%d high-rated drivers: band %s, want %s for score %v", i, result.RiskBand, band, result.RiskScore)
+        }
+    }
+    if previous < DefaultRiskConfig().HighRiskScore {
+        t.Errorf("score %v with five high-rated drivers, want the High band", previous)
+    }
+}
+
+func TestRiskScoreWithoutRisksIsZero(t *testing.T) {
+    score, breakdown := riskScore(nil)
+    if score != 0 || len(breakdown) != 0 {
+        t.Errorf("score %v with breakdown %v, want 0 and none", score, breakdown)
+    }
+}
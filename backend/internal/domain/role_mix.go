@@ -0,0 +1,123 @@
+package domain
+
+import (
+    "fmt"
+    "math"
+    "sort"
+)
+
+// Roles staffing the phases of a project
+const (
+    RoleProjectManager = "project_manager"
+    RoleArchitect      = "architect"
+    RoleDeveloper      = "developer"
+    RoleQA             = "qa"
+)
+
+// RoleMix holds the share of each role in the effort of a phase: phase code -> role -> share.
+// The shares of a phase sum to 1.
+type RoleMix map[string]map[string]float64
+
+// roleShareTolerance is the rounding tolerance of the sum of the role shares of a phase
+const roleShareTolerance = 0.001
+
+// DefaultRoleMix returns the typical role split of each phase: design phases are architect-heavy,
+// implementation is developer-heavy and the test phases are QA-heavy
+func DefaultRoleMix() RoleMix {
+    return RoleMix{
+        PhaseRequirements:    {RoleProjectManager: 0.40, RoleArchitect: 0.40, RoleDeveloper: 0.10, RoleQA: 0.10},
+        PhaseSystemDesign:    {RoleProjectManager: 0.15, RoleArchitect: 0.60, RoleDeveloper: 0.20, RoleQA: 0.05},
+        PhaseDetailedDesign:  {RoleProjectManager: 0.10, RoleArchitect: 0.35, RoleDeveloper: 0.50, RoleQA: 0.05},
+        PhaseImplementation:  {RoleProjectManager: 0.10, RoleArchitect: 0.10, RoleDeveloper: 0.70, RoleQA: 0.10},
+        PhaseIntegrationTest: {RoleProjectManager: 0.10, RoleArchitect: 0.10, RoleDeveloper: 0.35, RoleQA: 0.45},
+        PhaseSystemTest:      {RoleProjectManager: 0.15, RoleArchitect: 0.05, RoleDeveloper: 0.20, RoleQA: 0.60},
+    }
+}
+
+// WithDefaults returns the mix completed with the default split of the phases it leaves out
+func (m RoleMix) WithDefaults() RoleMix {
+    mix := DefaultRoleMix()
+    for phase, shares := range m {
+        mix[phase] = shares
+    }
+    return mix
+}
+
+// Validate checks that every phase is known and its role shares are non-negative and sum to 1
+func (m RoleMix) Validate() error {
+    phases := make([]string, 0, len(m))
+    for phase := range m {
+        phases = append(phases, phase)
+    }
+    sort.Strings(phases)
+
+    for _, phase := range phases {
+        if !isPhaseCode(phase) {
+            return fmt.Errorf("unknown phase %q in role mix", phase)
+        }
+        var total float64
+        for role, share := range m[phase] {
+            if role == "" {
+                return fmt.Errorf("role names of phase %s must not be empty", phase)
+            }
+            if share < 0 {
+                return fmt.Errorf("share of %s in phase %s must not be negative", role, phase)
+            }
+            total += share
+        }
+        if math.Abs(total-1) > roleShareTolerance {
+            return fmt.Errorf("role shares of phase %s must sum to 100%%, got %.1f%%", phase, total*100)
+        }
+    }
+    return nil
+}
+
+// RoleEffort represents the effort of a role across all phases
+type RoleEffort struct {
+    Role          string
+    PercentEffort float64            // Share of the total effort
+    Effort        float64            // Person-months
+    Cost          float64            // Share of the phase costs, 0 when not costed
+    ByPhase       map[string]float64 // Phase code -> person-months
+}
+
+// DistributeRoles splits the effort and cost of each phase across roles. Phases missing from
+// the mix are attributed to no role.
+func (r *COCOMODetailedResult) DistributeRoles(mix RoleMix) {
+    byRole := make(map[string]*RoleEffort)
+    var totalEffort float64
+    for _, phase := range r.PhaseDistribution {
+        totalEffort += phase.Effort
+        for role, share := range mix[phase.Code] {
+            re, ok := byRole[role]
+            if !ok {
+                re = &RoleEffort{Role: role, ByPhase: make(map[string]float64)}
+                byRole[role] = re
+            }
+            re.Effort += phase.Effort * share
+            re.Cost += phase.Cost * share
+            re.ByPhase[phase.Code] += phase.Effort * share
+        }
+    }
+
+    r.RoleDistribution = make([]RoleEffort, 0, len(byRole))
+    for _, re := range byRole {
+        if totalEffort > 0 {
+            re.PercentEffort = re.Effort / totalEffort
+        }
+        r.RoleDistribution = append(r.RoleDistribution, *re)
+    }
+    sort.Slice(r.RoleDistribution, func(i, j int) bool {
+        return r.RoleDistribution[i].Role < r.RoleDistribution[j].Role
+    })
+}
+
+// isPhaseCode reports whether phase is one of PhaseCodes
+func isPhaseCode(phase string) bool {
+    for _, code := range PhaseCodes {
+        if phase == code {
+            return true
+        }
+    }
+    return false
+}
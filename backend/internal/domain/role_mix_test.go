@@ -0,0 +1,66 @@
+package domain
+
+import "testing"
+
+func TestDefaultRoleMixSumsToOneHundredPercent(t *testing.T) {
+    mix := DefaultRoleMix()
+    if err := mix.Validate(); err != nil {
+        t.Fatal(err)
+    }
+    for _, phase := range PhaseCodes {
+        total := 0.0
+        for _, share := range mix[phase] {
+            total += share
+        }
+        if !approxEqual(total, 1, roleShareTolerance) {
+            t.Errorf("%s: role shares sum to %v, want 100%%", phase, total*100)
+        }
+    }
+}
+
+func TestRoleDistributionOfEachPhaseSumsToItsEffort(t *testing.T) {
+    custom := RoleMix{PhaseImplementation: {RoleDeveloper: 0.9, RoleQA: 0.1}}
+    if err := custom.Validate(); err != nil {
+        t.Fatal(err)
+    }
+    estimate := nominalEstimate(50)
+    estimate.CalculateEffort()
+    result := estimate.GenerateDetailedResult(RateCard{}, custom.WithDefaults(), PhaseProfile{}, DefaultEstimationConfig())
+
+    total := 0.0
+    for _, phase := range result.PhaseDistribution {
+        roles := 0.0
+        for _, re := range result.RoleDistribution {
+            roles += re.ByPhase[phase.Code]
+        }
+        if !approxEqual(roles, phase.Effort, 1e-9) {
+            t.Errorf("%s: roles share %v person-months, want the phase effort %v", phase.Code, roles, phase.Effort)
+        }
+    }
+    for _, re := range result.RoleDistribution {
+        total += re.PercentEffort
+        if re.Role == RoleArchitect && re.ByPhase[PhaseImplementation] != 0 {
+            t.Errorf("architect has %v person-months of implementation, want none with the custom mix", re.ByPhase[PhaseImplementation])
+        }
+    }
+    if !approxEqual(total, 1, 1e-9) {
+        t.Errorf("role percentages sum to %v, want 100%%", total*100)
+    }
+}
+
+func TestRoleMixRejectsInvalidShares(t *testing.T) {
+    tests := []struct {
+        name string
+        mix  RoleMix
+    }{
+        {"sum below 100%", RoleMix{PhaseImplementation: {RoleDeveloper: 0.5, RoleQA: 0.3}}},
+        {"negative share", RoleMix{PhaseImplementation: {RoleDeveloper: 1.2, RoleQA: -0.2}}},
+        {"unknown phase", RoleMix{"deployment": {RoleDeveloper: 1}}},
+        {"empty role", RoleMix{PhaseImplementation: {"": 1}}},
+    }
+    for _, tt := range tests {
+        if err := tt.mix.Validate(); err == nil {
+            t.Errorf("%s: got no error", tt.name)
+        }
+    }
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "math"
+
+// ScenarioPreset represents a named shift of the ratings of all cost drivers
+type ScenarioPreset struct {
+    Name   string
+    Levels float64 // Rating levels each cost driver moves: < 0 towards its favorable end, > 0 towards its unfavorable end
+}
+
+// DefaultScenarioPresets are the optimistic, nominal and pessimistic presets
+var DefaultScenarioPresets = []ScenarioPreset{
+    {Name: "optimistic", Levels: -1},
+    {Name: "nominal", Levels: 0},
+    {Name: "pessimistic", Levels: 1},
+}
+
+// ScenarioResult represents the outcome of an estimate calculated under a preset
+type ScenarioResult struct {
+    Preset     string
+    EffortPM   float64
+    DurationTM float64
+    TeamSize   float64
+    Cost       float64 // Only set when an hourly rate is applied
+}
+
+// PresetComparison represents the estimate calculated under each scenario preset
+type PresetComparison struct {
+    EstimateID string
+    Scenarios  []ScenarioResult
+}
+
+// CalculateScenario recalculates a copy of the estimate with the ratings of all cost drivers
+// shifted by the preset. Drivers already at the end of their scale, and drivers without
+// rating values, keep their multiplier.
+func (e *COCOMOEstimate) CalculateScenario(preset ScenarioPreset) ScenarioResult {
+    trial := *e
+    trial.CostDrivers = make([]CostDriver, len(e.CostDrivers))
+    for i, cd := range e.CostDrivers {
+        cd.shiftRating(preset.Levels)
+        trial.CostDrivers[i] = cd
+    }
+
+    trial.CalculateEffort()
+
+    return ScenarioResult{
+        Preset:     preset.Name,
+        EffortPM:   trial.EffortPM,
+        DurationTM: trial.DurationTM,
+        TeamSize:   trial.TeamSize,
+    }
+}
+
+// shiftRating moves the rating towards the end of the scale where the multiplier is higher
+// for levels > 0, and towards the lower multipliers for levels < 0
+func (cd *CostDriver) shiftRating(levels float64) {
+    if levels == 0 || len(cd.RatingValues) == 0 {
+        return
+    }
+    // Drivers such as the capabilities lower the effort as their rating rises
+    if cd.ValueAt(MaxRating) < cd.ValueAt(MinRating) {
+        levels = -levels
+    }
+    cd.SetRating(math.Max(MinRating, math.Min(MaxRating, cd.Rating+levels)))
+}
+
+// ApplyHourlyRate calculates the cost of each scenario
+func (p *PresetComparison) ApplyHourlyRate(hourlyRate float64, config EstimationConfig) {
+    for i := range p.Scenarios {
+        p.Scenarios[i].Cost = p.Scenarios[i].EffortPM * config.MonthlyHours() * hourlyRate
+    }
+}
@@ -0,0 +1,59 @@
+package domain
+
+import "testing"
+
+func TestScenarioPresetsBracketNominal(t *testing.T) {
+    estimate := nominalEstimate(100)
+    estimate.CalculateEffort()
+
+    results := make(map[string]ScenarioResult)
+    for _, preset := range DefaultScenarioPresets {
+        results[preset.Name] = estimate.CalculateScenario(preset)
+    }
+    optimistic, nominal, pessimistic := results["optimistic"], results["nominal"], results["pessimistic"]
+
+    if nominal.EffortPM != estimate.EffortPM {
+        t.Errorf("nominal EffortPM = %v, want the estimate's %v", nominal.EffortPM, estimate.EffortPM)
+    }
+    if !(optimistic.EffortPM < nominal.EffortPM && nominal.EffortPM < pessimistic.EffortPM) {
+        t.Errorf("EffortPM optimistic/nominal/pessimistic = %v/%v/%v, want increasing", optimistic.EffortPM, nominal.EffortPM, pessimistic.EffortPM)
+    }
+    if !(optimistic.DurationTM < nominal.DurationTM && nominal.DurationTM < pessimistic.DurationTM) {
+        t.Errorf("DurationTM optimistic/nominal/pessimistic = %v/%v/%v, want increasing", optimistic.DurationTM, nominal.DurationTM, pessimistic.DurationTM)
+    }
+}
+
+func TestCalculateScenarioLeavesEstimateUnchanged(t *testing.T) {
+    estimate := nominalEstimate(100)
+    estimate.CalculateScenario(ScenarioPreset{Name: "pessimistic", Levels: 1})
+
+    for _, cd := range estimate.CostDrivers {
+        if cd.Rating != nominalRating {
+            t.Errorf("%s rating = %v, want it left at nominal", cd.Type, cd.Rating)
+        }
+    }
+}
+
+func TestShiftRatingFollowsDirectionOfDriver(t *testing.T) {
+    // Higher reliability costs more effort, a more capable analyst saves effort
+    rely := NewCostDriver(CostDriverRELY)
+    rely.SetRating(nominalRating)
+    rely.shiftRating(1)
+    if rely.Value <= 1 {
+        t.Errorf("RELY value = %v after an unfavorable shift, want more than 1", rely.Value)
+    }
+
+    acap := NewCostDriver(CostDriverACAP)
+    acap.SetRating(nominalRating)
+    acap.shiftRating(1)
+    if acap.Rating != nominalRating-1 || acap.Value <= 1 {
+        t.Errorf("ACAP rating, value = %v, %v after an unfavorable shift, want Low and more than 1", acap.Rating, acap.Value)
+    }
+
+    // Drivers at the end of their scale stay there
+    acap.SetRating(MinRating)
+    acap.shiftRating(1)
+    if acap.Rating != MinRating {
+        t.Errorf("ACAP rating = %v, want it kept at %v", acap.Rating, MinRating)
+    }
+}
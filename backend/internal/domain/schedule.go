@@ -0,0 +1,89 @@
+package domain
+
+import "time"
+
+// DateLayout is the layout of calendar dates in requests and responses
+const DateLayout = "2006-01-02"
+
+// DefaultWorkingDaysPerWeek is the number of working days per week, Monday to Friday
+const DefaultWorkingDaysPerWeek = 5
+
+// TeamCalendar represents the days a team works on
+type TeamCalendar struct {
+    WorkingDaysPerWeek int         // Working days counted from Monday, e.g. 5 is Monday to Friday
+    Holidays           []time.Time // Non-working dates on top of the weekend
+}
+
+// IsWorkingDay reports whether the team works on the date
+func (c TeamCalendar) IsWorkingDay(date time.Time) bool {
+    // Days since Monday: Monday is 0, Sunday is 6
+    weekday := (int(date.Weekday()) + 6) % 7
+    if weekday >= c.WorkingDaysPerWeek {
+        return false
+    }
+    for _, holiday := range c.Holidays {
+        if sameDate(holiday, date) {
+            return false
+        }
+    }
+    return true
+}
+
+// WorkingDaysPerMonth returns the average number of working days in a month, ignoring holidays
+func (c TeamCalendar) WorkingDaysPerMonth() float64 {
+    return float64(c.WorkingDaysPerWeek) * weeksPerMonth
+}
+
+// ProjectSchedule represents the projected delivery date of an estimate
+type ProjectSchedule struct {
+    StartDate      time.Time
+    DurationMonths float64 // Calendar duration in months of the estimate
+    Calendar       TeamCalendar
+    // Calculated values
+    WorkingDays     int       // Working days needed, the start date included
+    EndDate         time.Time // Last working day
+    SkippedHolidays int       // Holidays falling on working weekdays between start and end
+}
+
+// NewProjectSchedule projects the end date of a duration starting on a date
+func NewProjectSchedule(start time.Time, durationMonths float64, calendar TeamCalendar) *ProjectSchedule {
+    s := &ProjectSchedule{
+        StartDate:      start,
+        DurationMonths: durationMonths,
+        Calendar:       calendar,
+    }
+    s.Calculate()
+    return s
+}
+
+// Calculate counts working days from the start date, skipping weekends and holidays
+func (s *ProjectSchedule) Calculate() {
+    s.WorkingDays = int(s.DurationMonths*s.Calendar.WorkingDaysPerMonth() + 0.5)
+    s.SkippedHolidays = 0
+    s.EndDate = s.StartDate
+    if s.WorkingDays <= 0 || s.Calendar.WorkingDaysPerWeek <= 0 {
+        return
+    }
+
+    date := s.StartDate
+    counted := 0
+    for {
+        if s.Calendar.IsWorkingDay(date) {
+            counted++
+            if counted == s.WorkingDays {
+                break
+            }
+        } else if (TeamCalendar{WorkingDaysPerWeek: s.Calendar.WorkingDaysPerWeek}).IsWorkingDay(date) {
+            s.SkippedHolidays++
+        }
+        date = date.AddDate(0, 0, 1)
+    }
+    s.EndDate = date
+}
+
+// sameDate reports whether two times fall on the same calendar date
+func sameDate(a, b time.Time) bool {
+    ay, am, ad := a.Date()
+    by, bm, bd := b.Date()
+    return ay == by && am == bm && ad == bd
+}
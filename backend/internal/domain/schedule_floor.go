@@ -0,0 +1,48 @@
+package domain
+
+// ScheduleFloorWarning reports a requested schedule shorter than COCOMO II can achieve
+type ScheduleFloorWarning struct {
+    RequestedDurationTM float64
+    MinimumDurationTM   float64 // The schedule floor, MinSchedulePercent of the nominal schedule
+    RequestedPercent    float64 // Requested duration as a ratio of the nominal schedule
+}
+
+// ValidationWarning flags a request value that is accepted but can't be met.
+// Message holds a message key.
+type ValidationWarning struct {
+    Field   string
+    Message string
+    Limit   float64 // The value the field would need to reach
+}
+
+// NominalDurationTM returns the schedule without SCED compression
+func (e *COCOMOEstimate) NominalDurationTM() float64 {
+    exponent := exponentB(e.Model, e.ScaleFactors)
+    em := withoutSCED(effortMultiplier(e.CostDrivers), e.CostDrivers)
+    return nominalDuration(e.Model.A*pow(e.EffectiveSize(), exponent)*em*e.teamOverhead(), exponent)
+}
+
+// MinimumDurationTM returns the schedule floor: no amount of staff compresses a project below
+// MinSchedulePercent of its nominal schedule
+func (e *COCOMOEstimate) MinimumDurationTM() float64 {
+    return e.NominalDurationTM() * MinSchedulePercent
+}
+
+// CheckScheduleFloor reports whether the requested duration of the estimate is below the schedule
+// floor, returning nil when no duration is requested or it is achievable. SCED ratings can't request
+// less than the floor since the lowest rating is the floor itself.
+func (e *COCOMOEstimate) CheckScheduleFloor() *ScheduleFloorWarning {
+    if e.TargetDurationTM <= 0 {
+        return nil
+    }
+    nominal := e.NominalDurationTM()
+    floor := nominal * MinSchedulePercent
+    if e.TargetDurationTM >= floor {
+        return nil
+    }
+    return &ScheduleFloorWarning{
+        RequestedDurationTM: e.TargetDurationTM,
+        MinimumDurationTM:   floor,
+        RequestedPercent:    e.TargetDurationTM / nominal,
+    }
+}
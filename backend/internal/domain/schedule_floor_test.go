@@ -0,0 +1,53 @@
+package domain
+
+import "testing"
+
+func TestScheduleFloorBoundary(t *testing.T) {
+    estimate := nominalEstimate(50)
+    estimate.CalculateEffort()
+    floor := estimate.NominalDurationTM() * MinSchedulePercent
+
+    tests := []struct {
+        name   string
+        target float64
+        warn   bool
+    }{
+        {"no target", 0, false},
+        {"nominal", estimate.DurationTM, false},
+        {"at the floor", floor, false},
+        {"just below the floor", floor * 0.999, true},
+        {"half the floor", floor / 2, true},
+    }
+    for _, tt := range tests {
+        estimate.TargetDurationTM = tt.target
+        warning := estimate.CheckScheduleFloor()
+        if (warning != nil) != tt.warn {
+            t.Errorf("%s: warning %+v, want one: %v", tt.name, warning, tt.warn)
+            continue
+        }
+
+        result := estimate.GenerateDetailedResult(RateCard{}, nil, PhaseProfile{}, DefaultEstimationConfig())
+        var floorRisk *RiskFactor
+        for i, risk := range result.RiskFactors {
+            if risk.Name == MsgRiskScheduleFloorName {
+                floorRisk = &result.RiskFactors[i]
+            }
+        }
+        if !tt.warn {
+            if floorRisk != nil || len(result.Warnings) != 0 {
+                t.Errorf("%s: risk %+v and warnings %v, want none", tt.name, floorRisk, result.Warnings)
+            }
+            continue
+        }
+
+        if !approxEqual(warning.MinimumDurationTM, floor, 1e-9) || warning.RequestedDurationTM != tt.target {
+            t.Errorf("%s: warning %+v, want floor %v for %v", tt.name, warning, floor, tt.target)
+        }
+        if floorRisk == nil || floorRisk.Category != "Schedule" || floorRisk.Level != "High" {
+            t.Errorf("%s: floor risk %+v, want a High schedule risk", tt.name, floorRisk)
+        }
+        if len(result.Warnings) != 1 || result.Warnings[0].Field != "targetDuration" || !approxEqual(result.Warnings[0].Limit, floor, 1e-9) {
+            t.Errorf("%s: warnings %+v, want one on targetDuration with the floor", tt.name, result.Warnings)
+        }
+    }
+}
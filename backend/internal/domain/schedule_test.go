@@ -0,0 +1,56 @@
+package domain
+
+import (
+    "testing"
+    "time"
+)
+
+// date parses a calendar date, panicking on malformed test input
+func date(s string) time.Time {
+    d, err := time.Parse(DateLayout, s)
+    if err != nil {
+        panic(err)
+    }
+    return d
+}
+
+// monthsOf returns the duration in months of a number of working days of a five-day week
+func monthsOf(workingDays float64) float64 {
+    return workingDays / TeamCalendar{WorkingDaysPerWeek: DefaultWorkingDaysPerWeek}.WorkingDaysPerMonth()
+}
+
+func TestScheduleStartingOnFriday(t *testing.T) {
+    calendar := TeamCalendar{WorkingDaysPerWeek: DefaultWorkingDaysPerWeek}
+    schedule := NewProjectSchedule(date("2024-03-01"), monthsOf(3), calendar)
+
+    // Friday, then Monday and Tuesday after the weekend
+    if schedule.WorkingDays != 3 {
+        t.Fatalf("WorkingDays = %d, want 3", schedule.WorkingDays)
+    }
+    if want := date("2024-03-05"); !schedule.EndDate.Equal(want) {
+        t.Errorf("EndDate = %s, want %s", schedule.EndDate.Format(DateLayout), want.Format(DateLayout))
+    }
+    if schedule.SkippedHolidays != 0 {
+        t.Errorf("SkippedHolidays = %d, want 0 as weekends are not holidays", schedule.SkippedHolidays)
+    }
+}
+
+func TestScheduleSkipsHoliday(t *testing.T) {
+    calendar := TeamCalendar{WorkingDaysPerWeek: DefaultWorkingDaysPerWeek, Holidays: []time.Time{date("2024-05-01")}}
+    schedule := NewProjectSchedule(date("2024-04-29"), monthsOf(5), calendar)
+
+    // Monday, Tuesday, the Wednesday holiday, Thursday, Friday and the next Monday
+    if want := date("2024-05-06"); !schedule.EndDate.Equal(want) {
+        t.Errorf("EndDate = %s, want %s", schedule.EndDate.Format(DateLayout), want.Format(DateLayout))
+    }
+    if schedule.SkippedHolidays != 1 {
+        t.Errorf("SkippedHolidays = %d, want 1", schedule.SkippedHolidays)
+    }
+
+    // A holiday falling on the weekend doesn't push the end date
+    calendar.Holidays = []time.Time{date("2024-05-04")}
+    schedule = NewProjectSchedule(date("2024-04-29"), monthsOf(5), calendar)
+    if want := date("2024-05-03"); !schedule.EndDate.Equal(want) || schedule.SkippedHolidays != 0 {
+        t.Errorf("EndDate = %s with %d holidays skipped, want %s and none", schedule.EndDate.Format(DateLayout), schedule.SkippedHolidays, want.Format(DateLayout))
+    }
+}
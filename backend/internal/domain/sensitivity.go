@@ -0,0 +1,64 @@
+package domain
+
+import "fmt"
+
+// SensitivityPoint represents the effort and duration of an estimate at one rating level of a factor
+type SensitivityPoint struct {
+    Rating     float64
+    EffortPM   float64
+    DurationTM float64
+}
+
+// SensitivityCurve represents the effort and duration across all rating levels of a single
+// scale factor or cost driver, with every other factor held at its current rating
+type SensitivityCurve struct {
+    EstimateID    string
+    FactorID      string
+    FactorName    string
+    FactorKind    string // scale_factor or cost_driver
+    CurrentRating float64
+    Points        []SensitivityPoint
+}
+
+// SensitivityCurve recalculates the estimate at every rating level of the given factor
+func (e *COCOMOEstimate) SensitivityCurve(factorID string) (*SensitivityCurve, error) {
+    curve := &SensitivityCurve{EstimateID: e.ID, FactorID: factorID}
+
+    // rate applies a rating to the target factor of a trial estimate
+    var rate func(trial *COCOMOEstimate, rating float64)
+    for i, sf := range e.ScaleFactors {
+        if sf.ID == factorID {
+            i := i
+            curve.FactorName, curve.FactorKind, curve.CurrentRating = sf.Name, "scale_factor", sf.Rating
+            rate = func(trial *COCOMOEstimate, rating float64) { trial.ScaleFactors[i].Rating = rating }
+            break
+        }
+    }
+    for i, cd := range e.CostDrivers {
+        if rate == nil && cd.ID == factorID {
+            i := i
+            curve.FactorName, curve.FactorKind, curve.CurrentRating = cd.Name, "cost_driver", cd.Rating
+            rate = func(trial *COCOMOEstimate, rating float64) { trial.CostDrivers[i].SetRating(rating) }
+            break
+        }
+    }
+    if rate == nil {
+        return nil, fmt.Errorf("factor %s of estimate %s: %w", factorID, e.ID, ErrNotFound)
+    }
+
+    for rating := MinRating; rating <= MaxRating; rating++ {
+        trial := *e
+        trial.ScaleFactors = append([]ScaleFactor(nil), e.ScaleFactors...)
+        trial.CostDrivers = append([]CostDriver(nil), e.CostDrivers...)
+        rate(&trial, rating)
+        trial.CalculateEffort()
+
+        curve.Points = append(curve.Points, SensitivityPoint{
+            Rating:     rating,
+            EffortPM:   trial.EffortPM,
+            DurationTM: trial.DurationTM,
+        })
+    }
+
+    return curve, nil
+}
@@ -0,0 +1,20 @@
+package domain
+
+// nominalExponentB is the COCOMO II effort exponent E with every scale factor at Nominal
+const nominalExponentB = 1.0997
+
+// StaffingForEffort derives an average team size and duration from the effort in person-months.
+// Duration follows the nominal COCOMO II schedule equation, so the team grows with the effort.
+// Small efforts are done by a single person, in which case the duration equals the effort.
+func StaffingForEffort(personMonths float64) (teamSize, durationMonths float64) {
+    if personMonths <= 0 {
+        return 1, 0
+    }
+
+    durationMonths = nominalDuration(personMonths, nominalExponentB)
+    teamSize = personMonths / durationMonths
+    if teamSize < 1 {
+        return 1, personMonths
+    }
+    return teamSize, durationMonths
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestStaffingGrowsWithEffort(t *testing.T) {
+    previous := 0.0
+    for _, personMonths := range []float64{0.3, 3, 30, 300, 3000} {
+        teamSize, duration := StaffingForEffort(personMonths)
+        if teamSize < 1 {
+            t.Errorf("%v PM: TeamSize = %v, want at least 1", personMonths, teamSize)
+        }
+        if teamSize < previous {
+            t.Errorf("%v PM: TeamSize = %v, want at least the %v of a smaller effort", personMonths, teamSize, previous)
+        }
+        if !approxEqual(teamSize*duration, personMonths, 1e-9) {
+            t.Errorf("%v PM: %v people over %v months, want the effort covered", personMonths, teamSize, duration)
+        }
+        previous = teamSize
+    }
+
+    small, _ := StaffingForEffort(3)
+    large, _ := StaffingForEffort(3000)
+    if large <= small {
+        t.Errorf("TeamSize = %v at 3000 PM, want more than the %v at 3 PM", large, small)
+    }
+}
+
+func TestStaffingOfTinyEffortIsOnePerson(t *testing.T) {
+    teamSize, duration := StaffingForEffort(0.3)
+    if teamSize != 1 || duration != 0.3 {
+        t.Errorf("TeamSize = %v, duration = %v, want 1 person for the whole 0.3 months", teamSize, duration)
+    }
+
+    teamSize, duration = StaffingForEffort(0)
+    if teamSize != 1 || duration != 0 {
+        t.Errorf("TeamSize = %v, duration = %v, want 1 person and no duration without effort", teamSize, duration)
+    }
+}
+
+func TestStaffingMatchesNominalSchedule(t *testing.T) {
+    _, duration := StaffingForEffort(100)
+    if want := durationCoefficient * pow(100, durationExponent(nominalExponentB)); !approxEqual(duration, want, 1e-9) {
+        t.Errorf("duration = %v, want the nominal COCOMO II schedule %v", duration, want)
+    }
+}
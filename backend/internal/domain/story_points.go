@@ -0,0 +1,51 @@
+package domain
+
+import "math"
+
+// weeksPerMonth is the average number of weeks in a calendar month
+const weeksPerMonth = 52.0 / 12.0
+
+// StoryPointEstimate converts the story points of an estimate's tasks into sprints, calendar
+// duration and effort hours
+type StoryPointEstimate struct {
+    Velocity      float64 // Story points completed per sprint
+    SprintWeeks   float64 // Length of a sprint in weeks
+    HoursPerPoint float64 // Effort hours per story point
+    // Calculated values
+    TotalPoints    float64
+    Sprints        int // Whole sprints needed to burn down TotalPoints
+    DurationWeeks  float64
+    DurationMonths float64
+    TotalHours     float64 // Before global factors
+}
+
+// Calculate derives sprints, duration and hours from the total story points
+func (s *StoryPointEstimate) Calculate(totalPoints float64) {
+    s.TotalPoints = totalPoints
+    s.Sprints = 0
+    if s.Velocity > 0 {
+        s.Sprints = int(math.Ceil(totalPoints / s.Velocity))
+    }
+    s.DurationWeeks = float64(s.Sprints) * s.SprintWeeks
+    s.DurationMonths = s.DurationWeeks / weeksPerMonth
+    s.TotalHours = totalPoints * s.HoursPerPoint
+}
+
+// TotalStoryPoints sums the story points of all tasks
+func (e *Estimate) TotalStoryPoints() float64 {
+    var total float64
+    for _, pe := range e.ProcessEstimates {
+        for _, task := range pe.Tasks {
+            total += task.StoryPoints
+        }
+    }
+    return total
+}
+
+// hoursPerStoryPoint returns the effort hours per story point, 0 without story point settings
+func (e *Estimate) hoursPerStoryPoint() float64 {
+    if e.StoryPoints == nil {
+        return 0
+    }
+    return e.StoryPoints.HoursPerPoint
+}
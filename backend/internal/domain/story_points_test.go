@@ -0,0 +1,41 @@
+package domain
+
+import "testing"
+
+func TestStoryPointsToSprintsAndHours(t *testing.T) {
+    estimate := &StoryPointEstimate{Velocity: 20, SprintWeeks: 2, HoursPerPoint: 6}
+    estimate.Calculate(95)
+
+    // 95 points at 20 per sprint need a fifth, partly filled sprint
+    if estimate.Sprints != 5 || estimate.DurationWeeks != 10 {
+        t.Errorf("%d sprints over %v weeks, want 5 over 10", estimate.Sprints, estimate.DurationWeeks)
+    }
+    if want := 10 / weeksPerMonth; !approxEqual(estimate.DurationMonths, want, 1e-9) {
+        t.Errorf("DurationMonths = %v, want %v", estimate.DurationMonths, want)
+    }
+    if estimate.TotalHours != 570 {
+        t.Errorf("TotalHours = %v, want 95 × 6 = 570", estimate.TotalHours)
+    }
+}
+
+func TestStoryPointTasksFlowIntoActivityHours(t *testing.T) {
+    implementation := &Process{ID: "implementation", Category: ProcessImplementation, Activities: []Activity{{ID: "a1", BaseHours: 10}}}
+    estimate := &Estimate{
+        StoryPoints: &StoryPointEstimate{Velocity: 20, SprintWeeks: 2, HoursPerPoint: 6},
+        ProcessEstimates: []ProcessEstimate{{Process: implementation, Tasks: []Task{
+            {ID: "t1", ActivityID: "a1", StoryPoints: 8},
+            {ID: "t2", ActivityID: "a1", StoryPoints: 5},
+        }}},
+    }
+    if points := estimate.TotalStoryPoints(); points != 13 {
+        t.Fatalf("TotalStoryPoints = %v, want 13", points)
+    }
+
+    result, err := estimate.calculateActivityBased(newProcessStore(implementation), DefaultEstimationConfig())
+    if err != nil {
+        t.Fatal(err)
+    }
+    if result.TotalHours != 13*6 {
+        t.Errorf("TotalHours = %v, want the 78 hours of 13 points", result.TotalHours)
+    }
+}
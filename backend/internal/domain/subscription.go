@@ -0,0 +1,118 @@
+package domain
+
+import (
+    "errors"
+    "net"
+    "net/netip"
+    "net/url"
+    "strings"
+    "time"
+)
+
+// DriftSubscription represents a request to be notified when an estimate grows beyond a threshold
+type DriftSubscription struct {
+    ID                string
+    EstimateID        string
+    ThresholdPercent  float64 // Growth from the last notified total that triggers a notification
+    NotifyURL         string
+    LastNotifiedHours float64 // Baseline total, updated each time a notification is sent
+    CreatedAt         time.Time
+}
+
+// DriftAlert represents the notification sent when an estimate drifts beyond a threshold
+type DriftAlert struct {
+    SubscriptionID   string
+    EstimateID       string
+    ThresholdPercent float64
+    PreviousHours    float64
+    CurrentHours     float64
+    ChangePercent    float64
+}
+
+// Exceeded reports whether the total has grown beyond the threshold since the last notification
+func (s *DriftSubscription) Exceeded(totalHours float64) bool {
+    if totalHours <= s.LastNotifiedHours {
+        return false
+    }
+    if s.LastNotifiedHours <= 0 {
+        return true
+    }
+    return s.ChangePercent(totalHours) > s.ThresholdPercent
+}
+
+// ChangePercent returns the change of the total from the last notified value in percent
+func (s *DriftSubscription) ChangePercent(totalHours float64) float64 {
+    if s.LastNotifiedHours <= 0 {
+        return 0
+    }
+    return (totalHours - s.LastNotifiedHours) / s.LastNotifiedHours * 100
+}
+
+// nonPublicPrefixes are the special-purpose IPv4 ranges net.IP has no predicate for
+var nonPublicPrefixes = []netip.Prefix{
+    netip.MustParsePrefix("0.0.0.0/8"),
+    netip.MustParsePrefix("100.64.0.0/10"),
+    netip.MustParsePrefix("192.0.0.0/24"),
+    netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// IsPublicIP reports whether a webhook may be delivered to the address: loopback, private,
+// link-local, multicast and other special-purpose addresses are internal
+func IsPublicIP(ip net.IP) bool {
+    if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+        ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+        return false
+    }
+    addr, ok := netip.AddrFromSlice(ip)
+    if !ok {
+        return false
+    }
+    addr = addr.Unmap()
+    for _, prefix := range nonPublicPrefixes {
+        if prefix.Contains(addr) {
+            return false
+        }
+    }
+    return true
+}
+
+// ValidateNotifyURL checks that a webhook URL is an absolute http or https URL of a public host,
+// so that subscriptions can't be used to reach internal services. Host names are resolved when
+// the alert is delivered, where the notifier checks the addresses again.
+func ValidateNotifyURL(raw string) error {
+    u, err := url.Parse(raw)
+    if err != nil {
+        return errors.New("notify URL is not a valid URL")
+    }
+    if u.Scheme != "http" && u.Scheme != "https" {
+        return errors.New("notify URL must use http or https")
+    }
+    if u.User != nil {
+        return errors.New("notify URL must not contain credentials")
+    }
+    host := strings.ToLower(u.Hostname())
+    if host == "" {
+        return errors.New("notify URL must have a host")
+    }
+    if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+        return errors.New("notify URL must not point to an internal address")
+    }
+    if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+        return errors.New("notify URL must not point to an internal address")
+    }
+    return nil
+}
+
+// Notifier defines the interface for delivering drift alerts
+type Notifier interface {
+    Notify(url string, alert DriftAlert) error
+}
+
+// SubscriptionRepository defines the interface for drift subscription persistence
+type SubscriptionRepository interface {
+    Save(subscription *DriftSubscription) error
+    FindByID(id string) (*DriftSubscription, error)
+    FindByEstimateID(estimateID string) ([]*DriftSubscription, error)
+    Update(subscription *DriftSubscription) error
+    Delete(id string) error
+}
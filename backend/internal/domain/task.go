@@ -1,6 +1,34 @@
 package domain
 
-import "time"
+import (
+    "fmt"
+    "time"
+)
+
+// WorkType represents the kind of work a task involves
+type WorkType string
+
+const (
+    WorkTypeNew    WorkType = "new"
+    WorkTypeChange WorkType = "change"
+    WorkTypeFix    WorkType = "fix"
+)
+
+// workTypeMultipliers holds the productivity multiplier of each work type.
+// Change and fix work build on existing design and code, so they take less time than new work.
+var workTypeMultipliers = map[WorkType]float64{
+    WorkTypeNew:    1.0,
+    WorkTypeChange: 0.8,
+    WorkTypeFix:    0.6,
+}
+
+// Multiplier returns the productivity multiplier of the work type
+func (w WorkType) Multiplier() float64 {
+    if m, ok := workTypeMultipliers[w]; ok {
+        return m
+    }
+    return 1.0
+}
 
 // Task represents a development task that needs to be estimated
 type Task struct {
@@ -13,20 +41,102 @@ type Task struct {
     Scale         float64         // Size/scale multiplier for the base hours
     Dependencies  []string        // IDs of dependent tasks
     CustomFactors []Factor        // Task-specific factors
+    WorkType      WorkType        // new, change or fix; empty is treated as new
+    // Optional story points; when set they replace the activity-based calculation,
+    // converted to hours with the story point settings of the estimate
+    StoryPoints   float64
+    // Optional three-point estimate in hours; when set it replaces the activity-based and story point calculations
+    Optimistic    float64
+    MostLikely    float64
+    Pessimistic   float64
     CreatedAt     time.Time
     UpdatedAt     time.Time
 }
 
 // CalculateBaseHours calculates the base hours for this task
-func (t *Task) CalculateBaseHours(activity Activity) float64 {
+func (t *Task) CalculateBaseHours(activity Activity, hoursPerPoint float64) float64 {
+    // Use the PERT expected value when a three-point estimate is given
+    if mean, _, ok := t.PERTEstimate(); ok {
+        return mean
+    }
+
+    // Convert story points to hours
+    if t.StoryPoints > 0 {
+        return t.StoryPoints * hoursPerPoint
+    }
+
     // Base calculation using activity's standard hours and task's scale
     baseHours := activity.BaseHours * t.Scale
     
-    // Adjust based on complexity (1-5 scale)
+    // Adjust based on complexity (1-5 scale) along the activity's curve
     // Complexity 3 is considered normal (multiplier 1.0)
-    complexityMultiplier := 0.8 + (float64(t.Complexity) * 0.2) // Results in range 1.0 +/- 40%
-    
-    return baseHours * complexityMultiplier
+    return baseHours * activity.ComplexityCurve.Multiplier(t.Complexity)
+}
+
+// EffectiveWorkType returns the work type of the task, defaulting to new work
+func (t *Task) EffectiveWorkType() WorkType {
+    if t.WorkType == "" {
+        return WorkTypeNew
+    }
+    return t.WorkType
+}
+
+// CalculateHours calculates the hours of the task within its process, applying the
+// work type multiplier and task-specific factors. It also returns the PERT standard deviation.
+// hoursPerPoint converts story points into hours.
+func (t *Task) CalculateHours(process *Process, hoursPerPoint float64) (hours, stdDev float64) {
+    // Find the corresponding activity
+    var activity Activity
+    for _, a := range process.Activities {
+        if a.ID == t.ActivityID {
+            activity = a
+            break
+        }
+    }
+    activity.ComplexityCurve = process.CurveFor(activity)
+
+    hours = t.CalculateBaseHours(activity, hoursPerPoint)
+    _, stdDev, _ = t.PERTEstimate()
+
+    // Apply the work type productivity
+    multiplier := t.EffectiveWorkType().Multiplier()
+    hours *= multiplier
+    stdDev *= multiplier
+
+    // Apply task-specific factors
+    for _, factor := range t.CustomFactors {
+        hours = factor.Apply(hours)
+        stdDev = factor.Apply(stdDev)
+    }
+
+    return hours, stdDev
+}
+
+// HasThreePointEstimate reports whether the task carries an optimistic/most likely/pessimistic estimate
+func (t *Task) HasThreePointEstimate() bool {
+    return t.MostLikely > 0 && t.Optimistic > 0 && t.Pessimistic > 0
+}
+
+// ValidateThreePointEstimate accepts either no three-point estimate or one with 0 < optimistic <= most likely <= pessimistic
+func (t *Task) ValidateThreePointEstimate() error {
+    if t.Optimistic == 0 && t.MostLikely == 0 && t.Pessimistic == 0 {
+        return nil
+    }
+    if !(t.Optimistic > 0 && t.Optimistic <= t.MostLikely && t.MostLikely <= t.Pessimistic) {
+        return fmt.Errorf("three-point estimate needs 0 < optimistic <= most likely <= pessimistic, got %v/%v/%v", t.Optimistic, t.MostLikely, t.Pessimistic)
+    }
+    return nil
+}
+
+// PERTEstimate calculates the PERT expected value (O + 4M + P) / 6 and standard deviation (P - O) / 6
+func (t *Task) PERTEstimate() (mean, stdDev float64, ok bool) {
+    if !t.HasThreePointEstimate() {
+        return 0, 0, false
+    }
+
+    mean = (t.Optimistic + 4*t.MostLikely + t.Pessimistic) / 6
+    stdDev = (t.Pessimistic - t.Optimistic) / 6
+    return mean, stdDev, true
 }
 
 // TaskRepository defines the interface for task persistence
@@ -0,0 +1,139 @@
+package domain
+
+import "testing"
+
+func TestPERTEstimate(t *testing.T) {
+    task := Task{Optimistic: 10, MostLikely: 20, Pessimistic: 60}
+
+    mean, stdDev, ok := task.PERTEstimate()
+    if !ok {
+        t.Fatal("PERTEstimate not ok for a complete three-point estimate")
+    }
+    // (10 + 4*20 + 60) / 6 = 25, (60 - 10) / 6
+    if mean != 25 {
+        t.Errorf("mean = %v, want 25", mean)
+    }
+    if want := 50.0 / 6; !approxEqual(stdDev, want, 1e-9) {
+        t.Errorf("stdDev = %v, want %v", stdDev, want)
+    }
+
+    // The expected value replaces the activity-based hours
+    if hours := task.CalculateBaseHours(Activity{BaseHours: 100}, 0); hours != 25 {
+        t.Errorf("CalculateBaseHours = %v, want the PERT mean 25", hours)
+    }
+}
+
+func TestPERTEstimateRequiresAllThreePoints(t *testing.T) {
+    task := Task{Optimistic: 10, MostLikely: 20, Scale: 1, Complexity: 3}
+
+    if _, _, ok := task.PERTEstimate(); ok {
+        t.Error("PERTEstimate ok without a pessimistic estimate")
+    }
+    if hours := task.CalculateBaseHours(Activity{BaseHours: 40, ComplexityCurve: ComplexityCurveFlat}, 0); hours != 40 {
+        t.Errorf("CalculateBaseHours = %v, want the activity's 40 hours", hours)
+    }
+}
+
+func TestValidateThreePointEstimate(t *testing.T) {
+    valid := []Task{{}, {Optimistic: 10, MostLikely: 20, Pessimistic: 60}, {Optimistic: 20, MostLikely: 20, Pessimistic: 20}}
+    for _, task := range valid {
+        if err := task.ValidateThreePointEstimate(); err != nil {
+            t.Errorf("%v/%v/%v: %v", task.Optimistic, task.MostLikely, task.Pessimistic, err)
+        }
+    }
+
+    invalid := []Task{{Optimistic: 40, MostLikely: 10, Pessimistic: 5}, {MostLikely: 10}, {Optimistic: 10, MostLikely: 20}, {Optimistic: -1, MostLikely: 20, Pessimistic: 30}}
+    for _, task := range invalid {
+        if err := task.ValidateThreePointEstimate(); err == nil {
+            t.Errorf("%v/%v/%v accepted", task.Optimistic, task.MostLikely, task.Pessimistic)
+        }
+    }
+}
+
+func TestActivityBasedVarianceSumsAcrossTasks(t *testing.T) {
+    design := &Process{ID: "design", Category: ProcessBasicDesign, Activities: []Activity{{ID: "a1", BaseHours: 10}}}
+    implementation := &Process{ID: "implementation", Category: ProcessImplementation, Activities: []Activity{{ID: "a2", BaseHours: 10}}}
+    repo := newProcessStore(design, implementation)
+
+    // Standard deviations 3, 4 and 12 hours
+    estimate := &Estimate{ProcessEstimates: []ProcessEstimate{
+        {Process: design, Tasks: []Task{{ActivityID: "a1", Optimistic: 10, MostLikely: 20, Pessimistic: 28}}},
+        {Process: implementation, Tasks: []Task{
+            {ActivityID: "a2", Optimistic: 10, MostLikely: 20, Pessimistic: 34},
+            {ActivityID: "a2", Optimistic: 4, MostLikely: 20, Pessimistic: 76},
+        }},
+    }}
+
+    result, err := estimate.calculateActivityBased(repo, DefaultEstimationConfig())
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // Variances 9 + 16 + 144 = 169
+    if !approxEqual(result.StdDevHours, 13, 1e-9) {
+        t.Errorf("StdDevHours = %v, want 13 from the summed variances", result.StdDevHours)
+    }
+    wantMean := (10+80+28)/6.0 + (10+80+34)/6.0 + (4+80+76)/6.0
+    if !approxEqual(result.TotalHours, wantMean, 1e-9) {
+        t.Errorf("TotalHours = %v, want the summed PERT means %v", result.TotalHours, wantMean)
+    }
+    if !approxEqual(result.Interval.High-result.TotalHours, confidenceZ*13, 1e-9) {
+        t.Errorf("interval = %+v, want ±%v around %v", result.Interval, confidenceZ*13, result.TotalHours)
+    }
+}
+
+// processStore is a ProcessRepository over a fixed set of processes
+type processStore map[string]*Process
+
+func newProcessStore(processes ...*Process) processStore {
+    store := make(processStore)
+    for _, p := range processes {
+        store[p.ID] = p
+    }
+    return store
+}
+
+func (s processStore) Save(process *Process) error {
+    s[process.ID] = process
+    return nil
+}
+
+func (s processStore) FindByID(id string) (*Process, error) {
+    if p, ok := s[id]; ok {
+        return p, nil
+    }
+    return nil, ErrNotFound
+}
+
+func (s processStore) FindByCategory(category ProcessCategory) (*Process, error) {
+    for _, p := range s {
+        if p.Category == category {
+            return p, nil
+        }
+    }
+    return nil, ErrNotFound
+}
+
+func (s processStore) FindAll() ([]*Process, error) {
+    var processes []*Process
+    for _, p := range s {
+        processes = append(processes, p)
+    }
+    return processes, nil
+}
+
+func (s processStore) Update(process *Process) error {
+    if _, ok := s[process.ID]; !ok {
+        return ErrNotFound
+    }
+    s[process.ID] = process
+    return nil
+}
+
+func (s processStore) Delete(id string) error {
+    if _, ok := s[id]; !ok {
+        return ErrNotFound
+    }
+    delete(s, id)
+    return nil
+}
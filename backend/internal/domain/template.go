@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// TemplateTask represents a task a template adds to the estimates created from it
+type TemplateTask struct {
+    ProcessID     string
+    ActivityID    string // Optional, selects an activity within the process
+    Name          string
+    Description   string
+    Complexity    int
+    Scale         float64
+    CustomFactors []string // Factor IDs
+}
+
+// EstimateTemplate represents a reusable starting point for estimates, not bound to a project
+type EstimateTemplate struct {
+    ID            string
+    Name          string
+    Description   string
+    Tasks         []TemplateTask
+    GlobalFactors []string // Factor IDs applied to the entire project
+    CreatedBy     string
+    CreatedAt     time.Time
+}
+
+// TemplateRepository defines the interface for estimate template persistence
+type TemplateRepository interface {
+    Save(template *EstimateTemplate) error
+    FindByID(id string) (*EstimateTemplate, error)
+    FindAll() ([]*EstimateTemplate, error)
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// CalculationTrace records each step of a COCOMO II calculation so that an auditor can reproduce it.
+// Numbers in the equations use the shortest representation that round-trips, so the trace of the same
+// estimate is always the same.
+type CalculationTrace struct {
+    EstimateID       string
+    Model            TraceModel
+    Size             TraceSize
+    ScaleFactors     []TraceScaleFactor
+    ExponentB        float64
+    CostDrivers      []TraceCostDriver
+    EffortMultiplier float64
+    TeamOverhead     float64     // Set when a planned team adds communication overhead
+    Steps            []TraceStep // The equations in the order they were applied
+    EffortPM         float64
+    DurationTM       float64
+    TeamSize         float64
+}
+
+// TraceModel records the model coefficients used
+type TraceModel struct {
+    ID   string
+    Name string
+    A    float64
+    B    float64
+}
+
+// TraceSize records the derivation of the effective size
+type TraceSize struct {
+    NewSize       float64
+    Reuse         []TraceReuse
+    REVL          float64 // Percent
+    EffectiveSize float64 // (NewSize + Σ equivalent size) * (1 + REVL/100)
+}
+
+// TraceReuse records the equivalent size of a reused component
+type TraceReuse struct {
+    Name           string
+    AdaptedSize    float64
+    EquivalentSize float64
+}
+
+// TraceScaleFactor records a scale factor's rating and its contribution to the exponent
+type TraceScaleFactor struct {
+    ID           string
+    Type         ScaleFactorType
+    Rating       float64
+    Weight       float64
+    Contribution float64 // 0.01 * Weight * (5 - Rating)
+}
+
+// TraceCostDriver records the effort multiplier a cost driver's rating maps to
+type TraceCostDriver struct {
+    ID     string
+    Type   CostDriverType
+    Rating float64
+    Value  float64
+}
+
+// TraceStep represents one equation of the calculation with the values substituted
+type TraceStep struct {
+    Name     string
+    Equation string
+    Result   float64
+}
+
+// Trace recalculates the estimate on a copy, recording each step of the calculation
+func (e *COCOMOEstimate) Trace() *CalculationTrace {
+    c := *e
+    trace := &CalculationTrace{EstimateID: e.ID}
+    c.calculate(trace)
+    return trace
+}
+
+// record fills the trace from an estimate that has just been calculated.
+// nominalDurationTM is the schedule before SCED compression.
+func (t *CalculationTrace) record(e *COCOMOEstimate, em, nominalDurationTM float64) {
+    t.Model = TraceModel{ID: e.Model.ID, Name: e.Model.Name, A: e.Model.A, B: e.Model.B}
+
+    // Size
+    t.Size = TraceSize{NewSize: e.ProjectSize, REVL: e.REVL, EffectiveSize: e.EffectiveSize()}
+    sizeTerms := []string{traceNumber(e.ProjectSize)}
+    for _, rc := range e.ReuseComponents {
+        equivalent := rc.EquivalentSLOC()
+        t.Size.Reuse = append(t.Size.Reuse, TraceReuse{Name: rc.Name, AdaptedSize: rc.AdaptedSize, EquivalentSize: equivalent})
+        sizeTerms = append(sizeTerms, traceNumber(equivalent))
+    }
+    t.step("Size", fmt.Sprintf("Size = (New + Σ equivalent size) × (1 + REVL/100) = (%s) × (1 + %s/100)",
+        strings.Join(sizeTerms, " + "), traceNumber(e.REVL)), t.Size.EffectiveSize)
+
+    // Exponent
+    var bTerms []string
+    for _, sf := range e.ScaleFactors {
+        t.ScaleFactors = append(t.ScaleFactors, TraceScaleFactor{
+            ID: sf.ID, Type: sf.Type, Rating: sf.Rating, Weight: sf.Weight, Contribution: sf.ExponentContribution(),
+        })
+        bTerms = append(bTerms, traceNumber(sf.Weight)+" × (5 − "+traceNumber(sf.Rating)+")")
+    }
+    if len(bTerms) == 0 {
+        bTerms = []string{"0"}
+    }
+    t.ExponentB = e.ExponentB
+    t.step("ExponentB", fmt.Sprintf("B = B0 + 0.01 × Σ(weight × (5 − rating)) = %s + 0.01 × (%s)",
+        traceNumber(e.Model.B), strings.Join(bTerms, " + ")), e.ExponentB)
+
+    // Effort multiplier
+    emTerms := []string{"1"}
+    for _, cd := range e.CostDrivers {
+        t.CostDrivers = append(t.CostDrivers, TraceCostDriver{ID: cd.ID, Type: cd.Type, Rating: cd.Rating, Value: cd.Value})
+        emTerms = append(emTerms, traceNumber(cd.Value))
+    }
+    t.EffortMultiplier = em
+    t.step("EffortMultiplier", "EM = Π(cost driver values) = "+strings.Join(emTerms, " × "), em)
+
+    // Effort
+    t.EffortPM = e.EffortPM
+    if overhead := e.teamOverhead(); overhead != 1 {
+        t.TeamOverhead = overhead
+        t.step("TeamOverhead", fmt.Sprintf("Team overhead for a planned team of %s", traceNumber(e.PlannedTeamSize)), overhead)
+        t.step("Effort", fmt.Sprintf("PM = A × Size^B × EM × team overhead = %s × %s^%s × %s × %s",
+            traceNumber(e.Model.A), traceNumber(t.Size.EffectiveSize), traceNumber(e.ExponentB), traceNumber(em), traceNumber(overhead)), e.EffortPM)
+    } else {
+        t.step("Effort", fmt.Sprintf("PM = A × Size^B × EM = %s × %s^%s × %s",
+            traceNumber(e.Model.A), traceNumber(t.Size.EffectiveSize), traceNumber(e.ExponentB), traceNumber(em)), e.EffortPM)
+    }
+
+    // Schedule
+    d := durationExponent(e.ExponentB)
+    t.step("DurationExponent", fmt.Sprintf("D = 0.28 + 0.2 × (B - 1.01) = 0.28 + 0.2 × (%s - 1.01)", traceNumber(e.ExponentB)), d)
+    if e.DurationTM == nominalDurationTM {
+        t.step("Duration", fmt.Sprintf("TDEV = C × PM^D = %s × %s^%s",
+            traceNumber(durationCoefficient), traceNumber(e.EffortPM), traceNumber(d)), e.DurationTM)
+    } else {
+        percent := e.SchedulePercent()
+        if percent < MinSchedulePercent {
+            percent = MinSchedulePercent
+        }
+        nominalEffort := e.Model.A * pow(t.Size.EffectiveSize, e.ExponentB) * withoutSCED(em, e.CostDrivers) * e.teamOverhead()
+        t.step("NominalEffort", fmt.Sprintf("PM without SCED = PM / SCED = %s / %s",
+            traceNumber(e.EffortPM), traceNumber(e.scheduleMultiplier())), nominalEffort)
+        t.step("Duration", fmt.Sprintf("TDEV = C × (PM without SCED)^D × SCED%% = %s × %s^%s × %s",
+            traceNumber(durationCoefficient), traceNumber(nominalEffort), traceNumber(d), traceNumber(percent)), e.DurationTM)
+    }
+    t.DurationTM = e.DurationTM
+
+    // Staffing
+    t.TeamSize = e.TeamSize
+    t.step("TeamSize", fmt.Sprintf("Staff = PM / TDEV = %s / %s", traceNumber(e.EffortPM), traceNumber(e.DurationTM)), e.TeamSize)
+}
+
+// step appends an equation and its result
+func (t *CalculationTrace) step(name, equation string, result float64) {
+    t.Steps = append(t.Steps, TraceStep{Name: name, Equation: equation, Result: result})
+}
+
+// traceNumber formats a number with the shortest representation that round-trips
+func traceNumber(v float64) string {
+    return strconv.FormatFloat(v, 'g', -1, 64)
+}
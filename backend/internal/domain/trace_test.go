@@ -0,0 +1,45 @@
+package domain
+
+import (
+    "reflect"
+    "testing"
+)
+
+// stepResult returns the result of the named step of a trace
+func stepResult(t *testing.T, trace *CalculationTrace, name string) float64 {
+    t.Helper()
+    for _, step := range trace.Steps {
+        if step.Name == name {
+            return step.Result
+        }
+    }
+    t.Fatalf("trace has no %s step", name)
+    return 0
+}
+
+func TestTraceReproducesStoredEffort(t *testing.T) {
+    plain := nominalEstimate(40)
+    detailed := nominalEstimate(40)
+    detailed.REVL = 15
+    detailed.PlannedTeamSize = 30
+    detailed.TeamOverhead = 1.1
+    detailed.ReuseComponents = []ReuseComponent{{Name: "lib", AdaptedSize: 20, DM: 10, CM: 20, IM: 30, SU: 30, UNFM: 0.4}}
+    detailed.rate(CostDriverSCED, 1)
+    detailed.rate(CostDriverCPLX, 4)
+
+    for _, estimate := range []*COCOMOEstimate{plain, detailed} {
+        estimate.CalculateEffort()
+        trace := estimate.Trace()
+
+        if trace.EffortPM != estimate.EffortPM || stepResult(t, trace, "Effort") != estimate.EffortPM {
+            t.Errorf("REVL %v: trace effort %v (step %v), want the stored %v", estimate.REVL, trace.EffortPM, stepResult(t, trace, "Effort"), estimate.EffortPM)
+        }
+        if trace.ExponentB != estimate.ExponentB || trace.DurationTM != estimate.DurationTM || trace.TeamSize != estimate.TeamSize {
+            t.Errorf("REVL %v: trace B %v, %v months, %v staff, want %v, %v, %v", estimate.REVL,
+                trace.ExponentB, trace.DurationTM, trace.TeamSize, estimate.ExponentB, estimate.DurationTM, estimate.TeamSize)
+        }
+        if again := estimate.Trace(); !reflect.DeepEqual(again, trace) {
+            t.Errorf("REVL %v: tracing twice gave different traces", estimate.REVL)
+        }
+    }
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "math"
+
+const (
+    // MaxSchedulePercent is the longest schedule the SCED ratings describe (160% of nominal)
+    MaxSchedulePercent = 1.6
+
+    // tradeoffStepPercent is the schedule step between two points of a tradeoff curve
+    tradeoffStepPercent = 5
+)
+
+// TradeoffPoint represents the effort and staffing needed to finish in a given schedule
+type TradeoffPoint struct {
+    SchedulePercent float64 // Ratio of the nominal schedule
+    DurationTM      float64
+    EffortPM        float64
+    TeamSize        float64
+}
+
+// TradeoffCurve represents how effort and staffing change as the schedule of a fixed size is
+// compressed or stretched. Compression below nominal costs effort through the SCED multiplier;
+// COCOMO II gives no effort back for stretching, so the minimum effort is reached at nominal.
+type TradeoffCurve struct {
+    EstimateID  string
+    ProjectSize float64
+    Points      []TradeoffPoint // Shortest schedule first
+    MinTime     TradeoffPoint   // Shortest feasible schedule
+    MinEffort   TradeoffPoint   // Shortest schedule among those with the least effort
+}
+
+// TradeoffCurve calculates the effort and staffing of the estimate's size for schedules from
+// MinSchedulePercent to MaxSchedulePercent of nominal, keeping every rating except SCED
+func (e *COCOMOEstimate) TradeoffCurve() *TradeoffCurve {
+    exponent := exponentB(e.Model, e.ScaleFactors)
+    nominalEffort := e.Model.A * pow(e.EffectiveSize(), exponent) * withoutSCED(effortMultiplier(e.CostDrivers), e.CostDrivers) * e.teamOverhead()
+    duration := nominalDuration(nominalEffort, exponent)
+
+    sced := NewCostDriver(CostDriverSCED)
+    for _, cd := range e.CostDrivers {
+        if cd.Type == CostDriverSCED {
+            sced = cd
+        }
+    }
+
+    curve := &TradeoffCurve{EstimateID: e.ID, ProjectSize: e.ProjectSize}
+    for percent := int(MinSchedulePercent * 100); percent <= int(MaxSchedulePercent*100); percent += tradeoffStepPercent {
+        ratio := float64(percent) / 100
+        effort := nominalEffort * sced.ValueAt(scheduleRating(ratio))
+        point := TradeoffPoint{
+            SchedulePercent: ratio,
+            DurationTM:      duration * ratio,
+            EffortPM:        effort,
+            TeamSize:        averageStaff(effort, duration*ratio),
+        }
+        curve.Points = append(curve.Points, point)
+
+        if len(curve.Points) == 1 || effort < curve.MinEffort.EffortPM-1e-9 {
+            curve.MinEffort = point
+        }
+    }
+    curve.MinTime = curve.Points[0]
+
+    return curve
+}
+
+// scheduleRating returns the SCED rating that requires the given ratio of the nominal schedule.
+// Ratios at or above nominal map to Nominal, since schedule expansion is not modelled.
+func scheduleRating(ratio float64) float64 {
+    if ratio <= scheduleByRating[0] {
+        return 0
+    }
+    for lower := 0; lower < len(scheduleByRating)-1; lower++ {
+        if ratio <= scheduleByRating[lower+1] {
+            fraction := (ratio - scheduleByRating[lower]) / (scheduleByRating[lower+1] - scheduleByRating[lower])
+            return float64(lower) + math.Min(fraction, 1)
+        }
+    }
+    return float64(len(scheduleByRating) - 1)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "testing"
+
+func TestTradeoffCurveCompressionRaisesEffort(t *testing.T) {
+    estimate := nominalEstimate(100)
+    estimate.CalculateEffort()
+    curve := estimate.TradeoffCurve()
+
+    var nominal *TradeoffPoint
+    for i := range curve.Points {
+        if approxEqual(curve.Points[i].SchedulePercent, 1.0, 1e-9) {
+            nominal = &curve.Points[i]
+        }
+    }
+    if nominal == nil {
+        t.Fatal("curve has no nominal point")
+    }
+    if !approxEqual(nominal.EffortPM, estimate.EffortPM, 1e-9) || !approxEqual(nominal.DurationTM, estimate.DurationTM, 1e-9) {
+        t.Errorf("nominal point %v PM over %v months, want the estimate's %v over %v", nominal.EffortPM, nominal.DurationTM, estimate.EffortPM, estimate.DurationTM)
+    }
+
+    // Points are ordered shortest first, so effort must rise strictly toward the compressed end
+    for i := 1; i < len(curve.Points) && curve.Points[i].SchedulePercent <= 1.0; i++ {
+        shorter, longer := curve.Points[i-1], curve.Points[i]
+        if shorter.EffortPM <= longer.EffortPM {
+            t.Errorf("%v%% of nominal takes %v PM, want more than %v at %v%%",
+                shorter.SchedulePercent*100, shorter.EffortPM, longer.EffortPM, longer.SchedulePercent*100)
+        }
+    }
+    if curve.MinTime.SchedulePercent != MinSchedulePercent || curve.MinTime.EffortPM <= nominal.EffortPM {
+        t.Errorf("minimum time at %v%% with %v PM, want %v%% costing more than nominal", curve.MinTime.SchedulePercent*100, curve.MinTime.EffortPM, MinSchedulePercent*100)
+    }
+    if !approxEqual(curve.MinEffort.SchedulePercent, 1.0, 1e-9) {
+        t.Errorf("minimum effort at %v%%, want nominal", curve.MinEffort.SchedulePercent*100)
+    }
+}
@@ -0,0 +1,69 @@
+package domain
+
+import "math"
+
+// Triangulation represents several independent estimates of the same project and their consensus
+type Triangulation struct {
+    Results        []CalculationResult
+    ConsensusHours float64 // Confidence-weighted average of all methods
+    SpreadHours    float64 // Difference between the highest and lowest method
+    RelativeSpread float64 // Coefficient of variation among the methods
+    Confidence     float64 // 0-1, lowered as the methods diverge
+}
+
+// ToCalculationResult converts an analogy estimate into a calculation result
+func (a *AnalogyEstimate) ToCalculationResult(config EstimationConfig) *CalculationResult {
+    return &CalculationResult{
+        Method:       CalculationMethodAnalogy,
+        TotalHours:   a.EstimatedHours,
+        PersonMonths: a.EstimatedHours / config.MonthlyHours(),
+        Confidence:   0.7,                      // Default confidence level for analogy-based estimation
+    }
+}
+
+// Triangulate combines the results of several estimation methods into a consensus.
+// Nil results are skipped.
+func Triangulate(results ...*CalculationResult) *Triangulation {
+    t := &Triangulation{}
+
+    var totalConfidence, weightedHours float64
+    low, high := math.Inf(1), math.Inf(-1)
+    for _, r := range results {
+        if r == nil {
+            continue
+        }
+        t.Results = append(t.Results, *r)
+        totalConfidence += r.Confidence
+        weightedHours += r.TotalHours * r.Confidence
+        low = math.Min(low, r.TotalHours)
+        high = math.Max(high, r.TotalHours)
+    }
+    if len(t.Results) == 0 || totalConfidence == 0 {
+        return t
+    }
+
+    t.ConsensusHours = weightedHours / totalConfidence
+    t.SpreadHours = high - low
+
+    // Coefficient of variation of the method results around their plain mean
+    var mean float64
+    for _, r := range t.Results {
+        mean += r.TotalHours
+    }
+    mean /= float64(len(t.Results))
+
+    var variance float64
+    for _, r := range t.Results {
+        variance += (r.TotalHours - mean) * (r.TotalHours - mean)
+    }
+    variance /= float64(len(t.Results))
+    if mean > 0 {
+        t.RelativeSpread = math.Sqrt(variance) / mean
+    }
+
+    // Start from the average method confidence and lower it as the methods diverge
+    averageConfidence := totalConfidence / float64(len(t.Results))
+    t.Confidence = averageConfidence * math.Max(0, 1-t.RelativeSpread)
+
+    return t
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "testing"
+
+// methodResult returns a calculation result of the given hours at the default activity confidence
+func methodResult(method CalculationMethod, hours float64) *CalculationResult {
+    return &CalculationResult{Method: method, TotalHours: hours, Confidence: 0.8}
+}
+
+func TestTriangulateCloseEstimatesAgree(t *testing.T) {
+    result := Triangulate(
+        methodResult(CalculationMethodCOCOMO, 1000),
+        methodResult(CalculationMethodActivity, 1020),
+        methodResult(CalculationMethodAnalogy, 980),
+    )
+
+    if !approxEqual(result.ConsensusHours, 1000, 1e-9) {
+        t.Errorf("ConsensusHours = %v, want 1000", result.ConsensusHours)
+    }
+    if result.SpreadHours != 40 {
+        t.Errorf("SpreadHours = %v, want 40", result.SpreadHours)
+    }
+    if result.Confidence < 0.75 {
+        t.Errorf("Confidence = %v, want close to the methods' 0.8", result.Confidence)
+    }
+}
+
+func TestTriangulateDivergentEstimateLowersConfidence(t *testing.T) {
+    close := Triangulate(
+        methodResult(CalculationMethodCOCOMO, 1000),
+        methodResult(CalculationMethodActivity, 1020),
+        methodResult(CalculationMethodAnalogy, 980),
+    )
+    divergent := Triangulate(
+        methodResult(CalculationMethodCOCOMO, 1000),
+        methodResult(CalculationMethodActivity, 1020),
+        methodResult(CalculationMethodAnalogy, 2500),
+    )
+
+    if divergent.Confidence >= close.Confidence {
+        t.Errorf("divergent confidence %v, want less than the %v of close estimates", divergent.Confidence, close.Confidence)
+    }
+    if divergent.RelativeSpread <= close.RelativeSpread {
+        t.Errorf("divergent RelativeSpread %v, want more than %v", divergent.RelativeSpread, close.RelativeSpread)
+    }
+}
+
+func TestTriangulateSkipsMissingMethods(t *testing.T) {
+    result := Triangulate(nil, methodResult(CalculationMethodActivity, 500), nil)
+
+    if len(result.Results) != 1 || result.ConsensusHours != 500 || result.SpreadHours != 0 {
+        t.Errorf("result = %+v, want the single activity result", result)
+    }
+}
@@ -0,0 +1,134 @@
+package domain
+
+// UCPComplexity represents the complexity class of an actor or a use case
+type UCPComplexity string
+
+const (
+    UCPSimple  UCPComplexity = "simple"
+    UCPAverage UCPComplexity = "average"
+    UCPComplex UCPComplexity = "complex"
+)
+
+// ucpActorWeights holds the weight of an actor by complexity:
+// simple is a system with an API, average a protocol-driven system, complex a human through a GUI
+var ucpActorWeights = map[UCPComplexity]float64{
+    UCPSimple:  1,
+    UCPAverage: 2,
+    UCPComplex: 3,
+}
+
+// ucpUseCaseWeights holds the weight of a use case by complexity:
+// simple has up to 3 transactions, average 4 to 7 and complex more than 7
+var ucpUseCaseWeights = map[UCPComplexity]float64{
+    UCPSimple:  5,
+    UCPAverage: 10,
+    UCPComplex: 15,
+}
+
+// UCPTechnicalWeights holds the weight of the 13 technical complexity factors
+var UCPTechnicalWeights = map[string]float64{
+    "T1":  2,   // Distributed system
+    "T2":  1,   // Response time or throughput objectives
+    "T3":  1,   // End-user efficiency
+    "T4":  1,   // Complex internal processing
+    "T5":  1,   // Reusable code
+    "T6":  0.5, // Easy to install
+    "T7":  0.5, // Easy to use
+    "T8":  2,   // Portable
+    "T9":  1,   // Easy to change
+    "T10": 1,   // Concurrent
+    "T11": 1,   // Special security features
+    "T12": 1,   // Direct access for third parties
+    "T13": 1,   // Special user training required
+}
+
+// UCPEnvironmentalWeights holds the weight of the 8 environmental complexity factors
+var UCPEnvironmentalWeights = map[string]float64{
+    "E1": 1.5, // Familiarity with the development process
+    "E2": 0.5, // Application experience
+    "E3": 1,   // Object-oriented experience
+    "E4": 0.5, // Lead analyst capability
+    "E5": 1,   // Motivation
+    "E6": 2,   // Stable requirements
+    "E7": -1,  // Part-time staff
+    "E8": -1,  // Difficult programming language
+}
+
+const (
+    // MaxUCPFactorRating is the highest rating of a technical or environmental factor (0 is irrelevant)
+    MaxUCPFactorRating = 5.0
+
+    // DefaultUCPHoursPerPoint is Karner's productivity factor
+    DefaultUCPHoursPerPoint = 20.0
+)
+
+// UseCasePoints represents an estimate sized in use case points
+type UseCasePoints struct {
+    Actors               map[UCPComplexity]int // Number of actors per complexity
+    UseCases             map[UCPComplexity]int // Number of use cases per complexity
+    TechnicalFactors     map[string]float64    // T1-T13 -> rating 0-5, missing factors are 0
+    EnvironmentalFactors map[string]float64    // E1-E8 -> rating 0-5, missing factors are 0
+    HoursPerPoint        float64               // Productivity factor, DefaultUCPHoursPerPoint when 0
+    // Calculated values
+    UAW            float64 // Unadjusted actor weight
+    UUCW           float64 // Unadjusted use case weight
+    UUCP           float64 // UAW + UUCW
+    TCF            float64 // Technical complexity factor
+    ECF            float64 // Environmental complexity factor
+    UCP            float64 // UUCP * TCF * ECF
+    EstimatedHours float64
+}
+
+// Calculate calculates the use case points and the hours they convert to
+func (u *UseCasePoints) Calculate() {
+    u.UAW = 0
+    for complexity, count := range u.Actors {
+        u.UAW += ucpActorWeights[complexity] * float64(count)
+    }
+    u.UUCW = 0
+    for complexity, count := range u.UseCases {
+        u.UUCW += ucpUseCaseWeights[complexity] * float64(count)
+    }
+    u.UUCP = u.UAW + u.UUCW
+
+    var technical float64
+    for factor, rating := range u.TechnicalFactors {
+        technical += UCPTechnicalWeights[factor] * rating
+    }
+    u.TCF = 0.6 + 0.01*technical
+
+    var environmental float64
+    for factor, rating := range u.EnvironmentalFactors {
+        environmental += UCPEnvironmentalWeights[factor] * rating
+    }
+    u.ECF = 1.4 - 0.03*environmental
+
+    u.UCP = u.UUCP * u.TCF * u.ECF
+
+    hoursPerPoint := u.HoursPerPoint
+    if hoursPerPoint == 0 {
+        hoursPerPoint = DefaultUCPHoursPerPoint
+    }
+    u.EstimatedHours = u.UCP * hoursPerPoint
+}
+
+// IsValidUCPComplexity reports whether c is a known actor or use case complexity
+func IsValidUCPComplexity(c UCPComplexity) bool {
+    _, ok := ucpActorWeights[c]
+    return ok
+}
+
+// ToCalculationResult converts a use case points estimate into a calculation result
+func (u *UseCasePoints) ToCalculationResult(config EstimationConfig) *CalculationResult {
+    personMonths := u.EstimatedHours / config.MonthlyHours()
+    teamSize, duration := StaffingForEffort(personMonths)
+
+    return &CalculationResult{
+        Method:         CalculationMethodUCP,
+        TotalHours:     u.EstimatedHours,
+        PersonMonths:   personMonths,
+        TeamSize:       teamSize,
+        DurationMonths: duration,
+        Confidence:     0.75, // Default confidence level for use case points estimation
+    }
+}
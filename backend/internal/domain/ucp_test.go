@@ -0,0 +1,57 @@
+package domain
+
+import "testing"
+
+// ratedFactors rates every factor of the weight table the same
+func ratedFactors(weights map[string]float64, rating float64) map[string]float64 {
+    factors := make(map[string]float64, len(weights))
+    for factor := range weights {
+        factors[factor] = rating
+    }
+    return factors
+}
+
+func TestUseCasePointsWorkedExample(t *testing.T) {
+    ucp := &UseCasePoints{
+        Actors:               map[UCPComplexity]int{UCPSimple: 2, UCPAverage: 2, UCPComplex: 2},
+        UseCases:             map[UCPComplexity]int{UCPSimple: 5, UCPAverage: 10, UCPComplex: 6},
+        TechnicalFactors:     ratedFactors(UCPTechnicalWeights, 3),
+        EnvironmentalFactors: ratedFactors(UCPEnvironmentalWeights, 3),
+    }
+    ucp.Calculate()
+
+    // UAW = 2*1 + 2*2 + 2*3 = 12; UUCW = 5*5 + 10*10 + 6*15 = 215
+    if ucp.UAW != 12 || ucp.UUCW != 215 || ucp.UUCP != 227 {
+        t.Errorf("UAW = %v, UUCW = %v, UUCP = %v, want 12, 215 and 227", ucp.UAW, ucp.UUCW, ucp.UUCP)
+    }
+    // TCF = 0.6 + 0.01 * 14 * 3; ECF = 1.4 - 0.03 * 4.5 * 3
+    if !approxEqual(ucp.TCF, 1.02, 1e-9) || !approxEqual(ucp.ECF, 0.995, 1e-9) {
+        t.Errorf("TCF = %v, ECF = %v, want 1.02 and 0.995", ucp.TCF, ucp.ECF)
+    }
+    if !approxEqual(ucp.UCP, 230.38, 0.01) {
+        t.Errorf("UCP = %v, want about 230.38", ucp.UCP)
+    }
+    if !approxEqual(ucp.EstimatedHours, 4607.6, 0.1) {
+        t.Errorf("EstimatedHours = %v, want about 4607.6 at 20 hours per point", ucp.EstimatedHours)
+    }
+}
+
+func TestUseCasePointsFactorBounds(t *testing.T) {
+    // Irrelevant technical factors and a best-case environment give the lowest factors
+    ucp := &UseCasePoints{
+        UseCases:             map[UCPComplexity]int{UCPAverage: 1},
+        EnvironmentalFactors: map[string]float64{"E1": 5, "E2": 5, "E3": 5, "E4": 5, "E5": 5, "E6": 5},
+        HoursPerPoint:        28,
+    }
+    ucp.Calculate()
+
+    if ucp.TCF != 0.6 {
+        t.Errorf("TCF = %v, want 0.6 without technical factors", ucp.TCF)
+    }
+    if !approxEqual(ucp.ECF, 0.425, 1e-9) {
+        t.Errorf("ECF = %v, want 1.4 - 0.03 * 32.5 = 0.425", ucp.ECF)
+    }
+    if !approxEqual(ucp.EstimatedHours, ucp.UCP*28, 1e-9) {
+        t.Errorf("EstimatedHours = %v, want %v at 28 hours per point", ucp.EstimatedHours, ucp.UCP*28)
+    }
+}
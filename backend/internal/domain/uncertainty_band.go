@@ -0,0 +1,43 @@
+package domain
+
+// UncertaintyBand represents the cone of uncertainty of the lifecycle phase an estimate is made in:
+// the multipliers of the nominal effort and duration at the optimistic and pessimistic ends of the range
+type UncertaintyBand struct {
+    Phase           string  // ID of the built-in model the phase follows from, empty for other models
+    EffortLow       float64
+    EffortHigh      float64
+    DurationLow     float64 // For the built-in models, follows from the effort multipliers through the TDEV exponent
+    DurationHigh    float64
+}
+
+// Effort multipliers of the cone of uncertainty (Boehm). Early Design estimates are made around
+// the concept of operations and the requirements, Post-Architecture ones once the architecture is in place.
+var (
+    earlyDesignEffortBand      = [2]float64{0.5, 2.0}
+    postArchitectureEffortBand = [2]float64{0.8, 1.25}
+)
+
+// defaultUncertaintyBand is the flat range of the models without a known lifecycle phase, such as calibrated ones
+var defaultUncertaintyBand = UncertaintyBand{EffortLow: 0.8, EffortHigh: 1.2, DurationLow: 0.85, DurationHigh: 1.15}
+
+// UncertaintyBand returns the uncertainty band of the estimate from the model it was calculated with
+func (e *COCOMOEstimate) UncertaintyBand() UncertaintyBand {
+    var effort [2]float64
+    switch e.Model.ID {
+    case ModelEarlyDesignID:
+        effort = earlyDesignEffortBand
+    case ModelPostArchitectureID:
+        effort = postArchitectureEffortBand
+    default:
+        return defaultUncertaintyBand
+    }
+
+    exponent := durationExponent(e.ExponentB)
+    return UncertaintyBand{
+        Phase:        e.Model.ID,
+        EffortLow:    effort[0],
+        EffortHigh:   effort[1],
+        DurationLow:  pow(effort[0], exponent),
+        DurationHigh: pow(effort[1], exponent),
+    }
+}
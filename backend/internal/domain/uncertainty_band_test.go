@@ -0,0 +1,55 @@
+package domain
+
+import "testing"
+
+// detailedResultWithModel calculates a nominal estimate with the coefficients of testModel under the given model ID,
+// so the effort is the same whichever lifecycle phase the ID stands for
+func detailedResultWithModel(id string) *COCOMODetailedResult {
+    estimate := nominalEstimate(100)
+    estimate.Model.ID = id
+    estimate.CalculateEffort()
+    return estimate.GenerateDetailedResult(RateCard{DefaultRate: 5000}, nil, PhaseProfile{}, DefaultEstimationConfig())
+}
+
+func TestEarlyDesignBandIsWiderThanPostArchitecture(t *testing.T) {
+    early := detailedResultWithModel(ModelEarlyDesignID)
+    post := detailedResultWithModel(ModelPostArchitectureID)
+    if early.AdjustedEffort != post.AdjustedEffort {
+        t.Fatalf("effort %v and %v, want the same effort under both models", early.AdjustedEffort, post.AdjustedEffort)
+    }
+
+    width := func(low, high float64) float64 { return high - low }
+    if e, p := width(early.EffortRange.Optimistic, early.EffortRange.Pessimistic), width(post.EffortRange.Optimistic, post.EffortRange.Pessimistic); e <= p {
+        t.Errorf("Early Design effort range %v wide, want wider than the Post-Architecture %v", e, p)
+    }
+    if e, p := width(early.DurationRange.Optimistic, early.DurationRange.Pessimistic), width(post.DurationRange.Optimistic, post.DurationRange.Pessimistic); e <= p {
+        t.Errorf("Early Design duration range %v wide, want wider than the Post-Architecture %v", e, p)
+    }
+    if e, p := width(early.CostEstimate.CostRange.Minimum, early.CostEstimate.CostRange.Maximum), width(post.CostEstimate.CostRange.Minimum, post.CostEstimate.CostRange.Maximum); e <= p {
+        t.Errorf("Early Design cost range %v wide, want wider than the Post-Architecture %v", e, p)
+    }
+}
+
+func TestUncertaintyBandIsSurfaced(t *testing.T) {
+    for _, tt := range []struct {
+        id        string
+        low, high float64
+    }{
+        {ModelEarlyDesignID, 0.5, 2.0},
+        {ModelPostArchitectureID, 0.8, 1.25},
+        {"calibrated", 0.8, 1.2},
+    } {
+        result := detailedResultWithModel(tt.id)
+        band := result.UncertaintyBand
+        if band.EffortLow != tt.low || band.EffortHigh != tt.high {
+            t.Errorf("%s: effort band %v-%v, want %v-%v", tt.id, band.EffortLow, band.EffortHigh, tt.low, tt.high)
+        }
+        if !approxEqual(result.EffortRange.Optimistic, result.AdjustedEffort*tt.low, 1e-9) || !approxEqual(result.EffortRange.Pessimistic, result.AdjustedEffort*tt.high, 1e-9) {
+            t.Errorf("%s: effort range %v-%v, want the band applied to %v", tt.id, result.EffortRange.Optimistic, result.EffortRange.Pessimistic, result.AdjustedEffort)
+        }
+        // The schedule is less uncertain than the effort it follows from
+        if !(band.EffortLow <= band.DurationLow && band.DurationLow < 1 && 1 < band.DurationHigh && band.DurationHigh <= band.EffortHigh) {
+            t.Errorf("%s: duration band %v-%v, want it within the effort band %v-%v", tt.id, band.DurationLow, band.DurationHigh, band.EffortLow, band.EffortHigh)
+        }
+    }
+}
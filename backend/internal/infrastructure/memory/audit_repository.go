@@ -0,0 +1,61 @@
+package memory
+
+import (
+    "sync"
+
+    "estimate-backend/internal/domain"
+)
+
+// InMemoryAuditRepository is a thread-safe in-memory implementation of domain.AuditRepository.
+// Entries are kept in the order they were recorded.
+type InMemoryAuditRepository struct {
+    mu      sync.RWMutex
+    entries []*domain.AuditLog
+}
+
+// NewInMemoryAuditRepository creates a new InMemoryAuditRepository
+func NewInMemoryAuditRepository() *InMemoryAuditRepository {
+    return &InMemoryAuditRepository{}
+}
+
+// Save appends an entry, generating an ID when empty
+func (r *InMemoryAuditRepository) Save(entry *domain.AuditLog) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if entry.ID == "" {
+        entry.ID = domain.NewID()
+    }
+    cp := *entry
+    r.entries = append(r.entries, &cp)
+    return nil
+}
+
+// FindByEntityID retrieves the entries of an entity, oldest first.
+// It returns an empty slice when the entity has no entries.
+func (r *InMemoryAuditRepository) FindByEntityID(entityID string) ([]*domain.AuditLog, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    entries := []*domain.AuditLog{}
+    for _, entry := range r.entries {
+        if entry.EntityID == entityID {
+            cp := *entry
+            entries = append(entries, &cp)
+        }
+    }
+    return entries, nil
+}
+
+// FindAll retrieves all entries, oldest first
+func (r *InMemoryAuditRepository) FindAll() ([]*domain.AuditLog, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    entries := make([]*domain.AuditLog, 0, len(r.entries))
+    for _, entry := range r.entries {
+        cp := *entry
+        entries = append(entries, &cp)
+    }
+    return entries, nil
+}
@@ -0,0 +1,223 @@
+package memory
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+
+    "estimate-backend/internal/domain"
+)
+
+// InMemoryCOCOMORepository is a thread-safe in-memory implementation of domain.COCOMORepository
+type InMemoryCOCOMORepository struct {
+    mu           sync.RWMutex
+    models       map[string]*domain.COCOMOModel
+    estimates    map[string]*domain.COCOMOEstimate
+    scaleFactors map[string]*domain.ScaleFactor
+    costDrivers  map[string]*domain.CostDriver
+}
+
+// NewInMemoryCOCOMORepository creates a new InMemoryCOCOMORepository
+func NewInMemoryCOCOMORepository() *InMemoryCOCOMORepository {
+    return &InMemoryCOCOMORepository{
+        models:       make(map[string]*domain.COCOMOModel),
+        estimates:    make(map[string]*domain.COCOMOEstimate),
+        scaleFactors: make(map[string]*domain.ScaleFactor),
+        costDrivers:  make(map[string]*domain.CostDriver),
+    }
+}
+
+// SaveModel stores a model, generating an ID when empty
+func (r *InMemoryCOCOMORepository) SaveModel(model *domain.COCOMOModel) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if model.ID == "" {
+        model.ID = domain.NewID()
+    }
+    cp := *model
+    r.models[model.ID] = &cp
+    return nil
+}
+
+// FindModelByID retrieves a model by ID
+func (r *InMemoryCOCOMORepository) FindModelByID(id string) (*domain.COCOMOModel, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    model, ok := r.models[id]
+    if !ok {
+        return nil, fmt.Errorf("COCOMO model %s: %w", id, domain.ErrNotFound)
+    }
+    cp := *model
+    return &cp, nil
+}
+
+// FindModelsByOwner retrieves the models of an owner together with the built-in models, sorted by name
+func (r *InMemoryCOCOMORepository) FindModelsByOwner(ownerID string) ([]*domain.COCOMOModel, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    models := []*domain.COCOMOModel{}
+    for _, model := range r.models {
+        if model.VisibleTo(ownerID) {
+            cp := *model
+            models = append(models, &cp)
+        }
+    }
+    sort.Slice(models, func(i, j int) bool {
+        if models[i].Name != models[j].Name {
+            return models[i].Name < models[j].Name
+        }
+        return models[i].ID < models[j].ID
+    })
+    return models, nil
+}
+
+// SaveEstimate stores an estimate, generating an ID when empty
+func (r *InMemoryCOCOMORepository) SaveEstimate(estimate *domain.COCOMOEstimate) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if estimate.ID == "" {
+        estimate.ID = domain.NewID()
+    }
+    r.estimates[estimate.ID] = copyCOCOMOEstimate(estimate)
+    return nil
+}
+
+// FindEstimateByID retrieves an estimate by ID
+func (r *InMemoryCOCOMORepository) FindEstimateByID(id string) (*domain.COCOMOEstimate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    estimate, ok := r.estimates[id]
+    if !ok {
+        return nil, fmt.Errorf("COCOMO estimate %s: %w", id, domain.ErrNotFound)
+    }
+    return copyCOCOMOEstimate(estimate), nil
+}
+
+// FindAllEstimates retrieves all estimates ordered by ID
+func (r *InMemoryCOCOMORepository) FindAllEstimates() ([]*domain.COCOMOEstimate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    estimates := make([]*domain.COCOMOEstimate, 0, len(r.estimates))
+    for _, estimate := range r.estimates {
+        estimates = append(estimates, copyCOCOMOEstimate(estimate))
+    }
+    sort.Slice(estimates, func(i, j int) bool {
+        return estimates[i].ID < estimates[j].ID
+    })
+    return estimates, nil
+}
+
+// DeleteEstimate removes an estimate by ID
+func (r *InMemoryCOCOMORepository) DeleteEstimate(id string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.estimates[id]; !ok {
+        return fmt.Errorf("COCOMO estimate %s: %w", id, domain.ErrNotFound)
+    }
+    delete(r.estimates, id)
+    return nil
+}
+
+// SaveScaleFactor stores a scale factor, generating an ID when empty
+func (r *InMemoryCOCOMORepository) SaveScaleFactor(factor *domain.ScaleFactor) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if factor.ID == "" {
+        factor.ID = domain.NewID()
+    }
+    cp := *factor
+    r.scaleFactors[factor.ID] = &cp
+    return nil
+}
+
+// FindScaleFactorByID retrieves a scale factor by ID
+func (r *InMemoryCOCOMORepository) FindScaleFactorByID(id string) (*domain.ScaleFactor, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    factor, ok := r.scaleFactors[id]
+    if !ok {
+        return nil, fmt.Errorf("scale factor %s: %w", id, domain.ErrNotFound)
+    }
+    cp := *factor
+    return &cp, nil
+}
+
+// FindAllScaleFactors retrieves all scale factors sorted by ID
+func (r *InMemoryCOCOMORepository) FindAllScaleFactors() ([]*domain.ScaleFactor, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    factors := make([]*domain.ScaleFactor, 0, len(r.scaleFactors))
+    for _, factor := range r.scaleFactors {
+        cp := *factor
+        factors = append(factors, &cp)
+    }
+    sort.Slice(factors, func(i, j int) bool { return factors[i].ID < factors[j].ID })
+    return factors, nil
+}
+
+// SaveCostDriver stores a cost driver, generating an ID when empty
+func (r *InMemoryCOCOMORepository) SaveCostDriver(driver *domain.CostDriver) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if driver.ID == "" {
+        driver.ID = domain.NewID()
+    }
+    cp := *driver
+    r.costDrivers[driver.ID] = &cp
+    return nil
+}
+
+// FindCostDriverByID retrieves a cost driver by ID
+func (r *InMemoryCOCOMORepository) FindCostDriverByID(id string) (*domain.CostDriver, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    driver, ok := r.costDrivers[id]
+    if !ok {
+        return nil, fmt.Errorf("cost driver %s: %w", id, domain.ErrNotFound)
+    }
+    cp := *driver
+    return &cp, nil
+}
+
+// FindAllCostDrivers retrieves all cost drivers sorted by ID
+func (r *InMemoryCOCOMORepository) FindAllCostDrivers() ([]*domain.CostDriver, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    drivers := make([]*domain.CostDriver, 0, len(r.costDrivers))
+    for _, driver := range r.costDrivers {
+        cp := *driver
+        drivers = append(drivers, &cp)
+    }
+    sort.Slice(drivers, func(i, j int) bool { return drivers[i].ID < drivers[j].ID })
+    return drivers, nil
+}
+
+// copyCOCOMOEstimate returns a deep copy so callers can't mutate the stored estimate
+func copyCOCOMOEstimate(estimate *domain.COCOMOEstimate) *domain.COCOMOEstimate {
+    cp := *estimate
+    if estimate.Model != nil {
+        model := *estimate.Model
+        cp.Model = &model
+    }
+    cp.ScaleFactors = append([]domain.ScaleFactor(nil), estimate.ScaleFactors...)
+    cp.CostDrivers = append([]domain.CostDriver(nil), estimate.CostDrivers...)
+    cp.ReuseComponents = append([]domain.ReuseComponent(nil), estimate.ReuseComponents...)
+    if estimate.Uncertainty != nil {
+        uncertainty := *estimate.Uncertainty
+        cp.Uncertainty = &uncertainty
+    }
+    return &cp
+}
@@ -0,0 +1,141 @@
+package memory
+
+import (
+    "errors"
+    "reflect"
+    "sync"
+    "testing"
+
+    "estimate-backend/internal/domain"
+)
+
+func TestCOCOMORepositoryParallelSaves(t *testing.T) {
+    repo := NewInMemoryCOCOMORepository()
+    const n = 50
+
+    var wg sync.WaitGroup
+    estimates := make([]*domain.COCOMOEstimate, n)
+    for i := 0; i < n; i++ {
+        estimates[i] = &domain.COCOMOEstimate{ProjectSize: float64(i + 1)}
+        wg.Add(3)
+        go func(estimate *domain.COCOMOEstimate) {
+            defer wg.Done()
+            if err := repo.SaveEstimate(estimate); err != nil {
+                t.Error(err)
+            }
+        }(estimates[i])
+        go func() {
+            defer wg.Done()
+            if err := repo.SaveModel(&domain.COCOMOModel{Name: "model"}); err != nil {
+                t.Error(err)
+            }
+        }()
+        go func() {
+            defer wg.Done()
+            if _, err := repo.FindAllEstimates(); err != nil {
+                t.Error(err)
+            }
+        }()
+    }
+    wg.Wait()
+
+    all, err := repo.FindAllEstimates()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(all) != n {
+        t.Errorf("got %d estimates, want %d", len(all), n)
+    }
+    ids := make(map[string]bool)
+    for _, estimate := range estimates {
+        if estimate.ID == "" || ids[estimate.ID] {
+            t.Fatalf("estimate ID %q is empty or duplicated", estimate.ID)
+        }
+        ids[estimate.ID] = true
+
+        found, err := repo.FindEstimateByID(estimate.ID)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if found.ProjectSize != estimate.ProjectSize {
+            t.Errorf("estimate %s: ProjectSize = %v, want %v", estimate.ID, found.ProjectSize, estimate.ProjectSize)
+        }
+    }
+    if models, _ := repo.FindModelsByOwner(""); len(models) != n {
+        t.Errorf("got %d models, want %d", len(models), n)
+    }
+}
+
+func TestCOCOMORepositoryNotFound(t *testing.T) {
+    repo := NewInMemoryCOCOMORepository()
+
+    if _, err := repo.FindModelByID("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindModelByID: got %v, want ErrNotFound", err)
+    }
+    if _, err := repo.FindEstimateByID("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindEstimateByID: got %v, want ErrNotFound", err)
+    }
+    if err := repo.DeleteEstimate("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("DeleteEstimate: got %v, want ErrNotFound", err)
+    }
+    if _, err := repo.FindScaleFactorByID("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindScaleFactorByID: got %v, want ErrNotFound", err)
+    }
+    if _, err := repo.FindCostDriverByID("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindCostDriverByID: got %v, want ErrNotFound", err)
+    }
+}
+
+func TestCOCOMORepositoryGeneratesFactorIDs(t *testing.T) {
+    repo := NewInMemoryCOCOMORepository()
+    factor := &domain.ScaleFactor{Type: domain.ScaleFactorPREC}
+    driver := &domain.CostDriver{Type: domain.CostDriverRELY}
+    if err := repo.SaveScaleFactor(factor); err != nil {
+        t.Fatal(err)
+    }
+    if err := repo.SaveCostDriver(driver); err != nil {
+        t.Fatal(err)
+    }
+
+    if found, err := repo.FindScaleFactorByID(factor.ID); err != nil || found.Type != domain.ScaleFactorPREC {
+        t.Errorf("FindScaleFactorByID(%q) = %v, %v", factor.ID, found, err)
+    }
+    if found, err := repo.FindCostDriverByID(driver.ID); err != nil || found.Type != domain.CostDriverRELY {
+        t.Errorf("FindCostDriverByID(%q) = %v, %v", driver.ID, found, err)
+    }
+}
+
+func TestCOCOMORepositoryFindModelsByOwner(t *testing.T) {
+    repo := NewInMemoryCOCOMORepository()
+    for _, model := range []*domain.COCOMOModel{
+        {ID: "builtin", Name: "Built-in"},
+        {ID: "org-a-model", Name: "Org A", OwnerID: "org-a"},
+        {ID: "org-b-model", Name: "Org B", OwnerID: "org-b"},
+    } {
+        if err := repo.SaveModel(model); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    tests := []struct {
+        owner string
+        want  []string
+    }{
+        {"org-a", []string{"builtin", "org-a-model"}},
+        {"org-b", []string{"builtin", "org-b-model"}},
+        {"", []string{"builtin"}},
+    }
+    for _, tt := range tests {
+        models, err := repo.FindModelsByOwner(tt.owner)
+        if err != nil {
+            t.Fatal(err)
+        }
+        var ids []string
+        for _, model := range models {
+            ids = append(ids, model.ID)
+        }
+        if !reflect.DeepEqual(ids, tt.want) {
+            t.Errorf("owner %q: models = %v, want %v", tt.owner, ids, tt.want)
+        }
+    }
+}
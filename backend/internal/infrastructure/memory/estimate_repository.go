@@ -0,0 +1,283 @@
+package memory
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+    "time"
+
+    "estimate-backend/internal/domain"
+)
+
+// InMemoryEstimateRepository is a thread-safe in-memory implementation of domain.EstimateRepository.
+// Every save also records an immutable snapshot of the estimate under its version number.
+// Deleted estimates are kept, with DeletedAt set, so they can be restored.
+type InMemoryEstimateRepository struct {
+    mu        sync.RWMutex
+    estimates map[string]*domain.Estimate
+    versions  map[string][]*domain.Estimate
+}
+
+// NewInMemoryEstimateRepository creates a new InMemoryEstimateRepository
+func NewInMemoryEstimateRepository() *InMemoryEstimateRepository {
+    return &InMemoryEstimateRepository{
+        estimates: make(map[string]*domain.Estimate),
+        versions:  make(map[string][]*domain.Estimate),
+    }
+}
+
+// Save stores a new estimate as version 1, generating IDs for the estimate and its tasks when empty
+func (r *InMemoryEstimateRepository) Save(estimate *domain.Estimate) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if estimate.ID == "" {
+        estimate.ID = domain.NewID()
+    }
+    assignTaskIDs(estimate)
+    estimate.Version = 1
+    r.estimates[estimate.ID] = copyEstimate(estimate)
+    r.versions[estimate.ID] = []*domain.Estimate{copyEstimate(estimate)}
+    return nil
+}
+
+// FindByID retrieves an estimate by ID
+func (r *InMemoryEstimateRepository) FindByID(id string) (*domain.Estimate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    estimate, ok := r.estimates[id]
+    if !ok || estimate.DeletedAt != nil {
+        return nil, fmt.Errorf("estimate %s: %w", id, domain.ErrNotFound)
+    }
+    return copyEstimate(estimate), nil
+}
+
+// FindByProjectID retrieves the estimates of a project, oldest first.
+// It returns an empty slice when the project has no estimates.
+func (r *InMemoryEstimateRepository) FindByProjectID(projectID string) ([]*domain.Estimate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    estimates := []*domain.Estimate{}
+    for _, estimate := range r.estimates {
+        if estimate.ProjectID == projectID && estimate.DeletedAt == nil {
+            estimates = append(estimates, copyEstimate(estimate))
+        }
+    }
+    sortEstimates(estimates)
+    return estimates, nil
+}
+
+// FindAll retrieves all estimates, oldest first
+func (r *InMemoryEstimateRepository) FindAll() ([]*domain.Estimate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    estimates := make([]*domain.Estimate, 0, len(r.estimates))
+    for _, estimate := range r.estimates {
+        if estimate.DeletedAt == nil {
+            estimates = append(estimates, copyEstimate(estimate))
+        }
+    }
+    sortEstimates(estimates)
+    return estimates, nil
+}
+
+// Update replaces an existing estimate and records it as the next version.
+// The estimate must carry the stored version, so that an update based on an outdated copy is rejected.
+func (r *InMemoryEstimateRepository) Update(estimate *domain.Estimate) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    current, ok := r.estimates[estimate.ID]
+    if !ok || current.DeletedAt != nil {
+        return fmt.Errorf("estimate %s: %w", estimate.ID, domain.ErrNotFound)
+    }
+    if estimate.Version != current.Version {
+        return fmt.Errorf("estimate %s: version %d is outdated, the current version is %d: %w", estimate.ID, estimate.Version, current.Version, domain.ErrConflict)
+    }
+    assignTaskIDs(estimate)
+    estimate.Version = current.Version + 1
+    r.estimates[estimate.ID] = copyEstimate(estimate)
+    r.versions[estimate.ID] = append(r.versions[estimate.ID], copyEstimate(estimate))
+    return nil
+}
+
+// Delete soft-deletes an estimate by ID
+func (r *InMemoryEstimateRepository) Delete(id string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    estimate, ok := r.estimates[id]
+    if !ok || estimate.DeletedAt != nil {
+        return fmt.Errorf("estimate %s: %w", id, domain.ErrNotFound)
+    }
+    now := time.Now()
+    estimate.DeletedAt = &now
+    return nil
+}
+
+// Restore undoes the deletion of an estimate. Restoring an estimate that isn't deleted is a no-op.
+func (r *InMemoryEstimateRepository) Restore(id string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    estimate, ok := r.estimates[id]
+    if !ok {
+        return fmt.Errorf("estimate %s: %w", id, domain.ErrNotFound)
+    }
+    estimate.DeletedAt = nil
+    return nil
+}
+
+// FindVersions retrieves all snapshots of an estimate, oldest version first
+func (r *InMemoryEstimateRepository) FindVersions(id string) ([]*domain.Estimate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    snapshots, ok := r.versions[id]
+    if !ok || r.estimates[id].DeletedAt != nil {
+        return nil, fmt.Errorf("estimate %s: %w", id, domain.ErrNotFound)
+    }
+    versions := make([]*domain.Estimate, len(snapshots))
+    for i, snapshot := range snapshots {
+        versions[i] = copyEstimate(snapshot)
+    }
+    return versions, nil
+}
+
+// FindVersion retrieves a single snapshot of an estimate
+func (r *InMemoryEstimateRepository) FindVersion(id string, version int) (*domain.Estimate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    snapshots, ok := r.versions[id]
+    if !ok || r.estimates[id].DeletedAt != nil {
+        return nil, fmt.Errorf("estimate %s: %w", id, domain.ErrNotFound)
+    }
+    if version < 1 || version > len(snapshots) {
+        return nil, fmt.Errorf("estimate %s version %d: %w", id, version, domain.ErrNotFound)
+    }
+    return copyEstimate(snapshots[version-1]), nil
+}
+
+// assignTaskIDs generates IDs for tasks that don't have one yet
+func assignTaskIDs(estimate *domain.Estimate) {
+    for i := range estimate.ProcessEstimates {
+        tasks := estimate.ProcessEstimates[i].Tasks
+        for j := range tasks {
+            if tasks[j].ID == "" {
+                tasks[j].ID = domain.NewID()
+            }
+        }
+    }
+}
+
+// sortEstimates orders estimates by creation time, then ID
+func sortEstimates(estimates []*domain.Estimate) {
+    sort.Slice(estimates, func(i, j int) bool {
+        if !estimates[i].CreatedAt.Equal(estimates[j].CreatedAt) {
+            return estimates[i].CreatedAt.Before(estimates[j].CreatedAt)
+        }
+        return estimates[i].ID < estimates[j].ID
+    })
+}
+
+// copyEstimate returns a deep copy so callers can't mutate the stored estimate
+func copyEstimate(estimate *domain.Estimate) *domain.Estimate {
+    cp := *estimate
+
+    cp.ProcessEstimates = make([]domain.ProcessEstimate, len(estimate.ProcessEstimates))
+    for i, pe := range estimate.ProcessEstimates {
+        if pe.Process != nil {
+            pe.Process = copyProcess(pe.Process)
+        }
+        pe.Tasks = copyTasks(pe.Tasks)
+        cp.ProcessEstimates[i] = pe
+    }
+
+    cp.GlobalFactors = append([]domain.Factor(nil), estimate.GlobalFactors...)
+    if estimate.ProcessFactors != nil {
+        cp.ProcessFactors = make(map[domain.ProcessCategory][]domain.Factor, len(estimate.ProcessFactors))
+        for category, factors := range estimate.ProcessFactors {
+            cp.ProcessFactors[category] = append([]domain.Factor(nil), factors...)
+        }
+    }
+    cp.AdditionalEfforts = append([]domain.AdditionalEffort(nil), estimate.AdditionalEfforts...)
+    cp.CompletedDeliverables = append([]string(nil), estimate.CompletedDeliverables...)
+    cp.LocalizationLanguages = append([]string(nil), estimate.LocalizationLanguages...)
+    if estimate.Reconciliation.Weights != nil {
+        cp.Reconciliation.Weights = make(map[domain.CalculationMethod]float64, len(estimate.Reconciliation.Weights))
+        for method, weight := range estimate.Reconciliation.Weights {
+            cp.Reconciliation.Weights[method] = weight
+        }
+    }
+    if estimate.COCOMOEstimate != nil {
+        cp.COCOMOEstimate = copyCOCOMOEstimate(estimate.COCOMOEstimate)
+    }
+    if estimate.StoryPoints != nil {
+        storyPoints := *estimate.StoryPoints
+        cp.StoryPoints = &storyPoints
+    }
+    if estimate.UseCasePoints != nil {
+        cp.UseCasePoints = copyUseCasePoints(estimate.UseCasePoints)
+    }
+    if estimate.ActivityResult != nil {
+        result := *estimate.ActivityResult
+        cp.ActivityResult = &result
+    }
+    if estimate.COCOMOResult != nil {
+        result := *estimate.COCOMOResult
+        cp.COCOMOResult = &result
+    }
+    if estimate.UCPResult != nil {
+        result := *estimate.UCPResult
+        cp.UCPResult = &result
+    }
+    if estimate.DeliverableResult != nil {
+        result := *estimate.DeliverableResult
+        cp.DeliverableResult = &result
+    }
+    if estimate.DeletedAt != nil {
+        deletedAt := *estimate.DeletedAt
+        cp.DeletedAt = &deletedAt
+    }
+    return &cp
+}
+
+// copyUseCasePoints returns a deep copy of a use case points estimate
+func copyUseCasePoints(ucp *domain.UseCasePoints) *domain.UseCasePoints {
+    cp := *ucp
+    cp.Actors = make(map[domain.UCPComplexity]int, len(ucp.Actors))
+    for k, v := range ucp.Actors {
+        cp.Actors[k] = v
+    }
+    cp.UseCases = make(map[domain.UCPComplexity]int, len(ucp.UseCases))
+    for k, v := range ucp.UseCases {
+        cp.UseCases[k] = v
+    }
+    cp.TechnicalFactors = make(map[string]float64, len(ucp.TechnicalFactors))
+    for k, v := range ucp.TechnicalFactors {
+        cp.TechnicalFactors[k] = v
+    }
+    cp.EnvironmentalFactors = make(map[string]float64, len(ucp.EnvironmentalFactors))
+    for k, v := range ucp.EnvironmentalFactors {
+        cp.EnvironmentalFactors[k] = v
+    }
+    return &cp
+}
+
+// copyTasks returns a deep copy of the tasks
+func copyTasks(tasks []domain.Task) []domain.Task {
+    if tasks == nil {
+        return nil
+    }
+    cp := make([]domain.Task, len(tasks))
+    for i, task := range tasks {
+        task.Dependencies = append([]string(nil), task.Dependencies...)
+        task.CustomFactors = append([]domain.Factor(nil), task.CustomFactors...)
+        cp[i] = task
+    }
+    return cp
+}
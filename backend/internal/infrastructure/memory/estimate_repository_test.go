@@ -0,0 +1,158 @@
+package memory
+
+import (
+    "errors"
+    "testing"
+
+    "estimate-backend/internal/domain"
+)
+
+// saveEstimates saves estimates of the given projects, failing the test on error
+func saveEstimates(t *testing.T, repo *InMemoryEstimateRepository, projectIDs ...string) []*domain.Estimate {
+    t.Helper()
+    estimates := make([]*domain.Estimate, len(projectIDs))
+    for i, projectID := range projectIDs {
+        estimates[i] = &domain.Estimate{ProjectID: projectID, ProjectName: projectID}
+        if err := repo.Save(estimates[i]); err != nil {
+            t.Fatal(err)
+        }
+    }
+    return estimates
+}
+
+func TestEstimateRepositoryFindByProjectID(t *testing.T) {
+    repo := NewInMemoryEstimateRepository()
+    saved := saveEstimates(t, repo, "project-a", "project-b", "project-a")
+
+    estimates, err := repo.FindByProjectID("project-a")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(estimates) != 2 {
+        t.Fatalf("got %d estimates, want the 2 of project-a", len(estimates))
+    }
+    for _, estimate := range estimates {
+        if estimate.ProjectID != "project-a" {
+            t.Errorf("estimate %s of %s returned for project-a", estimate.ID, estimate.ProjectID)
+        }
+        if estimate.ID != saved[0].ID && estimate.ID != saved[2].ID {
+            t.Errorf("unexpected estimate %s", estimate.ID)
+        }
+    }
+}
+
+func TestEstimateRepositoryFindByProjectIDWithoutEstimates(t *testing.T) {
+    repo := NewInMemoryEstimateRepository()
+    saveEstimates(t, repo, "project-a")
+
+    estimates, err := repo.FindByProjectID("project-z")
+    if err != nil {
+        t.Fatalf("got %v, want no error for a project without estimates", err)
+    }
+    if estimates == nil || len(estimates) != 0 {
+        t.Errorf("got %v, want an empty slice", estimates)
+    }
+}
+
+func TestEstimateRepositoryNotFound(t *testing.T) {
+    repo := NewInMemoryEstimateRepository()
+
+    if _, err := repo.FindByID("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindByID: got %v, want ErrNotFound", err)
+    }
+    if err := repo.Update(&domain.Estimate{ID: "unknown", Version: 1}); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("Update: got %v, want ErrNotFound", err)
+    }
+    if err := repo.Delete("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("Delete: got %v, want ErrNotFound", err)
+    }
+}
+
+func TestEstimateRepositorySaveIDs(t *testing.T) {
+    repo := NewInMemoryEstimateRepository()
+    estimate := &domain.Estimate{ProjectID: "project-a", ProcessEstimates: []domain.ProcessEstimate{
+        {Process: &domain.Process{ID: "p"}, Tasks: []domain.Task{{Name: "a"}, {Name: "b"}}},
+    }}
+    if err := repo.Save(estimate); err != nil {
+        t.Fatal(err)
+    }
+
+    tasks := estimate.ProcessEstimates[0].Tasks
+    if estimate.ID == "" || tasks[0].ID == "" || tasks[1].ID == "" || tasks[0].ID == tasks[1].ID {
+        t.Fatalf("IDs estimate %q, tasks %q and %q, want distinct non-empty IDs", estimate.ID, tasks[0].ID, tasks[1].ID)
+    }
+
+    other := saveEstimates(t, repo, "project-a")[0]
+    if other.ID == estimate.ID {
+        t.Errorf("two estimates share the ID %q", other.ID)
+    }
+}
+
+func TestEstimateRepositoryKeepsVersionSnapshots(t *testing.T) {
+    repo := NewInMemoryEstimateRepository()
+    estimate := saveEstimates(t, repo, "project-a")[0]
+    for _, hours := range []float64{200, 300} {
+        estimate.TotalHours = hours
+        if err := repo.Update(estimate); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    versions, err := repo.FindVersions(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    for i, want := range []float64{0, 200, 300} {
+        if versions[i].Version != i+1 || versions[i].TotalHours != want {
+            t.Errorf("snapshot %d: version %d with %v hours, want version %d with %v", i, versions[i].Version, versions[i].TotalHours, i+1, want)
+        }
+    }
+
+    // Snapshots are immutable
+    versions[0].TotalHours = 999
+    if first, _ := repo.FindVersion(estimate.ID, 1); first.TotalHours != 0 {
+        t.Errorf("version 1 has %v hours after mutating a returned copy, want 0", first.TotalHours)
+    }
+}
+
+func TestEstimateRepositorySoftDelete(t *testing.T) {
+    repo := NewInMemoryEstimateRepository()
+    saved := saveEstimates(t, repo, "project-a", "project-a")
+    deleted := saved[0]
+
+    if err := repo.Delete(deleted.ID); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := repo.FindByID(deleted.ID); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindByID: got %v, want ErrNotFound for a deleted estimate", err)
+    }
+    for _, find := range []func() ([]*domain.Estimate, error){
+        func() ([]*domain.Estimate, error) { return repo.FindByProjectID("project-a") },
+        repo.FindAll,
+    } {
+        estimates, err := find()
+        if err != nil {
+            t.Fatal(err)
+        }
+        if len(estimates) != 1 || estimates[0].ID != saved[1].ID {
+            t.Errorf("got %d estimates, want only %s", len(estimates), saved[1].ID)
+        }
+    }
+    if err := repo.Delete(deleted.ID); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("Delete twice: got %v, want ErrNotFound", err)
+    }
+
+    if err := repo.Restore(deleted.ID); err != nil {
+        t.Fatal(err)
+    }
+    restored, err := repo.FindByID(deleted.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if restored.DeletedAt != nil {
+        t.Errorf("DeletedAt = %v, want nil after the restore", restored.DeletedAt)
+    }
+    if estimates, _ := repo.FindByProjectID("project-a"); len(estimates) != 2 {
+        t.Errorf("got %d estimates, want both after the restore", len(estimates))
+    }
+}
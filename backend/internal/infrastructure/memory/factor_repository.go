@@ -0,0 +1,89 @@
+package memory
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+
+    "estimate-backend/internal/domain"
+)
+
+// InMemoryFactorRepository is a thread-safe in-memory implementation of domain.FactorRepository
+type InMemoryFactorRepository struct {
+    mu      sync.RWMutex
+    factors map[string]*domain.Factor
+}
+
+// NewInMemoryFactorRepository creates a new InMemoryFactorRepository
+func NewInMemoryFactorRepository() *InMemoryFactorRepository {
+    return &InMemoryFactorRepository{
+        factors: make(map[string]*domain.Factor),
+    }
+}
+
+// Save stores a factor, generating an ID when empty
+func (r *InMemoryFactorRepository) Save(factor *domain.Factor) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if factor.ID == "" {
+        factor.ID = domain.NewID()
+    }
+    cp := *factor
+    r.factors[factor.ID] = &cp
+    return nil
+}
+
+// FindByID retrieves a factor by ID
+func (r *InMemoryFactorRepository) FindByID(id string) (*domain.Factor, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    factor, ok := r.factors[id]
+    if !ok {
+        return nil, fmt.Errorf("factor %s: %w", id, domain.ErrNotFound)
+    }
+    cp := *factor
+    return &cp, nil
+}
+
+// FindAll retrieves all factors sorted by ID
+func (r *InMemoryFactorRepository) FindAll() ([]*domain.Factor, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    factors := make([]*domain.Factor, 0, len(r.factors))
+    for _, factor := range r.factors {
+        cp := *factor
+        factors = append(factors, &cp)
+    }
+    sort.Slice(factors, func(i, j int) bool {
+        return factors[i].ID < factors[j].ID
+    })
+    return factors, nil
+}
+
+// Update replaces an existing factor
+func (r *InMemoryFactorRepository) Update(factor *domain.Factor) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.factors[factor.ID]; !ok {
+        return fmt.Errorf("factor %s: %w", factor.ID, domain.ErrNotFound)
+    }
+    cp := *factor
+    r.factors[factor.ID] = &cp
+    return nil
+}
+
+// Delete removes a factor by ID
+func (r *InMemoryFactorRepository) Delete(id string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.factors[id]; !ok {
+        return fmt.Errorf("factor %s: %w", id, domain.ErrNotFound)
+    }
+    delete(r.factors, id)
+    return nil
+}
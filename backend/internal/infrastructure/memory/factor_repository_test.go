@@ -0,0 +1,79 @@
+package memory
+
+import (
+    "errors"
+    "testing"
+
+    "estimate-backend/internal/domain"
+)
+
+func TestFactorRepositoryCRUD(t *testing.T) {
+    repo := NewInMemoryFactorRepository()
+    factor := &domain.Factor{Name: "熟練チーム", Impact: 0.8}
+    if err := repo.Save(factor); err != nil {
+        t.Fatal(err)
+    }
+    if factor.ID == "" {
+        t.Fatal("Save left the ID empty")
+    }
+
+    found, err := repo.FindByID(factor.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    found.Impact = 0.9
+    if err := repo.Update(found); err != nil {
+        t.Fatal(err)
+    }
+    if updated, _ := repo.FindByID(factor.ID); updated.Impact != 0.9 {
+        t.Errorf("Impact = %v after Update, want 0.9", updated.Impact)
+    }
+
+    if err := repo.Delete(factor.ID); err != nil {
+        t.Fatal(err)
+    }
+    if all, _ := repo.FindAll(); len(all) != 0 {
+        t.Errorf("got %d factors after Delete, want 0", len(all))
+    }
+}
+
+func TestFactorRepositoryNotFound(t *testing.T) {
+    repo := NewInMemoryFactorRepository()
+
+    if _, err := repo.FindByID("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindByID: got %v, want ErrNotFound", err)
+    }
+    if err := repo.Update(&domain.Factor{ID: "unknown"}); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("Update: got %v, want ErrNotFound", err)
+    }
+    if err := repo.Delete("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("Delete: got %v, want ErrNotFound", err)
+    }
+}
+
+func TestFactorRepositorySaveIDs(t *testing.T) {
+    repo := NewInMemoryFactorRepository()
+    factor := &domain.Factor{Name: "要件不確実性", Impact: 1.3}
+    if err := repo.Save(factor); err != nil {
+        t.Fatal(err)
+    }
+    other := &domain.Factor{Name: "熟練チーム", Impact: 0.8}
+    if err := repo.Save(other); err != nil {
+        t.Fatal(err)
+    }
+    if factor.ID == "" || factor.ID == other.ID {
+        t.Fatalf("IDs %q and %q, want distinct non-empty IDs", factor.ID, other.ID)
+    }
+
+    // Saving again keeps the ID and stores a single factor
+    id := factor.ID
+    if err := repo.Save(factor); err != nil {
+        t.Fatal(err)
+    }
+    if factor.ID != id {
+        t.Errorf("ID = %q after re-saving, want %q", factor.ID, id)
+    }
+    if all, _ := repo.FindAll(); len(all) != 2 {
+        t.Errorf("got %d factors, want 2", len(all))
+    }
+}
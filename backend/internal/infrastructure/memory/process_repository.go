@@ -0,0 +1,132 @@
+package memory
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+
+    "estimate-backend/internal/domain"
+)
+
+// InMemoryProcessRepository is a thread-safe in-memory implementation of domain.ProcessRepository
+type InMemoryProcessRepository struct {
+    mu        sync.RWMutex
+    processes map[string]*domain.Process
+}
+
+// NewInMemoryProcessRepository creates a new InMemoryProcessRepository
+func NewInMemoryProcessRepository() *InMemoryProcessRepository {
+    return &InMemoryProcessRepository{
+        processes: make(map[string]*domain.Process),
+    }
+}
+
+// Save stores a process, generating IDs for the process and its activities when empty
+func (r *InMemoryProcessRepository) Save(process *domain.Process) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if process.ID == "" {
+        process.ID = domain.NewID()
+    }
+    assignActivityIDs(process)
+
+    r.processes[process.ID] = copyProcess(process)
+    return nil
+}
+
+// FindByID retrieves a process by ID
+func (r *InMemoryProcessRepository) FindByID(id string) (*domain.Process, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    process, ok := r.processes[id]
+    if !ok {
+        return nil, fmt.Errorf("process %s: %w", id, domain.ErrNotFound)
+    }
+    return copyProcess(process), nil
+}
+
+// FindByCategory retrieves a process by its category
+func (r *InMemoryProcessRepository) FindByCategory(category domain.ProcessCategory) (*domain.Process, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    for _, process := range r.processes {
+        if process.Category == category {
+            return copyProcess(process), nil
+        }
+    }
+    return nil, fmt.Errorf("process category %s: %w", category, domain.ErrNotFound)
+}
+
+// FindAll retrieves all processes sorted by their order
+func (r *InMemoryProcessRepository) FindAll() ([]*domain.Process, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    processes := make([]*domain.Process, 0, len(r.processes))
+    for _, process := range r.processes {
+        processes = append(processes, copyProcess(process))
+    }
+    sort.Slice(processes, func(i, j int) bool {
+        if processes[i].Order != processes[j].Order {
+            return processes[i].Order < processes[j].Order
+        }
+        return processes[i].ID < processes[j].ID
+    })
+    return processes, nil
+}
+
+// Update replaces an existing process
+func (r *InMemoryProcessRepository) Update(process *domain.Process) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.processes[process.ID]; !ok {
+        return fmt.Errorf("process %s: %w", process.ID, domain.ErrNotFound)
+    }
+    assignActivityIDs(process)
+
+    r.processes[process.ID] = copyProcess(process)
+    return nil
+}
+
+// Delete removes a process by ID
+func (r *InMemoryProcessRepository) Delete(id string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.processes[id]; !ok {
+        return fmt.Errorf("process %s: %w", id, domain.ErrNotFound)
+    }
+    delete(r.processes, id)
+    return nil
+}
+
+// assignActivityIDs generates IDs for activities that don't have one yet
+func assignActivityIDs(process *domain.Process) {
+    for i := range process.Activities {
+        if process.Activities[i].ID == "" {
+            process.Activities[i].ID = domain.NewID()
+        }
+    }
+}
+
+// copyProcess returns a deep copy so callers can't mutate the stored process
+func copyProcess(process *domain.Process) *domain.Process {
+    cp := *process
+    cp.Activities = make([]domain.Activity, len(process.Activities))
+    for i, activity := range process.Activities {
+        activity.Deliverables = append([]string(nil), activity.Deliverables...)
+        if activity.DeliverableHours != nil {
+            hours := make(map[string]float64, len(activity.DeliverableHours))
+            for deliverable, h := range activity.DeliverableHours {
+                hours[deliverable] = h
+            }
+            activity.DeliverableHours = hours
+        }
+        cp.Activities[i] = activity
+    }
+    return &cp
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+    "errors"
+    "testing"
+
+    "estimate-backend/internal/domain"
+)
+
+func TestProcessRepositoryCRUD(t *testing.T) {
+    repo := NewInMemoryProcessRepository()
+
+    design := &domain.Process{Category: domain.ProcessBasicDesign, Name: "基本設計", Order: 2,
+        Activities: []domain.Activity{{Name: "画面設計", BaseHours: 16}}}
+    requirements := &domain.Process{Category: domain.ProcessRequirementDefinition, Name: "要件定義", Order: 1}
+    for _, process := range []*domain.Process{design, requirements} {
+        if err := repo.Save(process); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if design.ID == "" || design.Activities[0].ID == "" {
+        t.Fatalf("Save left IDs empty: process %q, activity %q", design.ID, design.Activities[0].ID)
+    }
+
+    found, err := repo.FindByID(design.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if found.Name != "基本設計" || found.Activities[0].ID != design.Activities[0].ID {
+        t.Errorf("FindByID = %+v, want the saved process", found)
+    }
+
+    byCategory, err := repo.FindByCategory(domain.ProcessRequirementDefinition)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if byCategory.ID != requirements.ID {
+        t.Errorf("FindByCategory = %s, want %s", byCategory.ID, requirements.ID)
+    }
+
+    all, err := repo.FindAll()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(all) != 2 || all[0].ID != requirements.ID || all[1].ID != design.ID {
+        t.Errorf("FindAll returned %d processes, want both sorted by order", len(all))
+    }
+
+    found.Name = "外部設計"
+    if err := repo.Update(found); err != nil {
+        t.Fatal(err)
+    }
+    if updated, _ := repo.FindByID(design.ID); updated.Name != "外部設計" {
+        t.Errorf("Name = %s after Update, want 外部設計", updated.Name)
+    }
+
+    if err := repo.Delete(design.ID); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := repo.FindByID(design.ID); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindByID after Delete: got %v, want ErrNotFound", err)
+    }
+}
+
+func TestProcessRepositoryNotFound(t *testing.T) {
+    repo := NewInMemoryProcessRepository()
+
+    if _, err := repo.FindByID("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindByID: got %v, want ErrNotFound", err)
+    }
+    if _, err := repo.FindByCategory(domain.ProcessTesting); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("FindByCategory: got %v, want ErrNotFound", err)
+    }
+    if err := repo.Update(&domain.Process{ID: "unknown"}); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("Update: got %v, want ErrNotFound", err)
+    }
+    if err := repo.Delete("unknown"); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("Delete: got %v, want ErrNotFound", err)
+    }
+}
+
+func TestProcessRepositoryReturnsCopies(t *testing.T) {
+    repo := NewInMemoryProcessRepository()
+    process := &domain.Process{Category: domain.ProcessTesting, Activities: []domain.Activity{{Name: "結合テスト", Deliverables: []string{"テスト報告書"}}}}
+    if err := repo.Save(process); err != nil {
+        t.Fatal(err)
+    }
+
+    found, _ := repo.FindByID(process.ID)
+    found.Activities[0].Deliverables[0] = "changed"
+
+    if again, _ := repo.FindByID(process.ID); again.Activities[0].Deliverables[0] != "テスト報告書" {
+        t.Error("mutating a returned process changed the stored one")
+    }
+}
+
+func TestProcessRepositorySaveIDs(t *testing.T) {
+    repo := NewInMemoryProcessRepository()
+    ids := make(map[string]bool)
+    for i := 0; i < 10; i++ {
+        process := &domain.Process{Category: domain.ProcessTesting}
+        if err := repo.Save(process); err != nil {
+            t.Fatal(err)
+        }
+        if process.ID == "" || ids[process.ID] {
+            t.Fatalf("Save assigned ID %q, want a new non-empty ID", process.ID)
+        }
+        ids[process.ID] = true
+    }
+
+    // An existing ID is kept rather than replaced
+    process := &domain.Process{ID: "custom", Category: domain.ProcessTesting}
+    if err := repo.Save(process); err != nil {
+        t.Fatal(err)
+    }
+    if process.ID != "custom" {
+        t.Errorf("ID = %q after Save, want custom", process.ID)
+    }
+}
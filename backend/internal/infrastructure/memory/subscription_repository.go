@@ -0,0 +1,91 @@
+package memory
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+
+    "estimate-backend/internal/domain"
+)
+
+// InMemorySubscriptionRepository is a thread-safe in-memory implementation of domain.SubscriptionRepository
+type InMemorySubscriptionRepository struct {
+    mu            sync.RWMutex
+    subscriptions map[string]*domain.DriftSubscription
+}
+
+// NewInMemorySubscriptionRepository creates a new InMemorySubscriptionRepository
+func NewInMemorySubscriptionRepository() *InMemorySubscriptionRepository {
+    return &InMemorySubscriptionRepository{
+        subscriptions: make(map[string]*domain.DriftSubscription),
+    }
+}
+
+// Save stores a subscription, generating an ID when empty
+func (r *InMemorySubscriptionRepository) Save(subscription *domain.DriftSubscription) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if subscription.ID == "" {
+        subscription.ID = domain.NewID()
+    }
+    cp := *subscription
+    r.subscriptions[subscription.ID] = &cp
+    return nil
+}
+
+// FindByID retrieves a subscription by ID
+func (r *InMemorySubscriptionRepository) FindByID(id string) (*domain.DriftSubscription, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    subscription, ok := r.subscriptions[id]
+    if !ok {
+        return nil, fmt.Errorf("subscription %s: %w", id, domain.ErrNotFound)
+    }
+    cp := *subscription
+    return &cp, nil
+}
+
+// FindByEstimateID retrieves the subscriptions of an estimate
+func (r *InMemorySubscriptionRepository) FindByEstimateID(estimateID string) ([]*domain.DriftSubscription, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    subscriptions := []*domain.DriftSubscription{}
+    for _, subscription := range r.subscriptions {
+        if subscription.EstimateID == estimateID {
+            cp := *subscription
+            subscriptions = append(subscriptions, &cp)
+        }
+    }
+    sort.Slice(subscriptions, func(i, j int) bool {
+        return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+    })
+    return subscriptions, nil
+}
+
+// Update replaces an existing subscription
+func (r *InMemorySubscriptionRepository) Update(subscription *domain.DriftSubscription) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.subscriptions[subscription.ID]; !ok {
+        return fmt.Errorf("subscription %s: %w", subscription.ID, domain.ErrNotFound)
+    }
+    cp := *subscription
+    r.subscriptions[subscription.ID] = &cp
+    return nil
+}
+
+// Delete removes a subscription by ID
+func (r *InMemorySubscriptionRepository) Delete(id string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.subscriptions[id]; !ok {
+        return fmt.Errorf("subscription %s: %w", id, domain.ErrNotFound)
+    }
+    delete(r.subscriptions, id)
+    return nil
+}
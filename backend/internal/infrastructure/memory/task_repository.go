@@ -0,0 +1,114 @@
+package memory
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+
+    "estimate-backend/internal/domain"
+)
+
+// InMemoryTaskRepository is a thread-safe in-memory implementation of domain.TaskRepository
+type InMemoryTaskRepository struct {
+    mu    sync.RWMutex
+    tasks map[string]*domain.Task
+}
+
+// NewInMemoryTaskRepository creates a new InMemoryTaskRepository
+func NewInMemoryTaskRepository() *InMemoryTaskRepository {
+    return &InMemoryTaskRepository{
+        tasks: make(map[string]*domain.Task),
+    }
+}
+
+// Save stores a task, generating an ID when empty
+func (r *InMemoryTaskRepository) Save(task *domain.Task) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if task.ID == "" {
+        task.ID = domain.NewID()
+    }
+    r.tasks[task.ID] = copyTask(task)
+    return nil
+}
+
+// FindByID retrieves a task by ID
+func (r *InMemoryTaskRepository) FindByID(id string) (*domain.Task, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    task, ok := r.tasks[id]
+    if !ok {
+        return nil, fmt.Errorf("task %s: %w", id, domain.ErrNotFound)
+    }
+    return copyTask(task), nil
+}
+
+// FindByProcessID retrieves the tasks of a process, oldest first
+func (r *InMemoryTaskRepository) FindByProcessID(processID string) ([]*domain.Task, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    tasks := []*domain.Task{}
+    for _, task := range r.tasks {
+        if task.ProcessID == processID {
+            tasks = append(tasks, copyTask(task))
+        }
+    }
+    sortTasks(tasks)
+    return tasks, nil
+}
+
+// FindAll retrieves all tasks, oldest first
+func (r *InMemoryTaskRepository) FindAll() ([]*domain.Task, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    tasks := make([]*domain.Task, 0, len(r.tasks))
+    for _, task := range r.tasks {
+        tasks = append(tasks, copyTask(task))
+    }
+    sortTasks(tasks)
+    return tasks, nil
+}
+
+// Update replaces an existing task
+func (r *InMemoryTaskRepository) Update(task *domain.Task) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.tasks[task.ID]; !ok {
+        return fmt.Errorf("task %s: %w", task.ID, domain.ErrNotFound)
+    }
+    r.tasks[task.ID] = copyTask(task)
+    return nil
+}
+
+// Delete removes a task by ID
+func (r *InMemoryTaskRepository) Delete(id string) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if _, ok := r.tasks[id]; !ok {
+        return fmt.Errorf("task %s: %w", id, domain.ErrNotFound)
+    }
+    delete(r.tasks, id)
+    return nil
+}
+
+// sortTasks orders tasks by creation time, then ID
+func sortTasks(tasks []*domain.Task) {
+    sort.Slice(tasks, func(i, j int) bool {
+        if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+            return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+        }
+        return tasks[i].ID < tasks[j].ID
+    })
+}
+
+// copyTask returns a deep copy so callers can't mutate the stored task
+func copyTask(task *domain.Task) *domain.Task {
+    cp := copyTasks([]domain.Task{*task})[0]
+    return &cp
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+    "fmt"
+    "sort"
+    "sync"
+
+    "estimate-backend/internal/domain"
+)
+
+// InMemoryTemplateRepository is a thread-safe in-memory implementation of domain.TemplateRepository
+type InMemoryTemplateRepository struct {
+    mu        sync.RWMutex
+    templates map[string]*domain.EstimateTemplate
+}
+
+// NewInMemoryTemplateRepository creates a new InMemoryTemplateRepository
+func NewInMemoryTemplateRepository() *InMemoryTemplateRepository {
+    return &InMemoryTemplateRepository{
+        templates: make(map[string]*domain.EstimateTemplate),
+    }
+}
+
+// Save stores a template, generating an ID when empty
+func (r *InMemoryTemplateRepository) Save(template *domain.EstimateTemplate) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if template.ID == "" {
+        template.ID = domain.NewID()
+    }
+    r.templates[template.ID] = copyTemplate(template)
+    return nil
+}
+
+// FindByID retrieves a template by ID
+func (r *InMemoryTemplateRepository) FindByID(id string) (*domain.EstimateTemplate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    template, ok := r.templates[id]
+    if !ok {
+        return nil, fmt.Errorf("template %s: %w", id, domain.ErrNotFound)
+    }
+    return copyTemplate(template), nil
+}
+
+// FindAll retrieves all templates ordered by name
+func (r *InMemoryTemplateRepository) FindAll() ([]*domain.EstimateTemplate, error) {
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+
+    templates := make([]*domain.EstimateTemplate, 0, len(r.templates))
+    for _, template := range r.templates {
+        templates = append(templates, copyTemplate(template))
+    }
+    sort.Slice(templates, func(i, j int) bool {
+        if templates[i].Name != templates[j].Name {
+            return templates[i].Name < templates[j].Name
+        }
+        return templates[i].ID < templates[j].ID
+    })
+    return templates, nil
+}
+
+// copyTemplate returns a deep copy so callers can't mutate the stored template
+func copyTemplate(template *domain.EstimateTemplate) *domain.EstimateTemplate {
+    cp := *template
+    cp.Tasks = make([]domain.TemplateTask, len(template.Tasks))
+    for i, task := range template.Tasks {
+        task.CustomFactors = append([]string(nil), task.CustomFactors...)
+        cp.Tasks[i] = task
+    }
+    cp.GlobalFactors = append([]string(nil), template.GlobalFactors...)
+    return &cp
+}
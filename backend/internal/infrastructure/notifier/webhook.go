@@ -0,0 +1,69 @@
+package notifier
+
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net"
+    "net/http"
+    "syscall"
+    "time"
+
+    "estimate-backend/internal/domain"
+)
+
+// webhookTimeout bounds a delivery including connecting, redirects and reading the response
+const webhookTimeout = 5 * time.Second
+
+// errInternalAddress is returned when a webhook host resolves to an internal address
+var errInternalAddress = errors.New("webhook host resolves to an internal address")
+
+// WebhookNotifier delivers drift alerts by POSTing them as JSON to the subscribed URL.
+// It only connects to public addresses, including after DNS resolution and redirects.
+type WebhookNotifier struct {
+    client *http.Client
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier
+func NewWebhookNotifier() *WebhookNotifier {
+    dialer := &net.Dialer{
+        Timeout: webhookTimeout,
+        Control: func(network, address string, _ syscall.RawConn) error {
+            host, _, err := net.SplitHostPort(address)
+            if err != nil {
+                return err
+            }
+            if ip := net.ParseIP(host); ip == nil || !domain.IsPublicIP(ip) {
+                return errInternalAddress
+            }
+            return nil
+        },
+    }
+    return &WebhookNotifier{
+        client: &http.Client{
+            Timeout:   webhookTimeout,
+            Transport: &http.Transport{DialContext: dialer.DialContext}, // No proxy from the environment
+        },
+    }
+}
+
+// Notify sends the alert to the given URL
+func (n *WebhookNotifier) Notify(url string, alert domain.DriftAlert) error {
+    body, err := json.Marshal(alert)
+    if err != nil {
+        return err
+    }
+
+    resp, err := n.client.Post(url, "application/json", bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= 300 {
+        return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+    }
+
+    return nil
+}
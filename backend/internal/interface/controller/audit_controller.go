@@ -0,0 +1,42 @@
+package controller
+
+import (
+    "net/http"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/usecase"
+)
+
+// ActorHeader names the user making a change, recorded in the audit trail
+const ActorHeader = "X-Actor"
+
+// actor returns the user making the request, or an empty string when unknown
+func actor(c echo.Context) string {
+    return c.Request().Header.Get(ActorHeader)
+}
+
+// AuditController handles HTTP requests for the audit trail
+type AuditController struct {
+    auditUseCase *usecase.AuditUseCase
+}
+
+// NewAuditController creates a new AuditController
+func NewAuditController(au *usecase.AuditUseCase) *AuditController {
+    return &AuditController{
+        auditUseCase: au,
+    }
+}
+
+// RegisterRoutes registers the routes for the audit trail
+func (ac *AuditController) RegisterRoutes(e *echo.Echo) {
+    e.GET("/api/audit", ac.GetAuditLogs)
+}
+
+// GetAuditLogs handles GET /api/audit?entityId=
+func (ac *AuditController) GetAuditLogs(c echo.Context) error {
+    entries, err := ac.auditUseCase.GetAuditLogs(c.QueryParam("entityId"))
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, entries)
+}
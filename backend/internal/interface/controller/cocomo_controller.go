@@ -1,11 +1,17 @@
 package controller
 
 import (
+    "encoding/json"
+    "fmt"
     "net/http"
+    "strconv"
+    "sync/atomic"
+    "time"
 
     "github.com/labstack/echo/v4"
     "estimate-backend/internal/usecase"
     "estimate-backend/internal/domain"
+    "estimate-backend/internal/interface/i18n"
 )
 
 // COCOMOController handles HTTP requests for COCOMO II related operations
@@ -25,129 +31,730 @@ func (cc *COCOMOController) RegisterRoutes(e *echo.Echo) {
     e.GET("/api/cocomo/models", cc.GetModels)
     e.GET("/api/cocomo/scale-factors", cc.GetScaleFactors)
     e.GET("/api/cocomo/cost-drivers", cc.GetCostDrivers)
+    e.GET("/api/cocomo/phase-profiles", cc.GetPhaseProfiles)
     e.POST("/api/cocomo/calculate", cc.CalculateEstimate)
+    e.POST("/api/cocomo/calculate/batch", cc.CalculateBatch)
+    e.GET("/api/cocomo/estimates", cc.GetEstimates)
+    e.GET("/api/cocomo/estimates/:id", cc.GetEstimate)
+    e.DELETE("/api/cocomo/estimates/:id", cc.DeleteEstimate)
+    e.GET("/api/cocomo/estimates/:id/tradeoff", cc.TradeoffCurve)
+    e.GET("/api/cocomo/estimates/:id/trace", cc.Trace)
+    e.GET("/api/cocomo/estimates/:id/montecarlo/stream", cc.StreamMonteCarlo)
+    e.POST("/api/cocomo/compare", cc.Compare)
+    e.POST("/api/cocomo/calibrate", cc.Calibrate)
+    e.POST("/api/cocomo/reverse", cc.Reverse)
+    e.POST("/api/cocomo/maintenance", cc.EstimateMaintenance)
+    e.POST("/api/cocomo/incremental", cc.EstimateIncremental)
+    e.POST("/api/cocomo/sensitivity", cc.SensitivityCurve)
+    e.GET("/api/cocomo/:id/presets", cc.GetScenarioPresets)
+}
+
+// OrgHeader is the request header naming the organization of the caller
+const OrgHeader = "X-Org-ID"
+
+// orgID returns the organization of the caller, or an empty string when only the built-in models apply
+func orgID(c echo.Context) string {
+    return c.Request().Header.Get(OrgHeader)
+}
+
+// language returns the language of the messages for the caller, chosen from the Accept-Language header
+func language(c echo.Context) i18n.Language {
+    return i18n.FromAcceptLanguage(c.Request().Header.Get("Accept-Language"))
 }
 
 // GetModels handles GET /api/cocomo/models
 func (cc *COCOMOController) GetModels(c echo.Context) error {
-    // Initialize default models if not exists
-    if err := cc.cocomoUseCase.InitializeDefaultModel(); err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    // Return the built-in models and those of the caller's organization
+    models, err := cc.cocomoUseCase.GetModels(orgID(c))
+    if err != nil {
+        return httpError(err)
     }
-
-    // Return the models (Early Design and Post-Architecture)
     return c.JSON(http.StatusOK, map[string]interface{}{
-        "models": []string{"Early Design", "Post-Architecture"},
+        "models": models,
     })
 }
 
+// RatingLevelResponse represents a rating level of a scale factor or cost driver
+type RatingLevelResponse struct {
+    Level       string  `json:"level"`
+    Rating      float64 `json:"rating"`
+    Description string  `json:"description"`
+    Value       float64 `json:"value"` // Effort multiplier of a cost driver, exponent contribution of a scale factor
+}
+
+// ratingLevelResponses converts the rating levels of a domain rating guide,
+// translating the descriptions by the message keys guideKey returns for each level
+func ratingLevelResponses(levels []domain.RatingLevel, guideKey func(level string) string, lang i18n.Language) []RatingLevelResponse {
+    response := make([]RatingLevelResponse, len(levels))
+    for i, level := range levels {
+        response[i] = RatingLevelResponse{
+            Level:       level.Level,
+            Rating:      level.Rating,
+            Description: lang.TextOr(guideKey(level.Level), level.Description),
+            Value:       level.Value,
+        }
+    }
+    return response
+}
+
+// ScaleFactorResponse represents a stored scale factor; its ID is the key of scaleFactors in calculations
+type ScaleFactorResponse struct {
+    ID          string                 `json:"id"`
+    Type        domain.ScaleFactorType `json:"type"`
+    Name        string                 `json:"name"`
+    Description string                 `json:"description"`
+    Weight      float64                `json:"weight"`
+    RatingGuide []RatingLevelResponse  `json:"ratingGuide"`
+}
+
 // GetScaleFactors handles GET /api/cocomo/scale-factors
 func (cc *COCOMOController) GetScaleFactors(c echo.Context) error {
-    // Initialize default scale factors if not exists
-    if err := cc.cocomoUseCase.InitializeScaleFactors(); err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    factors, err := cc.cocomoUseCase.GetScaleFactors()
+    if err != nil {
+        return httpError(err)
     }
 
-    // Return the scale factors with their descriptions and weight ranges
+    // Return the scale factors with their descriptions, weights and rating guides
+    lang := language(c)
+    response := make([]ScaleFactorResponse, len(factors))
+    for i, sf := range factors {
+        response[i] = ScaleFactorResponse{
+            ID:          sf.ID,
+            Type:        sf.Type,
+            Name:        lang.TextOr(sf.Type.NameKey(), sf.Name),
+            Description: lang.TextOr(sf.Type.DescriptionKey(), sf.Description),
+            Weight:      sf.Weight,
+            RatingGuide: ratingLevelResponses(sf.Type.RatingGuide(), sf.Type.GuideKey, lang),
+        }
+    }
     return c.JSON(http.StatusOK, map[string]interface{}{
-        "scaleFactors": []map[string]interface{}{
-            {
-                "type": domain.ScaleFactorPREC,
-                "name": "先例性",
-                "description": "類似プロジェクトの経験度",
-                "ratingGuide": map[string]string{
-                    "very_low":    "全く新しい開発",
-                    "low":         "大部分が新規",
-                    "nominal":     "類似経験あり",
-                    "high":        "ほぼ同様の開発経験あり",
-                    "very_high":   "ほぼ同一の開発",
-                },
-            },
-            {
-                "type": domain.ScaleFactorFLEX,
-                "name": "開発の柔軟性",
-                "description": "開発プロセスの柔軟性",
-                "ratingGuide": map[string]string{
-                    "very_low":    "厳格な制約あり",
-                    "low":         "一部柔軟性あり",
-                    "nominal":     "ある程度柔軟",
-                    "high":        "大部分が柔軟",
-                    "very_high":   "完全に柔軟",
-                },
-            },
-            // 他のスケールファクターも同様に定義
-        },
+        "scaleFactors": response,
     })
 }
 
+// CostDriverResponse represents a stored cost driver; its ID is the key of costDrivers in calculations
+type CostDriverResponse struct {
+    ID           string                `json:"id"`
+    Type         domain.CostDriverType `json:"type"`
+    Name         string                `json:"name"`
+    Description  string                `json:"description"`
+    RatingValues []float64             `json:"ratingValues"` // Effort multipliers, Very Low to Extra High
+    RatingGuide  []RatingLevelResponse `json:"ratingGuide"`
+}
+
 // GetCostDrivers handles GET /api/cocomo/cost-drivers
 func (cc *COCOMOController) GetCostDrivers(c echo.Context) error {
-    // Initialize default cost drivers if not exists
-    if err := cc.cocomoUseCase.InitializeCostDrivers(); err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    drivers, err := cc.cocomoUseCase.GetCostDrivers()
+    if err != nil {
+        return httpError(err)
     }
 
-    // Return the cost drivers with their descriptions and rating guides
+    // Return the cost drivers with their descriptions, multipliers and rating guides
+    lang := language(c)
+    response := make([]CostDriverResponse, len(drivers))
+    for i, cd := range drivers {
+        response[i] = CostDriverResponse{
+            ID:           cd.ID,
+            Type:         cd.Type,
+            Name:         lang.TextOr(cd.Type.NameKey(), cd.Name),
+            Description:  lang.TextOr(cd.Type.DescriptionKey(), cd.Description),
+            RatingValues: cd.RatingValues,
+            RatingGuide:  ratingLevelResponses(cd.Type.RatingGuide(), cd.Type.GuideKey, lang),
+        }
+    }
     return c.JSON(http.StatusOK, map[string]interface{}{
-        "costDrivers": []map[string]interface{}{
-            {
-                "type": domain.CostDriverRELY,
-                "name": "要求される信頼性",
-                "description": "システム障害による影響の大きさ",
-                "ratingGuide": map[string]string{
-                    "very_low":    "軽微な不便",
-                    "low":         "軽度の損失",
-                    "nominal":     "中程度の損失",
-                    "high":        "大きな損失",
-                    "very_high":   "人命に関わる",
-                },
-            },
-            {
-                "type": domain.CostDriverCPLX,
-                "name": "製品の複雑さ",
-                "description": "制御操作、演算処理、デバイス処理、データ管理、UI管理の複雑さ",
-                "ratingGuide": map[string]string{
-                    "very_low":    "単純な処理",
-                    "low":         "やや複雑",
-                    "nominal":     "中程度",
-                    "high":        "複雑",
-                    "very_high":   "非常に複雑",
-                },
-            },
-            // 他のコストドライバーも同様に定義
-        },
+        "costDrivers": response,
     })
 }
 
-// CalculateEstimateRequest represents the request body for COCOMO II calculation
+// COCOMO81Request represents the parameters of an original COCOMO (1981) calculation
+type COCOMO81Request struct {
+    Level            string             `json:"level" validate:"oneof=basic intermediate detailed"`
+    Mode             string             `json:"mode" validate:"oneof=organic semidetached embedded"`
+    CostDrivers      map[string]string  `json:"costDrivers,omitempty"`      // e.g. "RELY": "high"
+    PhaseMultipliers map[string]float64 `json:"phaseMultipliers,omitempty"` // Detailed COCOMO only
+}
+
+// CalculateEstimateRequest represents the request body for COCOMO II calculation.
+// Setting method to cocomo81 calculates with the original COCOMO model instead, using ksloc as KDSI.
 type CalculateEstimateRequest struct {
+    Method        string             `json:"method,omitempty" validate:"omitempty,oneof=cocomo2 cocomo81"`
+    COCOMO81      *COCOMO81Request   `json:"cocomo81,omitempty"`
     ModelID       string             `json:"modelId"`
-    KSLOC        float64            `json:"ksloc"`
+    KSLOC        float64            `json:"ksloc" validate:"gt=0"`
     ScaleFactors map[string]float64 `json:"scaleFactors"`
     CostDrivers  map[string]float64 `json:"costDrivers"`
+    ReuseComponents []domain.ReuseComponent `json:"reuseComponents,omitempty"`
+    REVL         float64            `json:"revl,omitempty" validate:"min=0"`
+    TargetDuration float64          `json:"targetDuration,omitempty" validate:"min=0"` // Months; warns when below the schedule floor
+    PlannedTeamSize float64         `json:"plannedTeamSize,omitempty" validate:"min=0"` // Adds communication overhead above the configured threshold
+    MonteCarloIterations int            `json:"monteCarloIterations,omitempty"`
+    MonteCarloSeed       int64          `json:"monteCarloSeed,omitempty"`
+    HourlyRate   float64            `json:"hourlyRate,omitempty" validate:"min=0"` // Flat rate, and the rate of phases missing from phaseRates
+    Currency     string             `json:"currency,omitempty"`
+    PhaseRates   map[string]float64 `json:"phaseRates,omitempty"` // Phase code -> hourly rate
+    OverheadRate float64            `json:"overheadRate,omitempty" validate:"min=0"` // e.g. 0.3 for 1.3x
+    TaxRate      float64            `json:"taxRate,omitempty" validate:"min=0"`      // e.g. 0.1 for 10%
+    RoleMix      domain.RoleMix     `json:"roleMix,omitempty"` // Phase code -> role -> share, overriding the default split
+    PhaseProfile string             `json:"phaseProfile,omitempty"` // Built-in profile name, waterfall by default
+    PhaseShares  []PhaseShareRequest `json:"phaseShares,omitempty"` // Custom profile, replacing phaseProfile
 }
 
-// CalculateEstimate handles POST /api/cocomo/calculate
-func (cc *COCOMOController) CalculateEstimate(c echo.Context) error {
-    var req CalculateEstimateRequest
-    if err := c.Bind(&req); err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+// PhaseShareRequest represents the share of a phase in a custom phase profile
+type PhaseShareRequest struct {
+    Code     string  `json:"code" validate:"required"`
+    Effort   float64 `json:"effort" validate:"min=0"`
+    Duration float64 `json:"duration" validate:"min=0"`
+}
+
+// phaseProfile resolves the phase profile of the request
+func (req CalculateEstimateRequest) phaseProfile() (domain.PhaseProfile, error) {
+    if len(req.PhaseShares) > 0 {
+        profile := domain.PhaseProfile{Name: "custom"}
+        for _, share := range req.PhaseShares {
+            profile.Phases = append(profile.Phases, domain.PhaseShare{
+                Code:     share.Code,
+                Effort:   share.Effort,
+                Duration: share.Duration,
+            })
+        }
+        return profile, nil
     }
+    if req.PhaseProfile == "" {
+        return domain.DefaultPhaseProfile(), nil
+    }
+    profile, ok := domain.PhaseProfileByName(req.PhaseProfile)
+    if !ok {
+        return profile, ValidationErrors{{Field: "phaseProfile", Message: "unknown phase profile " + req.PhaseProfile}}
+    }
+    return profile, nil
+}
 
-    input := usecase.CreateEstimateInput{
+// estimateInput converts the COCOMO II parameters of the request into use case input
+func (req CalculateEstimateRequest) estimateInput(ownerID string) usecase.CreateEstimateInput {
+    return usecase.CreateEstimateInput{
         ModelID:      req.ModelID,
+        OwnerID:      ownerID,
         ProjectSize:  req.KSLOC,
         ScaleFactors: req.ScaleFactors,
         CostDrivers:  req.CostDrivers,
+        ReuseComponents: req.ReuseComponents,
+        REVL:         req.REVL,
+        TargetDurationTM: req.TargetDuration,
+        PlannedTeamSize:  req.PlannedTeamSize,
+    }
+}
+
+// rateCard returns the rate card of the request
+func (req CalculateEstimateRequest) rateCard() domain.RateCard {
+    return domain.RateCard{
+        Currency:    req.Currency,
+        DefaultRate: req.HourlyRate,
+        PhaseRates:  req.PhaseRates,
+        OverheadRate: req.OverheadRate,
+        TaxRate:      req.TaxRate,
+    }
+}
+
+// GetPhaseProfiles handles GET /api/cocomo/phase-profiles
+func (cc *COCOMOController) GetPhaseProfiles(c echo.Context) error {
+    return c.JSON(http.StatusOK, domain.PhaseProfiles())
+}
+
+// CalculateEstimate handles POST /api/cocomo/calculate
+func (cc *COCOMOController) CalculateEstimate(c echo.Context) error {
+    var req CalculateEstimateRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+    if req.Method == "cocomo81" {
+        return cc.calculateCOCOMO81(c, req)
+    }
+    if req.ModelID == "" {
+        return ValidationErrors{{Field: "modelId", Message: "is required"}}
+    }
+    if req.MonteCarloIterations < 0 || req.MonteCarloIterations > domain.MaxMonteCarloIterations {
+        return echo.NewHTTPError(http.StatusBadRequest, "monteCarloIterations is out of range")
+    }
+
+    phases, err := req.phaseProfile()
+    if err != nil {
+        return err
     }
 
-    estimate, err := cc.cocomoUseCase.CreateEstimate(input)
+    estimate, err := cc.cocomoUseCase.CreateEstimate(req.estimateInput(orgID(c)))
     if err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+        return httpError(err)
     }
 
     // Generate detailed result with cost calculation
-    detailedResult := estimate.GenerateDetailedResult(0) // hourlyRate = 0 for now
+    detailedResult, err := cc.cocomoUseCase.DetailedResult(estimate, req.rateCard(), req.RoleMix, phases)
+    if err != nil {
+        return httpError(err)
+    }
+
+    // Replace the fixed ranges with simulated percentiles if requested
+    if req.MonteCarloIterations > 0 {
+        detailedResult.ApplyMonteCarlo(estimate.RunMonteCarlo(req.MonteCarloIterations, req.MonteCarloSeed))
+    }
 
+    language(c).LocalizeDetailedResult(detailedResult)
     return c.JSON(http.StatusOK, detailedResult)
+}
+
+// BatchCalculateResponse represents the detailed results of a batch, in request order, and their roll-up
+type BatchCalculateResponse struct {
+    Results []*domain.COCOMODetailedResult `json:"results"`
+    Summary *domain.PortfolioSummary       `json:"summary"`
+}
+
+// CalculateBatch handles POST /api/cocomo/calculate/batch for the candidate projects of a portfolio.
+// Every item is calculated like POST /api/cocomo/calculate, but not stored. The batch is all or nothing:
+// invalid items fail it with 400 listing their fields by index, and the first item failing to calculate
+// (e.g. an unknown model) fails it with that item's status and index; no results are returned then.
+func (cc *COCOMOController) CalculateBatch(c echo.Context) error {
+    var reqs []CalculateEstimateRequest
+    if err := json.NewDecoder(c.Request().Body).Decode(&reqs); err != nil {
+        return bindError(err)
+    }
+    if len(reqs) == 0 {
+        return ValidationErrors{{Message: "at least one estimate is required"}}
+    }
+
+    var ve ValidationErrors
+    inputs := make([]usecase.DetailedInput, len(reqs))
+    for i, req := range reqs {
+        prefix := fmt.Sprintf("[%d].", i)
+        if err := c.Validate(&req); err != nil {
+            if itemErrors, ok := err.(ValidationErrors); ok {
+                for _, fe := range itemErrors {
+                    ve = append(ve, FieldError{Field: prefix + fe.Field, Message: fe.Message})
+                }
+                continue
+            }
+            return err
+        }
+        if req.Method == "cocomo81" {
+            ve = append(ve, FieldError{Field: prefix + "method", Message: "only COCOMO II estimates can be calculated in a batch"})
+            continue
+        }
+        if req.ModelID == "" {
+            ve = append(ve, FieldError{Field: prefix + "modelId", Message: "is required"})
+        }
+        if req.MonteCarloIterations < 0 || req.MonteCarloIterations > domain.MaxMonteCarloIterations {
+            ve = append(ve, FieldError{Field: prefix + "monteCarloIterations", Message: "is out of range"})
+        }
+        phases, err := req.phaseProfile()
+        if err != nil {
+            ve = append(ve, FieldError{Field: prefix + "phaseProfile", Message: "unknown phase profile " + req.PhaseProfile})
+        }
+        inputs[i] = usecase.DetailedInput{
+            Estimate:             req.estimateInput(orgID(c)),
+            Rates:                req.rateCard(),
+            Roles:                req.RoleMix,
+            Phases:               phases,
+            MonteCarloIterations: req.MonteCarloIterations,
+            MonteCarloSeed:       req.MonteCarloSeed,
+        }
+    }
+    if len(ve) > 0 {
+        return ve
+    }
+
+    batch, err := cc.cocomoUseCase.CalculateBatch(inputs)
+    if err != nil {
+        return httpError(err)
+    }
+
+    lang := language(c)
+    for _, result := range batch.Results {
+        lang.LocalizeDetailedResult(result)
+    }
+    return c.JSON(http.StatusOK, BatchCalculateResponse{Results: batch.Results, Summary: batch.Summary})
+}
+
+// GetEstimates handles GET /api/cocomo/estimates
+func (cc *COCOMOController) GetEstimates(c echo.Context) error {
+    estimates, err := cc.cocomoUseCase.GetEstimates()
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, map[string]interface{}{
+        "estimates": estimates,
+    })
+}
+
+// GetEstimate handles GET /api/cocomo/estimates/:id
+func (cc *COCOMOController) GetEstimate(c echo.Context) error {
+    estimate, err := cc.cocomoUseCase.GetEstimate(c.Param("id"))
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, estimate)
+}
+
+// DeleteEstimate handles DELETE /api/cocomo/estimates/:id
+func (cc *COCOMOController) DeleteEstimate(c echo.Context) error {
+    if err := cc.cocomoUseCase.DeleteEstimate(c.Param("id")); err != nil {
+        return httpError(err)
+    }
+    return c.NoContent(http.StatusNoContent)
+}
+
+// CompareRequest represents the request body for comparing two COCOMO II parameter sets.
+// Only the COCOMO II parameters of each side are used; costs, phases and simulation are ignored.
+type CompareRequest struct {
+    Estimate1 CalculateEstimateRequest `json:"estimate1"`
+    Estimate2 CalculateEstimateRequest `json:"estimate2"`
+}
+
+// Compare handles POST /api/cocomo/compare
+func (cc *COCOMOController) Compare(c echo.Context) error {
+    var req CompareRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+    var ve ValidationErrors
+    for i, side := range []CalculateEstimateRequest{req.Estimate1, req.Estimate2} {
+        field := fmt.Sprintf("estimate%d", i+1)
+        if side.Method == "cocomo81" {
+            ve = append(ve, FieldError{Field: field + ".method", Message: "only COCOMO II estimates can be compared"})
+        } else if side.ModelID == "" {
+            ve = append(ve, FieldError{Field: field + ".modelId", Message: "is required"})
+        }
+    }
+    if len(ve) > 0 {
+        return ve
+    }
+
+    comparison, err := cc.cocomoUseCase.Compare(req.Estimate1.estimateInput(orgID(c)), req.Estimate2.estimateInput(orgID(c)))
+    if err != nil {
+        return httpError(err)
+    }
+
+    language(c).LocalizeCOCOMOComparison(comparison)
+    return c.JSON(http.StatusOK, comparison)
+}
+
+// calculateCOCOMO81 handles POST /api/cocomo/calculate with method cocomo81
+func (cc *COCOMOController) calculateCOCOMO81(c echo.Context, req CalculateEstimateRequest) error {
+    if req.COCOMO81 == nil {
+        return ValidationErrors{{Field: "cocomo81", Message: "is required"}}
+    }
+
+    estimate, err := cc.cocomoUseCase.EstimateCOCOMO81(usecase.COCOMO81Input{
+        Level:            domain.COCOMO81Level(req.COCOMO81.Level),
+        Mode:             domain.COCOMO81Mode(req.COCOMO81.Mode),
+        KDSI:             req.KSLOC,
+        CostDrivers:      req.COCOMO81.CostDrivers,
+        PhaseMultipliers: req.COCOMO81.PhaseMultipliers,
+    })
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, estimate)
+}
+
+// CalibrationPointRequest represents a completed project used for calibration
+type CalibrationPointRequest struct {
+    Size         float64 `json:"size" validate:"gt=0"`         // KSLOC
+    ActualEffort float64 `json:"actualEffort" validate:"gt=0"` // Person-months
+}
+
+// CalibrateRequest represents the request body for calibrating a COCOMO II model
+type CalibrateRequest struct {
+    Points      []CalibrationPointRequest `json:"points" validate:"min=2"`
+    ScaleFactors map[string]float64       `json:"scaleFactors"` // Ratings the completed projects shared
+    Save        bool                      `json:"save"` // Save the fitted coefficients as a new model
+    Name        string                    `json:"name"`
+    Description string                    `json:"description"`
+}
+
+// Calibrate handles POST /api/cocomo/calibrate
+func (cc *COCOMOController) Calibrate(c echo.Context) error {
+    var req CalibrateRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    input := usecase.CalibrateInput{
+        ScaleFactors: req.ScaleFactors,
+        Save:        req.Save,
+        OwnerID:     orgID(c),
+        Name:        req.Name,
+        Description: req.Description,
+    }
+    for _, p := range req.Points {
+        input.Points = append(input.Points, domain.CalibrationPoint{Size: p.Size, ActualEffort: p.ActualEffort})
+    }
+
+    result, err := cc.cocomoUseCase.Calibrate(input)
+    if err != nil {
+        return httpError(err)
+    }
+
+    status := http.StatusOK
+    if result.Model != nil {
+        status = http.StatusCreated
+    }
+    return c.JSON(status, result)
+}
+
+// MaintenanceRequest represents the request body for a COCOMO II maintenance estimate
+type MaintenanceRequest struct {
+    ModelID             string             `json:"modelId" validate:"required"`
+    BaseKSLOC           float64            `json:"baseKsloc" validate:"gt=0"`
+    AnnualChangeTraffic float64            `json:"annualChangeTraffic" validate:"min=0,max=1"` // (added + modified) / total size per year
+    ScaleFactors        map[string]float64 `json:"scaleFactors"`
+    CostDrivers         map[string]float64 `json:"costDrivers"`
+}
+
+// EstimateMaintenance handles POST /api/cocomo/maintenance
+func (cc *COCOMOController) EstimateMaintenance(c echo.Context) error {
+    var req MaintenanceRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    estimate, err := cc.cocomoUseCase.EstimateMaintenance(usecase.MaintenanceInput{
+        ModelID:             req.ModelID,
+        OwnerID:             orgID(c),
+        BaseSize:            req.BaseKSLOC,
+        AnnualChangeTraffic: req.AnnualChangeTraffic,
+        ScaleFactors:        req.ScaleFactors,
+        CostDrivers:         req.CostDrivers,
+    })
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, estimate)
+}
+
+// IncrementalRequest represents the request body for a COCOMO II incremental development estimate
+type IncrementalRequest struct {
+    ModelID      string                 `json:"modelId" validate:"required"`
+    Increments   []float64              `json:"increments" validate:"min=1"` // KSLOC per increment
+    Adaptation   *domain.ReuseComponent `json:"adaptation,omitempty"`
+    ScaleFactors map[string]float64     `json:"scaleFactors"`
+    CostDrivers  map[string]float64     `json:"costDrivers"`
+}
+
+// EstimateIncremental handles POST /api/cocomo/incremental
+func (cc *COCOMOController) EstimateIncremental(c echo.Context) error {
+    var req IncrementalRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    estimate, err := cc.cocomoUseCase.EstimateIncremental(usecase.IncrementalInput{
+        ModelID:      req.ModelID,
+        OwnerID:      orgID(c),
+        Increments:   req.Increments,
+        Adaptation:   req.Adaptation,
+        ScaleFactors: req.ScaleFactors,
+        CostDrivers:  req.CostDrivers,
+    })
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, estimate)
+}
+
+// ReverseRequest represents the request body for solving the achievable size from a deadline
+type ReverseRequest struct {
+    ModelID        string             `json:"modelId" validate:"required"`
+    TargetDuration float64            `json:"targetDuration" validate:"gt=0"` // Months
+    TeamSize       float64            `json:"teamSize" validate:"gt=0"`       // Average staff
+    ScaleFactors   map[string]float64 `json:"scaleFactors"`
+    CostDrivers    map[string]float64 `json:"costDrivers"`
+    ReuseComponents []domain.ReuseComponent `json:"reuseComponents,omitempty"`
+    REVL           float64            `json:"revl,omitempty" validate:"min=0"`
+}
+
+// Reverse handles POST /api/cocomo/reverse.
+// It solves for the size (KSLOC) that the team can deliver by the target duration.
+func (cc *COCOMOController) Reverse(c echo.Context) error {
+    var req ReverseRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    reverse, err := cc.cocomoUseCase.Reverse(usecase.ReverseInput{
+        ModelID:          req.ModelID,
+        OwnerID:          orgID(c),
+        TargetDurationTM: req.TargetDuration,
+        TeamSize:         req.TeamSize,
+        ScaleFactors:     req.ScaleFactors,
+        CostDrivers:      req.CostDrivers,
+        ReuseComponents:  req.ReuseComponents,
+        REVL:             req.REVL,
+    })
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, reverse)
+}
+
+// SensitivityRequest represents the request body for a sensitivity curve
+type SensitivityRequest struct {
+    EstimateID string `json:"estimateId" validate:"required"`
+    FactorID   string `json:"factorId" validate:"required"` // Scale factor or cost driver ID
+}
+
+// SensitivityCurve handles POST /api/cocomo/sensitivity
+func (cc *COCOMOController) SensitivityCurve(c echo.Context) error {
+    var req SensitivityRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    curve, err := cc.cocomoUseCase.SensitivityCurve(req.EstimateID, req.FactorID)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, curve)
+}
+
+// Trace handles GET /api/cocomo/estimates/:id/trace
+func (cc *COCOMOController) Trace(c echo.Context) error {
+    trace, err := cc.cocomoUseCase.Trace(c.Param("id"))
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, trace)
+}
+
+// TradeoffCurve handles GET /api/cocomo/estimates/:id/tradeoff
+func (cc *COCOMOController) TradeoffCurve(c echo.Context) error {
+    curve, err := cc.cocomoUseCase.TradeoffCurve(c.Param("id"))
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, curve)
+}
+
+// monteCarloProgressInterval is how often a Monte Carlo stream reports its progress
+const monteCarloProgressInterval = 250 * time.Millisecond
+
+// defaultStreamIterations is the number of iterations of a Monte Carlo stream without an iterations parameter
+const defaultStreamIterations = 100000
+
+// MonteCarloProgress represents a progress event of a Monte Carlo stream
+type MonteCarloProgress struct {
+    Completed  int     `json:"completed"`
+    Iterations int     `json:"iterations"`
+    Percent    float64 `json:"percent"`
+}
+
+// StreamMonteCarlo handles GET /api/cocomo/estimates/:id/montecarlo/stream?iterations=&seed=.
+// It simulates the stored estimate, sending Server-Sent Events: progress events while it runs and
+// a final result event with the percentiles, or an error event. The simulation stops when the client disconnects.
+func (cc *COCOMOController) StreamMonteCarlo(c echo.Context) error {
+    iterations := defaultStreamIterations
+    if value := c.QueryParam("iterations"); value != "" {
+        n, err := strconv.Atoi(value)
+        if err != nil || n < 1 || n > domain.MaxMonteCarloIterations {
+            return ValidationErrors{{Field: "iterations", Message: fmt.Sprintf("must be between 1 and %d", domain.MaxMonteCarloIterations)}}
+        }
+        iterations = n
+    }
+    var seed int64
+    if value := c.QueryParam("seed"); value != "" {
+        n, err := strconv.ParseInt(value, 10, 64)
+        if err != nil {
+            return ValidationErrors{{Field: "seed", Message: "must be an integer"}}
+        }
+        seed = n
+    }
+    // Fail with a plain error response before the stream starts
+    if _, err := cc.cocomoUseCase.GetEstimate(c.Param("id")); err != nil {
+        return httpError(err)
+    }
+
+    type outcome struct {
+        result *domain.MonteCarloResult
+        err    error
+    }
+    var completed atomic.Int64
+    done := make(chan outcome, 1)
+    go func() {
+        result, err := cc.cocomoUseCase.RunMonteCarlo(c.Request().Context(), c.Param("id"), iterations, seed, func(n int) {
+            completed.Store(int64(n))
+        })
+        done <- outcome{result, err}
+    }()
+
+    res := c.Response()
+    res.Header().Set(echo.HeaderContentType, "text/event-stream")
+    res.Header().Set(echo.HeaderCacheControl, "no-cache")
+    res.Header().Set(echo.HeaderConnection, "keep-alive")
+    res.WriteHeader(http.StatusOK)
+
+    sent := -1
+    sendProgress := func(n int) error {
+        if n == sent {
+            return nil
+        }
+        sent = n
+        return writeEvent(res, "progress", MonteCarloProgress{
+            Completed:  n,
+            Iterations: iterations,
+            Percent:    float64(n) / float64(iterations) * 100,
+        })
+    }
+
+    ticker := time.NewTicker(monteCarloProgressInterval)
+    defer ticker.Stop()
+    for {
+        select {
+        case <-ticker.C:
+            if err := sendProgress(int(completed.Load())); err != nil {
+                return nil
+            }
+        case o := <-done:
+            if o.err != nil {
+                if c.Request().Context().Err() != nil {
+                    return nil // The client went away
+                }
+                writeEvent(res, "error", map[string]string{"message": o.err.Error()})
+                return nil
+            }
+            sendProgress(iterations)
+            writeEvent(res, "result", o.result)
+            return nil
+        }
+    }
+}
+
+// writeEvent writes a Server-Sent Event with JSON data and flushes it to the client
+func writeEvent(res *echo.Response, event string, data interface{}) error {
+    payload, err := json.Marshal(data)
+    if err != nil {
+        return err
+    }
+    if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+        return err
+    }
+    res.Flush()
+    return nil
+}
+
+// GetScenarioPresets handles GET /api/cocomo/:id/presets
+func (cc *COCOMOController) GetScenarioPresets(c echo.Context) error {
+    id := c.Param("id")
+    hourlyRate, _ := strconv.ParseFloat(c.QueryParam("hourlyRate"), 64)
+
+    comparison, err := cc.cocomoUseCase.ScenarioPresets(id, hourlyRate)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, comparison)
 }
\ No newline at end of file
@@ -0,0 +1,446 @@
+package controller
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "math"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/usecase"
+)
+
+// nominalCOCOMORequest returns a Post-Architecture request of the given size rated Nominal throughout
+func nominalCOCOMORequest(ksloc float64) CalculateEstimateRequest {
+    req := CalculateEstimateRequest{
+        ModelID:      domain.ModelPostArchitectureID,
+        KSLOC:        ksloc,
+        ScaleFactors: make(map[string]float64),
+        CostDrivers:  make(map[string]float64),
+    }
+    for _, t := range domain.ScaleFactorTypes {
+        req.ScaleFactors[string(t)] = 2
+    }
+    for _, t := range domain.CostDriverTypes {
+        req.CostDrivers[string(t)] = 2
+    }
+    return req
+}
+
+func TestCalculateEstimateStatus(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodPost, "/api/cocomo/calculate", nominalCOCOMORequest(50))
+    assertStatus(t, rec, http.StatusOK)
+
+    rec = s.request(http.MethodPost, "/api/cocomo/calculate", nominalCOCOMORequest(0))
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors ValidationErrors `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "ksloc" {
+        t.Errorf("errors = %v, want one naming ksloc", body.Errors)
+    }
+
+    unknown := nominalCOCOMORequest(50)
+    unknown.ModelID = "unknown"
+    rec = s.request(http.MethodPost, "/api/cocomo/calculate", unknown)
+    assertStatus(t, rec, http.StatusNotFound)
+
+    outOfRange := nominalCOCOMORequest(50)
+    outOfRange.CostDrivers[string(domain.CostDriverRELY)] = 99
+    rec = s.request(http.MethodPost, "/api/cocomo/calculate", outOfRange)
+    assertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestEstimateMaintenanceValidation(t *testing.T) {
+    s := newTestServer(t)
+    nominal := nominalCOCOMORequest(0)
+    valid := MaintenanceRequest{
+        ModelID:             domain.ModelPostArchitectureID,
+        BaseKSLOC:           100,
+        AnnualChangeTraffic: 0.15,
+        ScaleFactors:        nominal.ScaleFactors,
+        CostDrivers:         nominal.CostDrivers,
+    }
+
+    rec := s.request(http.MethodPost, "/api/cocomo/maintenance", valid)
+    assertStatus(t, rec, http.StatusOK)
+
+    tests := []struct {
+        field  string
+        modify func(*MaintenanceRequest)
+    }{
+        {"modelId", func(r *MaintenanceRequest) { r.ModelID = "" }},
+        {"baseKsloc", func(r *MaintenanceRequest) { r.BaseKSLOC = 0 }},
+        {"annualChangeTraffic", func(r *MaintenanceRequest) { r.AnnualChangeTraffic = 1.5 }},
+    }
+    for _, tt := range tests {
+        req := valid
+        tt.modify(&req)
+        rec := s.request(http.MethodPost, "/api/cocomo/maintenance", req)
+        assertStatus(t, rec, http.StatusBadRequest)
+        var body struct {
+            Errors ValidationErrors `json:"errors"`
+        }
+        decode(t, rec, &body)
+        if len(body.Errors) != 1 || body.Errors[0].Field != tt.field {
+            t.Errorf("errors = %v, want one naming %s", body.Errors, tt.field)
+        }
+    }
+}
+
+func TestEstimateIncrementalValidation(t *testing.T) {
+    s := newTestServer(t)
+    nominal := nominalCOCOMORequest(0)
+    valid := IncrementalRequest{
+        ModelID:      domain.ModelPostArchitectureID,
+        Increments:   []float64{30, 30, 30},
+        ScaleFactors: nominal.ScaleFactors,
+        CostDrivers:  nominal.CostDrivers,
+    }
+
+    rec := s.request(http.MethodPost, "/api/cocomo/incremental", valid)
+    assertStatus(t, rec, http.StatusOK)
+
+    empty := valid
+    empty.Increments = nil
+    rec = s.request(http.MethodPost, "/api/cocomo/incremental", empty)
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors ValidationErrors `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "increments" {
+        t.Errorf("errors = %v, want one naming increments", body.Errors)
+    }
+
+    // A non-positive increment is rejected by the use case
+    negative := valid
+    negative.Increments = []float64{30, -1}
+    rec = s.request(http.MethodPost, "/api/cocomo/incremental", negative)
+    assertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestSensitivityCurveRequiresFactor(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodPost, "/api/cocomo/sensitivity", SensitivityRequest{EstimateID: "estimate-1"})
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors ValidationErrors `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "factorId" {
+        t.Errorf("errors = %v, want one naming factorId", body.Errors)
+    }
+}
+
+func TestGetScaleFactorsListsAllFactors(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodGet, "/api/cocomo/scale-factors", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var body struct {
+        ScaleFactors []ScaleFactorResponse `json:"scaleFactors"`
+    }
+    decode(t, rec, &body)
+
+    if len(body.ScaleFactors) != len(domain.ScaleFactorTypes) {
+        t.Fatalf("got %d scale factors, want %d", len(body.ScaleFactors), len(domain.ScaleFactorTypes))
+    }
+    listed := make(map[domain.ScaleFactorType]bool)
+    for _, sf := range body.ScaleFactors {
+        listed[sf.Type] = true
+        if sf.Weight <= 0 {
+            t.Errorf("scale factor %s has weight %v, want a positive weight", sf.Type, sf.Weight)
+        }
+    }
+    for _, ty := range domain.ScaleFactorTypes {
+        if !listed[ty] {
+            t.Errorf("scale factor %s missing", ty)
+        }
+    }
+}
+
+func TestGetCostDriversListsAllDrivers(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodGet, "/api/cocomo/cost-drivers", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var body struct {
+        CostDrivers []CostDriverResponse `json:"costDrivers"`
+    }
+    decode(t, rec, &body)
+
+    if len(body.CostDrivers) != len(domain.CostDriverTypes) {
+        t.Fatalf("got %d cost drivers, want %d", len(body.CostDrivers), len(domain.CostDriverTypes))
+    }
+    listed := make(map[domain.CostDriverType]bool)
+    for _, cd := range body.CostDrivers {
+        listed[cd.Type] = true
+        if len(cd.RatingValues) < 3 || cd.RatingValues[2] != 1.0 {
+            t.Errorf("cost driver %s has multipliers %v, want Nominal at 1.0", cd.Type, cd.RatingValues)
+        }
+    }
+    for _, ty := range domain.CostDriverTypes {
+        if !listed[ty] {
+            t.Errorf("cost driver %s missing", ty)
+        }
+    }
+}
+
+func TestCOCOMOEstimateRetrieval(t *testing.T) {
+    s := newTestServer(t)
+    saved := s.saveCOCOMOEstimate(t, 30)
+
+    rec := s.request(http.MethodGet, "/api/cocomo/estimates/"+saved.ID, nil)
+    assertStatus(t, rec, http.StatusOK)
+    var estimate domain.COCOMOEstimate
+    decode(t, rec, &estimate)
+    if estimate.ID != saved.ID || estimate.EffortPM != saved.EffortPM {
+        t.Errorf("got estimate %s with %v PM, want %s with %v PM", estimate.ID, estimate.EffortPM, saved.ID, saved.EffortPM)
+    }
+
+    rec = s.request(http.MethodGet, "/api/cocomo/estimates", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var list struct {
+        Estimates []domain.COCOMOEstimate `json:"estimates"`
+    }
+    decode(t, rec, &list)
+    if len(list.Estimates) != 1 || list.Estimates[0].ID != saved.ID {
+        t.Errorf("listed %v, want only %s", list.Estimates, saved.ID)
+    }
+
+    rec = s.request(http.MethodDelete, "/api/cocomo/estimates/"+saved.ID, nil)
+    assertStatus(t, rec, http.StatusNoContent)
+    for _, method := range []string{http.MethodGet, http.MethodDelete} {
+        rec = s.request(method, "/api/cocomo/estimates/"+saved.ID, nil)
+        assertStatus(t, rec, http.StatusNotFound)
+    }
+}
+
+func TestCOCOMOEstimateNotFound(t *testing.T) {
+    s := newTestServer(t)
+
+    for _, path := range []string{"/api/cocomo/estimates/missing", "/api/cocomo/estimates/missing/trace"} {
+        rec := s.request(http.MethodGet, path, nil)
+        assertStatus(t, rec, http.StatusNotFound)
+    }
+}
+
+func TestCalculateEstimateIsLocalized(t *testing.T) {
+    s := newTestServer(t)
+    req := nominalCOCOMORequest(50)
+    req.CostDrivers[string(domain.CostDriverCPLX)] = 5
+    req.CostDrivers[string(domain.CostDriverACAP)] = 0
+
+    results := make(map[string]domain.COCOMODetailedResult)
+    for _, lang := range []string{"en", "ja"} {
+        rec := s.requestWithHeader(http.MethodPost, "/api/cocomo/calculate", req, http.Header{"Accept-Language": {lang}})
+        assertStatus(t, rec, http.StatusOK)
+        var result domain.COCOMODetailedResult
+        decode(t, rec, &result)
+        if len(result.RiskFactors) == 0 {
+            t.Fatalf("%s: no risk factors to compare", lang)
+        }
+        results[lang] = result
+    }
+
+    en, ja := results["en"], results["ja"]
+    if en.AdjustedEffort != ja.AdjustedEffort {
+        t.Errorf("effort %v in English and %v in Japanese, want the same estimate", en.AdjustedEffort, ja.AdjustedEffort)
+    }
+    for i := range en.RiskFactors {
+        enRisk, jaRisk := en.RiskFactors[i], ja.RiskFactors[i]
+        if enRisk.Name == jaRisk.Name || enRisk.Description == jaRisk.Description || enRisk.Mitigation == jaRisk.Mitigation {
+            t.Errorf("risk %d rendered the same in both languages: %+v", i, enRisk)
+        }
+        if strings.Contains(enRisk.Description, "risk.") || strings.Contains(jaRisk.Description, "risk.") {
+            t.Errorf("risk %d left a message key unresolved: %q / %q", i, enRisk.Description, jaRisk.Description)
+        }
+    }
+    if en.CostDriverAnalysis[0].Name == ja.CostDriverAnalysis[0].Name {
+        t.Errorf("cost driver name %q rendered the same in both languages", en.CostDriverAnalysis[0].Name)
+    }
+}
+
+func TestCalculateBatch(t *testing.T) {
+    s := newTestServer(t)
+    sizes := []float64{100, 20, 50}
+    reqs := make([]CalculateEstimateRequest, len(sizes))
+    for i, size := range sizes {
+        reqs[i] = nominalCOCOMORequest(size)
+    }
+
+    rec := s.request(http.MethodPost, "/api/cocomo/calculate/batch", reqs)
+    assertStatus(t, rec, http.StatusOK)
+    var batch BatchCalculateResponse
+    decode(t, rec, &batch)
+    if len(batch.Results) != len(sizes) {
+        t.Fatalf("got %d results, want %d", len(batch.Results), len(sizes))
+    }
+
+    var effort float64
+    for i, result := range batch.Results {
+        rec := s.request(http.MethodPost, "/api/cocomo/calculate", reqs[i])
+        assertStatus(t, rec, http.StatusOK)
+        var single domain.COCOMODetailedResult
+        decode(t, rec, &single)
+        if result.AdjustedEffort != single.AdjustedEffort {
+            t.Errorf("result %d: effort %v, want the %v of size %v", i, result.AdjustedEffort, single.AdjustedEffort, sizes[i])
+        }
+        effort += result.AdjustedEffort
+    }
+    if math.Abs(batch.Summary.TotalEffortPM-effort) > 1e-9 {
+        t.Errorf("summary effort %v, want the sum %v", batch.Summary.TotalEffortPM, effort)
+    }
+}
+
+func TestCalculateBatchFailsAsAWhole(t *testing.T) {
+    s := newTestServer(t)
+
+    invalid := []CalculateEstimateRequest{nominalCOCOMORequest(50), nominalCOCOMORequest(0)}
+    rec := s.request(http.MethodPost, "/api/cocomo/calculate/batch", invalid)
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors ValidationErrors `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "[1].ksloc" {
+        t.Errorf("errors = %v, want one naming [1].ksloc", body.Errors)
+    }
+
+    unknown := []CalculateEstimateRequest{nominalCOCOMORequest(50), nominalCOCOMORequest(20)}
+    unknown[1].ModelID = "unknown"
+    rec = s.request(http.MethodPost, "/api/cocomo/calculate/batch", unknown)
+    assertStatus(t, rec, http.StatusNotFound)
+    if !strings.Contains(rec.Body.String(), "estimate 1") {
+        t.Errorf("body %s does not name estimate 1", rec.Body.String())
+    }
+}
+
+// sseEvent is an event read from a Server-Sent Events stream
+type sseEvent struct {
+    name string
+    data string
+}
+
+// sseEvents parses the events of a Server-Sent Events stream
+func sseEvents(stream string) []sseEvent {
+    var events []sseEvent
+    for _, block := range strings.Split(strings.TrimSpace(stream), "\n\n") {
+        var event sseEvent
+        for _, line := range strings.Split(block, "\n") {
+            if name, ok := strings.CutPrefix(line, "event: "); ok {
+                event.name = name
+            } else if data, ok := strings.CutPrefix(line, "data: "); ok {
+                event.data = data
+            }
+        }
+        events = append(events, event)
+    }
+    return events
+}
+
+// saveCOCOMOEstimate stores a nominal COCOMO II estimate of the given size
+func (s *testServer) saveCOCOMOEstimate(t *testing.T, ksloc float64) *domain.COCOMOEstimate {
+    t.Helper()
+    req := nominalCOCOMORequest(ksloc)
+    saved, err := s.cocomo.CreateEstimate(usecase.CreateEstimateInput{
+        ModelID:      req.ModelID,
+        ProjectSize:  req.KSLOC,
+        ScaleFactors: req.ScaleFactors,
+        CostDrivers:  req.CostDrivers,
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    return saved
+}
+
+func TestStreamMonteCarloEndsWithResult(t *testing.T) {
+    s := newTestServer(t)
+    saved := s.saveCOCOMOEstimate(t, 50)
+
+    rec := s.request(http.MethodGet, "/api/cocomo/estimates/"+saved.ID+"/montecarlo/stream?iterations=20000&seed=7", nil)
+    assertStatus(t, rec, http.StatusOK)
+    if got := rec.Header().Get(echo.HeaderContentType); got != "text/event-stream" {
+        t.Errorf("Content-Type %q, want text/event-stream", got)
+    }
+
+    events := sseEvents(rec.Body.String())
+    if len(events) < 2 {
+        t.Fatalf("got events %v, want progress and a result", events)
+    }
+    for _, event := range events[:len(events)-1] {
+        if event.name != "progress" {
+            t.Errorf("event %q before the end, want only progress", event.name)
+        }
+    }
+    var progress MonteCarloProgress
+    if err := json.Unmarshal([]byte(events[len(events)-2].data), &progress); err != nil {
+        t.Fatal(err)
+    }
+    if progress.Completed != 20000 || progress.Percent != 100 {
+        t.Errorf("last progress %+v, want all 20000 iterations at 100%%", progress)
+    }
+
+    last := events[len(events)-1]
+    if last.name != "result" {
+        t.Fatalf("stream ends with %q, want a result event", last.name)
+    }
+    var result domain.MonteCarloResult
+    if err := json.Unmarshal([]byte(last.data), &result); err != nil {
+        t.Fatal(err)
+    }
+    if want := saved.RunMonteCarlo(20000, 7); result != *want {
+        t.Errorf("result %+v, want the simulation's %+v", result, *want)
+    }
+}
+
+func TestStreamMonteCarloStopsWhenClientDisconnects(t *testing.T) {
+    s := newTestServer(t)
+    saved := s.saveCOCOMOEstimate(t, 50)
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    path := fmt.Sprintf("/api/cocomo/estimates/%s/montecarlo/stream?iterations=%d", saved.ID, domain.MaxMonteCarloIterations)
+    req := httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx)
+    rec := httptest.NewRecorder()
+    s.echo.ServeHTTP(rec, req)
+
+    for _, event := range sseEvents(rec.Body.String()) {
+        if event.name == "result" || event.name == "error" {
+            t.Errorf("got a %s event after the client disconnected, want the stream to stop", event.name)
+        }
+    }
+}
+
+func TestStreamMonteCarloValidation(t *testing.T) {
+    s := newTestServer(t)
+    saved := s.saveCOCOMOEstimate(t, 50)
+
+    rec := s.request(http.MethodGet, "/api/cocomo/estimates/missing/montecarlo/stream", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+    rec = s.request(http.MethodGet, "/api/cocomo/estimates/"+saved.ID+"/montecarlo/stream?iterations=0", nil)
+    assertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestCalculateEstimateRejectsNegativeDM(t *testing.T) {
+    s := newTestServer(t)
+    req := nominalCOCOMORequest(1)
+    req.ReuseComponents = []domain.ReuseComponent{{AdaptedSize: 10, DM: -100}}
+
+    rec := s.request(http.MethodPost, "/api/cocomo/calculate", req)
+    assertStatus(t, rec, http.StatusBadRequest)
+    // Nothing was stored, so the estimates still list
+    rec = s.request(http.MethodGet, "/api/cocomo/estimates", nil)
+    assertStatus(t, rec, http.StatusOK)
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+    "net/http"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/usecase"
+    "estimate-backend/internal/domain"
+)
+
+// ConfigController handles HTTP requests for the estimation configuration
+type ConfigController struct {
+    configUseCase *usecase.ConfigUseCase
+}
+
+// NewConfigController creates a new ConfigController
+func NewConfigController(cu *usecase.ConfigUseCase) *ConfigController {
+    return &ConfigController{
+        configUseCase: cu,
+    }
+}
+
+// RegisterRoutes registers the routes for the estimation configuration
+func (cc *ConfigController) RegisterRoutes(e *echo.Echo) {
+    e.GET("/api/config", cc.GetConfig)
+    e.POST("/api/config", cc.UpdateConfig)
+}
+
+// ConfigRequest represents the request body for updating the estimation configuration
+type ConfigRequest struct {
+    HoursPerPersonMonth float64            `json:"hoursPerPersonMonth"`
+    Risk                *RiskConfigRequest `json:"risk"` // Omit to keep the current thresholds
+    TeamOverhead        *TeamOverheadConfigRequest `json:"teamOverhead"` // Omit to keep the current overhead
+}
+
+// TeamOverheadConfigRequest represents the communication overhead added to the effort of large planned teams
+type TeamOverheadConfigRequest struct {
+    Threshold float64 `json:"threshold"`
+    Rate      float64 `json:"rate"`
+}
+
+// RiskConfigRequest represents the thresholds used to assess the risk of a COCOMO II estimate
+type RiskConfigRequest struct {
+    ScaleFactorRating float64 `json:"scaleFactorRating"`
+    CostDriverValue   float64 `json:"costDriverValue"`
+    HighRiskCount     int     `json:"highRiskCount"`
+    MediumRiskCount   int     `json:"mediumRiskCount"`
+    LargeProjectSize  float64 `json:"largeProjectSize"`
+    LargeEffortPM     float64 `json:"largeEffort"`
+    LargeTeamSize     float64 `json:"largeTeamSize"`
+    MediumRiskScore   float64 `json:"mediumRiskScore"`
+    HighRiskScore     float64 `json:"highRiskScore"`
+}
+
+// GetConfig handles GET /api/config
+func (cc *ConfigController) GetConfig(c echo.Context) error {
+    return c.JSON(http.StatusOK, cc.configUseCase.GetConfig())
+}
+
+// UpdateConfig handles POST /api/config
+func (cc *ConfigController) UpdateConfig(c echo.Context) error {
+    var req ConfigRequest
+    if err := c.Bind(&req); err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+    }
+
+    config := domain.EstimationConfig{
+        HoursPerPersonMonth: req.HoursPerPersonMonth,
+    }
+    if req.Risk != nil {
+        config.Risk = domain.RiskConfig{
+            ScaleFactorRating: req.Risk.ScaleFactorRating,
+            CostDriverValue:   req.Risk.CostDriverValue,
+            HighRiskCount:     req.Risk.HighRiskCount,
+            MediumRiskCount:   req.Risk.MediumRiskCount,
+            LargeProjectSize:  req.Risk.LargeProjectSize,
+            LargeEffortPM:     req.Risk.LargeEffortPM,
+            LargeTeamSize:     req.Risk.LargeTeamSize,
+            MediumRiskScore:   req.Risk.MediumRiskScore,
+            HighRiskScore:     req.Risk.HighRiskScore,
+        }
+    }
+
+    if req.TeamOverhead != nil {
+        config.TeamOverhead = domain.TeamOverheadConfig{
+            Threshold: req.TeamOverhead.Threshold,
+            Rate:      req.TeamOverhead.Rate,
+        }
+    }
+
+    config, err := cc.configUseCase.UpdateConfig(config)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, config)
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+    "net/http"
+    "os"
+    "strings"
+
+    "github.com/labstack/echo/v4"
+    "github.com/labstack/echo/v4/middleware"
+)
+
+// Environment variables configuring CORS, each a comma-separated list
+const (
+    corsOriginsEnv = "CORS_ALLOWED_ORIGINS"
+    corsMethodsEnv = "CORS_ALLOWED_METHODS"
+    corsHeadersEnv = "CORS_ALLOWED_HEADERS"
+)
+
+// defaultCORSOrigins are the origins of the frontend development and preview servers
+var defaultCORSOrigins = []string{
+    "http://localhost:5173",
+    "http://127.0.0.1:5173",
+    "http://localhost:3000",
+    "http://127.0.0.1:3000",
+}
+
+var defaultCORSMethods = []string{
+    http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// defaultCORSHeaders are the request headers the API reads
+var defaultCORSHeaders = []string{
+    echo.HeaderContentType, echo.HeaderAccept, "Accept-Language", "If-Match",
+    ActorHeader, OrgHeader, echo.HeaderXRequestID,
+}
+
+// corsExposeHeaders are the response headers the frontend may read
+var corsExposeHeaders = []string{echo.HeaderContentDisposition, "ETag", echo.HeaderXRequestID}
+
+// CORSConfigFromEnv returns the CORS configuration from the environment, falling back to the
+// localhost development origins. Credentials are allowed, so a "*" origin is ignored; echo would
+// otherwise allow every origin.
+func CORSConfigFromEnv() middleware.CORSConfig {
+    var allowed []string
+    for _, origin := range envList(corsOriginsEnv, defaultCORSOrigins) {
+        if origin != "*" {
+            allowed = append(allowed, origin)
+        }
+    }
+    if len(allowed) == 0 {
+        allowed = defaultCORSOrigins
+    }
+    return middleware.CORSConfig{
+        AllowOrigins:     allowed,
+        AllowMethods:     envList(corsMethodsEnv, defaultCORSMethods),
+        AllowHeaders:     envList(corsHeadersEnv, defaultCORSHeaders),
+        ExposeHeaders:    corsExposeHeaders,
+        AllowCredentials: true,
+    }
+}
+
+// envList returns the comma-separated values of an environment variable, or fallback when it is unset or empty
+func envList(name string, fallback []string) []string {
+    var values []string
+    for _, value := range strings.Split(os.Getenv(name), ",") {
+        if value = strings.TrimSpace(value); value != "" {
+            values = append(values, value)
+        }
+    }
+    if len(values) == 0 {
+        return fallback
+    }
+    return values
+}
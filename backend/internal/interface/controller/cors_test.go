@@ -0,0 +1,68 @@
+package controller
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "testing"
+
+    "github.com/labstack/echo/v4"
+    "github.com/labstack/echo/v4/middleware"
+)
+
+// corsPreflight sends a preflight request from origin to a server with the CORS configuration from the environment
+func corsPreflight(origin string) *httptest.ResponseRecorder {
+    e := echo.New()
+    e.Use(middleware.CORSWithConfig(CORSConfigFromEnv()))
+    e.POST("/api/estimates", func(c echo.Context) error { return c.NoContent(http.StatusCreated) })
+
+    req := httptest.NewRequest(http.MethodOptions, "/api/estimates", nil)
+    req.Header.Set(echo.HeaderOrigin, origin)
+    req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPost)
+    rec := httptest.NewRecorder()
+    e.ServeHTTP(rec, req)
+    return rec
+}
+
+func TestCORSAllowsConfiguredOrigin(t *testing.T) {
+    t.Setenv(corsOriginsEnv, "https://estimate.example.com, http://localhost:5173")
+
+    rec := corsPreflight("https://estimate.example.com")
+    if got := rec.Header().Get(echo.HeaderAccessControlAllowOrigin); got != "https://estimate.example.com" {
+        t.Errorf("Access-Control-Allow-Origin %q, want the configured origin", got)
+    }
+    if got := rec.Header().Get(echo.HeaderAccessControlAllowCredentials); got != "true" {
+        t.Errorf("Access-Control-Allow-Credentials %q, want true", got)
+    }
+    if got := rec.Header().Get(echo.HeaderAccessControlAllowMethods); got == "" {
+        t.Error("preflight response has no Access-Control-Allow-Methods")
+    }
+}
+
+func TestCORSRejectsDisallowedOrigin(t *testing.T) {
+    t.Setenv(corsOriginsEnv, "https://estimate.example.com")
+
+    rec := corsPreflight("https://evil.example.com")
+    if got := rec.Header().Get(echo.HeaderAccessControlAllowOrigin); got != "" {
+        t.Errorf("Access-Control-Allow-Origin %q for a disallowed origin, want none", got)
+    }
+}
+
+func TestCORSIgnoresWildcardOrigin(t *testing.T) {
+    t.Setenv(corsOriginsEnv, "*")
+
+    if got := CORSConfigFromEnv().AllowOrigins; !reflect.DeepEqual(got, defaultCORSOrigins) {
+        t.Errorf("origins %v, want the localhost defaults instead of *", got)
+    }
+    if got := corsPreflight("https://evil.example.com").Header().Get(echo.HeaderAccessControlAllowOrigin); got != "" {
+        t.Errorf("Access-Control-Allow-Origin %q with a * origin, want none", got)
+    }
+}
+
+func TestCORSDefaultsToLocalhost(t *testing.T) {
+    t.Setenv(corsOriginsEnv, "")
+
+    if got := corsPreflight("http://localhost:5173").Header().Get(echo.HeaderAccessControlAllowOrigin); got != "http://localhost:5173" {
+        t.Errorf("Access-Control-Allow-Origin %q, want the development server", got)
+    }
+}
@@ -0,0 +1,24 @@
+package controller
+
+import (
+    "errors"
+    "net/http"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/usecase"
+)
+
+// httpError maps a use case error to an HTTP error:
+// validation errors to 400, not found to 404, version conflicts to 409 and anything else to 500
+func httpError(err error) *echo.HTTPError {
+    switch {
+    case errors.Is(err, usecase.ErrValidation):
+        return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+    case errors.Is(err, usecase.ErrNotFound):
+        return echo.NewHTTPError(http.StatusNotFound, err.Error())
+    case errors.Is(err, usecase.ErrConflict):
+        return echo.NewHTTPError(http.StatusConflict, err.Error())
+    default:
+        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    }
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+    "errors"
+    "fmt"
+    "net/http"
+    "testing"
+
+    "estimate-backend/internal/usecase"
+)
+
+func TestHTTPError(t *testing.T) {
+    tests := []struct {
+        err    error
+        status int
+    }{
+        {fmt.Errorf("estimate x: %w", usecase.ErrNotFound), http.StatusNotFound},
+        {fmt.Errorf("estimate x: %w", usecase.ErrConflict), http.StatusConflict},
+        {fmt.Errorf("input: %w", usecase.ErrValidation), http.StatusBadRequest},
+        {errors.New("disk full"), http.StatusInternalServerError},
+    }
+    for _, tt := range tests {
+        if got := httpError(tt.err); got.Code != tt.status {
+            t.Errorf("httpError(%v) = %d, want %d", tt.err, got.Code, tt.status)
+        }
+    }
+}
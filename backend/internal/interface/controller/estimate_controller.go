@@ -1,10 +1,17 @@
 package controller
 
 import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "io"
     "net/http"
     "strconv"
+    "strings"
+    "time"
 
     "github.com/labstack/echo/v4"
+    "estimate-backend/internal/interface/presenter"
     "estimate-backend/internal/usecase"
     "estimate-backend/internal/domain"
 )
@@ -24,44 +31,206 @@ func NewEstimateController(eu *usecase.EstimateUseCase) *EstimateController {
 // RegisterRoutes registers the routes for estimate management
 func (ec *EstimateController) RegisterRoutes(e *echo.Echo) {
     e.POST("/api/estimates", ec.CreateEstimate)
+    e.GET("/api/estimates", ec.GetEstimates)
+    e.GET("/api/estimates/search", ec.SearchEstimates)
     e.GET("/api/estimates/:id", ec.GetEstimate)
     e.PUT("/api/estimates/:id", ec.UpdateEstimate)
+    e.PUT("/api/estimates/:id/status", ec.TransitionStatus)
+    e.DELETE("/api/estimates/:id", ec.DeleteEstimate)
+    e.POST("/api/estimates/:id/restore", ec.RestoreEstimate)
+    e.POST("/api/estimates/:id/clone", ec.CloneEstimate)
     e.GET("/api/estimates/:id/detailed", ec.GetDetailedEstimate)
+    e.GET("/api/estimates/:id/versions", ec.GetEstimateVersions)
+    e.GET("/api/estimates/:id/critical-path", ec.GetCriticalPath)
+    e.GET("/api/estimates/:id/schedule", ec.GetSchedule)
+    e.GET("/api/estimates/:id/versions/:version", ec.GetEstimateVersion)
+    e.GET("/api/estimates/:id/export.pdf", ec.ExportPDF)
+    e.GET("/api/estimates/:id/export.xlsx", ec.ExportXLSX)
+    e.GET("/api/estimates/:id/export.json", ec.ExportJSON)
+    e.POST("/api/estimates/import", ec.ImportEstimate)
     e.GET("/api/projects/:projectId/estimates", ec.GetProjectEstimates)
+    e.GET("/api/projects/:projectId/summary", ec.GetProjectSummary)
+    e.POST("/api/estimates/batch", ec.CreateEstimates)
+    e.POST("/api/estimates/calculate", ec.CalculateEstimate)
     e.POST("/api/estimates/compare", ec.CompareEstimates)
+    e.POST("/api/estimates/recalculate", ec.RecalculateEstimates)
+    e.POST("/api/estimates/:id/recalculate", ec.RecalculateEstimate)
+    e.POST("/api/estimates/analogy", ec.AnalogyEstimate)
+    e.POST("/api/estimates/:id/migration", ec.MigrationEffort)
+    e.GET("/api/estimates/:id/planning-confidence", ec.GetPlanningConfidence)
+    e.GET("/api/estimates/:id/by-work-type", ec.GetEffortByWorkType)
+    e.GET("/api/estimates/:id/deliverables", ec.GetDeliverables)
+    e.POST("/api/estimates/:id/triangulate", ec.TriangulateEstimate)
+    e.POST("/api/estimates/:id/subscriptions", ec.Subscribe)
+    e.GET("/api/estimates/:id/subscriptions", ec.GetSubscriptions)
+    e.DELETE("/api/subscriptions/:id", ec.Unsubscribe)
 }
 
 // CreateEstimateRequest represents the request body for creating an estimate
 type CreateEstimateRequest struct {
-    ProjectID     string                `json:"projectId"`
-    ProjectName   string                `json:"projectName"`
+    ProjectID     string                `json:"projectId" validate:"required"`
+    ProjectName   string                `json:"projectName" validate:"required"`
     Tasks         []usecase.TaskInput   `json:"tasks"`
     GlobalFactors []string              `json:"globalFactors"`
+    ProcessFactors map[domain.ProcessCategory][]string `json:"processFactors,omitempty"` // Process category -> factor IDs
     COCOMOData    *usecase.COCOMOInput  `json:"cocomoData,omitempty"`
+    UCPData       *usecase.UCPInput     `json:"ucpData,omitempty"`
+    StoryPointData *usecase.StoryPointInput `json:"storyPointData,omitempty"`
+    DeliverableBased bool               `json:"deliverableBased,omitempty"` // Also estimate from the deliverables of the selected activities
+    Reconciliation *usecase.ReconciliationInput `json:"reconciliation,omitempty"` // Weights the methods by confidence without one
     CreatedBy     string                `json:"createdBy"`
     Notes         string                `json:"notes"`
+    Attributes    domain.ProjectAttributes `json:"attributes"`
+    AccessibilityLevel    string        `json:"accessibilityLevel,omitempty" validate:"omitempty,oneof=A AA AAA"`
+    LocalizationLanguages []string      `json:"localizationLanguages,omitempty"`
 }
 
 // CreateEstimate handles POST /api/estimates
 func (ec *EstimateController) CreateEstimate(c echo.Context) error {
     var req CreateEstimateRequest
-    if err := c.Bind(&req); err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    input := createEstimateInput(c, req)
+    estimate, err := ec.estimateUseCase.CreateEstimate(input)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusCreated, estimate)
+}
+
+// CalculateEstimate handles POST /api/estimates/calculate.
+// It calculates the estimate described by a create request without storing it.
+func (ec *EstimateController) CalculateEstimate(c echo.Context) error {
+    var req CreateEstimateRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
     }
 
-    input := usecase.CreateEstimateInput{
+    estimate, err := ec.estimateUseCase.CalculateEstimate(createEstimateInput(c, req))
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, estimate)
+}
+
+// createEstimateInput converts a create request into the usecase input
+func createEstimateInput(c echo.Context, req CreateEstimateRequest) usecase.CreateProjectEstimateInput {
+    return usecase.CreateProjectEstimateInput{
         ProjectID:     req.ProjectID,
         ProjectName:   req.ProjectName,
         Tasks:         req.Tasks,
         GlobalFactors: req.GlobalFactors,
-        COCOMOData:    req.COCOMOData,
+        ProcessFactors: req.ProcessFactors,
+        COCOMOData:    withOrg(req.COCOMOData, orgID(c)),
+        UCPData:       req.UCPData,
+        StoryPointData: req.StoryPointData,
+        DeliverableBased: req.DeliverableBased,
+        Reconciliation: req.Reconciliation,
         CreatedBy:     req.CreatedBy,
         Notes:         req.Notes,
+        Attributes:    req.Attributes,
+        AccessibilityLevel:    domain.AccessibilityLevel(req.AccessibilityLevel),
+        LocalizationLanguages: req.LocalizationLanguages,
+        Actor:         actor(c),
     }
+}
 
-    estimate, err := ec.estimateUseCase.CreateEstimate(input)
+// BatchItemResult represents the outcome of one item of a batch creation
+type BatchItemResult struct {
+    Index    int              `json:"index"`
+    Status   int              `json:"status"` // HTTP status the item would have had on its own
+    Estimate *domain.Estimate `json:"estimate,omitempty"`
+    Errors   ValidationErrors `json:"errors,omitempty"`
+    Error    string           `json:"error,omitempty"`
+}
+
+// BatchCreateResponse represents the response of a batch creation
+type BatchCreateResponse struct {
+    Created int               `json:"created"`
+    Failed  int               `json:"failed"`
+    Results []BatchItemResult `json:"results"`
+}
+
+// CreateEstimates handles POST /api/estimates/batch.
+// Every item is created independently; the response is 201 when all succeed and 207 otherwise.
+func (ec *EstimateController) CreateEstimates(c echo.Context) error {
+    var reqs []CreateEstimateRequest
+    if err := json.NewDecoder(c.Request().Body).Decode(&reqs); err != nil {
+        return bindError(err)
+    }
+    if len(reqs) == 0 {
+        return ValidationErrors{{Message: "at least one estimate is required"}}
+    }
+
+    response := BatchCreateResponse{Results: make([]BatchItemResult, len(reqs))}
+    for i, req := range reqs {
+        result := BatchItemResult{Index: i}
+        if err := c.Validate(&req); err != nil {
+            result.Status = http.StatusBadRequest
+            if ve, ok := err.(ValidationErrors); ok {
+                result.Errors = ve
+            } else {
+                result.Error = err.Error()
+            }
+        } else if estimate, err := ec.estimateUseCase.CreateEstimate(createEstimateInput(c, req)); err != nil {
+            he := httpError(err)
+            result.Status = he.Code
+            result.Error = err.Error()
+        } else {
+            result.Status = http.StatusCreated
+            result.Estimate = estimate
+        }
+
+        if result.Status == http.StatusCreated {
+            response.Created++
+        } else {
+            response.Failed++
+        }
+        response.Results[i] = result
+    }
+
+    status := http.StatusCreated
+    if response.Failed > 0 {
+        status = http.StatusMultiStatus
+    }
+    return c.JSON(status, response)
+}
+
+// withOrg sets the organization whose models the COCOMO II data may use
+func withOrg(data *usecase.COCOMOInput, org string) *usecase.COCOMOInput {
+    if data != nil {
+        data.OwnerID = org
+    }
+    return data
+}
+
+// CloneEstimateRequest represents the request body for cloning an estimate
+type CloneEstimateRequest struct {
+    ProjectName string `json:"projectName" validate:"required"`
+    ProjectID   string `json:"projectId,omitempty"` // Optional, defaults to the project of the copied estimate
+    CreatedBy   string `json:"createdBy"`
+}
+
+// CloneEstimate handles POST /api/estimates/:id/clone
+func (ec *EstimateController) CloneEstimate(c echo.Context) error {
+    var req CloneEstimateRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    estimate, err := ec.estimateUseCase.CloneEstimate(usecase.CloneEstimateInput{
+        ID:          c.Param("id"),
+        ProjectID:   req.ProjectID,
+        ProjectName: req.ProjectName,
+        CreatedBy:   req.CreatedBy,
+        Actor:       actor(c),
+    })
     if err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+        return httpError(err)
     }
 
     return c.JSON(http.StatusCreated, estimate)
@@ -72,43 +241,229 @@ func (ec *EstimateController) GetEstimate(c echo.Context) error {
     id := c.Param("id")
     estimate, err := ec.estimateUseCase.GetEstimate(id)
     if err != nil {
-        return echo.NewHTTPError(http.StatusNotFound, "Estimate not found")
+        return httpError(err)
+    }
+    setETag(c, estimate)
+    return c.JSON(http.StatusOK, estimate)
+}
+
+// GetCriticalPath handles GET /api/estimates/:id/critical-path
+func (ec *EstimateController) GetCriticalPath(c echo.Context) error {
+    result, err := ec.estimateUseCase.CriticalPath(c.Param("id"))
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, result)
+}
+
+// GetSchedule handles GET /api/estimates/:id/schedule?start=YYYY-MM-DD&workingDays=5&holidays=YYYY-MM-DD,...
+func (ec *EstimateController) GetSchedule(c echo.Context) error {
+    start, err := time.Parse(domain.DateLayout, c.QueryParam("start"))
+    if err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, "start must be a date formatted as YYYY-MM-DD")
+    }
+
+    var calendar domain.TeamCalendar
+    if p := c.QueryParam("workingDays"); p != "" {
+        if calendar.WorkingDaysPerWeek, err = strconv.Atoi(p); err != nil {
+            return echo.NewHTTPError(http.StatusBadRequest, "workingDays must be an integer")
+        }
+    }
+    for _, holiday := range strings.Split(c.QueryParam("holidays"), ",") {
+        if holiday = strings.TrimSpace(holiday); holiday == "" {
+            continue
+        }
+        date, err := time.Parse(domain.DateLayout, holiday)
+        if err != nil {
+            return echo.NewHTTPError(http.StatusBadRequest, "holidays must be dates formatted as YYYY-MM-DD")
+        }
+        calendar.Holidays = append(calendar.Holidays, date)
+    }
+
+    schedule, err := ec.estimateUseCase.ProjectSchedule(c.Param("id"), start, calendar)
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, schedule)
+}
+
+// GetEstimateVersions handles GET /api/estimates/:id/versions
+func (ec *EstimateController) GetEstimateVersions(c echo.Context) error {
+    versions, err := ec.estimateUseCase.GetEstimateVersions(c.Param("id"))
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, versions)
+}
+
+// GetEstimateVersion handles GET /api/estimates/:id/versions/:version
+func (ec *EstimateController) GetEstimateVersion(c echo.Context) error {
+    version, err := strconv.Atoi(c.Param("version"))
+    if err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, "version must be an integer")
+    }
+
+    estimate, err := ec.estimateUseCase.GetEstimateVersion(c.Param("id"), version)
+    if err != nil {
+        return httpError(err)
     }
     return c.JSON(http.StatusOK, estimate)
 }
 
 // UpdateEstimateRequest represents the request body for updating an estimate
 type UpdateEstimateRequest struct {
+    Version       int                   `json:"version"` // Version being edited; the If-Match header takes precedence
     Tasks         []usecase.TaskInput   `json:"tasks"`
     GlobalFactors []string              `json:"globalFactors"`
+    ProcessFactors map[domain.ProcessCategory][]string `json:"processFactors,omitempty"` // Process category -> factor IDs
     COCOMOData    *usecase.COCOMOInput  `json:"cocomoData,omitempty"`
+    UCPData       *usecase.UCPInput     `json:"ucpData,omitempty"`
+    StoryPointData *usecase.StoryPointInput `json:"storyPointData,omitempty"`
+    DeliverableBased bool               `json:"deliverableBased,omitempty"`
+    Reconciliation *usecase.ReconciliationInput `json:"reconciliation,omitempty"`
     Notes         string                `json:"notes"`
+    CompletedDeliverables []string      `json:"completedDeliverables"`
+    Attributes    domain.ProjectAttributes `json:"attributes"`
+    ActualHours   float64               `json:"actualHours"`
+    AccessibilityLevel    string        `json:"accessibilityLevel,omitempty" validate:"omitempty,oneof=A AA AAA"`
+    LocalizationLanguages []string      `json:"localizationLanguages,omitempty"`
 }
 
 // UpdateEstimate handles PUT /api/estimates/:id
 func (ec *EstimateController) UpdateEstimate(c echo.Context) error {
     id := c.Param("id")
     var req UpdateEstimateRequest
-    if err := c.Bind(&req); err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    version, err := ifMatchVersion(c, req.Version)
+    if err != nil {
+        return err
     }
 
     input := usecase.UpdateEstimateInput{
         ID:            id,
+        Version:       version,
         Tasks:         req.Tasks,
         GlobalFactors: req.GlobalFactors,
-        COCOMOData:    req.COCOMOData,
+        ProcessFactors: req.ProcessFactors,
+        COCOMOData:    withOrg(req.COCOMOData, orgID(c)),
+        UCPData:       req.UCPData,
+        StoryPointData: req.StoryPointData,
+        DeliverableBased: req.DeliverableBased,
+        Reconciliation: req.Reconciliation,
         Notes:         req.Notes,
+        CompletedDeliverables: req.CompletedDeliverables,
+        Attributes:    req.Attributes,
+        ActualHours:   req.ActualHours,
+        AccessibilityLevel:    domain.AccessibilityLevel(req.AccessibilityLevel),
+        LocalizationLanguages: req.LocalizationLanguages,
+        Actor:         actor(c),
     }
 
     estimate, err := ec.estimateUseCase.UpdateEstimate(input)
     if err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+        return httpError(err)
+    }
+
+    setETag(c, estimate)
+    return c.JSON(http.StatusOK, estimate)
+}
+
+// setETag sets the ETag header to the version of the estimate
+func setETag(c echo.Context, estimate *domain.Estimate) {
+    c.Response().Header().Set("ETag", strconv.Quote(strconv.Itoa(estimate.Version)))
+}
+
+// ifMatchVersion returns the estimate version of the If-Match header, or fallback without the header
+func ifMatchVersion(c echo.Context, fallback int) (int, error) {
+    header := c.Request().Header.Get("If-Match")
+    if header == "" {
+        return fallback, nil
+    }
+    version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(strings.TrimSpace(header), "W/"), `"`))
+    if err != nil {
+        return 0, echo.NewHTTPError(http.StatusBadRequest, "If-Match must be an ETag returned for the estimate")
+    }
+    return version, nil
+}
+
+// DeleteEstimate handles DELETE /api/estimates/:id
+func (ec *EstimateController) DeleteEstimate(c echo.Context) error {
+    if err := ec.estimateUseCase.DeleteEstimate(c.Param("id"), actor(c)); err != nil {
+        return httpError(err)
+    }
+    return c.NoContent(http.StatusNoContent)
+}
+
+// RestoreEstimate handles POST /api/estimates/:id/restore
+func (ec *EstimateController) RestoreEstimate(c echo.Context) error {
+    estimate, err := ec.estimateUseCase.RestoreEstimate(c.Param("id"), actor(c))
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, estimate)
+}
+
+// TransitionStatusRequest represents the request body for changing the status of an estimate
+type TransitionStatusRequest struct {
+    Status string `json:"status" validate:"required"`
+}
+
+// TransitionStatus handles PUT /api/estimates/:id/status
+func (ec *EstimateController) TransitionStatus(c echo.Context) error {
+    var req TransitionStatusRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
     }
 
+    estimate, err := ec.estimateUseCase.TransitionStatus(c.Param("id"), domain.EstimateStatus(req.Status), actor(c))
+    if err != nil {
+        return httpError(err)
+    }
     return c.JSON(http.StatusOK, estimate)
 }
 
+// detailedEstimateResponse represents an estimate together with its COCOMO details
+type detailedEstimateResponse struct {
+    *domain.Estimate
+    COCOMODetails *domain.COCOMODetailedResult `json:"cocomoDetails,omitempty"`
+    MethodResults []MethodResultResponse       `json:"methodResults"`
+}
+
+// MethodResultResponse represents the result of one estimation method behind the total hours,
+// with the spread clients need to draw error bars
+type MethodResultResponse struct {
+    Method      domain.CalculationMethod `json:"method"`
+    TotalHours  float64                  `json:"totalHours"`
+    Confidence  float64                  `json:"confidence"`  // 0-1
+    StdDevHours float64                  `json:"stdDevHours"` // 0 without three-point task estimates
+    Probability float64                  `json:"probability,omitempty"` // Of the interval below
+    LowHours    float64                  `json:"lowHours,omitempty"`
+    HighHours   float64                  `json:"highHours,omitempty"`
+    Share       float64                  `json:"share"` // Weight in the reconciled total hours, 0-1
+}
+
+// methodResultResponses lists the method results of an estimate in reconciliation order
+func methodResultResponses(estimate *domain.Estimate) []MethodResultResponse {
+    shares := estimate.ReconciliationShares()
+    response := []MethodResultResponse{}
+    for _, result := range estimate.Results() {
+        response = append(response, MethodResultResponse{
+            Method:      result.Method,
+            TotalHours:  result.TotalHours,
+            Confidence:  result.Confidence,
+            StdDevHours: result.StdDevHours,
+            Probability: result.Interval.Probability,
+            LowHours:    result.Interval.Low,
+            HighHours:   result.Interval.High,
+            Share:       shares[result.Method],
+        })
+    }
+    return response
+}
+
 // GetDetailedEstimate handles GET /api/estimates/:id/detailed
 func (ec *EstimateController) GetDetailedEstimate(c echo.Context) error {
     id := c.Param("id")
@@ -116,28 +471,165 @@ func (ec *EstimateController) GetDetailedEstimate(c echo.Context) error {
 
     estimate, cocomoResult, err := ec.estimateUseCase.GetDetailedEstimateResult(id, hourlyRate)
     if err != nil {
-        return echo.NewHTTPError(http.StatusNotFound, "Estimate not found")
+        return httpError(err)
     }
+    language(c).LocalizeDetailedResult(cocomoResult)
 
-    response := struct {
-        *domain.Estimate
-        COCOMODetails *domain.COCOMODetailedResult `json:"cocomoDetails,omitempty"`
-    }{
+    response := detailedEstimateResponse{
         Estimate:      estimate,
         COCOMODetails: cocomoResult,
+        MethodResults: methodResultResponses(estimate),
     }
 
     return c.JSON(http.StatusOK, response)
 }
 
+// ExportPDF handles GET /api/estimates/:id/export.pdf
+func (ec *EstimateController) ExportPDF(c echo.Context) error {
+    id := c.Param("id")
+    hourlyRate, _ := strconv.ParseFloat(c.QueryParam("hourlyRate"), 64)
+
+    estimate, cocomoResult, err := ec.estimateUseCase.GetDetailedEstimateResult(id, hourlyRate)
+    if err != nil {
+        return httpError(err)
+    }
+    language(c).LocalizeDetailedResult(cocomoResult)
+
+    var buf bytes.Buffer
+    if err := presenter.RenderEstimatePDF(&buf, estimate, cocomoResult); err != nil {
+        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    }
+
+    c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="estimate-%s.pdf"`, estimate.ID))
+    return c.Blob(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// ExportXLSX handles GET /api/estimates/:id/export.xlsx
+func (ec *EstimateController) ExportXLSX(c echo.Context) error {
+    id := c.Param("id")
+    hourlyRate, _ := strconv.ParseFloat(c.QueryParam("hourlyRate"), 64)
+
+    estimate, cocomoResult, err := ec.estimateUseCase.GetDetailedEstimateResult(id, hourlyRate)
+    if err != nil {
+        return httpError(err)
+    }
+    language(c).LocalizeDetailedResult(cocomoResult)
+
+    var buf bytes.Buffer
+    if err := presenter.RenderEstimateXLSX(&buf, estimate, cocomoResult); err != nil {
+        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    }
+
+    c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="estimate-%s.xlsx"`, estimate.ID))
+    return c.Blob(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buf.Bytes())
+}
+
+// ExportJSON handles GET /api/estimates/:id/export.json, a backup that POST /api/estimates/import restores
+func (ec *EstimateController) ExportJSON(c echo.Context) error {
+    doc, err := ec.estimateUseCase.ExportEstimate(c.Param("id"))
+    if err != nil {
+        return httpError(err)
+    }
+
+    c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="estimate-%s.json"`, doc.Estimate.ID))
+    return c.JSON(http.StatusOK, doc)
+}
+
+// ImportEstimate handles POST /api/estimates/import with a document from GET /api/estimates/:id/export.json.
+// Documents of earlier schema versions are upgraded before the estimate is rebuilt.
+func (ec *EstimateController) ImportEstimate(c echo.Context) error {
+    doc, err := io.ReadAll(c.Request().Body)
+    if err != nil {
+        return err
+    }
+
+    estimate, err := ec.estimateUseCase.ImportEstimate(usecase.ImportEstimateInput{
+        Document: doc,
+        OwnerID:  orgID(c),
+        Actor:    actor(c),
+    })
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusCreated, estimate)
+}
+
+// estimateListQuery parses the ?status=&page=&pageSize=&sort= query parameters of estimate lists.
+// status accepts several comma-separated statuses.
+func estimateListQuery(c echo.Context) (usecase.EstimateListQuery, error) {
+    query := usecase.EstimateListQuery{Sort: c.QueryParam("sort")}
+    var err error
+    if p := c.QueryParam("page"); p != "" {
+        if query.Page, err = strconv.Atoi(p); err != nil {
+            return query, echo.NewHTTPError(http.StatusBadRequest, "page must be an integer")
+        }
+    }
+    if p := c.QueryParam("pageSize"); p != "" {
+        if query.PageSize, err = strconv.Atoi(p); err != nil {
+            return query, echo.NewHTTPError(http.StatusBadRequest, "pageSize must be an integer")
+        }
+    }
+    for _, status := range strings.Split(c.QueryParam("status"), ",") {
+        if status = strings.TrimSpace(status); status != "" {
+            query.Statuses = append(query.Statuses, domain.EstimateStatus(status))
+        }
+    }
+    return query, nil
+}
+
+// GetEstimates handles GET /api/estimates
+func (ec *EstimateController) GetEstimates(c echo.Context) error {
+    query, err := estimateListQuery(c)
+    if err != nil {
+        return err
+    }
+
+    page, err := ec.estimateUseCase.ListEstimates(query)
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, page)
+}
+
+// SearchEstimates handles GET /api/estimates/search?q=. It accepts the same status, paging and
+// sort parameters as GetEstimates; an empty q lists the most recent estimates.
+func (ec *EstimateController) SearchEstimates(c echo.Context) error {
+    query, err := estimateListQuery(c)
+    if err != nil {
+        return err
+    }
+
+    page, err := ec.estimateUseCase.SearchEstimates(c.QueryParam("q"), query)
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, page)
+}
+
 // GetProjectEstimates handles GET /api/projects/:projectId/estimates
 func (ec *EstimateController) GetProjectEstimates(c echo.Context) error {
-    projectID := c.Param("projectId")
-    estimates, err := ec.estimateUseCase.GetProjectEstimates(projectID)
+    query, err := estimateListQuery(c)
     if err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+        return err
+    }
+
+    page, err := ec.estimateUseCase.ListProjectEstimates(c.Param("projectId"), query)
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, page)
+}
+
+// GetProjectSummary handles GET /api/projects/:projectId/summary?hourlyRate=
+func (ec *EstimateController) GetProjectSummary(c echo.Context) error {
+    hourlyRate, _ := strconv.ParseFloat(c.QueryParam("hourlyRate"), 64)
+
+    summary, err := ec.estimateUseCase.ProjectSummary(c.Param("projectId"), hourlyRate)
+    if err != nil {
+        return httpError(err)
     }
-    return c.JSON(http.StatusOK, estimates)
+    return c.JSON(http.StatusOK, summary)
 }
 
 // CompareEstimatesRequest represents the request body for comparing estimates
@@ -155,8 +647,197 @@ func (ec *EstimateController) CompareEstimates(c echo.Context) error {
 
     comparison, err := ec.estimateUseCase.CompareEstimates(req.EstimateID1, req.EstimateID2)
     if err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+        return httpError(err)
     }
 
     return c.JSON(http.StatusOK, comparison)
+}
+
+// RecalculateEstimateResponse represents the recalculated estimate and the change of its total
+type RecalculateEstimateResponse struct {
+    Estimate *domain.Estimate       `json:"estimate"`
+    Change   usecase.EstimateChange `json:"change"`
+}
+
+// RecalculateEstimate handles POST /api/estimates/:id/recalculate
+func (ec *EstimateController) RecalculateEstimate(c echo.Context) error {
+    estimate, change, err := ec.estimateUseCase.RecalculateEstimate(c.Param("id"), actor(c))
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, RecalculateEstimateResponse{
+        Estimate: estimate,
+        Change:   change,
+    })
+}
+
+// RecalculateEstimatesRequest represents the request body for recalculating stored estimates
+type RecalculateEstimatesRequest struct {
+    ProjectID string `json:"projectId,omitempty"` // Optional, limits the recalculation to one project
+}
+
+// RecalculateEstimates handles POST /api/estimates/recalculate
+func (ec *EstimateController) RecalculateEstimates(c echo.Context) error {
+    var req RecalculateEstimatesRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    summary, err := ec.estimateUseCase.RecalculateEstimates(req.ProjectID, actor(c))
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, summary)
+}
+
+// AnalogyEstimateRequest represents the request body for analogy-based estimation
+type AnalogyEstimateRequest struct {
+    Attributes domain.ProjectAttributes `json:"attributes"`
+    K          int                      `json:"k" validate:"gt=0"`
+}
+
+// AnalogyEstimate handles POST /api/estimates/analogy
+func (ec *EstimateController) AnalogyEstimate(c echo.Context) error {
+    var req AnalogyEstimateRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    result, err := ec.estimateUseCase.AnalogyEstimate(req.Attributes, req.K)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, result)
+}
+
+// MigrationEffortRequest represents the request body for data-migration sizing
+type MigrationEffortRequest struct {
+    SourceTables             int     `json:"sourceTables" validate:"gt=0"`
+    RecordVolume             float64 `json:"recordVolume" validate:"min=0"`
+    TransformationComplexity int     `json:"transformationComplexity" validate:"min=1,max=5"`
+}
+
+// MigrationEffort handles POST /api/estimates/:id/migration
+func (ec *EstimateController) MigrationEffort(c echo.Context) error {
+    id := c.Param("id")
+    var req MigrationEffortRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    spec := domain.MigrationSpec{
+        SourceTables:             req.SourceTables,
+        RecordVolume:             req.RecordVolume,
+        TransformationComplexity: req.TransformationComplexity,
+    }
+
+    migration, err := ec.estimateUseCase.MigrationEffort(id, spec, actor(c))
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, migration)
+}
+
+// GetPlanningConfidence handles GET /api/estimates/:id/planning-confidence
+func (ec *EstimateController) GetPlanningConfidence(c echo.Context) error {
+    id := c.Param("id")
+    confidence, err := ec.estimateUseCase.PlanningMaturityConfidence(id)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, map[string]interface{}{
+        "confidence": confidence,
+    })
+}
+
+// GetEffortByWorkType handles GET /api/estimates/:id/by-work-type
+func (ec *EstimateController) GetEffortByWorkType(c echo.Context) error {
+    id := c.Param("id")
+    byWorkType, err := ec.estimateUseCase.EffortByWorkType(id)
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, byWorkType)
+}
+
+// GetDeliverables handles GET /api/estimates/:id/deliverables
+func (ec *EstimateController) GetDeliverables(c echo.Context) error {
+    checklist, err := ec.estimateUseCase.DeliverableChecklist(c.Param("id"))
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, checklist)
+}
+
+// TriangulateEstimateRequest represents the request body for triangulating an estimate
+type TriangulateEstimateRequest struct {
+    Attributes domain.ProjectAttributes `json:"attributes"`
+}
+
+// TriangulateEstimate handles POST /api/estimates/:id/triangulate
+func (ec *EstimateController) TriangulateEstimate(c echo.Context) error {
+    id := c.Param("id")
+    var req TriangulateEstimateRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    triangulation, err := ec.estimateUseCase.TriangulateEstimate(id, req.Attributes)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, triangulation)
+}
+
+// SubscribeRequest represents the request body for subscribing to drift alerts
+type SubscribeRequest struct {
+    ThresholdPercent float64 `json:"thresholdPercent" validate:"gt=0"`
+    NotifyURL        string  `json:"notifyUrl" validate:"required"`
+}
+
+// Subscribe handles POST /api/estimates/:id/subscriptions
+func (ec *EstimateController) Subscribe(c echo.Context) error {
+    id := c.Param("id")
+    var req SubscribeRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    input := usecase.SubscribeInput{
+        EstimateID:       id,
+        ThresholdPercent: req.ThresholdPercent,
+        NotifyURL:        req.NotifyURL,
+    }
+
+    subscription, err := ec.estimateUseCase.Subscribe(input)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusCreated, subscription)
+}
+
+// GetSubscriptions handles GET /api/estimates/:id/subscriptions
+func (ec *EstimateController) GetSubscriptions(c echo.Context) error {
+    id := c.Param("id")
+    subscriptions, err := ec.estimateUseCase.GetSubscriptions(id)
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, subscriptions)
+}
+
+// Unsubscribe handles DELETE /api/subscriptions/:id
+func (ec *EstimateController) Unsubscribe(c echo.Context) error {
+    id := c.Param("id")
+    if err := ec.estimateUseCase.Unsubscribe(id); err != nil {
+        return httpError(err)
+    }
+    return c.NoContent(http.StatusNoContent)
 }
\ No newline at end of file
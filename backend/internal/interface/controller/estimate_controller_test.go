@@ -0,0 +1,706 @@
+package controller
+
+import (
+    "bytes"
+    "math"
+    "net/http"
+    "testing"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/infrastructure/memory"
+    "estimate-backend/internal/usecase"
+)
+
+func TestMigrationEffortStatus(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    valid := MigrationEffortRequest{SourceTables: 10, RecordVolume: 100000, TransformationComplexity: 3}
+    invalid := MigrationEffortRequest{SourceTables: 0, TransformationComplexity: 3}
+
+    rec := s.request(http.MethodPost, "/api/estimates/"+estimate.ID+"/migration", valid)
+    assertStatus(t, rec, http.StatusOK)
+
+    rec = s.request(http.MethodPost, "/api/estimates/"+estimate.ID+"/migration", invalid)
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors ValidationErrors `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "sourceTables" {
+        t.Errorf("errors = %v, want one naming sourceTables", body.Errors)
+    }
+
+    rec = s.request(http.MethodPost, "/api/estimates/unknown/migration", valid)
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestGetPlanningConfidence(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    rec := s.request(http.MethodGet, "/api/estimates/"+estimate.ID+"/planning-confidence", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var body struct {
+        Confidence float64 `json:"confidence"`
+    }
+    decode(t, rec, &body)
+    if body.Confidence != 0.25 {
+        t.Errorf("confidence = %v, want 0.25 before any deliverable is complete", body.Confidence)
+    }
+
+    rec = s.request(http.MethodGet, "/api/estimates/unknown/planning-confidence", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestSubscribeValidation(t *testing.T) {
+    s := newTestServer(t)
+    s.estimates.SetDriftNotification(memory.NewInMemorySubscriptionRepository(), nil)
+    estimate := s.createEstimate(t)
+    path := "/api/estimates/" + estimate.ID + "/subscriptions"
+
+    rec := s.request(http.MethodPost, path, SubscribeRequest{ThresholdPercent: 10, NotifyURL: "https://example.com/hook"})
+    assertStatus(t, rec, http.StatusCreated)
+
+    rec = s.request(http.MethodPost, path, SubscribeRequest{NotifyURL: "https://example.com/hook"})
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors ValidationErrors `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "thresholdPercent" {
+        t.Errorf("errors = %v, want one naming thresholdPercent", body.Errors)
+    }
+
+    // Internal webhook targets are rejected
+    rec = s.request(http.MethodPost, path, SubscribeRequest{ThresholdPercent: 10, NotifyURL: "http://127.0.0.1:8080/hook"})
+    assertStatus(t, rec, http.StatusBadRequest)
+
+    rec = s.request(http.MethodPost, "/api/estimates/unknown/subscriptions", SubscribeRequest{ThresholdPercent: 10, NotifyURL: "https://example.com/hook"})
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestGetEffortByWorkTypeStatus(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    rec := s.request(http.MethodGet, "/api/estimates/"+estimate.ID+"/by-work-type", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var body map[string]float64
+    decode(t, rec, &body)
+    if body["new"] != estimate.TotalHours {
+        t.Errorf("new = %v hours, want the total %v of the untyped task", body["new"], estimate.TotalHours)
+    }
+
+    rec = s.request(http.MethodGet, "/api/estimates/unknown/by-work-type", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestCreateEstimateRejectsUnknownWorkType(t *testing.T) {
+    s := newTestServer(t)
+    task := s.task(t, domain.ProcessImplementation, 1)
+    task.WorkType = "refactor"
+
+    rec := s.request(http.MethodPost, "/api/estimates", map[string]interface{}{
+        "projectId":   "project-1",
+        "projectName": "Project 1",
+        "tasks":       []usecase.TaskInput{task},
+    })
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors ValidationErrors `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "tasks[0].workType" {
+        t.Errorf("errors = %v, want one naming tasks[0].workType", body.Errors)
+    }
+}
+
+func TestAnalogyEstimateStatus(t *testing.T) {
+    s := newTestServer(t)
+    attributes := domain.ProjectAttributes{SizeKSLOC: 20, FunctionCount: 10, Complexity: 3, TeamExperience: 3}
+
+    rec := s.request(http.MethodPost, "/api/estimates/analogy", AnalogyEstimateRequest{Attributes: attributes})
+    assertStatus(t, rec, http.StatusBadRequest)
+
+    // No completed estimates to compare with yet
+    rec = s.request(http.MethodPost, "/api/estimates/analogy", AnalogyEstimateRequest{Attributes: attributes, K: 3})
+    assertStatus(t, rec, http.StatusBadRequest)
+
+    estimate := s.createEstimate(t)
+    _, err := s.estimates.UpdateEstimate(usecase.UpdateEstimateInput{
+        ID:          estimate.ID,
+        Version:     estimate.Version,
+        Tasks:       []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 1)},
+        Attributes:  attributes,
+        ActualHours: 1200,
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rec = s.request(http.MethodPost, "/api/estimates/analogy", AnalogyEstimateRequest{Attributes: attributes, K: 3})
+    assertStatus(t, rec, http.StatusOK)
+    var body domain.AnalogyEstimate
+    decode(t, rec, &body)
+    if body.EstimatedHours != 1200 {
+        t.Errorf("EstimatedHours = %v, want the actual 1200 of the exact analog", body.EstimatedHours)
+    }
+}
+
+func TestTriangulateEstimateStatus(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    rec := s.request(http.MethodPost, "/api/estimates/"+estimate.ID+"/triangulate", TriangulateEstimateRequest{})
+    assertStatus(t, rec, http.StatusOK)
+    var body domain.Triangulation
+    decode(t, rec, &body)
+    if len(body.Results) != 1 || math.Abs(body.ConsensusHours-estimate.TotalHours) > 1e-9 {
+        t.Errorf("triangulation = %+v, want the activity-based result alone", body)
+    }
+
+    rec = s.request(http.MethodPost, "/api/estimates/unknown/triangulate", TriangulateEstimateRequest{})
+    assertStatus(t, rec, http.StatusNotFound)
+
+    rec = s.request(http.MethodPost, "/api/estimates/"+estimate.ID+"/triangulate", `{"attributes": []}`)
+    assertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestUpdateEstimateRequirements(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+    path := "/api/estimates/" + estimate.ID
+
+    rec := s.request(http.MethodPut, path, UpdateEstimateRequest{
+        Version:            estimate.Version,
+        Tasks:              []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 1)},
+        AccessibilityLevel: "AAAA",
+    })
+    assertStatus(t, rec, http.StatusBadRequest)
+
+    rec = s.request(http.MethodPut, path, UpdateEstimateRequest{
+        Version:               estimate.Version,
+        Tasks:                 []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 1)},
+        LocalizationLanguages: []string{"en", "zh"},
+    })
+    assertStatus(t, rec, http.StatusOK)
+    var body domain.Estimate
+    decode(t, rec, &body)
+    if want := estimate.TotalHours + 2*domain.LocalizationHoursPerLanguage; math.Abs(body.TotalHours-want) > 1e-9 {
+        t.Errorf("TotalHours = %v, want %v with two languages", body.TotalHours, want)
+    }
+}
+
+func TestCreateEstimateReturnsID(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodPost, "/api/estimates", CreateEstimateRequest{
+        ProjectID:   "project-1",
+        ProjectName: "Project 1",
+        Tasks:       []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 1)},
+    })
+    assertStatus(t, rec, http.StatusCreated)
+    var body domain.Estimate
+    decode(t, rec, &body)
+    if body.ID == "" {
+        t.Fatal("created estimate has no ID")
+    }
+
+    rec = s.request(http.MethodGet, "/api/estimates/"+body.ID, nil)
+    assertStatus(t, rec, http.StatusOK)
+}
+
+func TestGetEstimateNotFound(t *testing.T) {
+    s := newTestServer(t)
+
+    for _, path := range []string{"/api/estimates/unknown", "/api/estimates/unknown/detailed"} {
+        rec := s.request(http.MethodGet, path, nil)
+        assertStatus(t, rec, http.StatusNotFound)
+    }
+}
+
+func TestExportPDFStatus(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    rec := s.request(http.MethodGet, "/api/estimates/"+estimate.ID+"/export.pdf?hourlyRate=5000", nil)
+    assertStatus(t, rec, http.StatusOK)
+    if ct := rec.Header().Get(echo.HeaderContentType); ct != "application/pdf" {
+        t.Errorf("Content-Type = %s, want application/pdf", ct)
+    }
+    if !bytes.HasPrefix(rec.Body.Bytes(), []byte("%PDF-")) {
+        t.Error("body is not a PDF")
+    }
+
+    rec = s.request(http.MethodGet, "/api/estimates/unknown/export.pdf", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestExportXLSXStatus(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    rec := s.request(http.MethodGet, "/api/estimates/"+estimate.ID+"/export.xlsx", nil)
+    assertStatus(t, rec, http.StatusOK)
+    if cd := rec.Header().Get(echo.HeaderContentDisposition); cd != `attachment; filename="estimate-`+estimate.ID+`.xlsx"` {
+        t.Errorf("Content-Disposition = %s", cd)
+    }
+
+    rec = s.request(http.MethodGet, "/api/estimates/unknown/export.xlsx", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestExportImportJSON(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    rec := s.request(http.MethodGet, "/api/estimates/"+estimate.ID+"/export.json", nil)
+    assertStatus(t, rec, http.StatusOK)
+    if cd := rec.Header().Get(echo.HeaderContentDisposition); cd != `attachment; filename="estimate-`+estimate.ID+`.json"` {
+        t.Errorf("Content-Disposition = %s", cd)
+    }
+
+    rec = s.request(http.MethodPost, "/api/estimates/import", rec.Body.String())
+    assertStatus(t, rec, http.StatusCreated)
+    var imported domain.Estimate
+    decode(t, rec, &imported)
+    if imported.ID == estimate.ID || math.Abs(imported.TotalHours-estimate.TotalHours) > 1e-9 {
+        t.Errorf("imported %s with %v hours, want a new estimate with the original %v", imported.ID, imported.TotalHours, estimate.TotalHours)
+    }
+
+    rec = s.request(http.MethodGet, "/api/estimates/unknown/export.json", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+    rec = s.request(http.MethodPost, "/api/estimates/import", `{"format":"other"}`)
+    assertStatus(t, rec, http.StatusBadRequest)
+}
+
+// createCOCOMOEstimate creates an estimate with one implementation task and nominal COCOMO II data through the API
+func (s *testServer) createCOCOMOEstimate(t *testing.T) *domain.Estimate {
+    t.Helper()
+    nominal := nominalCOCOMORequest(10)
+    rec := s.request(http.MethodPost, "/api/estimates", CreateEstimateRequest{
+        ProjectID:   "project-1",
+        ProjectName: "Project 1",
+        Tasks:       []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 1)},
+        COCOMOData: &usecase.COCOMOInput{
+            ModelID:      domain.ModelPostArchitectureID,
+            KSLOC:        10,
+            ScaleFactors: nominal.ScaleFactors,
+            CostDrivers:  nominal.CostDrivers,
+        },
+    })
+    assertStatus(t, rec, http.StatusCreated)
+    var created domain.Estimate
+    decode(t, rec, &created)
+    return &created
+}
+
+func TestGetDetailedEstimateListsMethodResults(t *testing.T) {
+    s := newTestServer(t)
+    created := s.createCOCOMOEstimate(t)
+
+    rec := s.request(http.MethodGet, "/api/estimates/"+created.ID+"/detailed", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var body struct {
+        TotalHours     float64
+        ActivityResult *domain.CalculationResult
+        COCOMOResult   *domain.CalculationResult
+        MethodResults  []MethodResultResponse `json:"methodResults"`
+    }
+    decode(t, rec, &body)
+
+    if body.ActivityResult == nil || body.COCOMOResult == nil {
+        t.Fatalf("body = %s, want both method results", rec.Body.String())
+    }
+    if len(body.MethodResults) != 2 {
+        t.Errorf("got %d method results, want activity and COCOMO", len(body.MethodResults))
+    }
+    low := math.Min(body.ActivityResult.TotalHours, body.COCOMOResult.TotalHours)
+    high := math.Max(body.ActivityResult.TotalHours, body.COCOMOResult.TotalHours)
+    if body.TotalHours < low || body.TotalHours > high {
+        t.Errorf("TotalHours = %v, want between %v and %v", body.TotalHours, low, high)
+    }
+}
+
+func TestGetDetailedEstimateShowsMethodConfidences(t *testing.T) {
+    s := newTestServer(t)
+    created := s.createCOCOMOEstimate(t)
+
+    rec := s.request(http.MethodGet, "/api/estimates/"+created.ID+"/detailed", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var body struct {
+        MethodResults []map[string]interface{} `json:"methodResults"`
+    }
+    decode(t, rec, &body)
+
+    confidences := make(map[string]interface{})
+    var shares float64
+    for _, result := range body.MethodResults {
+        method, _ := result["method"].(string)
+        confidences[method] = result["confidence"]
+        if _, ok := result["stdDevHours"]; !ok {
+            t.Errorf("%s result %v has no stdDevHours", method, result)
+        }
+        share, _ := result["share"].(float64)
+        shares += share
+    }
+    for method, want := range map[domain.CalculationMethod]float64{
+        domain.CalculationMethodActivity: created.ActivityResult.Confidence,
+        domain.CalculationMethodCOCOMO:   created.COCOMOResult.Confidence,
+    } {
+        if got := confidences[string(method)]; got != want {
+            t.Errorf("%s confidence %v in the JSON, want %v", method, got, want)
+        }
+    }
+    if math.Abs(shares-1) > 1e-9 {
+        t.Errorf("shares sum to %v, want 1", shares)
+    }
+}
+
+func TestGetCriticalPath(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    rec := s.request(http.MethodGet, "/api/estimates/"+estimate.ID+"/critical-path", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var body domain.CriticalPathResult
+    decode(t, rec, &body)
+    if len(body.Path) != 1 || body.DurationHours <= 0 {
+        t.Errorf("critical path = %+v, want the single task", body)
+    }
+
+    rec = s.request(http.MethodGet, "/api/estimates/unknown/critical-path", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestRecalculateEstimatesEndpoint(t *testing.T) {
+    s := newTestServer(t)
+    s.createEstimate(t)
+
+    rec := s.request(http.MethodPost, "/api/estimates/recalculate", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var summary usecase.RecalculationSummary
+    decode(t, rec, &summary)
+    if summary.Recalculated != 1 || summary.Changed != 0 {
+        t.Errorf("summary = %+v, want 1 recalculated and nothing changed", summary)
+    }
+
+    rec = s.request(http.MethodPost, "/api/estimates/recalculate", `{"projectId": 1}`)
+    assertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestGetProjectEstimatesIsPaginated(t *testing.T) {
+    s := newTestServer(t)
+    for i := 0; i < 3; i++ {
+        s.createEstimate(t)
+    }
+
+    rec := s.request(http.MethodGet, "/api/projects/project-1/estimates?page=2&pageSize=2", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var page usecase.EstimatePage
+    decode(t, rec, &page)
+    if page.Page != 2 || page.PageSize != 2 || page.Total != 3 || len(page.Items) != 1 {
+        t.Errorf("page %d of size %d with %d items of %d, want the last item on page 2", page.Page, page.PageSize, len(page.Items), page.Total)
+    }
+
+    rec = s.request(http.MethodGet, "/api/projects/project-1/estimates?page=5&pageSize=2", nil)
+    assertStatus(t, rec, http.StatusOK)
+    decode(t, rec, &page)
+    if len(page.Items) != 0 {
+        t.Errorf("got %d items past the last page, want none", len(page.Items))
+    }
+}
+
+func TestListEstimatesByStatus(t *testing.T) {
+    s := newTestServer(t)
+    s.createEstimate(t)
+    completed := s.createEstimate(t)
+    rec := s.request(http.MethodPut, "/api/estimates/"+completed.ID+"/status", TransitionStatusRequest{Status: "completed"})
+    assertStatus(t, rec, http.StatusOK)
+
+    tests := []struct {
+        query string
+        total int
+    }{
+        {"?status=completed", 1},
+        {"?status=draft,completed", 2},
+        {"?status=approved", 0},
+    }
+    for _, tt := range tests {
+        rec := s.request(http.MethodGet, "/api/estimates"+tt.query, nil)
+        assertStatus(t, rec, http.StatusOK)
+        var page usecase.EstimatePage
+        decode(t, rec, &page)
+        if page.Total != tt.total {
+            t.Errorf("%s: Total = %d, want %d", tt.query, page.Total, tt.total)
+        }
+    }
+
+    rec = s.request(http.MethodGet, "/api/estimates?status=archived", nil)
+    assertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestDeleteAndRestoreEstimate(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    rec := s.request(http.MethodDelete, "/api/estimates/"+estimate.ID, nil)
+    assertStatus(t, rec, http.StatusNoContent)
+    rec = s.request(http.MethodGet, "/api/estimates/"+estimate.ID, nil)
+    assertStatus(t, rec, http.StatusNotFound)
+
+    rec = s.request(http.MethodPost, "/api/estimates/"+estimate.ID+"/restore", nil)
+    assertStatus(t, rec, http.StatusOK)
+    rec = s.request(http.MethodGet, "/api/estimates/"+estimate.ID, nil)
+    assertStatus(t, rec, http.StatusOK)
+
+    rec = s.request(http.MethodPost, "/api/estimates/unknown/restore", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestGetProjectSummary(t *testing.T) {
+    s := newTestServer(t)
+    s.createEstimate(t)
+    completed := s.createEstimate(t)
+    rec := s.request(http.MethodPut, "/api/estimates/"+completed.ID+"/status", TransitionStatusRequest{Status: "completed"})
+    assertStatus(t, rec, http.StatusOK)
+
+    rec = s.request(http.MethodGet, "/api/projects/project-1/summary?hourlyRate=5000", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var summary domain.ProjectSummary
+    decode(t, rec, &summary)
+    if summary.EstimateCount != 2 || summary.CountByStatus[domain.EstimateStatusDraft] != 1 || summary.CountByStatus[domain.EstimateStatusCompleted] != 1 {
+        t.Errorf("summary = %+v, want one draft and one completed estimate", summary)
+    }
+    if want := summary.TotalHours * 5000; math.Abs(summary.TotalCost-want) > 1e-6 {
+        t.Errorf("TotalCost = %v, want %v", summary.TotalCost, want)
+    }
+
+    // A project without estimates sums to zero
+    rec = s.request(http.MethodGet, "/api/projects/empty/summary", nil)
+    assertStatus(t, rec, http.StatusOK)
+    summary = domain.ProjectSummary{}
+    decode(t, rec, &summary)
+    if summary.EstimateCount != 0 || summary.TotalHours != 0 || summary.AverageConfidence != 0 {
+        t.Errorf("summary = %+v, want zeros", summary)
+    }
+}
+
+func TestGetSchedule(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    rec := s.request(http.MethodGet, "/api/estimates/"+estimate.ID+"/schedule?start=2024-03-01&holidays=2024-03-04", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var schedule domain.ProjectSchedule
+    decode(t, rec, &schedule)
+    if schedule.WorkingDays <= 0 || !schedule.EndDate.After(schedule.StartDate) {
+        t.Errorf("schedule = %+v, want an end date after the start", schedule)
+    }
+
+    for _, query := range []string{"", "?start=01/03/2024", "?start=2024-03-01&holidays=tomorrow", "?start=2024-03-01&workingDays=five"} {
+        rec := s.request(http.MethodGet, "/api/estimates/"+estimate.ID+"/schedule"+query, nil)
+        assertStatus(t, rec, http.StatusBadRequest)
+    }
+}
+
+func TestCreateEstimatesMixedBatch(t *testing.T) {
+    s := newTestServer(t)
+    unknownProcess := s.task(t, domain.ProcessImplementation, 1)
+    unknownProcess.ProcessID = "unknown"
+
+    rec := s.request(http.MethodPost, "/api/estimates/batch", []CreateEstimateRequest{
+        {ProjectID: "batch", ProjectName: "Valid 1", Tasks: []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 1)}},
+        {ProjectID: "batch"},
+        {ProjectID: "batch", ProjectName: "Unknown process", Tasks: []usecase.TaskInput{unknownProcess}},
+        {ProjectID: "batch", ProjectName: "Valid 2", Tasks: []usecase.TaskInput{s.task(t, domain.ProcessTesting, 0)}},
+    })
+    assertStatus(t, rec, http.StatusMultiStatus)
+    var body BatchCreateResponse
+    decode(t, rec, &body)
+
+    if body.Created != 2 || body.Failed != 2 {
+        t.Errorf("created %d and failed %d, want 2 and 2", body.Created, body.Failed)
+    }
+    for i, want := range []int{http.StatusCreated, http.StatusBadRequest, http.StatusNotFound, http.StatusCreated} {
+        result := body.Results[i]
+        if result.Index != i || result.Status != want {
+            t.Errorf("result %d: index %d with status %d, want status %d", i, result.Index, result.Status, want)
+        }
+        if (want == http.StatusCreated) != (result.Estimate != nil) {
+            t.Errorf("result %d: estimate %v with status %d", i, result.Estimate, result.Status)
+        }
+    }
+    if len(body.Results[1].Errors) == 0 || body.Results[1].Errors[0].Field != "projectName" {
+        t.Errorf("invalid item errors = %v, want one on projectName", body.Results[1].Errors)
+    }
+
+    estimates, err := s.estimates.GetProjectEstimates("batch")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(estimates) != 2 {
+        t.Errorf("got %d stored estimates, want the 2 valid items", len(estimates))
+    }
+}
+
+// countingEstimateRepository counts the writes to an estimate repository
+type countingEstimateRepository struct {
+    domain.EstimateRepository
+    writes int
+}
+
+func (r *countingEstimateRepository) Save(estimate *domain.Estimate) error {
+    r.writes++
+    return r.EstimateRepository.Save(estimate)
+}
+
+func (r *countingEstimateRepository) Update(estimate *domain.Estimate) error {
+    r.writes++
+    return r.EstimateRepository.Update(estimate)
+}
+
+// countingCOCOMORepository counts the COCOMO II estimates saved to a repository
+type countingCOCOMORepository struct {
+    domain.COCOMORepository
+    writes int
+}
+
+func (r *countingCOCOMORepository) SaveEstimate(estimate *domain.COCOMOEstimate) error {
+    r.writes++
+    return r.COCOMORepository.SaveEstimate(estimate)
+}
+
+func TestCalculateEstimateNeverSaves(t *testing.T) {
+    s := newTestServer(t)
+    estimates := &countingEstimateRepository{EstimateRepository: memory.NewInMemoryEstimateRepository()}
+    cocomoRepo := &countingCOCOMORepository{COCOMORepository: memory.NewInMemoryCOCOMORepository()}
+    configUseCase := usecase.NewConfigUseCase()
+    cocomoUseCase := usecase.NewCOCOMOUseCase(cocomoRepo, configUseCase)
+    for _, initialize := range []func() error{cocomoUseCase.InitializeDefaultModel, cocomoUseCase.InitializeScaleFactors, cocomoUseCase.InitializeCostDrivers} {
+        if err := initialize(); err != nil {
+            t.Fatal(err)
+        }
+    }
+    e := echo.New()
+    e.Validator = NewRequestValidator()
+    estimateUseCase := usecase.NewEstimateUseCase(estimates, s.processes, s.factors, memory.NewInMemoryTaskRepository(), cocomoRepo, configUseCase)
+    NewEstimateController(estimateUseCase).RegisterRoutes(e)
+    s.echo = e
+
+    cocomo := nominalCOCOMORequest(20)
+    rec := s.request(http.MethodPost, "/api/estimates/calculate", CreateEstimateRequest{
+        ProjectID:   "dry-run",
+        ProjectName: "Dry run",
+        Tasks:       []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 1)},
+        COCOMOData: &usecase.COCOMOInput{
+            ModelID:      cocomo.ModelID,
+            KSLOC:        cocomo.KSLOC,
+            ScaleFactors: cocomo.ScaleFactors,
+            CostDrivers:  cocomo.CostDrivers,
+        },
+    })
+    assertStatus(t, rec, http.StatusOK)
+    var estimate domain.Estimate
+    decode(t, rec, &estimate)
+
+    if estimate.TotalHours <= 0 {
+        t.Errorf("TotalHours = %v, want a calculated total", estimate.TotalHours)
+    }
+    if estimates.writes != 0 || cocomoRepo.writes != 0 {
+        t.Errorf("%d estimate and %d COCOMO II writes, want none", estimates.writes, cocomoRepo.writes)
+    }
+    if stored, _ := estimates.FindByProjectID("dry-run"); len(stored) != 0 {
+        t.Errorf("got %d stored estimates, want none", len(stored))
+    }
+
+    // The same body is stored through the create route
+    rec = s.request(http.MethodPost, "/api/estimates", CreateEstimateRequest{
+        ProjectID:   "dry-run",
+        ProjectName: "Dry run",
+        Tasks:       []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 1)},
+    })
+    assertStatus(t, rec, http.StatusCreated)
+    if estimates.writes == 0 {
+        t.Error("create route recorded no writes")
+    }
+}
+
+func TestRecalculateEstimateReflectsBaseHours(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+
+    process, err := s.processes.FindByCategory(domain.ProcessImplementation)
+    if err != nil {
+        t.Fatal(err)
+    }
+    process.Activities[1].BaseHours *= 2
+    if err := s.processes.Update(process); err != nil {
+        t.Fatal(err)
+    }
+
+    rec := s.request(http.MethodPost, "/api/estimates/"+estimate.ID+"/recalculate", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var body RecalculateEstimateResponse
+    decode(t, rec, &body)
+
+    // The only task is on the changed activity, so its total doubles
+    if body.Change.PreviousHours != estimate.TotalHours || math.Abs(body.Change.CurrentHours-2*estimate.TotalHours) > 1e-9 {
+        t.Errorf("change %+v, want %v doubled", body.Change, estimate.TotalHours)
+    }
+    if body.Estimate.TotalHours != body.Change.CurrentHours {
+        t.Errorf("returned total %v, want the current %v", body.Estimate.TotalHours, body.Change.CurrentHours)
+    }
+    stored, err := s.estimates.GetEstimate(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if stored.TotalHours != body.Change.CurrentHours {
+        t.Errorf("stored total %v, want the recalculated %v", stored.TotalHours, body.Change.CurrentHours)
+    }
+
+    rec = s.request(http.MethodPost, "/api/estimates/missing/recalculate", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestUpdateEstimateConcurrency(t *testing.T) {
+    s := newTestServer(t)
+    estimate := s.createEstimate(t)
+    path := "/api/estimates/" + estimate.ID
+    update := UpdateEstimateRequest{Tasks: []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 2)}}
+
+    rec := s.request(http.MethodGet, path, nil)
+    assertStatus(t, rec, http.StatusOK)
+    etag := rec.Header().Get("ETag")
+    if etag != `"1"` {
+        t.Fatalf("ETag = %s, want \"1\"", etag)
+    }
+
+    // A fresh update succeeds and moves the ETag on
+    rec = s.requestWithHeader(http.MethodPut, path, update, http.Header{"If-Match": {etag}})
+    assertStatus(t, rec, http.StatusOK)
+    if next := rec.Header().Get("ETag"); next != `"2"` {
+        t.Errorf("ETag after the update = %s, want \"2\"", next)
+    }
+
+    // Another client still holding the first ETag is rejected, by header or by body
+    rec = s.requestWithHeader(http.MethodPut, path, update, http.Header{"If-Match": {etag}})
+    assertStatus(t, rec, http.StatusConflict)
+    update.Version = 1
+    rec = s.request(http.MethodPut, path, update)
+    assertStatus(t, rec, http.StatusConflict)
+
+    stored, err := s.estimates.GetEstimate(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if stored.Version != 2 {
+        t.Errorf("stored version %d, want 2 after one accepted update", stored.Version)
+    }
+
+    update.Version = 2
+    rec = s.request(http.MethodPut, path, update)
+    assertStatus(t, rec, http.StatusOK)
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+    "net/http"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/usecase"
+    "estimate-backend/internal/domain"
+)
+
+// FactorController handles HTTP requests for estimation factor management
+type FactorController struct {
+    factorUseCase *usecase.FactorUseCase
+}
+
+// NewFactorController creates a new FactorController
+func NewFactorController(fu *usecase.FactorUseCase) *FactorController {
+    return &FactorController{
+        factorUseCase: fu,
+    }
+}
+
+// RegisterRoutes registers the routes for factor management
+func (fc *FactorController) RegisterRoutes(e *echo.Echo) {
+    e.POST("/api/factors", fc.CreateFactor)
+    e.GET("/api/factors", fc.GetAllFactors)
+    e.GET("/api/factors/:id", fc.GetFactor)
+    e.PUT("/api/factors/:id", fc.UpdateFactor)
+    e.DELETE("/api/factors/:id", fc.DeleteFactor)
+}
+
+// FactorRequest represents the request body for creating or updating a factor
+type FactorRequest struct {
+    Type        string  `json:"type"`
+    Name        string  `json:"name" validate:"required"`
+    Description string  `json:"description"`
+    Impact      float64 `json:"impact" validate:"gt=0"`
+}
+
+// CreateFactor handles POST /api/factors
+func (fc *FactorController) CreateFactor(c echo.Context) error {
+    var req FactorRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    input := usecase.CreateFactorInput{
+        Type:        domain.FactorType(req.Type),
+        Name:        req.Name,
+        Description: req.Description,
+        Impact:      req.Impact,
+        Actor:       actor(c),
+    }
+
+    factor, err := fc.factorUseCase.CreateFactor(input)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusCreated, factor)
+}
+
+// GetAllFactors handles GET /api/factors
+func (fc *FactorController) GetAllFactors(c echo.Context) error {
+    factors, err := fc.factorUseCase.GetAllFactors()
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, factors)
+}
+
+// GetFactor handles GET /api/factors/:id
+func (fc *FactorController) GetFactor(c echo.Context) error {
+    id := c.Param("id")
+    factor, err := fc.factorUseCase.GetFactor(id)
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, factor)
+}
+
+// UpdateFactor handles PUT /api/factors/:id
+func (fc *FactorController) UpdateFactor(c echo.Context) error {
+    id := c.Param("id")
+    var req FactorRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    input := usecase.UpdateFactorInput{
+        ID:          id,
+        Type:        domain.FactorType(req.Type),
+        Name:        req.Name,
+        Description: req.Description,
+        Impact:      req.Impact,
+        Actor:       actor(c),
+    }
+
+    factor, err := fc.factorUseCase.UpdateFactor(input)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, factor)
+}
+
+// DeleteFactor handles DELETE /api/factors/:id
+func (fc *FactorController) DeleteFactor(c echo.Context) error {
+    id := c.Param("id")
+    if err := fc.factorUseCase.DeleteFactor(id, actor(c)); err != nil {
+        return httpError(err)
+    }
+    return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+    "net/http"
+    "testing"
+
+    "estimate-backend/internal/domain"
+)
+
+func TestFactorCRUD(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodPost, "/api/factors", FactorRequest{Type: "team", Name: "新メンバー多数", Impact: 1.2})
+    assertStatus(t, rec, http.StatusCreated)
+    var created domain.Factor
+    decode(t, rec, &created)
+    if created.ID == "" || created.Name != "新メンバー多数" {
+        t.Fatalf("created %+v, want the factor with an ID", created)
+    }
+
+    rec = s.request(http.MethodGet, "/api/factors/"+created.ID, nil)
+    assertStatus(t, rec, http.StatusOK)
+
+    rec = s.request(http.MethodPut, "/api/factors/"+created.ID, FactorRequest{Type: "team", Name: "新メンバー多数", Impact: 1.3})
+    assertStatus(t, rec, http.StatusOK)
+    var updated domain.Factor
+    decode(t, rec, &updated)
+    if updated.Impact != 1.3 {
+        t.Errorf("Impact = %v after update, want 1.3", updated.Impact)
+    }
+
+    rec = s.request(http.MethodGet, "/api/factors", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var all []domain.Factor
+    decode(t, rec, &all)
+    found := false
+    for _, factor := range all {
+        found = found || factor.ID == created.ID
+    }
+    if !found {
+        t.Errorf("factor %s missing from the list", created.ID)
+    }
+
+    rec = s.request(http.MethodDelete, "/api/factors/"+created.ID, nil)
+    assertStatus(t, rec, http.StatusNoContent)
+    rec = s.request(http.MethodGet, "/api/factors/"+created.ID, nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestFactorValidationAndNotFound(t *testing.T) {
+    s := newTestServer(t)
+
+    tests := []struct {
+        name  string
+        req   FactorRequest
+        field string
+    }{
+        {"empty name", FactorRequest{Impact: 1.2}, "name"},
+        {"zero impact", FactorRequest{Name: "新メンバー多数"}, "impact"},
+        {"negative impact", FactorRequest{Name: "新メンバー多数", Impact: -1}, "impact"},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            rec := s.request(http.MethodPost, "/api/factors", tt.req)
+            assertStatus(t, rec, http.StatusBadRequest)
+            var body struct {
+                Errors ValidationErrors `json:"errors"`
+            }
+            decode(t, rec, &body)
+            if len(body.Errors) != 1 || body.Errors[0].Field != tt.field {
+                t.Errorf("errors = %v, want one naming %s", body.Errors, tt.field)
+            }
+        })
+    }
+
+    rec := s.request(http.MethodGet, "/api/factors/unknown", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+    rec = s.request(http.MethodPut, "/api/factors/unknown", FactorRequest{Name: "x", Impact: 1})
+    assertStatus(t, rec, http.StatusNotFound)
+    rec = s.request(http.MethodDelete, "/api/factors/unknown", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
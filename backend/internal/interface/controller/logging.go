@@ -0,0 +1,66 @@
+package controller
+
+import (
+    "context"
+    "log/slog"
+
+    "github.com/labstack/echo/v4"
+    "github.com/labstack/echo/v4/middleware"
+    "estimate-backend/internal/domain"
+)
+
+// requestIDKey is the context key of the request ID
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the ID of the request a context belongs to, or "" outside a request
+func RequestIDFromContext(ctx context.Context) string {
+    id, _ := ctx.Value(requestIDKey{}).(string)
+    return id
+}
+
+// RequestID is a middleware that forwards the X-Request-ID header of the request, or generates one,
+// echoes it in the response and stores it in the request context
+func RequestID() echo.MiddlewareFunc {
+    return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+        Generator: domain.NewID,
+        RequestIDHandler: func(c echo.Context, id string) {
+            c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), requestIDKey{}, id)))
+        },
+    })
+}
+
+// RequestLogger is a middleware that logs each request as a JSON line with its method, path, status,
+// latency and request ID, and the error of failed requests, at warning level for client errors and
+// error level for server errors. It runs the error handler itself, so the
+// logged status is the one sent.
+func RequestLogger(logger *slog.Logger) echo.MiddlewareFunc {
+    return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+        HandleError:  true,
+        LogMethod:    true,
+        LogURIPath:   true,
+        LogStatus:    true,
+        LogLatency:   true,
+        LogRequestID: true,
+        LogError:     true,
+        LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+            attrs := []slog.Attr{
+                slog.String("method", v.Method),
+                slog.String("path", v.URIPath),
+                slog.Int("status", v.Status),
+                slog.Duration("latency", v.Latency),
+                slog.String("requestId", v.RequestID),
+            }
+            level := slog.LevelInfo
+            if v.Status >= 500 {
+                level = slog.LevelError
+            } else if v.Status >= 400 {
+                level = slog.LevelWarn
+            }
+            if v.Error != nil {
+                attrs = append(attrs, slog.String("error", v.Error.Error()))
+            }
+            logger.LogAttrs(c.Request().Context(), level, "request", attrs...)
+            return nil
+        },
+    })
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+    "bytes"
+    "encoding/json"
+    "log/slog"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/labstack/echo/v4"
+)
+
+// newLoggedServer returns a server with the request ID and logging middleware logging into a buffer,
+// and a GET /ping route that answers the request ID it sees in its context
+func newLoggedServer() (*echo.Echo, *bytes.Buffer) {
+    var logs bytes.Buffer
+    e := echo.New()
+    e.Use(RequestID())
+    e.Use(RequestLogger(slog.New(slog.NewJSONHandler(&logs, nil))))
+    e.GET("/ping", func(c echo.Context) error {
+        return c.String(http.StatusOK, RequestIDFromContext(c.Request().Context()))
+    })
+    return e, &logs
+}
+
+// logLine decodes the single JSON log line written to logs
+func logLine(t *testing.T, logs *bytes.Buffer) map[string]interface{} {
+    t.Helper()
+    var line map[string]interface{}
+    if err := json.Unmarshal(logs.Bytes(), &line); err != nil {
+        t.Fatalf("log %q is not one JSON line: %v", logs.String(), err)
+    }
+    return line
+}
+
+func TestRequestIDIsGeneratedAndLogged(t *testing.T) {
+    e, logs := newLoggedServer()
+    rec := httptest.NewRecorder()
+    e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+    id := rec.Header().Get(echo.HeaderXRequestID)
+    if id == "" {
+        t.Fatal("response has no X-Request-ID header")
+    }
+    if rec.Body.String() != id {
+        t.Errorf("handler saw request ID %q, want %q", rec.Body.String(), id)
+    }
+    line := logLine(t, logs)
+    if line["requestId"] != id {
+        t.Errorf("log line request ID %v, want %q", line["requestId"], id)
+    }
+    if line["method"] != http.MethodGet || line["path"] != "/ping" || line["status"] != float64(http.StatusOK) {
+        t.Errorf("log line %v, want GET /ping with status 200", line)
+    }
+}
+
+func TestRequestIDIsForwarded(t *testing.T) {
+    e, logs := newLoggedServer()
+    req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+    req.Header.Set(echo.HeaderXRequestID, "client-id")
+    rec := httptest.NewRecorder()
+    e.ServeHTTP(rec, req)
+
+    if id := rec.Header().Get(echo.HeaderXRequestID); id != "client-id" {
+        t.Errorf("response X-Request-ID %q, want the forwarded client-id", id)
+    }
+    if id := logLine(t, logs)["requestId"]; id != "client-id" {
+        t.Errorf("log line request ID %v, want client-id", id)
+    }
+}
+
+func TestRequestLoggerLogsClientErrorsAsWarnings(t *testing.T) {
+    e, logs := newLoggedServer()
+    rec := httptest.NewRecorder()
+    e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+    line := logLine(t, logs)
+    if line["status"] != float64(http.StatusNotFound) || line["level"] != "WARN" {
+        t.Errorf("log line %v, want status 404 at WARN level", line)
+    }
+    if line["requestId"] != rec.Header().Get(echo.HeaderXRequestID) {
+        t.Errorf("log line request ID %v, want the response's %q", line["requestId"], rec.Header().Get(echo.HeaderXRequestID))
+    }
+}
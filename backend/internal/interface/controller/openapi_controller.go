@@ -0,0 +1,145 @@
+package controller
+
+import (
+    "net/http"
+    "sort"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/interface/openapi"
+    "estimate-backend/internal/usecase"
+)
+
+// OpenAPIController serves an OpenAPI spec generated from the routes registered on the server
+type OpenAPIController struct {
+    echo *echo.Echo
+}
+
+// NewOpenAPIController creates a new OpenAPIController.
+// Register its routes after those of the other controllers; routes are read on every request.
+func NewOpenAPIController(e *echo.Echo) *OpenAPIController {
+    return &OpenAPIController{
+        echo: e,
+    }
+}
+
+// RegisterRoutes registers the route for the OpenAPI spec
+func (oc *OpenAPIController) RegisterRoutes(e *echo.Echo) {
+    e.GET("/api/openapi.json", oc.GetSpec)
+}
+
+// apiOperations documents the request and response bodies of the registered routes
+var apiOperations = map[openapi.Route]openapi.Operation{
+    // Processes
+    {Method: http.MethodGet, Path: "/api/processes"}:                              {Summary: "List processes", Response: []*domain.Process{}},
+    {Method: http.MethodGet, Path: "/api/processes/export.csv"}:                   {Summary: "Export activities as CSV", ContentType: "text/csv"},
+    {Method: http.MethodPost, Path: "/api/processes/import.csv"}:                  {Summary: "Import activities from CSV", Response: usecase.ActivityImportResult{}},
+    {Method: http.MethodPut, Path: "/api/processes/activities/bulk"}:              {Summary: "Update the base hours of several activities all-or-nothing", Request: BulkUpdateActivityHoursRequest{}, Response: usecase.ActivityBulkUpdateResult{}},
+    {Method: http.MethodPut, Path: "/api/processes/reorder"}:                      {Summary: "Set the display order of the processes", Request: ReorderProcessesRequest{}, Response: []*domain.Process{}},
+    {Method: http.MethodGet, Path: "/api/processes/:id"}:                          {Summary: "Get a process", Response: domain.Process{}},
+    {Method: http.MethodPut, Path: "/api/processes/:id"}:                          {Summary: "Update a process", Request: UpdateProcessRequest{}, Response: domain.Process{}},
+    {Method: http.MethodPut, Path: "/api/processes/:id/activities/:activityId"}:   {Summary: "Update an activity", Request: domain.Activity{}, Response: domain.Activity{}},
+    {Method: http.MethodGet, Path: "/api/processes/:processId/tasks"}:             {Summary: "List the tasks of a process", Response: []*domain.Task{}},
+
+    // Factors
+    {Method: http.MethodPost, Path: "/api/factors"}:       {Summary: "Create a factor", Request: FactorRequest{}, Response: domain.Factor{}, Status: http.StatusCreated},
+    {Method: http.MethodGet, Path: "/api/factors"}:        {Summary: "List factors", Response: []*domain.Factor{}},
+    {Method: http.MethodGet, Path: "/api/factors/:id"}:    {Summary: "Get a factor", Response: domain.Factor{}},
+    {Method: http.MethodPut, Path: "/api/factors/:id"}:    {Summary: "Update a factor", Request: FactorRequest{}, Response: domain.Factor{}},
+    {Method: http.MethodDelete, Path: "/api/factors/:id"}: {Summary: "Delete a factor", Status: http.StatusNoContent},
+
+    // Tasks
+    {Method: http.MethodPost, Path: "/api/tasks"}:       {Summary: "Create a task", Request: TaskRequest{}, Response: domain.Task{}, Status: http.StatusCreated},
+    {Method: http.MethodGet, Path: "/api/tasks"}:        {Summary: "List tasks", Response: []*domain.Task{}},
+    {Method: http.MethodGet, Path: "/api/tasks/:id"}:    {Summary: "Get a task", Response: domain.Task{}},
+    {Method: http.MethodPut, Path: "/api/tasks/:id"}:    {Summary: "Update a task", Request: TaskRequest{}, Response: domain.Task{}},
+    {Method: http.MethodDelete, Path: "/api/tasks/:id"}: {Summary: "Delete a task", Status: http.StatusNoContent},
+
+    // Estimates
+    {Method: http.MethodPost, Path: "/api/estimates"}:                           {Summary: "Create an estimate", Request: CreateEstimateRequest{}, Response: domain.Estimate{}, Status: http.StatusCreated},
+    {Method: http.MethodGet, Path: "/api/estimates"}:                           {Summary: "List estimates", Response: usecase.EstimatePage{}},
+    {Method: http.MethodGet, Path: "/api/estimates/search"}:                    {Summary: "Search estimates by project name and notes", Response: usecase.EstimatePage{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id"}:                        {Summary: "Get an estimate", Response: domain.Estimate{}},
+    {Method: http.MethodPut, Path: "/api/estimates/:id"}:                        {Summary: "Update an estimate", Request: UpdateEstimateRequest{}, Response: domain.Estimate{}},
+    {Method: http.MethodDelete, Path: "/api/estimates/:id"}:                     {Summary: "Delete an estimate", Status: http.StatusNoContent},
+    {Method: http.MethodPost, Path: "/api/estimates/:id/clone"}:                 {Summary: "Start a new draft estimate from an existing one", Request: CloneEstimateRequest{}, Response: domain.Estimate{}, Status: http.StatusCreated},
+    {Method: http.MethodPost, Path: "/api/estimates/:id/restore"}:               {Summary: "Restore a deleted estimate", Response: domain.Estimate{}},
+    {Method: http.MethodPut, Path: "/api/estimates/:id/status"}:                 {Summary: "Change the status of an estimate", Request: TransitionStatusRequest{}, Response: domain.Estimate{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/detailed"}:               {Summary: "Get an estimate with COCOMO details", Response: detailedEstimateResponse{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/versions"}:               {Summary: "List the versions of an estimate", Response: []*domain.Estimate{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/versions/:version"}:      {Summary: "Get a version of an estimate", Response: domain.Estimate{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/schedule"}:               {Summary: "Project the delivery date of an estimate", Response: domain.ProjectSchedule{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/critical-path"}:          {Summary: "Get the critical path of an estimate", Response: domain.CriticalPathResult{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/export.pdf"}:             {Summary: "Export an estimate as PDF", ContentType: "application/pdf"},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/export.xlsx"}:            {Summary: "Export an estimate as Excel", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/export.json"}:            {Summary: "Export an estimate with the processes it refers to as a JSON backup", Response: usecase.EstimateExport{}},
+    {Method: http.MethodPost, Path: "/api/estimates/import"}:                    {Summary: "Restore an estimate from a JSON backup as a new estimate", Request: usecase.EstimateExport{}, Response: domain.Estimate{}, Status: http.StatusCreated},
+    {Method: http.MethodGet, Path: "/api/projects/:projectId/estimates"}:        {Summary: "List the estimates of a project", Response: usecase.EstimatePage{}},
+    {Method: http.MethodGet, Path: "/api/projects/:projectId/summary"}:         {Summary: "Summarize the estimates of a project", Response: domain.ProjectSummary{}},
+    {Method: http.MethodPost, Path: "/api/estimates/calculate"}:                 {Summary: "Calculate an estimate without storing it", Request: CreateEstimateRequest{}, Response: domain.Estimate{}},
+    {Method: http.MethodPost, Path: "/api/estimates/batch"}:                     {Summary: "Create several estimates; 207 when some items fail", Request: []CreateEstimateRequest{}, Response: BatchCreateResponse{}, Status: http.StatusCreated},
+    {Method: http.MethodPost, Path: "/api/estimates/compare"}:                   {Summary: "Compare two estimates", Request: CompareEstimatesRequest{}, Response: domain.EstimateComparison{}},
+    {Method: http.MethodPost, Path: "/api/estimates/recalculate"}:               {Summary: "Recalculate stored estimates", Request: RecalculateEstimatesRequest{}, Response: usecase.RecalculationSummary{}},
+    {Method: http.MethodPost, Path: "/api/estimates/:id/recalculate"}:           {Summary: "Recalculate one stored estimate against the current processes and factors", Response: RecalculateEstimateResponse{}},
+    {Method: http.MethodPost, Path: "/api/estimates/analogy"}:                   {Summary: "Estimate by analogy", Request: AnalogyEstimateRequest{}, Response: domain.AnalogyEstimate{}},
+    {Method: http.MethodPost, Path: "/api/estimates/:id/migration"}:             {Summary: "Estimate migration effort", Request: MigrationEffortRequest{}, Response: domain.MigrationEffort{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/planning-confidence"}:    {Summary: "Get the planning confidence of an estimate", Response: map[string]float64{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/by-work-type"}:           {Summary: "Get the effort by work type", Response: map[string]float64{}},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/deliverables"}:           {Summary: "Get the deliverable checklist of an estimate", Response: domain.DeliverableChecklist{}},
+    {Method: http.MethodPost, Path: "/api/estimates/:id/triangulate"}:           {Summary: "Triangulate an estimate", Request: TriangulateEstimateRequest{}, Response: domain.Triangulation{}},
+    {Method: http.MethodPost, Path: "/api/estimates/:id/subscriptions"}:         {Summary: "Subscribe to estimate drift", Request: SubscribeRequest{}, Response: domain.DriftSubscription{}, Status: http.StatusCreated},
+    {Method: http.MethodGet, Path: "/api/estimates/:id/subscriptions"}:          {Summary: "List drift subscriptions", Response: []*domain.DriftSubscription{}},
+    {Method: http.MethodDelete, Path: "/api/subscriptions/:id"}:                 {Summary: "Delete a drift subscription", Status: http.StatusNoContent},
+    {Method: http.MethodPost, Path: "/api/templates"}:                 {Summary: "Create an estimate template", Request: CreateTemplateRequest{}, Response: domain.EstimateTemplate{}, Status: http.StatusCreated},
+    {Method: http.MethodGet, Path: "/api/templates"}:                  {Summary: "List estimate templates", Response: []*domain.EstimateTemplate{}},
+    {Method: http.MethodPost, Path: "/api/templates/:id/instantiate"}: {Summary: "Create an estimate for a project from a template", Request: InstantiateTemplateRequest{}, Response: domain.Estimate{}, Status: http.StatusCreated},
+
+    // COCOMO
+    {Method: http.MethodGet, Path: "/api/cocomo/models"}:         {Summary: "List COCOMO models", Response: map[string][]*domain.COCOMOModel{}},
+    {Method: http.MethodGet, Path: "/api/cocomo/scale-factors"}:  {Summary: "List scale factors", Response: map[string][]ScaleFactorResponse{}},
+    {Method: http.MethodGet, Path: "/api/cocomo/cost-drivers"}:   {Summary: "List cost drivers", Response: map[string][]CostDriverResponse{}},
+    {Method: http.MethodGet, Path: "/api/cocomo/phase-profiles"}: {Summary: "List the built-in phase profiles", Response: []domain.PhaseProfile{}},
+    {Method: http.MethodPost, Path: "/api/cocomo/calculate"}:     {Summary: "Calculate a COCOMO II estimate, or a COCOMO 81 estimate with method cocomo81", Request: CalculateEstimateRequest{}, Response: domain.COCOMODetailedResult{}},
+    {Method: http.MethodPost, Path: "/api/cocomo/calculate/batch"}: {Summary: "Calculate several COCOMO II estimates without storing them and roll them up; the first failing estimate fails the whole batch", Request: []CalculateEstimateRequest{}, Response: BatchCalculateResponse{}},
+    {Method: http.MethodGet, Path: "/api/cocomo/estimates"}:        {Summary: "List stored COCOMO II estimates", Response: map[string][]*domain.COCOMOEstimate{}},
+    {Method: http.MethodGet, Path: "/api/cocomo/estimates/:id"}:    {Summary: "Get a stored COCOMO II estimate", Response: domain.COCOMOEstimate{}},
+    {Method: http.MethodDelete, Path: "/api/cocomo/estimates/:id"}: {Summary: "Delete a stored COCOMO II estimate", Status: http.StatusNoContent},
+    {Method: http.MethodGet, Path: "/api/cocomo/estimates/:id/trace"}:    {Summary: "Get the step-by-step calculation trace of a stored estimate for auditing", Response: domain.CalculationTrace{}},
+    {Method: http.MethodGet, Path: "/api/cocomo/estimates/:id/montecarlo/stream"}: {Summary: "Stream the progress and result of a Monte Carlo simulation of a stored estimate as Server-Sent Events", Response: domain.MonteCarloResult{}},
+    {Method: http.MethodGet, Path: "/api/cocomo/estimates/:id/tradeoff"}: {Summary: "Get the schedule-vs-effort tradeoff curve of a stored estimate", Response: domain.TradeoffCurve{}},
+    {Method: http.MethodPost, Path: "/api/cocomo/compare"}:       {Summary: "Compare two COCOMO II parameter sets", Request: CompareRequest{}, Response: domain.COCOMOComparison{}},
+    {Method: http.MethodPost, Path: "/api/cocomo/calibrate"}:     {Summary: "Fit A and B to completed projects, optionally saving a model", Request: CalibrateRequest{}, Response: usecase.CalibrationResult{}},
+    {Method: http.MethodPost, Path: "/api/cocomo/reverse"}:       {Summary: "Solve the largest size deliverable by a deadline with a given team", Request: ReverseRequest{}, Response: domain.ReverseEstimate{}},
+    {Method: http.MethodPost, Path: "/api/cocomo/maintenance"}:   {Summary: "Estimate maintenance effort", Request: MaintenanceRequest{}, Response: domain.MaintenanceEstimate{}},
+    {Method: http.MethodPost, Path: "/api/cocomo/incremental"}:   {Summary: "Estimate incremental development", Request: IncrementalRequest{}, Response: domain.IncrementalEstimate{}},
+    {Method: http.MethodPost, Path: "/api/cocomo/sensitivity"}:   {Summary: "Get the sensitivity curve of a factor", Request: SensitivityRequest{}, Response: domain.SensitivityCurve{}},
+    {Method: http.MethodGet, Path: "/api/cocomo/:id/presets"}:    {Summary: "Compare scenario presets", Response: domain.PresetComparison{}},
+
+    // Use case points
+    {Method: http.MethodPost, Path: "/api/ucp/calculate"}: {Summary: "Calculate a use case points estimate", Request: usecase.UCPInput{}, Response: usecase.UCPResult{}},
+
+    // Audit
+    {Method: http.MethodGet, Path: "/api/audit"}: {Summary: "List audit log entries, optionally of a single entity", Response: []*domain.AuditLog{}},
+
+    // Configuration
+    {Method: http.MethodGet, Path: "/api/config"}:  {Summary: "Get the estimation configuration", Response: domain.EstimationConfig{}},
+    {Method: http.MethodPost, Path: "/api/config"}: {Summary: "Update the estimation configuration", Request: ConfigRequest{}, Response: domain.EstimationConfig{}},
+
+    {Method: http.MethodGet, Path: "/api/openapi.json"}: {Summary: "Get this OpenAPI spec", Response: map[string]interface{}{}},
+}
+
+// GetSpec handles GET /api/openapi.json
+func (oc *OpenAPIController) GetSpec(c echo.Context) error {
+    var routes []openapi.Route
+    for _, r := range oc.echo.Routes() {
+        routes = append(routes, openapi.Route{Method: r.Method, Path: r.Path})
+    }
+    sort.Slice(routes, func(i, j int) bool {
+        if routes[i].Path != routes[j].Path {
+            return routes[i].Path < routes[j].Path
+        }
+        return routes[i].Method < routes[j].Method
+    })
+
+    return c.JSON(http.StatusOK, openapi.Generate("Estimate API", "1.0.0", routes, apiOperations))
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+    "net/http"
+    "strings"
+    "testing"
+)
+
+// openAPIPath converts the Echo path parameters of a route to OpenAPI templates
+func openAPIPath(echoPath string) string {
+    segments := strings.Split(echoPath, "/")
+    for i, segment := range segments {
+        if strings.HasPrefix(segment, ":") {
+            segments[i] = "{" + segment[1:] + "}"
+        }
+    }
+    return strings.Join(segments, "/")
+}
+
+func TestOpenAPISpecCoversRegisteredRoutes(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodGet, "/api/openapi.json", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var spec struct {
+        OpenAPI string                            `json:"openapi"`
+        Paths   map[string]map[string]interface{} `json:"paths"`
+    }
+    decode(t, rec, &spec)
+
+    if !strings.HasPrefix(spec.OpenAPI, "3.") {
+        t.Errorf("openapi = %q, want version 3", spec.OpenAPI)
+    }
+    for _, route := range s.echo.Routes() {
+        item, ok := spec.Paths[openAPIPath(route.Path)]
+        if !ok {
+            t.Errorf("path %s missing from the spec", route.Path)
+            continue
+        }
+        if _, ok := item[strings.ToLower(route.Method)]; !ok {
+            t.Errorf("%s %s missing from the spec", route.Method, route.Path)
+        }
+    }
+}
+
+func TestOpenAPIOperationsAreRegistered(t *testing.T) {
+    s := newTestServer(t)
+
+    registered := make(map[string]bool)
+    for _, route := range s.echo.Routes() {
+        registered[route.Method+" "+route.Path] = true
+    }
+    // A documented route that is not registered is a stale entry
+    for route := range apiOperations {
+        if !registered[route.Method+" "+route.Path] {
+            t.Errorf("%s %s is documented but not registered", route.Method, route.Path)
+        }
+    }
+}
@@ -1,9 +1,15 @@
 package controller
 
 import (
+    "bytes"
+    "errors"
+    "fmt"
+    "io"
     "net/http"
+    "strings"
 
     "github.com/labstack/echo/v4"
+    "estimate-backend/internal/interface/presenter"
     "estimate-backend/internal/usecase"
     "estimate-backend/internal/domain"
 )
@@ -23,6 +29,10 @@ func NewProcessController(pu *usecase.ProcessUseCase) *ProcessController {
 // RegisterRoutes registers the routes for process management
 func (pc *ProcessController) RegisterRoutes(e *echo.Echo) {
     e.GET("/api/processes", pc.GetAllProcesses)
+    e.GET("/api/processes/export.csv", pc.ExportActivitiesCSV)
+    e.POST("/api/processes/import.csv", pc.ImportActivitiesCSV)
+    e.PUT("/api/processes/activities/bulk", pc.BulkUpdateActivityHours)
+    e.PUT("/api/processes/reorder", pc.ReorderProcesses)
     e.GET("/api/processes/:id", pc.GetProcess)
     e.PUT("/api/processes/:id", pc.UpdateProcess)
     e.PUT("/api/processes/:id/activities/:activityId", pc.UpdateActivity)
@@ -37,6 +47,51 @@ func (pc *ProcessController) GetAllProcesses(c echo.Context) error {
     return c.JSON(http.StatusOK, processes)
 }
 
+// ExportActivitiesCSV handles GET /api/processes/export.csv
+func (pc *ProcessController) ExportActivitiesCSV(c echo.Context) error {
+    processes, err := pc.processUseCase.GetAllProcesses()
+    if err != nil {
+        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    }
+
+    var buf bytes.Buffer
+    if err := presenter.WriteActivitiesCSV(&buf, processes); err != nil {
+        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    }
+
+    c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="activities.csv"`)
+    return c.Blob(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// ImportActivitiesCSV handles POST /api/processes/import.csv.
+// The CSV is read from the multipart "file" field, or from the raw request body when absent.
+func (pc *ProcessController) ImportActivitiesCSV(c echo.Context) error {
+    var src io.Reader = c.Request().Body
+    if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+        fh, err := c.FormFile("file")
+        if err != nil {
+            return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+        }
+        file, err := fh.Open()
+        if err != nil {
+            return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+        }
+        defer file.Close()
+        src = file
+    }
+
+    rows, err := presenter.ReadActivitiesCSV(src)
+    if err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+    }
+
+    result, err := pc.processUseCase.ImportActivities(rows, actor(c))
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, result)
+}
+
 // GetProcess handles GET /api/processes/:id
 func (pc *ProcessController) GetProcess(c echo.Context) error {
     id := c.Param("id")
@@ -49,17 +104,18 @@ func (pc *ProcessController) GetProcess(c echo.Context) error {
 
 // UpdateProcessRequest represents the request body for updating a process
 type UpdateProcessRequest struct {
-    Name        string `json:"name"`
+    Name        string `json:"name" validate:"required"`
     Description string `json:"description"`
     Activities  []domain.Activity `json:"activities"`
+    ComplexityCurve string        `json:"complexityCurve,omitempty" validate:"omitempty,oneof=linear exponential flat"`
 }
 
 // UpdateProcess handles PUT /api/processes/:id
 func (pc *ProcessController) UpdateProcess(c echo.Context) error {
     id := c.Param("id")
     var req UpdateProcessRequest
-    if err := c.Bind(&req); err != nil {
-        return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
     }
 
     process := &domain.Process{
@@ -67,10 +123,11 @@ func (pc *ProcessController) UpdateProcess(c echo.Context) error {
         Name:        req.Name,
         Description: req.Description,
         Activities:  req.Activities,
+        ComplexityCurve: domain.ComplexityCurve(req.ComplexityCurve),
     }
 
-    if err := pc.processUseCase.UpdateProcess(process); err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    if err := pc.processUseCase.UpdateProcess(process, actor(c)); err != nil {
+        return httpError(err)
     }
 
     return c.JSON(http.StatusOK, process)
@@ -87,9 +144,56 @@ func (pc *ProcessController) UpdateActivity(c echo.Context) error {
     }
 
     activity.ID = activityID
-    if err := pc.processUseCase.UpdateActivity(processID, activity); err != nil {
-        return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+    if err := pc.processUseCase.UpdateActivity(processID, activity, actor(c)); err != nil {
+        return httpError(err)
     }
 
     return c.JSON(http.StatusOK, activity)
-}
\ No newline at end of file
+}
+// ReorderProcessesRequest represents the request body for reordering the processes
+type ReorderProcessesRequest struct {
+    ProcessIDs []string `json:"processIds" validate:"required,min=1"` // Every process, in the new display order
+}
+
+// ReorderProcesses handles PUT /api/processes/reorder
+func (pc *ProcessController) ReorderProcesses(c echo.Context) error {
+    var req ReorderProcessesRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    processes, err := pc.processUseCase.ReorderProcesses(req.ProcessIDs, actor(c))
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, processes)
+}
+
+// BulkUpdateActivityHoursRequest represents the request body for a bulk update of activity base hours
+type BulkUpdateActivityHoursRequest struct {
+    Updates []usecase.ActivityHoursUpdate `json:"updates" validate:"required,min=1"`
+}
+
+// BulkUpdateActivityHours handles PUT /api/processes/activities/bulk.
+// The updates are applied all-or-nothing; every update that fails validation is reported.
+func (pc *ProcessController) BulkUpdateActivityHours(c echo.Context) error {
+    var req BulkUpdateActivityHoursRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    result, err := pc.processUseCase.BulkUpdateActivityHours(req.Updates, actor(c))
+    var bulkErr *usecase.BulkUpdateError
+    if errors.As(err, &bulkErr) {
+        ve := make(ValidationErrors, len(bulkErr.Failures))
+        for i, f := range bulkErr.Failures {
+            ve[i] = FieldError{Field: fmt.Sprintf("updates[%d].%s", f.Index, f.Field), Message: f.Message}
+        }
+        return ve
+    } else if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+    "net/http"
+    "strings"
+    "testing"
+
+    "estimate-backend/internal/usecase"
+)
+
+func TestActivitiesCSVRoundTrip(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodGet, "/api/processes/export.csv", nil)
+    assertStatus(t, rec, http.StatusOK)
+    exported := rec.Body.String()
+
+    rec = s.request(http.MethodPost, "/api/processes/import.csv", exported)
+    assertStatus(t, rec, http.StatusOK)
+    var result usecase.ActivityImportResult
+    decode(t, rec, &result)
+    rows := strings.Count(strings.TrimSpace(exported), "\n")
+    if result.Updated != 0 || result.Unchanged != rows || len(result.Unknown) != 0 {
+        t.Errorf("result = %+v, want all %d rows unchanged", result, rows)
+    }
+
+    rec = s.request(http.MethodGet, "/api/processes/export.csv", nil)
+    if rec.Body.String() != exported {
+        t.Error("export changed after importing it")
+    }
+}
+
+func TestImportActivitiesCSVReportsUnknownRows(t *testing.T) {
+    s := newTestServer(t)
+    rec := s.request(http.MethodGet, "/api/processes/export.csv", nil)
+    lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+
+    // Change the base hours of the first activity and add a row for an unknown process
+    fields := strings.Split(lines[1], ",")
+    fields[2] = "999"
+    csv := strings.Join([]string{lines[0], strings.Join(fields, ","), "未知の工程,作業,10,"}, "\n")
+
+    rec = s.request(http.MethodPost, "/api/processes/import.csv", csv)
+    assertStatus(t, rec, http.StatusOK)
+    var result usecase.ActivityImportResult
+    decode(t, rec, &result)
+    if result.Updated != 1 || len(result.Unknown) != 1 || result.Unknown[0] != "未知の工程/作業" {
+        t.Errorf("result = %+v, want one update and the unknown row reported", result)
+    }
+
+    rec = s.request(http.MethodPost, "/api/processes/import.csv", "not,a,valid\nheader")
+    assertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestImportActivitiesCSVRejectsBadRowMidFile(t *testing.T) {
+    s := newTestServer(t)
+    rec := s.request(http.MethodGet, "/api/processes/export.csv", nil)
+    exported := rec.Body.String()
+    lines := strings.Split(strings.TrimSpace(exported), "\n")
+
+    // Change the first activity, then give the second one invalid hours
+    for _, hours := range []string{"-5", "NaN"} {
+        first := strings.Split(lines[1], ",")
+        first[2] = "999"
+        second := strings.Split(lines[2], ",")
+        second[2] = hours
+        csv := strings.Join([]string{lines[0], strings.Join(first, ","), strings.Join(second, ",")}, "\n")
+
+        rec = s.request(http.MethodPost, "/api/processes/import.csv", csv)
+        assertStatus(t, rec, http.StatusBadRequest)
+        rec = s.request(http.MethodGet, "/api/processes/export.csv", nil)
+        if rec.Body.String() != exported {
+            t.Errorf("hours %s: activities changed by a rejected import", hours)
+        }
+    }
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+    "math"
+    "net/http"
+    "os"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/labstack/echo/v4"
+    "github.com/labstack/echo/v4/middleware"
+    "golang.org/x/time/rate"
+)
+
+// Environment variables configuring the rate limit
+const (
+    rateLimitRateEnv  = "RATE_LIMIT_RPS"   // Requests per second a client may sustain
+    rateLimitBurstEnv = "RATE_LIMIT_BURST" // Requests a client may send at once
+)
+
+// RateLimitConfig configures the per-client rate limit of the API
+type RateLimitConfig struct {
+    Rate      float64       // Requests per second
+    Burst     int
+    ExpiresIn time.Duration // Idle time after which the state of a client is dropped
+}
+
+// DefaultRateLimitConfig returns the default rate limit of 10 requests per second with bursts of 20
+func DefaultRateLimitConfig() RateLimitConfig {
+    return RateLimitConfig{Rate: 10, Burst: 20, ExpiresIn: 3 * time.Minute}
+}
+
+// RateLimitConfigFromEnv returns the rate limit from the environment, keeping the default for
+// unset or invalid values
+func RateLimitConfigFromEnv() RateLimitConfig {
+    config := DefaultRateLimitConfig()
+    if value, err := strconv.ParseFloat(os.Getenv(rateLimitRateEnv), 64); err == nil && value > 0 {
+        config.Rate = value
+    }
+    if value, err := strconv.Atoi(os.Getenv(rateLimitBurstEnv)); err == nil && value > 0 {
+        config.Burst = value
+    }
+    return config
+}
+
+// RateLimit is a middleware limiting the requests to /api/ per client IP, as resolved by the IPExtractor of
+// the server. Nothing verifies API keys, so a client-supplied key would let a caller rotate it to bypass the limit.
+// Requests over the limit get 429 with a Retry-After header of the seconds until the next one is allowed.
+func RateLimit(config RateLimitConfig) echo.MiddlewareFunc {
+    retryAfter := strconv.Itoa(int(math.Ceil(1 / config.Rate)))
+    return middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
+        Skipper: func(c echo.Context) bool {
+            return !strings.HasPrefix(c.Request().URL.Path, "/api/")
+        },
+        Store: middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+            Rate:      rate.Limit(config.Rate),
+            Burst:     config.Burst,
+            ExpiresIn: config.ExpiresIn,
+        }),
+        IdentifierExtractor: func(c echo.Context) (string, error) {
+            return c.RealIP(), nil
+        },
+        DenyHandler: func(c echo.Context, identifier string, err error) error {
+            c.Response().Header().Set(echo.HeaderRetryAfter, retryAfter)
+            return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+        },
+    })
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/labstack/echo/v4"
+)
+
+// newRateLimitedServer returns a server rate limited by config with a GET route under /api/ and one outside
+func newRateLimitedServer(config RateLimitConfig) *echo.Echo {
+    e := echo.New()
+    e.IPExtractor = echo.ExtractIPDirect()
+    e.Use(RateLimit(config))
+    ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+    e.GET("/api/ping", ok)
+    e.GET("/openapi.json", ok)
+    return e
+}
+
+// getFrom sends a GET request from a client IP with optional extra headers and returns the response
+func getFrom(e *echo.Echo, path, ip string, header map[string]string) *httptest.ResponseRecorder {
+    req := httptest.NewRequest(http.MethodGet, path, nil)
+    req.RemoteAddr = ip + ":12345"
+    for name, value := range header {
+        req.Header.Set(name, value)
+    }
+    rec := httptest.NewRecorder()
+    e.ServeHTTP(rec, req)
+    return rec
+}
+
+func TestRateLimitRejectsBurstThenRecovers(t *testing.T) {
+    config := RateLimitConfig{Rate: 20, Burst: 2, ExpiresIn: time.Minute}
+    e := newRateLimitedServer(config)
+
+    for i := 0; i < config.Burst; i++ {
+        if rec := getFrom(e, "/api/ping", "192.0.2.1", nil); rec.Code != http.StatusOK {
+            t.Fatalf("request %d: status %d, want 200 within the burst", i, rec.Code)
+        }
+    }
+    rec := getFrom(e, "/api/ping", "192.0.2.1", nil)
+    if rec.Code != http.StatusTooManyRequests {
+        t.Fatalf("status %d past the burst, want 429", rec.Code)
+    }
+    if got := rec.Header().Get(echo.HeaderRetryAfter); got != "1" {
+        t.Errorf("Retry-After %q, want 1", got)
+    }
+
+    // One request is allowed again after 1/Rate seconds
+    time.Sleep(time.Duration(float64(time.Second)/config.Rate) + 10*time.Millisecond)
+    if rec := getFrom(e, "/api/ping", "192.0.2.1", nil); rec.Code != http.StatusOK {
+        t.Errorf("status %d after the window, want 200", rec.Code)
+    }
+}
+
+func TestRateLimitIsPerClient(t *testing.T) {
+    e := newRateLimitedServer(RateLimitConfig{Rate: 1, Burst: 1, ExpiresIn: time.Minute})
+
+    if rec := getFrom(e, "/api/ping", "192.0.2.1", nil); rec.Code != http.StatusOK {
+        t.Fatalf("status %d, want 200", rec.Code)
+    }
+    if rec := getFrom(e, "/api/ping", "192.0.2.1", nil); rec.Code != http.StatusTooManyRequests {
+        t.Errorf("status %d past the limit, want 429", rec.Code)
+    }
+    if rec := getFrom(e, "/api/ping", "192.0.2.2", nil); rec.Code != http.StatusOK {
+        t.Errorf("status %d for another client IP, want 200", rec.Code)
+    }
+}
+
+func TestRateLimitIgnoresClientSuppliedIdentity(t *testing.T) {
+    e := newRateLimitedServer(RateLimitConfig{Rate: 1, Burst: 1, ExpiresIn: time.Minute})
+
+    if rec := getFrom(e, "/api/ping", "192.0.2.1", map[string]string{"X-API-Key": "first"}); rec.Code != http.StatusOK {
+        t.Fatalf("status %d, want 200", rec.Code)
+    }
+    // Rotating the API key or the forwarded address does not give the client a fresh limit
+    headers := []map[string]string{
+        {"X-API-Key": "second"},
+        {echo.HeaderXForwardedFor: "198.51.100.7"},
+        {echo.HeaderXRealIP: "198.51.100.8"},
+    }
+    for _, header := range headers {
+        if rec := getFrom(e, "/api/ping", "192.0.2.1", header); rec.Code != http.StatusTooManyRequests {
+            t.Errorf("%v: status %d, want 429", header, rec.Code)
+        }
+    }
+}
+
+func TestRateLimitSkipsNonAPIPaths(t *testing.T) {
+    e := newRateLimitedServer(RateLimitConfig{Rate: 1, Burst: 1, ExpiresIn: time.Minute})
+
+    for i := 0; i < 3; i++ {
+        if rec := getFrom(e, "/openapi.json", "192.0.2.1", nil); rec.Code != http.StatusOK {
+            t.Fatalf("request %d: status %d, want 200 outside /api/", i, rec.Code)
+        }
+    }
+}
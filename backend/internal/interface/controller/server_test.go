@@ -0,0 +1,161 @@
+package controller
+
+import (
+    "bytes"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/infrastructure/memory"
+    "estimate-backend/internal/usecase"
+)
+
+// testServer is the API wired as in cmd/api, backed by in-memory repositories seeded with the defaults
+type testServer struct {
+    echo      *echo.Echo
+    processes *memory.InMemoryProcessRepository
+    factors   *memory.InMemoryFactorRepository
+    estimates *usecase.EstimateUseCase
+    cocomo    *usecase.COCOMOUseCase
+}
+
+func newTestServer(t *testing.T) *testServer {
+    t.Helper()
+    e := echo.New()
+    e.Use(ValidationErrorHandler())
+    e.Validator = NewRequestValidator()
+
+    processRepo := memory.NewInMemoryProcessRepository()
+    cocomoRepo := memory.NewInMemoryCOCOMORepository()
+    estimateRepo := memory.NewInMemoryEstimateRepository()
+    factorRepo := memory.NewInMemoryFactorRepository()
+    taskRepo := memory.NewInMemoryTaskRepository()
+
+    configUseCase := usecase.NewConfigUseCase()
+    auditUseCase := usecase.NewAuditUseCase(memory.NewInMemoryAuditRepository())
+    processUseCase := usecase.NewProcessUseCase(processRepo)
+    processUseCase.SetAuditLog(auditUseCase)
+    factorUseCase := usecase.NewFactorUseCase(factorRepo)
+    factorUseCase.SetAuditLog(auditUseCase)
+    taskUseCase := usecase.NewTaskUseCase(taskRepo, processRepo, factorRepo)
+    estimateUseCase := usecase.NewEstimateUseCase(estimateRepo, processRepo, factorRepo, taskRepo, cocomoRepo, configUseCase)
+    estimateUseCase.SetAuditLog(auditUseCase)
+    templateUseCase := usecase.NewTemplateUseCase(memory.NewInMemoryTemplateRepository(), processRepo, factorRepo, estimateUseCase)
+    cocomoUseCase := usecase.NewCOCOMOUseCase(cocomoRepo, configUseCase)
+    ucpUseCase := usecase.NewUCPUseCase(configUseCase)
+
+    for _, initialize := range []func() error{
+        processUseCase.InitializeDefaultProcesses,
+        factorUseCase.InitializeDefaultFactors,
+        cocomoUseCase.InitializeDefaultModel,
+        cocomoUseCase.InitializeScaleFactors,
+        cocomoUseCase.InitializeCostDrivers,
+    } {
+        if err := initialize(); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    NewProcessController(processUseCase).RegisterRoutes(e)
+    NewFactorController(factorUseCase).RegisterRoutes(e)
+    NewTaskController(taskUseCase).RegisterRoutes(e)
+    NewEstimateController(estimateUseCase).RegisterRoutes(e)
+    NewTemplateController(templateUseCase).RegisterRoutes(e)
+    NewCOCOMOController(cocomoUseCase).RegisterRoutes(e)
+    NewUCPController(ucpUseCase).RegisterRoutes(e)
+    NewConfigController(configUseCase).RegisterRoutes(e)
+    NewAuditController(auditUseCase).RegisterRoutes(e)
+    NewOpenAPIController(e).RegisterRoutes(e)
+
+    return &testServer{
+        echo:      e,
+        processes: processRepo,
+        factors:   factorRepo,
+        estimates: estimateUseCase,
+        cocomo:    cocomoUseCase,
+    }
+}
+
+// request serves a request with an optional JSON body and returns the recorded response
+func (s *testServer) request(method, path string, body interface{}) *httptest.ResponseRecorder {
+    return s.requestWithHeader(method, path, body, nil)
+}
+
+// requestWithHeader serves a request like request, adding the given headers
+func (s *testServer) requestWithHeader(method, path string, body interface{}, header http.Header) *httptest.ResponseRecorder {
+    var reader *bytes.Reader
+    switch b := body.(type) {
+    case nil:
+        reader = bytes.NewReader(nil)
+    case string:
+        reader = bytes.NewReader([]byte(b))
+    default:
+        data, err := json.Marshal(b)
+        if err != nil {
+            panic(err)
+        }
+        reader = bytes.NewReader(data)
+    }
+
+    req := httptest.NewRequest(method, path, reader)
+    if body != nil {
+        req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+    }
+    for name, values := range header {
+        for _, value := range values {
+            req.Header.Add(name, value)
+        }
+    }
+    rec := httptest.NewRecorder()
+    s.echo.ServeHTTP(rec, req)
+    return rec
+}
+
+// task returns a nominal task request for an activity of the default process of a category
+func (s *testServer) task(t *testing.T, category domain.ProcessCategory, activity int) usecase.TaskInput {
+    t.Helper()
+    process, err := s.processes.FindByCategory(category)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return usecase.TaskInput{
+        ProcessID:  process.ID,
+        ActivityID: process.Activities[activity].ID,
+        Name:       process.Activities[activity].Name,
+        Complexity: 3,
+        Scale:      1,
+    }
+}
+
+// createEstimate creates an estimate with one implementation task through the use case
+func (s *testServer) createEstimate(t *testing.T) *domain.Estimate {
+    t.Helper()
+    estimate, err := s.estimates.CreateEstimate(usecase.CreateProjectEstimateInput{
+        ProjectID:   "project-1",
+        ProjectName: "Project 1",
+        Tasks:       []usecase.TaskInput{s.task(t, domain.ProcessImplementation, 1)},
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    return estimate
+}
+
+// decode unmarshals a JSON response body, failing the test on error
+func decode(t *testing.T, rec *httptest.ResponseRecorder, v interface{}) {
+    t.Helper()
+    if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+        t.Fatalf("decoding %q: %v", rec.Body.String(), err)
+    }
+}
+
+// assertStatus fails the test unless the response has the given status
+func assertStatus(t *testing.T, rec *httptest.ResponseRecorder, status int) {
+    t.Helper()
+    if rec.Code != status {
+        t.Fatalf("status = %d, want %d; body: %s", rec.Code, status, rec.Body.String())
+    }
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+    "net/http"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/usecase"
+)
+
+// TaskController handles HTTP requests for task management
+type TaskController struct {
+    taskUseCase *usecase.TaskUseCase
+}
+
+// NewTaskController creates a new TaskController
+func NewTaskController(tu *usecase.TaskUseCase) *TaskController {
+    return &TaskController{
+        taskUseCase: tu,
+    }
+}
+
+// RegisterRoutes registers the routes for task management
+func (tc *TaskController) RegisterRoutes(e *echo.Echo) {
+    e.POST("/api/tasks", tc.CreateTask)
+    e.GET("/api/tasks", tc.GetAllTasks)
+    e.GET("/api/tasks/:id", tc.GetTask)
+    e.PUT("/api/tasks/:id", tc.UpdateTask)
+    e.DELETE("/api/tasks/:id", tc.DeleteTask)
+    e.GET("/api/processes/:processId/tasks", tc.GetProcessTasks)
+}
+
+// TaskRequest represents the request body for creating or updating a task
+type TaskRequest struct {
+    ProcessID     string   `json:"processId"`
+    ActivityID    string   `json:"activityId"`
+    Name          string   `json:"name"`
+    Description   string   `json:"description"`
+    Complexity    int      `json:"complexity"`
+    Scale         float64  `json:"scale"`
+    Dependencies  []string `json:"dependencies"`
+    CustomFactors []string `json:"customFactors"`
+}
+
+// toInput converts the request into use case input
+func (r TaskRequest) toInput() usecase.SaveTaskInput {
+    return usecase.SaveTaskInput{
+        ProcessID:     r.ProcessID,
+        ActivityID:    r.ActivityID,
+        Name:          r.Name,
+        Description:   r.Description,
+        Complexity:    r.Complexity,
+        Scale:         r.Scale,
+        Dependencies:  r.Dependencies,
+        CustomFactors: r.CustomFactors,
+    }
+}
+
+// CreateTask handles POST /api/tasks
+func (tc *TaskController) CreateTask(c echo.Context) error {
+    var req TaskRequest
+    if err := c.Bind(&req); err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+    }
+
+    task, err := tc.taskUseCase.CreateTask(req.toInput())
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusCreated, task)
+}
+
+// GetAllTasks handles GET /api/tasks
+func (tc *TaskController) GetAllTasks(c echo.Context) error {
+    tasks, err := tc.taskUseCase.GetAllTasks()
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, tasks)
+}
+
+// GetTask handles GET /api/tasks/:id
+func (tc *TaskController) GetTask(c echo.Context) error {
+    id := c.Param("id")
+    task, err := tc.taskUseCase.GetTask(id)
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, task)
+}
+
+// UpdateTask handles PUT /api/tasks/:id
+func (tc *TaskController) UpdateTask(c echo.Context) error {
+    id := c.Param("id")
+    var req TaskRequest
+    if err := c.Bind(&req); err != nil {
+        return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+    }
+
+    task, err := tc.taskUseCase.UpdateTask(id, req.toInput())
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, task)
+}
+
+// DeleteTask handles DELETE /api/tasks/:id
+func (tc *TaskController) DeleteTask(c echo.Context) error {
+    id := c.Param("id")
+    if err := tc.taskUseCase.DeleteTask(id); err != nil {
+        return httpError(err)
+    }
+    return c.NoContent(http.StatusNoContent)
+}
+
+// GetProcessTasks handles GET /api/processes/:processId/tasks
+func (tc *TaskController) GetProcessTasks(c echo.Context) error {
+    processID := c.Param("processId")
+    tasks, err := tc.taskUseCase.GetTasksByProcess(processID)
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, tasks)
+}
@@ -0,0 +1,32 @@
+package controller
+
+import (
+    "net/http"
+    "testing"
+
+    "estimate-backend/internal/domain"
+)
+
+func TestProcessTasks(t *testing.T) {
+    s := newTestServer(t)
+    task := s.task(t, domain.ProcessImplementation, 0)
+    req := TaskRequest{ProcessID: task.ProcessID, ActivityID: task.ActivityID, Name: task.Name, Complexity: 5, Scale: 1}
+
+    rec := s.request(http.MethodPost, "/api/tasks", req)
+    assertStatus(t, rec, http.StatusCreated)
+
+    req.Complexity = 6
+    rec = s.request(http.MethodPost, "/api/tasks", req)
+    assertStatus(t, rec, http.StatusBadRequest)
+
+    rec = s.request(http.MethodGet, "/api/processes/"+task.ProcessID+"/tasks", nil)
+    assertStatus(t, rec, http.StatusOK)
+    var tasks []domain.Task
+    decode(t, rec, &tasks)
+    if len(tasks) != 1 || tasks[0].Complexity != 5 {
+        t.Errorf("tasks = %+v, want the one created", tasks)
+    }
+
+    rec = s.request(http.MethodGet, "/api/processes/unknown/tasks", nil)
+    assertStatus(t, rec, http.StatusNotFound)
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+    "net/http"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/usecase"
+)
+
+// TemplateController handles HTTP requests for estimate templates
+type TemplateController struct {
+    templateUseCase *usecase.TemplateUseCase
+}
+
+// NewTemplateController creates a new TemplateController
+func NewTemplateController(tu *usecase.TemplateUseCase) *TemplateController {
+    return &TemplateController{
+        templateUseCase: tu,
+    }
+}
+
+// RegisterRoutes registers the routes for estimate templates
+func (tc *TemplateController) RegisterRoutes(e *echo.Echo) {
+    e.POST("/api/templates", tc.CreateTemplate)
+    e.GET("/api/templates", tc.GetTemplates)
+    e.POST("/api/templates/:id/instantiate", tc.InstantiateTemplate)
+}
+
+// CreateTemplateRequest represents the request body for creating a template
+type CreateTemplateRequest struct {
+    Name          string                      `json:"name" validate:"required"`
+    Description   string                      `json:"description"`
+    Tasks         []usecase.TemplateTaskInput `json:"tasks" validate:"min=1"`
+    GlobalFactors []string                    `json:"globalFactors"`
+    CreatedBy     string                      `json:"createdBy"`
+}
+
+// CreateTemplate handles POST /api/templates
+func (tc *TemplateController) CreateTemplate(c echo.Context) error {
+    var req CreateTemplateRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    template, err := tc.templateUseCase.CreateTemplate(usecase.CreateTemplateInput{
+        Name:          req.Name,
+        Description:   req.Description,
+        Tasks:         req.Tasks,
+        GlobalFactors: req.GlobalFactors,
+        CreatedBy:     req.CreatedBy,
+    })
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusCreated, template)
+}
+
+// GetTemplates handles GET /api/templates
+func (tc *TemplateController) GetTemplates(c echo.Context) error {
+    templates, err := tc.templateUseCase.GetTemplates()
+    if err != nil {
+        return httpError(err)
+    }
+    return c.JSON(http.StatusOK, templates)
+}
+
+// InstantiateTemplateRequest represents the request body for creating an estimate from a template
+type InstantiateTemplateRequest struct {
+    ProjectID   string `json:"projectId" validate:"required"`
+    ProjectName string `json:"projectName" validate:"required"`
+    CreatedBy   string `json:"createdBy"`
+}
+
+// InstantiateTemplate handles POST /api/templates/:id/instantiate
+func (tc *TemplateController) InstantiateTemplate(c echo.Context) error {
+    var req InstantiateTemplateRequest
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    estimate, err := tc.templateUseCase.InstantiateTemplate(usecase.InstantiateTemplateInput{
+        TemplateID:  c.Param("id"),
+        ProjectID:   req.ProjectID,
+        ProjectName: req.ProjectName,
+        CreatedBy:   req.CreatedBy,
+        Actor:       actor(c),
+    })
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusCreated, estimate)
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+    "net/http"
+
+    "github.com/labstack/echo/v4"
+    "estimate-backend/internal/usecase"
+)
+
+// UCPController handles HTTP requests for use case points estimation
+type UCPController struct {
+    ucpUseCase *usecase.UCPUseCase
+}
+
+// NewUCPController creates a new UCPController
+func NewUCPController(uu *usecase.UCPUseCase) *UCPController {
+    return &UCPController{
+        ucpUseCase: uu,
+    }
+}
+
+// RegisterRoutes registers the routes for use case points estimation
+func (uc *UCPController) RegisterRoutes(e *echo.Echo) {
+    e.POST("/api/ucp/calculate", uc.Calculate)
+}
+
+// Calculate handles POST /api/ucp/calculate
+func (uc *UCPController) Calculate(c echo.Context) error {
+    var req usecase.UCPInput
+    if err := bindAndValidate(c, &req); err != nil {
+        return err
+    }
+
+    result, err := uc.ucpUseCase.Calculate(req)
+    if err != nil {
+        return httpError(err)
+    }
+
+    return c.JSON(http.StatusOK, result)
+}
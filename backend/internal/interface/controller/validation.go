@@ -0,0 +1,226 @@
+package controller
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "reflect"
+    "strconv"
+    "strings"
+
+    "github.com/labstack/echo/v4"
+)
+
+// FieldError describes why a single field of a request body is invalid
+type FieldError struct {
+    Field   string `json:"field"`   // JSON path of the field, e.g. tasks[0].processId; empty for the whole body
+    Message string `json:"message"`
+}
+
+// ValidationErrors is returned by handlers when the request body is malformed or invalid.
+// ValidationErrorHandler turns it into a 400 response listing every field error.
+type ValidationErrors []FieldError
+
+func (ve ValidationErrors) Error() string {
+    messages := make([]string, len(ve))
+    for i, fe := range ve {
+        if fe.Field == "" {
+            messages[i] = fe.Message
+        } else {
+            messages[i] = fe.Field + ": " + fe.Message
+        }
+    }
+    return strings.Join(messages, "; ")
+}
+
+// ValidationErrorHandler is a middleware that renders ValidationErrors as
+// {"errors": [{"field": ..., "message": ...}]} with status 400
+func ValidationErrorHandler() echo.MiddlewareFunc {
+    return func(next echo.HandlerFunc) echo.HandlerFunc {
+        return func(c echo.Context) error {
+            err := next(c)
+            var ve ValidationErrors
+            if err != nil && errors.As(err, &ve) {
+                return c.JSON(http.StatusBadRequest, map[string]interface{}{
+                    "errors": ve,
+                })
+            }
+            return err
+        }
+    }
+}
+
+// bindAndValidate binds the request body into req and validates it against its validate tags
+func bindAndValidate(c echo.Context, req interface{}) error {
+    if err := c.Bind(req); err != nil {
+        return bindError(err)
+    }
+    return c.Validate(req)
+}
+
+// bindError converts a binding failure into ValidationErrors, naming the field when known
+func bindError(err error) error {
+    var typeErr *json.UnmarshalTypeError
+    if errors.As(err, &typeErr) {
+        return ValidationErrors{{Field: typeErr.Field, Message: "must be of type " + typeErr.Type.String()}}
+    }
+    var syntaxErr *json.SyntaxError
+    if errors.As(err, &syntaxErr) {
+        return ValidationErrors{{Message: fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset)}}
+    }
+    var httpErr *echo.HTTPError
+    if errors.As(err, &httpErr) {
+        return ValidationErrors{{Message: fmt.Sprint(httpErr.Message)}}
+    }
+    return ValidationErrors{{Message: err.Error()}}
+}
+
+// RequestValidator implements echo.Validator using validate struct tags.
+// Supported rules, separated by commas:
+//   required      the value must not be empty
+//   omitempty     skip the other rules when the value is empty
+//   min=N, max=N  numeric bounds, or length bounds of strings and slices
+//   gt=N          the number must be greater than N
+//   oneof=a b c   the string must be one of the listed values
+// Nested structs, pointers to structs and slices of structs are validated recursively.
+type RequestValidator struct{}
+
+// NewRequestValidator creates a new RequestValidator
+func NewRequestValidator() *RequestValidator {
+    return &RequestValidator{}
+}
+
+// Validate validates i and returns ValidationErrors listing every invalid field
+func (rv *RequestValidator) Validate(i interface{}) error {
+    var errs ValidationErrors
+    validateValue(reflect.ValueOf(i), "", &errs)
+    if len(errs) > 0 {
+        return errs
+    }
+    return nil
+}
+
+// validateValue validates the fields of a struct, descending into nested values
+func validateValue(v reflect.Value, prefix string, errs *ValidationErrors) {
+    for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+        if v.IsNil() {
+            return
+        }
+        v = v.Elem()
+    }
+
+    switch v.Kind() {
+    case reflect.Struct:
+        t := v.Type()
+        for i := 0; i < t.NumField(); i++ {
+            field := t.Field(i)
+            if !field.IsExported() {
+                continue
+            }
+            name := jsonName(field)
+            if name == "-" {
+                continue
+            }
+            path := name
+            if prefix != "" {
+                path = prefix + "." + name
+            }
+
+            fv := v.Field(i)
+            if message := checkRules(fv, field.Tag.Get("validate")); message != "" {
+                *errs = append(*errs, FieldError{Field: path, Message: message})
+                continue
+            }
+            validateValue(fv, path, errs)
+        }
+    case reflect.Slice, reflect.Array:
+        for i := 0; i < v.Len(); i++ {
+            validateValue(v.Index(i), fmt.Sprintf("%s[%d]", prefix, i), errs)
+        }
+    }
+}
+
+// checkRules applies the validate tag rules to a value and returns the first failure message
+func checkRules(v reflect.Value, tag string) string {
+    if tag == "" {
+        return ""
+    }
+    rules := strings.Split(tag, ",")
+    empty := v.IsZero() || ((v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0)
+
+    for _, rule := range rules {
+        name, param, _ := strings.Cut(rule, "=")
+        switch name {
+        case "required":
+            if empty {
+                return "is required"
+            }
+        case "omitempty":
+            if empty {
+                return ""
+            }
+        case "min":
+            if limit, size := parseLimit(param), measure(v); size < limit {
+                return "must be at least " + param
+            }
+        case "max":
+            if limit, size := parseLimit(param), measure(v); size > limit {
+                return "must be at most " + param
+            }
+        case "gt":
+            if limit, size := parseLimit(param), measure(v); size <= limit {
+                return "must be greater than " + param
+            }
+        case "oneof":
+            allowed := strings.Fields(param)
+            if !contains(allowed, fmt.Sprint(v.Interface())) {
+                return "must be one of " + strings.Join(allowed, ", ")
+            }
+        default:
+            panic(fmt.Sprintf("unknown validation rule %q", name))
+        }
+    }
+    return ""
+}
+
+// measure returns the number compared by min, max and gt: the value of numbers, the length otherwise
+func measure(v reflect.Value) float64 {
+    switch v.Kind() {
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        return float64(v.Int())
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        return float64(v.Uint())
+    case reflect.Float32, reflect.Float64:
+        return v.Float()
+    case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+        return float64(v.Len())
+    }
+    return 0
+}
+
+func parseLimit(param string) float64 {
+    limit, err := strconv.ParseFloat(param, 64)
+    if err != nil {
+        panic(fmt.Sprintf("invalid validation parameter %q", param))
+    }
+    return limit
+}
+
+// jsonName returns the JSON property name of a struct field
+func jsonName(field reflect.StructField) string {
+    name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+    if name == "" {
+        return field.Name
+    }
+    return name
+}
+
+func contains(values []string, value string) bool {
+    for _, v := range values {
+        if v == value {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+    "errors"
+    "net/http"
+    "reflect"
+    "testing"
+
+    "estimate-backend/internal/domain"
+)
+
+func TestRequestValidatorRules(t *testing.T) {
+    type item struct {
+        Kind string `json:"kind" validate:"omitempty,oneof=a b"`
+    }
+    type request struct {
+        Name  string   `json:"name" validate:"required"`
+        Count int      `json:"count" validate:"min=1,max=3"`
+        Rate  float64  `json:"rate" validate:"gt=0"`
+        Items []item   `json:"items"`
+        Tags  []string `json:"tags" validate:"min=1"`
+    }
+
+    valid := request{Name: "n", Count: 2, Rate: 0.5, Items: []item{{}, {Kind: "a"}}, Tags: []string{"t"}}
+    if err := NewRequestValidator().Validate(&valid); err != nil {
+        t.Fatalf("valid request: %v", err)
+    }
+
+    invalid := request{Count: 4, Items: []item{{Kind: "a"}, {Kind: "c"}}}
+    err := NewRequestValidator().Validate(&invalid)
+    var ve ValidationErrors
+    if !errors.As(err, &ve) {
+        t.Fatalf("got %v, want ValidationErrors", err)
+    }
+    var fields []string
+    for _, fe := range ve {
+        fields = append(fields, fe.Field)
+    }
+    if want := []string{"name", "count", "rate", "items[1].kind", "tags"}; !reflect.DeepEqual(fields, want) {
+        t.Errorf("fields = %v, want %v", fields, want)
+    }
+}
+
+func TestCreateEstimateMissingProjectID(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodPost, "/api/estimates", map[string]interface{}{
+        "projectName": "Project 1",
+        "tasks":       []interface{}{s.task(t, domain.ProcessImplementation, 1)},
+    })
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors []FieldError `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "projectId" || body.Errors[0].Message == "" {
+        t.Errorf("errors = %+v, want one naming projectId", body.Errors)
+    }
+}
+
+func TestMalformedBodyIsStructured(t *testing.T) {
+    s := newTestServer(t)
+
+    rec := s.request(http.MethodPost, "/api/estimates", `{"projectId": 1}`)
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors []FieldError `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "projectId" {
+        t.Errorf("errors = %+v, want the mistyped projectId", body.Errors)
+    }
+
+    rec = s.request(http.MethodPost, "/api/estimates", `{"projectId":`)
+    assertStatus(t, rec, http.StatusBadRequest)
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Message == "" {
+        t.Errorf("errors = %+v, want one describing the malformed JSON", body.Errors)
+    }
+}
+
+func TestUpdateProcessMissingName(t *testing.T) {
+    s := newTestServer(t)
+    process, err := s.processes.FindByCategory(domain.ProcessImplementation)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    rec := s.request(http.MethodPut, "/api/processes/"+process.ID, UpdateProcessRequest{Description: "no name"})
+    assertStatus(t, rec, http.StatusBadRequest)
+    var body struct {
+        Errors []FieldError `json:"errors"`
+    }
+    decode(t, rec, &body)
+    if len(body.Errors) != 1 || body.Errors[0].Field != "name" {
+        t.Errorf("errors = %+v, want one naming name", body.Errors)
+    }
+}
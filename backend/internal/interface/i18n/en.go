@@ -0,0 +1,194 @@
+package i18n
+
+import "estimate-backend/internal/domain"
+
+// factorText holds the English texts of a scale factor or cost driver; Guide is indexed by rating
+type factorText struct {
+    Name        string
+    Description string
+    Guide       [6]string
+}
+
+var englishScaleFactors = map[domain.ScaleFactorType]factorText{
+    domain.ScaleFactorPREC: {
+        Name: "Precedentedness", Description: "Experience with similar projects",
+        Guide: [6]string{"Thoroughly unprecedented", "Largely unprecedented", "Somewhat unprecedented", "Generally familiar", "Largely familiar", "Thoroughly familiar"},
+    },
+    domain.ScaleFactorFLEX: {
+        Name: "Development Flexibility", Description: "Flexibility of the development process",
+        Guide: [6]string{"Rigorous", "Occasional relaxation", "Some relaxation", "General conformity", "Some conformity", "General goals"},
+    },
+    domain.ScaleFactorRESL: {
+        Name: "Architecture / Risk Resolution", Description: "Extent of risk management and architecture definition",
+        Guide: [6]string{"Little risk resolution", "Some risks resolved", "Often resolved", "Generally resolved", "Mostly resolved", "Full risk resolution"},
+    },
+    domain.ScaleFactorTEAM: {
+        Name: "Team Cohesion", Description: "Cooperation and consistency of the stakeholders",
+        Guide: [6]string{"Very difficult interactions", "Some difficult interactions", "Basically cooperative", "Largely cooperative", "Highly cooperative", "Seamless interactions"},
+    },
+    domain.ScaleFactorPMAT: {
+        Name: "Process Maturity", Description: "Process maturity of the organization",
+        Guide: [6]string{"CMMI level 1 (lower)", "CMMI level 1 (upper)", "CMMI level 2", "CMMI level 3", "CMMI level 4", "CMMI level 5"},
+    },
+}
+
+var englishCostDrivers = map[domain.CostDriverType]factorText{
+    // Product Factors
+    domain.CostDriverRELY: {
+        Name: "Required Reliability", Description: "Consequences of a software failure",
+        Guide: [6]string{"Slight inconvenience", "Low, easily recoverable losses", "Moderate, recoverable losses", "High financial loss", "Risk to human life"},
+    },
+    domain.CostDriverDATA: {
+        Name: "Database Size", Description: "Ratio of test database size to program size",
+        Guide: [6]string{"Below 10 (same as Low)", "Below 10", "10 to 100", "100 to 1000", "1000 or more"},
+    },
+    domain.CostDriverCPLX: {
+        Name: "Product Complexity", Description: "Complexity of control, computation, device, data management and UI operations",
+        Guide: [6]string{"Simple", "Slightly complex", "Moderate", "Complex", "Very complex", "Extremely complex"},
+    },
+    domain.CostDriverREUS: {
+        Name: "Required Reusability", Description: "Extent of development for reuse elsewhere",
+        Guide: [6]string{"None (same as Low)", "None", "Across the project", "Across the program", "Across the product line", "Across multiple product lines"},
+    },
+    domain.CostDriverDOCU: {
+        Name: "Documentation", Description: "Match of the documentation to life-cycle needs",
+        Guide: [6]string{"Many needs uncovered", "Some needs uncovered", "Right-sized to needs", "Excessive for needs", "Very excessive for needs"},
+    },
+    // Platform Factors
+    domain.CostDriverTIME: {
+        Name: "Execution Time Constraint", Description: "Constraint on the available execution time",
+        Guide: [6]string{"50% or less of execution time (same as Nominal)", "50% or less of execution time (same as Nominal)", "50% or less of execution time", "70%", "85%", "95%"},
+    },
+    domain.CostDriverSTOR: {
+        Name: "Main Storage Constraint", Description: "Constraint on the main storage",
+        Guide: [6]string{"50% or less of main storage (same as Nominal)", "50% or less of main storage (same as Nominal)", "50% or less of main storage", "70%", "85%", "95%"},
+    },
+    domain.CostDriverPVOL: {
+        Name: "Platform Volatility", Description: "Frequency of changes to the hardware, OS and other platform",
+        Guide: [6]string{"Major change every 12 months (same as Low)", "Major change every 12 months", "Major change every 6 months", "Major change every 2 months", "Major change every 2 weeks"},
+    },
+    // Personnel Factors
+    domain.CostDriverACAP: {
+        Name: "Analyst Capability", Description: "Ability and experience of the analysts",
+        Guide: [6]string{"15th percentile", "35th percentile", "55th percentile", "75th percentile", "90th percentile"},
+    },
+    domain.CostDriverPCAP: {
+        Name: "Programmer Capability", Description: "Ability and experience of the programmers",
+        Guide: [6]string{"15th percentile", "35th percentile", "55th percentile", "75th percentile", "90th percentile"},
+    },
+    domain.CostDriverPCON: {
+        Name: "Personnel Continuity", Description: "Annual turnover of the project personnel",
+        Guide: [6]string{"48% per year", "24% per year", "12% per year", "6% per year", "3% per year"},
+    },
+    domain.CostDriverAPEX: {
+        Name: "Applications Experience", Description: "Experience with the application domain",
+        Guide: [6]string{"2 months or less", "6 months", "1 year", "3 years", "6 years"},
+    },
+    domain.CostDriverPLEX: {
+        Name: "Platform Experience", Description: "Experience with the platform",
+        Guide: [6]string{"2 months or less", "6 months", "1 year", "3 years", "6 years"},
+    },
+    domain.CostDriverLTEX: {
+        Name: "Language and Tool Experience", Description: "Experience with the language and tools",
+        Guide: [6]string{"2 months or less", "6 months", "1 year", "3 years", "6 years"},
+    },
+    // Project Factors
+    domain.CostDriverTOOL: {
+        Name: "Use of Software Tools", Description: "Maturity and capability of the tools",
+        Guide: [6]string{"Edit, code, debug", "Simple tools", "Basic life-cycle tools", "Mature life-cycle tools", "Integrated, proactive tools"},
+    },
+    domain.CostDriverSITE: {
+        Name: "Multisite Development", Description: "Distribution of the team and its communication support",
+        Guide: [6]string{"International, phone and mail", "Multi-city, individual phone and fax", "Same city, narrowband", "Same city, wideband", "Same building, interactive multimedia", "Fully collocated, interactive multimedia"},
+    },
+    domain.CostDriverSCED: {
+        Name: "Required Development Schedule", Description: "Schedule compression relative to the nominal schedule",
+        Guide: [6]string{"75% of nominal", "85%", "100%", "130%", "160%"},
+    },
+}
+
+// english returns the English catalog
+func english() map[string]string {
+    messages := map[string]string{
+        domain.MsgRecommendScaleFactor: "Improving this factor may reduce the effort",
+        domain.MsgRecommendCostDriver:  "Optimizing this factor may reduce the effort",
+
+        domain.ScaleFactorPREC.RecommendationKey(domain.RecommendWhenLow): "Involve people experienced with similar projects or validate with a prototype first",
+        domain.ScaleFactorFLEX.RecommendationKey(domain.RecommendWhenLow): "Negotiate to relax the requirement and process constraints that allow it",
+        domain.ScaleFactorRESL.RecommendationKey(domain.RecommendWhenLow): "Hold architecture reviews and set up a risk management plan early",
+        domain.ScaleFactorTEAM.RecommendationKey(domain.RecommendWhenLow): "Build agreement among the stakeholders through kick-offs and team building",
+        domain.ScaleFactorPMAT.RecommendationKey(domain.RecommendWhenLow): "Invest in process maturity, e.g. following CMMI",
+        domain.CostDriverRELY.RecommendationKey(domain.RecommendWhenHigh): "Invest in test automation and verification and validation processes",
+        domain.CostDriverDATA.RecommendationKey(domain.RecommendWhenHigh): "Automate the generation and management of test data",
+        domain.CostDriverCPLX.RecommendationKey(domain.RecommendWhenHigh): "Review the design of the complex parts and prototype them early",
+        domain.CostDriverREUS.RecommendationKey(domain.RecommendWhenHigh): "Limit the reusability to what will actually be reused",
+        domain.CostDriverDOCU.RecommendationKey(domain.RecommendWhenHigh): "Trim the documentation to the life-cycle needs",
+        domain.CostDriverTIME.RecommendationKey(domain.RecommendWhenHigh): "Consider hardware with more processing headroom",
+        domain.CostDriverSTOR.RecommendationKey(domain.RecommendWhenHigh): "Consider hardware with more main storage headroom",
+        domain.CostDriverPVOL.RecommendationKey(domain.RecommendWhenHigh): "Pin platform versions or introduce an abstraction layer",
+        domain.CostDriverACAP.RecommendationKey(domain.RecommendWhenLow):  "Improve analyst capability by assigning experienced analysts or training in requirements analysis",
+        domain.CostDriverPCAP.RecommendationKey(domain.RecommendWhenLow):  "Assign skilled programmers and use code reviews or pair programming",
+        domain.CostDriverPCON.RecommendationKey(domain.RecommendWhenLow):  "Put retention measures and knowledge sharing in place",
+        domain.CostDriverAPEX.RecommendationKey(domain.RecommendWhenLow):  "Train the team in the business domain or involve domain experts",
+        domain.CostDriverPLEX.RecommendationKey(domain.RecommendWhenLow):  "Train the team on the platform or assign experienced members",
+        domain.CostDriverLTEX.RecommendationKey(domain.RecommendWhenLow):  "Train the team on the language and tools or assign experienced members",
+        domain.CostDriverTOOL.RecommendationKey(domain.RecommendWhenLow):  "Introduce tools such as CI/CD and integrated development environments",
+        domain.CostDriverSITE.RecommendationKey(domain.RecommendWhenLow):  "Set up collaboration tools and regular cross-site meetings",
+        domain.CostDriverSCED.RecommendationKey(domain.RecommendWhenLow):  "Prioritize the scope and release in stages to ease the schedule compression",
+
+        domain.MsgRiskScaleFactorDescription: "Impact of a low scale factor rating",
+        domain.MsgRiskScaleFactorMitigation:  "Consider process improvements and risk reduction measures",
+        domain.MsgRiskCostDriverDescription:  "Impact of a high cost driver multiplier",
+        domain.MsgRiskCostDriverMitigation:   "Consider technical countermeasures and improvements",
+
+        domain.MsgRiskLargeProjectName:        "Large project",
+        domain.MsgRiskLargeProjectDescription: "Increased complexity due to the project size",
+        domain.MsgRiskLargeProjectMitigation:  "Consider modularization and incremental development",
+
+        domain.MsgRiskScheduleCompressionName:        "Schedule compression",
+        domain.MsgRiskScheduleCompressionDescription: "More staff and effort due to a schedule shorter than nominal",
+        domain.MsgRiskScheduleCompressionMitigation:  "Consider splitting the scope into staged releases or renegotiating the deadline",
+
+        domain.MsgRiskScheduleFloorName:        "Infeasible schedule",
+        domain.MsgRiskScheduleFloorDescription: "The requested duration is below the minimum schedule (75% of nominal), which no amount of staff can achieve",
+        domain.MsgRiskScheduleFloorMitigation:  "Consider reducing the scope or extending the deadline",
+
+        domain.MsgWarningScheduleFloor: "The requested duration is below the minimum schedule",
+
+        domain.MsgRiskLargeEffortName:        "Large effort",
+        domain.MsgRiskLargeEffortDescription: "The effort is large, so estimation errors weigh heavily on the cost",
+        domain.MsgRiskLargeEffortMitigation:  "Consider a contingency reserve and tracking actuals at each milestone",
+
+        domain.MsgRiskLargeTeamName:        "Large team",
+        domain.MsgRiskLargeTeamDescription: "The team is large, increasing communication and management costs",
+        domain.MsgRiskLargeTeamMitigation:  "Consider splitting into small sub-teams with clear responsibilities",
+
+        domain.PhaseNameKey(domain.PhaseRequirements):    "Plans and Requirements",
+        domain.PhaseNameKey(domain.PhaseSystemDesign):    "System Design",
+        domain.PhaseNameKey(domain.PhaseDetailedDesign):  "Detailed Design",
+        domain.PhaseNameKey(domain.PhaseImplementation):  "Implementation and Unit Test",
+        domain.PhaseNameKey(domain.PhaseIntegrationTest): "Integration Test",
+        domain.PhaseNameKey(domain.PhaseSystemTest):      "System Test",
+    }
+
+    for t, text := range englishScaleFactors {
+        messages[t.NameKey()] = text.Name
+        messages[t.DescriptionKey()] = text.Description
+        for i, guide := range text.Guide {
+            if guide != "" {
+                messages[t.GuideKey(domain.RatingLevels[i])] = guide
+            }
+        }
+    }
+    for t, text := range englishCostDrivers {
+        messages[t.NameKey()] = text.Name
+        messages[t.DescriptionKey()] = text.Description
+        for i, guide := range text.Guide {
+            if guide != "" {
+                messages[t.GuideKey(domain.RatingLevels[i])] = guide
+            }
+        }
+    }
+
+    return messages
+}
@@ -0,0 +1,116 @@
+package i18n
+
+import (
+    "sort"
+    "strconv"
+    "strings"
+
+    "estimate-backend/internal/domain"
+)
+
+// Language identifies a message catalog
+type Language string
+
+const (
+    Japanese Language = "ja"
+    English  Language = "en"
+
+    // DefaultLanguage is used when the client accepts none of the supported languages
+    DefaultLanguage = Japanese
+)
+
+// catalogs holds the messages of each supported language by message key
+var catalogs = map[Language]map[string]string{
+    Japanese: japanese(),
+    English:  english(),
+}
+
+// FromAcceptLanguage returns the supported language the client prefers, following the
+// quality values of an Accept-Language header such as "en-US,en;q=0.9,ja;q=0.8"
+func FromAcceptLanguage(header string) Language {
+    type candidate struct {
+        language Language
+        quality  float64
+    }
+    var candidates []candidate
+    for _, part := range strings.Split(header, ",") {
+        tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+        quality := 1.0
+        if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+            if v, err := strconv.ParseFloat(q, 64); err == nil {
+                quality = v
+            }
+        }
+
+        // Only the primary subtag selects the catalog: en-US and en-GB both get English
+        primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+        if _, ok := catalogs[Language(primary)]; ok && quality > 0 {
+            candidates = append(candidates, candidate{Language(primary), quality})
+        }
+    }
+    if len(candidates) == 0 {
+        return DefaultLanguage
+    }
+
+    sort.SliceStable(candidates, func(i, j int) bool {
+        return candidates[i].quality > candidates[j].quality
+    })
+    return candidates[0].language
+}
+
+// Text returns the message of a key, falling back to the default language and then to the key
+// itself, so texts that aren't message keys, such as the names of custom factors, pass through
+func (l Language) Text(key string) string {
+    return l.TextOr(key, key)
+}
+
+// TextOr returns the message of a key, falling back to the default language and then to fallback
+func (l Language) TextOr(key, fallback string) string {
+    if message, ok := catalogs[l][key]; ok {
+        return message
+    }
+    if message, ok := catalogs[DefaultLanguage][key]; ok {
+        return message
+    }
+    return fallback
+}
+
+// LocalizeCOCOMOComparison replaces the factor names of a COCOMO II comparison with their messages
+func (l Language) LocalizeCOCOMOComparison(comparison *domain.COCOMOComparison) {
+    if comparison == nil {
+        return
+    }
+    for i := range comparison.Factors {
+        comparison.Factors[i].Name = l.Text(comparison.Factors[i].Name)
+    }
+}
+
+// LocalizeDetailedResult replaces the message keys of a detailed COCOMO II result with their messages
+func (l Language) LocalizeDetailedResult(result *domain.COCOMODetailedResult) {
+    if result == nil {
+        return
+    }
+    for i := range result.PhaseDistribution {
+        result.PhaseDistribution[i].Phase = l.Text(result.PhaseDistribution[i].Phase)
+    }
+    for _, analyses := range [][]domain.FactorAnalysis{result.ScaleFactorAnalysis, result.CostDriverAnalysis} {
+        for i := range analyses {
+            analyses[i].Name = l.Text(analyses[i].Name)
+            if analyses[i].Recommendation != "" {
+                analyses[i].Recommendation = l.Text(analyses[i].Recommendation)
+            }
+        }
+    }
+    for i := range result.SensitivityRanking {
+        result.SensitivityRanking[i].Name = l.Text(result.SensitivityRanking[i].Name)
+    }
+    for i := range result.RiskFactors {
+        risk := &result.RiskFactors[i]
+        risk.Name = l.Text(risk.Name)
+        risk.Description = l.Text(risk.Description)
+        risk.Mitigation = l.Text(risk.Mitigation)
+    }
+    for i := range result.Warnings {
+        result.Warnings[i].Message = l.Text(result.Warnings[i].Message)
+    }
+}
@@ -0,0 +1,88 @@
+package i18n
+
+import "estimate-backend/internal/domain"
+
+// japanese returns the Japanese catalog. The texts of the scale factors and cost drivers come
+// from their built-in definitions, which are written in Japanese.
+func japanese() map[string]string {
+    messages := map[string]string{
+        domain.MsgRecommendScaleFactor: "この要因の改善により工数を削減できる可能性があります",
+        domain.MsgRecommendCostDriver:  "この要因の最適化により工数を削減できる可能性があります",
+
+        domain.ScaleFactorPREC.RecommendationKey(domain.RecommendWhenLow): "類似プロジェクトの経験者の参画やプロトタイプによる事前検証を検討",
+        domain.ScaleFactorFLEX.RecommendationKey(domain.RecommendWhenLow): "要件やプロセスの制約のうち交渉可能なものの緩和を検討",
+        domain.ScaleFactorRESL.RecommendationKey(domain.RecommendWhenLow): "アーキテクチャレビューとリスク管理計画を早期に実施",
+        domain.ScaleFactorTEAM.RecommendationKey(domain.RecommendWhenLow): "キックオフやチームビルディングによる関係者間の合意形成を検討",
+        domain.ScaleFactorPMAT.RecommendationKey(domain.RecommendWhenLow): "CMMIなどに基づくプロセス成熟度の向上への投資を検討",
+        domain.CostDriverRELY.RecommendationKey(domain.RecommendWhenHigh): "テスト自動化と検証・妥当性確認プロセスへの投資を検討",
+        domain.CostDriverDATA.RecommendationKey(domain.RecommendWhenHigh): "テストデータの生成と管理の自動化を検討",
+        domain.CostDriverCPLX.RecommendationKey(domain.RecommendWhenHigh): "複雑な部分の設計レビューとプロトタイピングを早期に実施",
+        domain.CostDriverREUS.RecommendationKey(domain.RecommendWhenHigh): "再利用の範囲を実際に必要なものに絞ることを検討",
+        domain.CostDriverDOCU.RecommendationKey(domain.RecommendWhenHigh): "ドキュメントをライフサイクルのニーズに合わせて削減",
+        domain.CostDriverTIME.RecommendationKey(domain.RecommendWhenHigh): "処理性能に余裕のあるハードウェアの採用を検討",
+        domain.CostDriverSTOR.RecommendationKey(domain.RecommendWhenHigh): "主記憶に余裕のあるハードウェアの採用を検討",
+        domain.CostDriverPVOL.RecommendationKey(domain.RecommendWhenHigh): "プラットフォームのバージョン固定や抽象化層の導入を検討",
+        domain.CostDriverACAP.RecommendationKey(domain.RecommendWhenLow):  "経験豊富なアナリストの配置や要件分析の研修によるアナリスト能力の向上を検討",
+        domain.CostDriverPCAP.RecommendationKey(domain.RecommendWhenLow):  "熟練プログラマの配置やコードレビュー、ペアプログラミングを検討",
+        domain.CostDriverPCON.RecommendationKey(domain.RecommendWhenLow):  "要員の定着策と知識共有の仕組みを整備",
+        domain.CostDriverAPEX.RecommendationKey(domain.RecommendWhenLow):  "業務知識の研修や業務担当者の参画を検討",
+        domain.CostDriverPLEX.RecommendationKey(domain.RecommendWhenLow):  "プラットフォームの研修や経験者の配置を検討",
+        domain.CostDriverLTEX.RecommendationKey(domain.RecommendWhenLow):  "言語・ツールの研修や経験者の配置を検討",
+        domain.CostDriverTOOL.RecommendationKey(domain.RecommendWhenLow):  "CI/CDや統合開発環境などのツール導入を検討",
+        domain.CostDriverSITE.RecommendationKey(domain.RecommendWhenLow):  "コラボレーションツールの整備と拠点間の定例会議を検討",
+        domain.CostDriverSCED.RecommendationKey(domain.RecommendWhenLow):  "スコープの優先順位付けと段階的リリースにより工期の短縮を緩和",
+
+        domain.MsgRiskScaleFactorDescription: "低いスケールファクター評価による影響",
+        domain.MsgRiskScaleFactorMitigation:  "プロセスの改善とリスク軽減策の実施を検討",
+        domain.MsgRiskCostDriverDescription:  "高いコストドライバー値による影響",
+        domain.MsgRiskCostDriverMitigation:   "技術的な対策と改善策の実施を検討",
+
+        domain.MsgRiskLargeProjectName:        "大規模プロジェクト",
+        domain.MsgRiskLargeProjectDescription: "プロジェクト規模が大きいことによる複雑性の増加",
+        domain.MsgRiskLargeProjectMitigation:  "モジュール化とインクリメンタル開発の採用を検討",
+
+        domain.MsgRiskScheduleCompressionName:        "工期短縮",
+        domain.MsgRiskScheduleCompressionDescription: "標準工期より短い工期による要員増と工数増",
+        domain.MsgRiskScheduleCompressionMitigation:  "スコープの段階的リリースへの分割、または納期の再交渉を検討",
+
+        domain.MsgRiskScheduleFloorName:        "実現不可能な工期",
+        domain.MsgRiskScheduleFloorDescription: "要求工期が最短工期（標準工期の75%）を下回っており、要員を増やしても達成できない",
+        domain.MsgRiskScheduleFloorMitigation:  "スコープの削減または納期の延長を検討",
+
+        domain.MsgWarningScheduleFloor: "要求工期が最短工期を下回っています",
+
+        domain.MsgRiskLargeEffortName:        "大規模工数",
+        domain.MsgRiskLargeEffortDescription: "見積工数が大きく、見積誤差がコストに与える影響が大きい",
+        domain.MsgRiskLargeEffortMitigation:  "予備費の確保とマイルストーンごとの予実管理を検討",
+
+        domain.MsgRiskLargeTeamName:        "大規模チーム",
+        domain.MsgRiskLargeTeamDescription: "チーム規模が大きく、コミュニケーションと管理のコストが増加",
+        domain.MsgRiskLargeTeamMitigation:  "小規模なサブチームへの分割と責任範囲の明確化を検討",
+
+        domain.PhaseNameKey(domain.PhaseRequirements):    "要件定義・計画",
+        domain.PhaseNameKey(domain.PhaseSystemDesign):    "システム設計",
+        domain.PhaseNameKey(domain.PhaseDetailedDesign):  "詳細設計",
+        domain.PhaseNameKey(domain.PhaseImplementation):  "実装・単体テスト",
+        domain.PhaseNameKey(domain.PhaseIntegrationTest): "結合テスト",
+        domain.PhaseNameKey(domain.PhaseSystemTest):      "システムテスト",
+    }
+
+    for _, t := range domain.ScaleFactorTypes {
+        sf := domain.NewScaleFactor(t)
+        messages[t.NameKey()] = sf.Name
+        messages[t.DescriptionKey()] = sf.Description
+        for _, level := range t.RatingGuide() {
+            messages[t.GuideKey(level.Level)] = level.Description
+        }
+    }
+    for _, t := range domain.CostDriverTypes {
+        cd := domain.NewCostDriver(t)
+        messages[t.NameKey()] = cd.Name
+        messages[t.DescriptionKey()] = cd.Description
+        for _, level := range t.RatingGuide() {
+            messages[t.GuideKey(level.Level)] = level.Description
+        }
+    }
+
+    return messages
+}
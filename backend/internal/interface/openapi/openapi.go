@@ -0,0 +1,221 @@
+package openapi
+
+import (
+    "net/http"
+    "path"
+    "reflect"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// Route identifies a registered route by method and Echo path, e.g. GET /api/estimates/:id
+type Route struct {
+    Method string
+    Path   string
+}
+
+// Operation documents the request and response bodies of a route.
+// Request and Response hold a value of the body type; nil means the route has no JSON body.
+type Operation struct {
+    Summary     string
+    Request     interface{}
+    Response    interface{}
+    Status      int    // Success status, defaults to 200
+    ContentType string // Response content type of non-JSON responses such as file exports
+}
+
+// Generate builds an OpenAPI 3 document covering every route.
+// Routes without a documented operation are listed with a generic success response.
+func Generate(title, version string, routes []Route, operations map[Route]Operation) map[string]interface{} {
+    g := &generator{schemas: make(map[string]interface{})}
+
+    paths := make(map[string]interface{})
+    for _, route := range routes {
+        p := toOpenAPIPath(route.Path)
+        item, ok := paths[p].(map[string]interface{})
+        if !ok {
+            item = make(map[string]interface{})
+            paths[p] = item
+        }
+        item[strings.ToLower(route.Method)] = g.operation(route, operations[route])
+    }
+
+    return map[string]interface{}{
+        "openapi": "3.0.3",
+        "info": map[string]interface{}{
+            "title":   title,
+            "version": version,
+        },
+        "paths": paths,
+        "components": map[string]interface{}{
+            "schemas": g.schemas,
+        },
+    }
+}
+
+// generator collects the component schemas referenced by the operations
+type generator struct {
+    schemas map[string]interface{}
+}
+
+// operation builds the OpenAPI operation object of a route
+func (g *generator) operation(route Route, op Operation) map[string]interface{} {
+    result := map[string]interface{}{
+        "operationId": operationID(route),
+    }
+    if op.Summary != "" {
+        result["summary"] = op.Summary
+    }
+
+    var params []interface{}
+    for _, segment := range strings.Split(route.Path, "/") {
+        if strings.HasPrefix(segment, ":") {
+            params = append(params, map[string]interface{}{
+                "name":     segment[1:],
+                "in":       "path",
+                "required": true,
+                "schema":   map[string]interface{}{"type": "string"},
+            })
+        }
+    }
+    if len(params) > 0 {
+        result["parameters"] = params
+    }
+
+    if op.Request != nil {
+        result["requestBody"] = map[string]interface{}{
+            "required": true,
+            "content": map[string]interface{}{
+                "application/json": map[string]interface{}{
+                    "schema": g.schemaOf(reflect.TypeOf(op.Request)),
+                },
+            },
+        }
+    }
+
+    status := op.Status
+    if status == 0 {
+        status = http.StatusOK
+    }
+    response := map[string]interface{}{
+        "description": http.StatusText(status),
+    }
+    switch {
+    case op.ContentType != "":
+        response["content"] = map[string]interface{}{
+            op.ContentType: map[string]interface{}{
+                "schema": map[string]interface{}{"type": "string", "format": "binary"},
+            },
+        }
+    case op.Response != nil:
+        response["content"] = map[string]interface{}{
+            "application/json": map[string]interface{}{
+                "schema": g.schemaOf(reflect.TypeOf(op.Response)),
+            },
+        }
+    }
+    result["responses"] = map[string]interface{}{
+        strconv.Itoa(status): response,
+    }
+
+    return result
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaOf returns the schema of a Go type. Named structs become component schemas referenced by $ref.
+func (g *generator) schemaOf(t reflect.Type) map[string]interface{} {
+    for t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    if t == timeType {
+        return map[string]interface{}{"type": "string", "format": "date-time"}
+    }
+
+    switch t.Kind() {
+    case reflect.Bool:
+        return map[string]interface{}{"type": "boolean"}
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+        reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        return map[string]interface{}{"type": "integer"}
+    case reflect.Float32, reflect.Float64:
+        return map[string]interface{}{"type": "number"}
+    case reflect.String:
+        return map[string]interface{}{"type": "string"}
+    case reflect.Slice, reflect.Array:
+        if t.Elem().Kind() == reflect.Uint8 {
+            return map[string]interface{}{"type": "string", "format": "byte"}
+        }
+        return map[string]interface{}{"type": "array", "items": g.schemaOf(t.Elem())}
+    case reflect.Map:
+        return map[string]interface{}{"type": "object", "additionalProperties": g.schemaOf(t.Elem())}
+    case reflect.Struct:
+        if t.Name() == "" {
+            return g.structSchema(t)
+        }
+        name := path.Base(t.PkgPath()) + "." + t.Name()
+        if _, ok := g.schemas[name]; !ok {
+            // Register before descending so recursive types terminate
+            g.schemas[name] = map[string]interface{}{}
+            g.schemas[name] = g.structSchema(t)
+        }
+        return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+    default:
+        return map[string]interface{}{}
+    }
+}
+
+// structSchema returns the object schema of a struct, following encoding/json naming rules
+func (g *generator) structSchema(t reflect.Type) map[string]interface{} {
+    properties := make(map[string]interface{})
+    g.addProperties(t, properties)
+    return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+// addProperties adds the JSON properties of a struct, inlining untagged embedded structs
+func (g *generator) addProperties(t reflect.Type, properties map[string]interface{}) {
+    for i := 0; i < t.NumField(); i++ {
+        field := t.Field(i)
+        tag := field.Tag.Get("json")
+        if tag == "-" {
+            continue
+        }
+        name := strings.Split(tag, ",")[0]
+
+        if field.Anonymous && name == "" {
+            embedded := field.Type
+            for embedded.Kind() == reflect.Ptr {
+                embedded = embedded.Elem()
+            }
+            if embedded.Kind() == reflect.Struct {
+                g.addProperties(embedded, properties)
+                continue
+            }
+        }
+        if !field.IsExported() {
+            continue
+        }
+        if name == "" {
+            name = field.Name
+        }
+        properties[name] = g.schemaOf(field.Type)
+    }
+}
+
+// toOpenAPIPath converts Echo path parameters (:id) to OpenAPI templates ({id})
+func toOpenAPIPath(echoPath string) string {
+    segments := strings.Split(echoPath, "/")
+    for i, segment := range segments {
+        if strings.HasPrefix(segment, ":") {
+            segments[i] = "{" + segment[1:] + "}"
+        }
+    }
+    return strings.Join(segments, "/")
+}
+
+// operationID derives a stable operation ID from the method and path
+func operationID(route Route) string {
+    replacer := strings.NewReplacer("/", "_", ":", "", ".", "_", "-", "_")
+    return strings.ToLower(route.Method) + replacer.Replace(strings.TrimPrefix(route.Path, "/api"))
+}
@@ -0,0 +1,95 @@
+package presenter
+
+import (
+    "bufio"
+    "encoding/csv"
+    "fmt"
+    "math"
+    "io"
+    "strconv"
+    "strings"
+
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/usecase"
+)
+
+// utf8BOM lets spreadsheet applications detect UTF-8 so Japanese names survive a round trip
+const utf8BOM = "\ufeff"
+
+// deliverableSeparator joins the deliverables of an activity within a single cell
+const deliverableSeparator = ";"
+
+var activityCSVHeader = []string{"process", "activity", "baseHours", "deliverables"}
+
+// WriteActivitiesCSV writes one row per activity of the given processes
+func WriteActivitiesCSV(w io.Writer, processes []*domain.Process) error {
+    if _, err := io.WriteString(w, utf8BOM); err != nil {
+        return err
+    }
+
+    cw := csv.NewWriter(w)
+    if err := cw.Write(activityCSVHeader); err != nil {
+        return err
+    }
+    for _, process := range processes {
+        for _, activity := range process.Activities {
+            record := []string{
+                process.Name,
+                activity.Name,
+                strconv.FormatFloat(activity.BaseHours, 'f', -1, 64),
+                strings.Join(activity.Deliverables, deliverableSeparator),
+            }
+            if err := cw.Write(record); err != nil {
+                return err
+            }
+        }
+    }
+    cw.Flush()
+    return cw.Error()
+}
+
+// ReadActivitiesCSV parses rows written by WriteActivitiesCSV
+func ReadActivitiesCSV(r io.Reader) ([]usecase.ActivityImportRow, error) {
+    br := bufio.NewReader(r)
+    if bom, err := br.Peek(len(utf8BOM)); err == nil && string(bom) == utf8BOM {
+        br.Discard(len(utf8BOM))
+    }
+
+    cr := csv.NewReader(br)
+    cr.FieldsPerRecord = len(activityCSVHeader)
+    records, err := cr.ReadAll()
+    if err != nil {
+        return nil, fmt.Errorf("invalid CSV: %w", err)
+    }
+    if len(records) == 0 {
+        return nil, fmt.Errorf("CSV is empty")
+    }
+    for i, column := range activityCSVHeader {
+        if strings.TrimSpace(records[0][i]) != column {
+            return nil, fmt.Errorf("unexpected header %q, want %q", records[0][i], column)
+        }
+    }
+
+    rows := make([]usecase.ActivityImportRow, 0, len(records)-1)
+    for i, record := range records[1:] {
+        baseHours, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+        if err != nil || math.IsNaN(baseHours) || math.IsInf(baseHours, 0) {
+            return nil, fmt.Errorf("line %d: invalid baseHours %q", i+2, record[2])
+        }
+
+        var deliverables []string
+        for _, d := range strings.Split(record[3], deliverableSeparator) {
+            if d = strings.TrimSpace(d); d != "" {
+                deliverables = append(deliverables, d)
+            }
+        }
+
+        rows = append(rows, usecase.ActivityImportRow{
+            ProcessName:  strings.TrimSpace(record[0]),
+            ActivityName: strings.TrimSpace(record[1]),
+            BaseHours:    baseHours,
+            Deliverables: deliverables,
+        })
+    }
+    return rows, nil
+}
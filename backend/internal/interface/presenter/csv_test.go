@@ -0,0 +1,46 @@
+package presenter
+
+import (
+    "bytes"
+    "reflect"
+    "testing"
+
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/usecase"
+)
+
+func TestActivitiesCSVRoundTrip(t *testing.T) {
+    processes := []*domain.Process{{
+        Name: "要件定義",
+        Activities: []domain.Activity{
+            {Name: "業務分析", BaseHours: 24.5, Deliverables: []string{"業務フロー図", "課題一覧"}},
+            {Name: "要件レビュー, 承認", BaseHours: 8},
+        },
+    }}
+
+    var buf bytes.Buffer
+    if err := WriteActivitiesCSV(&buf, processes); err != nil {
+        t.Fatal(err)
+    }
+    rows, err := ReadActivitiesCSV(&buf)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    want := []usecase.ActivityImportRow{
+        {ProcessName: "要件定義", ActivityName: "業務分析", BaseHours: 24.5, Deliverables: []string{"業務フロー図", "課題一覧"}},
+        {ProcessName: "要件定義", ActivityName: "要件レビュー, 承認", BaseHours: 8},
+    }
+    if !reflect.DeepEqual(rows, want) {
+        t.Errorf("rows = %+v, want %+v", rows, want)
+    }
+}
+
+func TestReadActivitiesCSVRejectsInvalidHours(t *testing.T) {
+    for _, hours := range []string{"many", "NaN", "Inf", "-Inf"} {
+        csv := "process,activity,baseHours,deliverables\n要件定義,業務分析," + hours + ",\n"
+        if _, err := ReadActivitiesCSV(bytes.NewBufferString(csv)); err == nil {
+            t.Errorf("baseHours %s accepted", hours)
+        }
+    }
+}
@@ -0,0 +1,225 @@
+package presenter
+
+import (
+    "fmt"
+    "io"
+    "os"
+
+    "github.com/go-pdf/fpdf"
+    "estimate-backend/internal/domain"
+)
+
+// pdfFontEnv names the environment variable pointing to a UTF-8 TrueType font.
+// The core PDF fonts can't render Japanese, so set it to e.g. a Noto Sans JP file.
+const pdfFontEnv = "PDF_FONT_PATH"
+
+// pdfDocument wraps fpdf with the font selection and text translation in use
+type pdfDocument struct {
+    pdf       *fpdf.Fpdf
+    family    string
+    translate func(string) string
+}
+
+// newPDFDocument creates an A4 document using the configured UTF-8 font or Helvetica as fallback
+func newPDFDocument() *pdfDocument {
+    pdf := fpdf.New("P", "mm", "A4", "")
+    doc := &pdfDocument{pdf: pdf, family: "Helvetica", translate: func(s string) string { return s }}
+
+    if fontPath := os.Getenv(pdfFontEnv); fontPath != "" {
+        pdf.AddUTF8Font("Estimate", "", fontPath)
+        pdf.AddUTF8Font("Estimate", "B", fontPath)
+        doc.family = "Estimate"
+    } else {
+        doc.translate = pdf.UnicodeTranslatorFromDescriptor("")
+    }
+
+    pdf.SetTitle("Estimate", true)
+    pdf.AddPage()
+    return doc
+}
+
+// heading writes a section heading
+func (d *pdfDocument) heading(text string) {
+    d.pdf.Ln(4)
+    d.pdf.SetFont(d.family, "B", 12)
+    d.pdf.CellFormat(0, 8, d.translate(text), "", 1, "L", false, 0, "")
+    d.pdf.SetFont(d.family, "", 10)
+}
+
+// line writes a label/value line
+func (d *pdfDocument) line(label, value string) {
+    d.pdf.CellFormat(60, 6, d.translate(label), "", 0, "L", false, 0, "")
+    d.pdf.CellFormat(0, 6, d.translate(value), "", 1, "L", false, 0, "")
+}
+
+// table writes a table with a header row; the first column is left aligned, the others right aligned
+func (d *pdfDocument) table(widths []float64, header []string, rows [][]string) {
+    d.pdf.SetFont(d.family, "B", 9)
+    for i, h := range header {
+        d.pdf.CellFormat(widths[i], 7, d.translate(h), "1", 0, "C", false, 0, "")
+    }
+    d.pdf.Ln(-1)
+
+    d.pdf.SetFont(d.family, "", 9)
+    for _, row := range rows {
+        for i, cell := range row {
+            align := "R"
+            if i == 0 {
+                align = "L"
+            }
+            d.pdf.CellFormat(widths[i], 6, d.translate(cell), "1", 0, align, false, 0, "")
+        }
+        d.pdf.Ln(-1)
+    }
+}
+
+// RenderEstimatePDF writes the estimate and its detailed COCOMO II result as a PDF.
+// The COCOMO II sections are omitted when result is nil, the cost section when no hourly rate was applied.
+func RenderEstimatePDF(w io.Writer, estimate *domain.Estimate, result *domain.COCOMODetailedResult) error {
+    doc := newPDFDocument()
+    pdf := doc.pdf
+
+    pdf.SetFont(doc.family, "B", 16)
+    pdf.CellFormat(0, 10, doc.translate("Estimate: "+estimate.ProjectName), "", 1, "L", false, 0, "")
+    pdf.SetFont(doc.family, "", 10)
+
+    doc.line("Project ID", estimate.ProjectID)
+    doc.line("Status", string(estimate.Status))
+    doc.line("Created", estimate.CreatedAt.Format("2006-01-02"))
+    doc.line("Total hours", hours(estimate.TotalHours))
+
+    // Activity-based breakdown
+    if len(estimate.ProcessEstimates) > 0 {
+        doc.heading("Process breakdown")
+        var rows [][]string
+        for _, pe := range estimate.ProcessEstimates {
+            name := ""
+            if pe.Process != nil {
+                name = pe.Process.Name
+            }
+            rows = append(rows, []string{name, hours(pe.BaseHours), hours(pe.TotalHours)})
+        }
+        doc.table([]float64{90, 45, 45}, []string{"Process", "Base hours", "Total hours"}, rows)
+    }
+
+    if len(estimate.AdditionalEfforts) > 0 {
+        doc.heading("Additional effort")
+        for _, line := range estimate.AdditionalEfforts {
+            doc.line(line.Name, hours(line.Hours))
+        }
+    }
+
+    if result != nil {
+        renderCOCOMOResult(doc, result)
+    }
+
+    if err := pdf.Error(); err != nil {
+        return err
+    }
+    return pdf.Output(w)
+}
+
+// renderCOCOMOResult writes the COCOMO II sections of the PDF
+func renderCOCOMOResult(doc *pdfDocument, result *domain.COCOMODetailedResult) {
+    doc.heading("COCOMO II (" + result.ModelType + ")")
+    doc.line("Project size (KSLOC)", number(result.ProjectSize))
+    doc.line("Effort (person-months)", fmt.Sprintf("%s (%s - %s)",
+        number(result.EffortRange.Nominal), number(result.EffortRange.Optimistic), number(result.EffortRange.Pessimistic)))
+    doc.line("Duration (months)", fmt.Sprintf("%s (%s - %s)",
+        number(result.DurationRange.Nominal), number(result.DurationRange.Optimistic), number(result.DurationRange.Pessimistic)))
+    doc.line("Team size", fmt.Sprintf("%s (%s - %s)",
+        number(result.TeamSizeRange.Average), number(result.TeamSizeRange.Minimum), number(result.TeamSizeRange.Maximum)))
+
+    costed := result.CostEstimate.HourlyRate > 0
+    if costed {
+        doc.heading("Cost")
+        if result.CostEstimate.Currency != "" {
+            doc.line("Currency", result.CostEstimate.Currency)
+        }
+        doc.line("Hourly rate (blended)", number(result.CostEstimate.HourlyRate))
+        doc.line("Labor cost", number(result.CostEstimate.Subtotal))
+        doc.line("Overhead", number(result.CostEstimate.Overhead))
+        doc.line("Tax", number(result.CostEstimate.Tax))
+        doc.line("Total cost", number(result.CostEstimate.GrandTotal))
+        doc.line("Cost range", fmt.Sprintf("%s - %s",
+            number(result.CostEstimate.CostRange.Minimum), number(result.CostEstimate.CostRange.Maximum)))
+    }
+
+    doc.heading("Phase distribution")
+    widths := []float64{60, 25, 30, 30, 35}
+    header := []string{"Phase", "Effort %", "Effort (PM)", "Duration", "Avg. staff"}
+    if costed {
+        widths = []float64{50, 20, 25, 25, 25, 35}
+        header = []string{"Phase", "Effort %", "Effort (PM)", "Duration", "Avg. staff", "Cost"}
+    }
+    var phaseRows [][]string
+    for _, phase := range result.PhaseDistribution {
+        row := []string{
+            phase.Phase,
+            fmt.Sprintf("%.0f%%", phase.PercentEffort*100),
+            number(phase.Effort),
+            number(phase.Duration),
+            number(phase.AverageStaff),
+        }
+        if costed {
+            row = append(row, number(phase.Cost))
+        }
+        phaseRows = append(phaseRows, row)
+    }
+    doc.table(widths, header, phaseRows)
+
+    if len(result.RoleDistribution) > 0 {
+        doc.heading("Role distribution")
+        widths = []float64{60, 25, 30}
+        header = []string{"Role", "Effort %", "Effort (PM)"}
+        if costed {
+            widths = append(widths, 35)
+            header = append(header, "Cost")
+        }
+        var roleRows [][]string
+        for _, role := range result.RoleDistribution {
+            row := []string{role.Role, fmt.Sprintf("%.0f%%", role.PercentEffort*100), number(role.Effort)}
+            if costed {
+                row = append(row, number(role.Cost))
+            }
+            roleRows = append(roleRows, row)
+        }
+        doc.table(widths, header, roleRows)
+    }
+
+    renderFactorAnalysis(doc, "Scale factor analysis", result.ScaleFactorAnalysis)
+    renderFactorAnalysis(doc, "Cost driver analysis", result.CostDriverAnalysis)
+
+    doc.heading("Risk assessment: " + result.RiskLevel)
+    if len(result.RiskFactors) > 0 {
+        var riskRows [][]string
+        for _, risk := range result.RiskFactors {
+            riskRows = append(riskRows, []string{risk.Name, risk.Category, risk.Level, number(risk.Impact)})
+        }
+        doc.table([]float64{80, 35, 30, 35}, []string{"Risk", "Category", "Level", "Impact"}, riskRows)
+    }
+}
+
+// renderFactorAnalysis writes a factor analysis table
+func renderFactorAnalysis(doc *pdfDocument, title string, analyses []domain.FactorAnalysis) {
+    if len(analyses) == 0 {
+        return
+    }
+
+    doc.heading(title)
+    var rows [][]string
+    for _, a := range analyses {
+        rows = append(rows, []string{a.Name, number(a.Rating), number(a.Impact), number(a.Sensitivity)})
+    }
+    doc.table([]float64{80, 30, 35, 35}, []string{"Factor", "Rating", "Impact", "Sensitivity"}, rows)
+}
+
+// hours formats an hour value
+func hours(v float64) string {
+    return fmt.Sprintf("%.1f h", v)
+}
+
+// number formats a numeric value with two decimals
+func number(v float64) string {
+    return fmt.Sprintf("%.2f", v)
+}
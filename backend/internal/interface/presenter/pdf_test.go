@@ -0,0 +1,84 @@
+package presenter
+
+import (
+    "bytes"
+    "compress/zlib"
+    "io"
+    "regexp"
+    "testing"
+
+    "estimate-backend/internal/domain"
+)
+
+// pdfStream matches the content streams of a PDF
+var pdfStream = regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`)
+
+// pdfContent returns the PDF with its compressed streams inflated, so that text can be searched
+func pdfContent(data []byte) []byte {
+    content := append([]byte(nil), data...)
+    for _, match := range pdfStream.FindAllSubmatch(data, -1) {
+        r, err := zlib.NewReader(bytes.NewReader(match[1]))
+        if err != nil {
+            continue
+        }
+        if inflated, err := io.ReadAll(r); err == nil {
+            content = append(content, inflated...)
+        }
+    }
+    return content
+}
+
+// testEstimate returns an estimate with one process and a COCOMO II estimate
+func testEstimate() (*domain.Estimate, *domain.COCOMODetailedResult) {
+    process := &domain.Process{ID: "p1", Category: domain.ProcessImplementation, Name: "Implementation"}
+    estimate := &domain.Estimate{
+        ID:          "e1",
+        ProjectID:   "project-1",
+        ProjectName: "Billing Renewal",
+        TotalHours:  480,
+        ProcessEstimates: []domain.ProcessEstimate{
+            {Process: process, BaseHours: 400, TotalHours: 480},
+        },
+    }
+
+    cocomo := &domain.COCOMOEstimate{
+        ProjectSize: 20,
+        Model:       &domain.COCOMOModel{ID: domain.ModelPostArchitectureID, Name: "Post-Architecture", A: 2.94, B: 0.91},
+    }
+    for _, t := range domain.ScaleFactorTypes {
+        sf := domain.NewScaleFactor(t)
+        sf.Rating = 2
+        cocomo.ScaleFactors = append(cocomo.ScaleFactors, sf)
+    }
+    for _, t := range domain.CostDriverTypes {
+        cd := domain.NewCostDriver(t)
+        cd.SetRating(2)
+        cocomo.CostDrivers = append(cocomo.CostDrivers, cd)
+    }
+    cocomo.CalculateEffort()
+    result := cocomo.GenerateDetailedResult(domain.FlatRateCard(5000), nil, domain.PhaseProfile{}, domain.DefaultEstimationConfig())
+    return estimate, result
+}
+
+func TestRenderEstimatePDF(t *testing.T) {
+    estimate, result := testEstimate()
+
+    var buf bytes.Buffer
+    if err := RenderEstimatePDF(&buf, estimate, result); err != nil {
+        t.Fatal(err)
+    }
+
+    data := buf.Bytes()
+    if !bytes.HasPrefix(data, []byte("%PDF-")) {
+        t.Fatalf("output starts with %q, want a PDF header", data[:min(len(data), 8)])
+    }
+    if !bytes.Contains(bytes.TrimSpace(data), []byte("%%EOF")) {
+        t.Error("output has no PDF trailer")
+    }
+    content := pdfContent(data)
+    for _, text := range []string{"Billing Renewal", "Process breakdown", "Implementation"} {
+        if !bytes.Contains(content, []byte(text)) {
+            t.Errorf("PDF does not contain %q", text)
+        }
+    }
+}
@@ -0,0 +1,119 @@
+package presenter
+
+import (
+    "io"
+
+    "github.com/xuri/excelize/v2"
+    "estimate-backend/internal/domain"
+)
+
+const (
+    processSheet = "Processes"
+    cocomoSheet  = "COCOMO"
+)
+
+// RenderEstimateXLSX writes the estimate as a workbook with a process breakdown sheet
+// and, when result is set, a COCOMO II sheet with phase distribution and cost ranges
+func RenderEstimateXLSX(w io.Writer, estimate *domain.Estimate, result *domain.COCOMODetailedResult) error {
+    f := excelize.NewFile()
+    defer f.Close()
+
+    if err := f.SetSheetName("Sheet1", processSheet); err != nil {
+        return err
+    }
+    if err := writeProcessSheet(f, estimate); err != nil {
+        return err
+    }
+
+    if result != nil {
+        if _, err := f.NewSheet(cocomoSheet); err != nil {
+            return err
+        }
+        if err := writeCOCOMOSheet(f, result); err != nil {
+            return err
+        }
+    }
+
+    return f.Write(w)
+}
+
+// writeProcessSheet writes one row per process estimate below a header row
+func writeProcessSheet(f *excelize.File, estimate *domain.Estimate) error {
+    header := []interface{}{"Process", "Tasks", "Base hours", "Accessibility hours", "Adjusted hours"}
+    if err := f.SetSheetRow(processSheet, "A1", &header); err != nil {
+        return err
+    }
+
+    for i, pe := range estimate.ProcessEstimates {
+        name := ""
+        if pe.Process != nil {
+            name = pe.Process.Name
+        }
+        row := []interface{}{name, len(pe.Tasks), pe.BaseHours, pe.AccessibilityHours, pe.TotalHours}
+        cell, err := excelize.CoordinatesToCellName(1, i+2)
+        if err != nil {
+            return err
+        }
+        if err := f.SetSheetRow(processSheet, cell, &row); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// writeCOCOMOSheet writes the effort, duration and cost ranges followed by the phase distribution table
+func writeCOCOMOSheet(f *excelize.File, result *domain.COCOMODetailedResult) error {
+    rows := [][]interface{}{
+        {"", "Optimistic", "Nominal", "Pessimistic"},
+        {"Effort (person-months)", result.EffortRange.Optimistic, result.EffortRange.Nominal, result.EffortRange.Pessimistic},
+        {"Duration (months)", result.DurationRange.Optimistic, result.DurationRange.Nominal, result.DurationRange.Pessimistic},
+    }
+    costed := result.CostEstimate.HourlyRate > 0
+    if costed {
+        cost := result.CostEstimate
+        rows = append(rows,
+            []interface{}{"Cost", cost.CostRange.Minimum, cost.GrandTotal, cost.CostRange.Maximum},
+            []interface{}{"Labor cost", cost.Subtotal},
+            []interface{}{"Overhead", cost.Overhead},
+            []interface{}{"Tax", cost.Tax},
+            []interface{}{"Hourly rate", cost.HourlyRate, cost.Currency},
+        )
+    }
+
+    header := []interface{}{"Phase", "Effort %", "Effort (PM)", "Duration (months)", "Avg. staff"}
+    if costed {
+        header = append(header, "Hourly rate", "Cost")
+    }
+    rows = append(rows, []interface{}{}, header)
+    for _, phase := range result.PhaseDistribution {
+        row := []interface{}{phase.Phase, phase.PercentEffort, phase.Effort, phase.Duration, phase.AverageStaff}
+        if costed {
+            row = append(row, phase.HourlyRate, phase.Cost)
+        }
+        rows = append(rows, row)
+    }
+
+    roleHeader := []interface{}{"Role", "Effort %", "Effort (PM)"}
+    if costed {
+        roleHeader = append(roleHeader, "Cost")
+    }
+    rows = append(rows, []interface{}{}, roleHeader)
+    for _, role := range result.RoleDistribution {
+        row := []interface{}{role.Role, role.PercentEffort, role.Effort}
+        if costed {
+            row = append(row, role.Cost)
+        }
+        rows = append(rows, row)
+    }
+
+    for i := range rows {
+        cell, err := excelize.CoordinatesToCellName(1, i+1)
+        if err != nil {
+            return err
+        }
+        if err := f.SetSheetRow(cocomoSheet, cell, &rows[i]); err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -0,0 +1,45 @@
+package presenter
+
+import (
+    "bytes"
+    "testing"
+
+    "github.com/xuri/excelize/v2"
+    "estimate-backend/internal/domain"
+)
+
+func TestRenderEstimateXLSX(t *testing.T) {
+    estimate, result := testEstimate()
+    estimate.ProcessEstimates = append(estimate.ProcessEstimates, domain.ProcessEstimate{
+        Process:    &domain.Process{ID: "p2", Category: domain.ProcessTesting, Name: "Testing"},
+        BaseHours:  100,
+        TotalHours: 120,
+    })
+
+    var buf bytes.Buffer
+    if err := RenderEstimateXLSX(&buf, estimate, result); err != nil {
+        t.Fatal(err)
+    }
+
+    f, err := excelize.OpenReader(&buf)
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer f.Close()
+
+    rows, err := f.GetRows(processSheet)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // A header row followed by one row per process
+    if got := len(rows) - 1; got != len(estimate.ProcessEstimates) {
+        t.Errorf("got %d process rows, want %d", got, len(estimate.ProcessEstimates))
+    }
+    if rows[2][0] != "Testing" {
+        t.Errorf("second process row starts with %q, want Testing", rows[2][0])
+    }
+
+    if index, err := f.GetSheetIndex(cocomoSheet); err != nil || index < 0 {
+        t.Errorf("workbook has no %s sheet", cocomoSheet)
+    }
+}
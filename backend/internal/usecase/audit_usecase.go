@@ -0,0 +1,85 @@
+package usecase
+
+import (
+    "time"
+
+    "estimate-backend/internal/domain"
+)
+
+// AuditUseCase records and retrieves the audit trail of estimates, factors, processes and activities
+type AuditUseCase struct {
+    auditRepo domain.AuditRepository
+}
+
+// NewAuditUseCase creates a new AuditUseCase
+func NewAuditUseCase(auditRepo domain.AuditRepository) *AuditUseCase {
+    return &AuditUseCase{
+        auditRepo: auditRepo,
+    }
+}
+
+// GetAuditLogs retrieves the audit trail of an entity, oldest first. An empty entityID retrieves every entry.
+func (uc *AuditUseCase) GetAuditLogs(entityID string) ([]*domain.AuditLog, error) {
+    if entityID == "" {
+        return uc.auditRepo.FindAll()
+    }
+    return uc.auditRepo.FindByEntityID(entityID)
+}
+
+// record saves an audit entry. It is a no-op when auditing isn't enabled, and failures
+// don't fail the change being recorded, which has already been saved.
+func (uc *AuditUseCase) record(actor string, entityType domain.AuditEntityType, entityID string, action domain.AuditAction, before, after map[string]interface{}) {
+    if uc == nil {
+        return
+    }
+    if actor == "" {
+        actor = "anonymous"
+    }
+    uc.auditRepo.Save(&domain.AuditLog{
+        Actor:      actor,
+        EntityType: entityType,
+        EntityID:   entityID,
+        Action:     action,
+        Before:     before,
+        After:      after,
+        Timestamp:  time.Now(),
+    })
+}
+
+// estimateSummary returns the audited fields of an estimate
+func estimateSummary(e *domain.Estimate) map[string]interface{} {
+    return map[string]interface{}{
+        "projectId":  e.ProjectID,
+        "status":     e.Status,
+        "totalHours": e.TotalHours,
+        "version":    e.Version,
+    }
+}
+
+// factorSummary returns the audited fields of a factor
+func factorSummary(f *domain.Factor) map[string]interface{} {
+    return map[string]interface{}{
+        "name":   f.Name,
+        "type":   f.Type,
+        "impact": f.Impact,
+    }
+}
+
+// processSummary returns the audited fields of a process
+func processSummary(p *domain.Process) map[string]interface{} {
+    return map[string]interface{}{
+        "name":       p.Name,
+        "activities": len(p.Activities),
+        "order":      p.Order,
+    }
+}
+
+// activitySummary returns the audited fields of an activity
+func activitySummary(processID string, a domain.Activity) map[string]interface{} {
+    return map[string]interface{}{
+        "processId":    processID,
+        "name":         a.Name,
+        "baseHours":    a.BaseHours,
+        "deliverables": len(a.Deliverables),
+    }
+}
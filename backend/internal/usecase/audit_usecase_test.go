@@ -0,0 +1,102 @@
+package usecase
+
+import (
+    "testing"
+
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/infrastructure/memory"
+)
+
+func TestUpdateEstimateRecordsOneAuditEntry(t *testing.T) {
+    f := newEstimateFixture(t)
+    audit := NewAuditUseCase(memory.NewInMemoryAuditRepository())
+    f.uc.SetAuditLog(audit)
+
+    task := f.task(t, domain.ProcessImplementation, 1)
+    estimate := f.create(t, CreateProjectEstimateInput{Tasks: []TaskInput{task}, CreatedBy: "alice"})
+
+    task.Scale = 2
+    updated, err := f.uc.UpdateEstimate(UpdateEstimateInput{
+        ID:      estimate.ID,
+        Version: estimate.Version,
+        Tasks:   []TaskInput{task},
+        Actor:   "bob",
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    entries, err := audit.GetAuditLogs(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    var updates []*domain.AuditLog
+    for _, entry := range entries {
+        if entry.Action == domain.AuditActionUpdated {
+            updates = append(updates, entry)
+        }
+    }
+    if len(updates) != 1 {
+        t.Fatalf("got %d update entries, want exactly 1", len(updates))
+    }
+
+    entry := updates[0]
+    if entry.Actor != "bob" || entry.EntityType != domain.AuditEntityEstimate || entry.Timestamp.IsZero() {
+        t.Errorf("entry = %+v, want bob updating the estimate", entry)
+    }
+    if before := entry.Before["totalHours"]; before != estimate.TotalHours {
+        t.Errorf("before totalHours = %v, want %v", before, estimate.TotalHours)
+    }
+    if after := entry.After["totalHours"]; after != updated.TotalHours {
+        t.Errorf("after totalHours = %v, want %v", after, updated.TotalHours)
+    }
+    if estimate.TotalHours == updated.TotalHours {
+        t.Errorf("TotalHours = %v before and after, want the doubled scale to change it", updated.TotalHours)
+    }
+}
+
+func TestMigrationEffortRecordsAuditEntry(t *testing.T) {
+    f := newEstimateFixture(t)
+    audit := NewAuditUseCase(memory.NewInMemoryAuditRepository())
+    f.uc.SetAuditLog(audit)
+
+    estimate := f.create(t, CreateProjectEstimateInput{Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)}, CreatedBy: "alice"})
+    if _, err := f.uc.MigrationEffort(estimate.ID, domain.MigrationSpec{SourceTables: 20, RecordVolume: 1000000, TransformationComplexity: 3}, "bob"); err != nil {
+        t.Fatal(err)
+    }
+    updated, err := f.uc.GetEstimate(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    entries, err := audit.GetAuditLogs(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    var updates []*domain.AuditLog
+    for _, entry := range entries {
+        if entry.Action == domain.AuditActionUpdated {
+            updates = append(updates, entry)
+        }
+    }
+    if len(updates) != 1 {
+        t.Fatalf("got %d update entries, want exactly 1", len(updates))
+    }
+
+    entry := updates[0]
+    if entry.Actor != "bob" || entry.EntityType != domain.AuditEntityEstimate {
+        t.Errorf("entry = %+v, want bob updating the estimate", entry)
+    }
+    if before := entry.Before["totalHours"]; before != estimate.TotalHours {
+        t.Errorf("before totalHours = %v, want %v", before, estimate.TotalHours)
+    }
+    if after := entry.After["totalHours"]; after != updated.TotalHours || updated.TotalHours <= estimate.TotalHours {
+        t.Errorf("after totalHours = %v, want the %v including the migration line", after, updated.TotalHours)
+    }
+}
+
+func TestAuditRecordingIsOptional(t *testing.T) {
+    var audit *AuditUseCase
+    // A use case without an audit log records nothing instead of failing
+    audit.record("alice", domain.AuditEntityFactor, "factor-1", domain.AuditActionCreated, nil, nil)
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+    "container/list"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "sort"
+    "strings"
+    "sync"
+
+    "estimate-backend/internal/domain"
+)
+
+// cocomoCacheSize is the number of calculated COCOMO II estimates kept by the use case
+const cocomoCacheSize = 256
+
+// cocomoCache is a least recently used cache of calculated COCOMO II estimates keyed by their inputs.
+// The entries hold the model, scale factors and cost drivers as they were loaded, so it must be
+// cleared whenever one of those is saved.
+type cocomoCache struct {
+    mu       sync.Mutex
+    capacity int
+    order    *list.List               // Most recently used first
+    entries  map[string]*list.Element // Key -> element holding a cocomoCacheEntry
+}
+
+type cocomoCacheEntry struct {
+    key      string
+    estimate *domain.COCOMOEstimate
+}
+
+// newCOCOMOCache creates an empty cache holding at most capacity estimates
+func newCOCOMOCache(capacity int) *cocomoCache {
+    return &cocomoCache{
+        capacity: capacity,
+        order:    list.New(),
+        entries:  make(map[string]*list.Element),
+    }
+}
+
+// get returns a copy of the cached estimate for a key
+func (c *cocomoCache) get(key string) (*domain.COCOMOEstimate, bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    element, ok := c.entries[key]
+    if !ok {
+        return nil, false
+    }
+    c.order.MoveToFront(element)
+    return copyCachedEstimate(element.Value.(*cocomoCacheEntry).estimate), true
+}
+
+// put stores a copy of an estimate, evicting the least recently used one when the cache is full
+func (c *cocomoCache) put(key string, estimate *domain.COCOMOEstimate) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    if element, ok := c.entries[key]; ok {
+        element.Value.(*cocomoCacheEntry).estimate = copyCachedEstimate(estimate)
+        c.order.MoveToFront(element)
+        return
+    }
+    c.entries[key] = c.order.PushFront(&cocomoCacheEntry{key: key, estimate: copyCachedEstimate(estimate)})
+    if c.order.Len() > c.capacity {
+        oldest := c.order.Back()
+        c.order.Remove(oldest)
+        delete(c.entries, oldest.Value.(*cocomoCacheEntry).key)
+    }
+}
+
+// clear drops every cached estimate
+func (c *cocomoCache) clear() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+
+    c.order.Init()
+    c.entries = make(map[string]*list.Element)
+}
+
+// cocomoCacheKey hashes everything a calculated estimate depends on: the inputs, with the ratings
+// in ID order, and the team overhead multiplier taken from the configuration
+func cocomoCacheKey(input CreateEstimateInput, teamOverhead float64) string {
+    var b strings.Builder
+    fmt.Fprintf(&b, "%q|%q|%v|%v|%v|%v|%v|", input.ModelID, input.OwnerID, input.ProjectSize,
+        input.REVL, input.TargetDurationTM, input.PlannedTeamSize, teamOverhead)
+    writeSortedRatings(&b, input.ScaleFactors)
+    writeSortedRatings(&b, input.CostDrivers)
+    for _, rc := range input.ReuseComponents {
+        fmt.Fprintf(&b, "%q:%v:%v:%v:%v:%v:%v:%v,", rc.Name, rc.AdaptedSize, rc.DM, rc.CM, rc.IM, rc.AA, rc.SU, rc.UNFM)
+    }
+    sum := sha256.Sum256([]byte(b.String()))
+    return hex.EncodeToString(sum[:])
+}
+
+// sortedRatingIDs returns the factor IDs of ratings in ID order
+func sortedRatingIDs(ratings map[string]float64) []string {
+    ids := make([]string, 0, len(ratings))
+    for id := range ratings {
+        ids = append(ids, id)
+    }
+    sort.Strings(ids)
+    return ids
+}
+
+// writeSortedRatings writes ratings keyed by factor ID in ID order
+func writeSortedRatings(b *strings.Builder, ratings map[string]float64) {
+    for _, id := range sortedRatingIDs(ratings) {
+        fmt.Fprintf(b, "%q=%v,", id, ratings[id])
+    }
+    b.WriteString("|")
+}
+
+// copyCachedEstimate returns a copy sharing nothing mutable with the cached estimate,
+// so saving it (which assigns an ID) leaves the cache untouched
+func copyCachedEstimate(estimate *domain.COCOMOEstimate) *domain.COCOMOEstimate {
+    cp := *estimate
+    if estimate.Model != nil {
+        model := *estimate.Model
+        cp.Model = &model
+    }
+    cp.ScaleFactors = append([]domain.ScaleFactor(nil), estimate.ScaleFactors...)
+    cp.CostDrivers = append([]domain.CostDriver(nil), estimate.CostDrivers...)
+    cp.ReuseComponents = append([]domain.ReuseComponent(nil), estimate.ReuseComponents...)
+    return &cp
+}
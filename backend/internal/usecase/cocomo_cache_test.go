@@ -0,0 +1,101 @@
+package usecase
+
+import (
+    "testing"
+
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/infrastructure/memory"
+)
+
+// countingModelRepository counts the model lookups, one per calculation not served from the cache
+type countingModelRepository struct {
+    domain.COCOMORepository
+    lookups int
+}
+
+func (r *countingModelRepository) FindModelByID(id string) (*domain.COCOMOModel, error) {
+    r.lookups++
+    return r.COCOMORepository.FindModelByID(id)
+}
+
+// newCountingCOCOMOUseCase returns a COCOMO use case over the defaults with its lookups counted from zero
+func newCountingCOCOMOUseCase(t *testing.T) (*COCOMOUseCase, *countingModelRepository) {
+    t.Helper()
+    repo := &countingModelRepository{COCOMORepository: memory.NewInMemoryCOCOMORepository()}
+    uc := NewCOCOMOUseCase(repo, NewConfigUseCase())
+    for _, initialize := range []func() error{uc.InitializeDefaultModel, uc.InitializeScaleFactors, uc.InitializeCostDrivers} {
+        if err := initialize(); err != nil {
+            t.Fatal(err)
+        }
+    }
+    repo.lookups = 0
+    return uc, repo
+}
+
+func TestCalculateServesIdenticalInputsFromCache(t *testing.T) {
+    uc, repo := newCountingCOCOMOUseCase(t)
+
+    first, err := uc.Calculate(nominalInput(50))
+    if err != nil {
+        t.Fatal(err)
+    }
+    second, err := uc.Calculate(nominalInput(50))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if repo.lookups != 1 {
+        t.Errorf("got %d model lookups for two identical calculations, want 1", repo.lookups)
+    }
+    if second.EffortPM != first.EffortPM {
+        t.Errorf("cached effort %v, want %v", second.EffortPM, first.EffortPM)
+    }
+
+    // The cache hands out copies
+    second.Model.A = 99
+    if third, _ := uc.Calculate(nominalInput(50)); third.Model.A == 99 {
+        t.Error("mutating a returned estimate changed the cached one")
+    }
+
+    if _, err := uc.Calculate(nominalInput(60)); err != nil {
+        t.Fatal(err)
+    }
+    if repo.lookups != 2 {
+        t.Errorf("got %d model lookups after another size, want 2", repo.lookups)
+    }
+}
+
+func TestCalculateCacheIsClearedWhenModelSaved(t *testing.T) {
+    uc, repo := newCountingCOCOMOUseCase(t)
+    if _, err := uc.Calculate(nominalInput(50)); err != nil {
+        t.Fatal(err)
+    }
+
+    points := []domain.CalibrationPoint{{Size: 10, ActualEffort: 30}, {Size: 100, ActualEffort: 400}}
+    if _, err := uc.Calibrate(CalibrateInput{Points: points, Save: true, Name: "calibrated"}); err != nil {
+        t.Fatal(err)
+    }
+    repo.lookups = 0
+    if _, err := uc.Calculate(nominalInput(50)); err != nil {
+        t.Fatal(err)
+    }
+    if repo.lookups != 1 {
+        t.Errorf("got %d model lookups after saving a model, want 1", repo.lookups)
+    }
+}
+
+func TestCOCOMOCacheEvictsLeastRecentlyUsed(t *testing.T) {
+    cache := newCOCOMOCache(2)
+    cache.put("a", &domain.COCOMOEstimate{ProjectSize: 1})
+    cache.put("b", &domain.COCOMOEstimate{ProjectSize: 2})
+    cache.get("a")
+    cache.put("c", &domain.COCOMOEstimate{ProjectSize: 3})
+
+    if _, ok := cache.get("b"); ok {
+        t.Error("b is cached, want it evicted as the least recently used")
+    }
+    for _, key := range []string{"a", "c"} {
+        if _, ok := cache.get(key); !ok {
+            t.Errorf("%s was evicted, want it cached", key)
+        }
+    }
+}
@@ -1,26 +1,38 @@
 package usecase
 
 import (
+    "context"
     "errors"
+    "fmt"
+    "runtime"
+    "strings"
+    "sync"
+
     "estimate-backend/internal/domain"
 )
 
 // COCOMOUseCase handles the business logic for COCOMO II estimations
 type COCOMOUseCase struct {
     cocomoRepo domain.COCOMORepository
+    config     *ConfigUseCase
+    cache      *cocomoCache // Calculated estimates; cleared whenever a model, scale factor or cost driver is saved
 }
 
 // NewCOCOMOUseCase creates a new COCOMOUseCase
-func NewCOCOMOUseCase(cocomoRepo domain.COCOMORepository) *COCOMOUseCase {
+func NewCOCOMOUseCase(cocomoRepo domain.COCOMORepository, config *ConfigUseCase) *COCOMOUseCase {
     return &COCOMOUseCase{
         cocomoRepo: cocomoRepo,
+        config:     config,
+        cache:      newCOCOMOCache(cocomoCacheSize),
     }
 }
 
-// InitializeDefaultModel initializes the default COCOMO II model
+// InitializeDefaultModel initializes the built-in COCOMO II models.
+// Models that already exist are left untouched, so it is safe to call repeatedly.
 func (uc *COCOMOUseCase) InitializeDefaultModel() error {
     // Initialize Early Design model
     earlyDesign := &domain.COCOMOModel{
+        ID:          domain.ModelEarlyDesignID,
         Name:        "Early Design",
         Description: "COCOMO II Early Design model for early project estimation",
         A:           2.94,  // Calibrated value for Early Design
@@ -29,139 +41,108 @@ func (uc *COCOMOUseCase) InitializeDefaultModel() error {
 
     // Initialize Post-Architecture model
     postArchitecture := &domain.COCOMOModel{
+        ID:          domain.ModelPostArchitectureID,
         Name:        "Post-Architecture",
         Description: "COCOMO II Post-Architecture model for detailed estimation",
         A:           2.45,  // Calibrated value for Post-Architecture
         B:           0.91,  // Initial exponent
     }
 
-    if err := uc.cocomoRepo.SaveModel(earlyDesign); err != nil {
-        return err
-    }
-    if err := uc.cocomoRepo.SaveModel(postArchitecture); err != nil {
-        return err
+    for _, model := range []*domain.COCOMOModel{earlyDesign, postArchitecture} {
+        _, err := uc.cocomoRepo.FindModelByID(model.ID)
+        if err == nil {
+            continue
+        }
+        if !errors.Is(err, domain.ErrNotFound) {
+            return err
+        }
+        if err := uc.cocomoRepo.SaveModel(model); err != nil {
+            return err
+        }
+        uc.cache.clear()
     }
 
     return nil
 }
 
-// InitializeScaleFactors initializes the default scale factors
+// GetScaleFactors retrieves all scale factors, seeding the defaults when none are stored
+func (uc *COCOMOUseCase) GetScaleFactors() ([]*domain.ScaleFactor, error) {
+    factors, err := uc.cocomoRepo.FindAllScaleFactors()
+    if err != nil || len(factors) > 0 {
+        return factors, err
+    }
+    if err := uc.InitializeScaleFactors(); err != nil {
+        return nil, err
+    }
+    return uc.cocomoRepo.FindAllScaleFactors()
+}
+
+// GetCostDrivers retrieves all cost drivers, seeding the defaults when none are stored
+func (uc *COCOMOUseCase) GetCostDrivers() ([]*domain.CostDriver, error) {
+    drivers, err := uc.cocomoRepo.FindAllCostDrivers()
+    if err != nil || len(drivers) > 0 {
+        return drivers, err
+    }
+    if err := uc.InitializeCostDrivers(); err != nil {
+        return nil, err
+    }
+    return uc.cocomoRepo.FindAllCostDrivers()
+}
+
+// GetModels retrieves the models visible to an organization: its own and the built-in ones
+func (uc *COCOMOUseCase) GetModels(ownerID string) ([]*domain.COCOMOModel, error) {
+    return uc.cocomoRepo.FindModelsByOwner(ownerID)
+}
+
+// findModel retrieves a model, reporting models of other organizations as not found
+func findModel(repo domain.COCOMORepository, id, ownerID string) (*domain.COCOMOModel, error) {
+    model, err := repo.FindModelByID(id)
+    if err != nil {
+        return nil, err
+    }
+    if !model.VisibleTo(ownerID) {
+        return nil, fmt.Errorf("COCOMO model %s: %w", id, domain.ErrNotFound)
+    }
+    return model, nil
+}
+
+// InitializeScaleFactors initializes the default scale factors, identified by their type.
+// Scale factors that already exist are left untouched, so it is safe to call repeatedly.
 func (uc *COCOMOUseCase) InitializeScaleFactors() error {
-    scaleFactors := []domain.ScaleFactor{
-        {
-            Type:        domain.ScaleFactorPREC,
-            Name:        "先例性",
-            Description: "類似プロジェクトの経験度",
-            Weight:      4.05,
-        },
-        {
-            Type:        domain.ScaleFactorFLEX,
-            Name:        "開発の柔軟性",
-            Description: "開発プロセスの柔軟性",
-            Weight:      3.04,
-        },
-        {
-            Type:        domain.ScaleFactorRESL,
-            Name:        "アーキテクチャ/リスク対応",
-            Description: "リスク管理とアーキテクチャ対応の程度",
-            Weight:      4.24,
-        },
-        {
-            Type:        domain.ScaleFactorTEAM,
-            Name:        "チーム凝集性",
-            Description: "チームの協力度と一貫性",
-            Weight:      3.29,
-        },
-        {
-            Type:        domain.ScaleFactorPMAT,
-            Name:        "プロセス成熟度",
-            Description: "組織のプロセス成熟度",
-            Weight:      4.68,
-        },
-    }
-
-    for _, sf := range scaleFactors {
+    for _, t := range domain.ScaleFactorTypes {
+        sf := domain.NewScaleFactor(t)
+        _, err := uc.cocomoRepo.FindScaleFactorByID(sf.ID)
+        if err == nil {
+            continue
+        }
+        if !errors.Is(err, domain.ErrNotFound) {
+            return err
+        }
         if err := uc.cocomoRepo.SaveScaleFactor(&sf); err != nil {
             return err
         }
+        uc.cache.clear()
     }
 
     return nil
 }
 
-// InitializeCostDrivers initializes the default cost drivers
+// InitializeCostDrivers initializes the default cost drivers, identified by their type.
+// Cost drivers that already exist are left untouched, so it is safe to call repeatedly.
 func (uc *COCOMOUseCase) InitializeCostDrivers() error {
-    costDrivers := []domain.CostDriver{
-        // Product Factors
-        {
-            Type:        domain.CostDriverRELY,
-            Name:        "要求される信頼性",
-            Description: "システム障害による影響の大きさ",
-            Value:       1.0, // Nominal value
-        },
-        {
-            Type:        domain.CostDriverDATA,
-            Name:        "データベース規模",
-            Description: "テストデータベースサイズ/プログラムサイズの比",
-            Value:       1.0,
-        },
-        {
-            Type:        domain.CostDriverCPLX,
-            Name:        "製品の複雑さ",
-            Description: "制御操作、演算処理、デバイス処理、データ管理、UI管理の複雑さ",
-            Value:       1.0,
-        },
-        // Platform Factors
-        {
-            Type:        domain.CostDriverTIME,
-            Name:        "実行時間制約",
-            Description: "使用可能な実行時間の制約",
-            Value:       1.0,
-        },
-        {
-            Type:        domain.CostDriverSTOR,
-            Name:        "主記憶制約",
-            Description: "主記憶の制約",
-            Value:       1.0,
-        },
-        // Personnel Factors
-        {
-            Type:        domain.CostDriverACAP,
-            Name:        "アナリスト能力",
-            Description: "分析担当者の能力と経験",
-            Value:       1.0,
-        },
-        {
-            Type:        domain.CostDriverPCAP,
-            Name:        "プログラマ能力",
-            Description: "プログラマの能力と経験",
-            Value:       1.0,
-        },
-        {
-            Type:        domain.CostDriverPCON,
-            Name:        "要員の継続性",
-            Description: "プロジェクト期間中の要員の交代率",
-            Value:       1.0,
-        },
-        // Project Factors
-        {
-            Type:        domain.CostDriverTOOL,
-            Name:        "ツール使用",
-            Description: "使用するツールの成熟度と機能",
-            Value:       1.0,
-        },
-        {
-            Type:        domain.CostDriverSITE,
-            Name:        "開発拠点の分散",
-            Description: "開発チームの地理的分散と通信手段",
-            Value:       1.0,
-        },
-    }
-
-    for _, cd := range costDrivers {
+    for _, t := range domain.CostDriverTypes {
+        cd := domain.NewCostDriver(t)
+        _, err := uc.cocomoRepo.FindCostDriverByID(cd.ID)
+        if err == nil {
+            continue
+        }
+        if !errors.Is(err, domain.ErrNotFound) {
+            return err
+        }
         if err := uc.cocomoRepo.SaveCostDriver(&cd); err != nil {
             return err
         }
+        uc.cache.clear()
     }
 
     return nil
@@ -170,61 +151,361 @@ func (uc *COCOMOUseCase) InitializeCostDrivers() error {
 // CreateEstimateInput represents input for creating a COCOMO II estimate
 type CreateEstimateInput struct {
     ModelID       string
+    OwnerID       string               // Organization of the caller, selects which models are visible
     ProjectSize   float64              // KSLOC or Function Points
     ScaleFactors map[string]float64    // Factor ID -> Rating
     CostDrivers  map[string]float64    // Driver ID -> Rating
+    ReuseComponents []domain.ReuseComponent // Optional adapted or reused code
+    REVL          float64               // Optional requirements volatility in percent
+    TargetDurationTM float64            // Optional requested schedule, checked against the schedule floor
+    PlannedTeamSize  float64            // Optional staffing plan; large teams add communication overhead
 }
 
-// CreateEstimate creates a new COCOMO II estimate
+// CreateEstimate calculates a new COCOMO II estimate and stores it
 func (uc *COCOMOUseCase) CreateEstimate(input CreateEstimateInput) (*domain.COCOMOEstimate, error) {
-    // Validate input
-    if input.ProjectSize <= 0 {
-        return nil, errors.New("project size must be greater than 0")
+    estimate, err := uc.Calculate(input)
+    if err != nil {
+        return nil, err
+    }
+
+    // Save estimate
+    if err := uc.cocomoRepo.SaveEstimate(estimate); err != nil {
+        return nil, err
+    }
+
+    return estimate, nil
+}
+
+// Calculate calculates a COCOMO II estimate without storing it.
+// The model, scale factors and cost drivers are only read from the repository, and not at all when
+// the same inputs were calculated before: the result is then served from the cache.
+func (uc *COCOMOUseCase) Calculate(input CreateEstimateInput) (*domain.COCOMOEstimate, error) {
+    if err := validateEstimateInput(input); err != nil {
+        return nil, err
+    }
+
+    teamOverhead := uc.config.GetConfig().TeamOverheadSettings().Multiplier(input.PlannedTeamSize)
+    key := cocomoCacheKey(input, teamOverhead)
+    if estimate, ok := uc.cache.get(key); ok {
+        return estimate, nil
     }
 
     // Get model
-    model, err := uc.cocomoRepo.FindModelByID(input.ModelID)
+    model, err := findModel(uc.cocomoRepo, input.ModelID, input.OwnerID)
+    if err != nil {
+        return nil, err
+    }
+
+    scaleFactors, costDrivers, err := resolveRatings(uc.cocomoRepo, input.ScaleFactors, input.CostDrivers)
+    if err != nil {
+        return nil, err
+    }
+
+    // Create estimate
+    estimate := &domain.COCOMOEstimate{
+        ProjectSize:  input.ProjectSize,
+        Model:        model,
+        ScaleFactors: scaleFactors,
+        CostDrivers:  costDrivers,
+        ReuseComponents: input.ReuseComponents,
+        REVL:         input.REVL,
+        TargetDurationTM: input.TargetDurationTM,
+        PlannedTeamSize:  input.PlannedTeamSize,
+        TeamOverhead:     teamOverhead,
+    }
+
+    // Calculate effort and other metrics
+    estimate.CalculateEffort()
+    uc.cache.put(key, estimate)
+
+    return estimate, nil
+}
+
+// Compare calculates two COCOMO II estimates without storing them and compares them
+func (uc *COCOMOUseCase) Compare(input1, input2 CreateEstimateInput) (*domain.COCOMOComparison, error) {
+    estimate1, err := uc.Calculate(input1)
+    if err != nil {
+        return nil, err
+    }
+    estimate2, err := uc.Calculate(input2)
     if err != nil {
         return nil, err
     }
+    return domain.CompareCOCOMO(estimate1, estimate2), nil
+}
+
+// ReverseInput represents input for solving the achievable size from a deadline and a team size
+type ReverseInput struct {
+    ModelID          string
+    OwnerID          string             // Organization of the caller, selects which models are visible
+    TargetDurationTM float64            // Deadline in months
+    TeamSize         float64            // Average staff
+    ScaleFactors     map[string]float64 // Factor ID -> Rating
+    CostDrivers      map[string]float64 // Driver ID -> Rating
+    ReuseComponents  []domain.ReuseComponent
+    REVL             float64
+}
+
+// Reverse solves for the largest project size deliverable by the deadline with the given team
+func (uc *COCOMOUseCase) Reverse(input ReverseInput) (*domain.ReverseEstimate, error) {
+    if input.TargetDurationTM <= 0 || input.TeamSize <= 0 {
+        return nil, newValidationError("target duration and team size must be greater than 0")
+    }
+    if err := validateRatings(input.ScaleFactors, input.CostDrivers); err != nil {
+        return nil, err
+    }
+    if err := validateReuseComponents(input.ReuseComponents); err != nil {
+        return nil, err
+    }
+    if input.REVL < 0 {
+        return nil, newValidationError("REVL must not be negative")
+    }
+
+    model, err := findModel(uc.cocomoRepo, input.ModelID, input.OwnerID)
+    if err != nil {
+        return nil, err
+    }
+
+    scaleFactors, costDrivers, err := resolveRatings(uc.cocomoRepo, input.ScaleFactors, input.CostDrivers)
+    if err != nil {
+        return nil, err
+    }
+
+    reverse, err := domain.SolveSize(domain.COCOMOEstimate{
+        Model:           model,
+        ScaleFactors:    scaleFactors,
+        CostDrivers:     costDrivers,
+        ReuseComponents: input.ReuseComponents,
+        REVL:            input.REVL,
+    }, input.TargetDurationTM, input.TeamSize)
+    if err != nil {
+        return nil, newValidationError(err.Error())
+    }
+    return reverse, nil
+}
 
-    // Process scale factors
+// resolveRatings loads the rated scale factors and cost drivers in ID order, so the same ratings
+// always sum to the same exponent and multiply to the same effort multiplier
+func resolveRatings(repo domain.COCOMORepository, scaleRatings, driverRatings map[string]float64) ([]domain.ScaleFactor, []domain.CostDriver, error) {
     var scaleFactors []domain.ScaleFactor
-    for id, rating := range input.ScaleFactors {
-        sf, err := uc.cocomoRepo.FindScaleFactorByID(id)
+    for _, id := range sortedRatingIDs(scaleRatings) {
+        sf, err := repo.FindScaleFactorByID(id)
         if err != nil {
-            return nil, err
+            return nil, nil, err
         }
-        sf.Rating = rating
+        sf.Rating = scaleRatings[id]
         scaleFactors = append(scaleFactors, *sf)
     }
 
-    // Process cost drivers
     var costDrivers []domain.CostDriver
-    for id, rating := range input.CostDrivers {
-        cd, err := uc.cocomoRepo.FindCostDriverByID(id)
+    for _, id := range sortedRatingIDs(driverRatings) {
+        cd, err := repo.FindCostDriverByID(id)
         if err != nil {
-            return nil, err
+            return nil, nil, err
         }
-        cd.Rating = rating
+        cd.SetRating(driverRatings[id])
         costDrivers = append(costDrivers, *cd)
     }
 
-    // Create estimate
-    estimate := &domain.COCOMOEstimate{
-        ProjectSize:  input.ProjectSize,
-        Model:        model,
-        ScaleFactors: scaleFactors,
-        CostDrivers:  costDrivers,
+    return scaleFactors, costDrivers, nil
+}
+
+// MaintenanceInput represents input for a COCOMO II maintenance estimate
+type MaintenanceInput struct {
+    ModelID             string
+    OwnerID             string             // Organization of the caller, selects which models are visible
+    BaseSize            float64            // Size of the existing system
+    AnnualChangeTraffic float64            // (added + modified) / total size per year
+    ScaleFactors        map[string]float64 // Factor ID -> Rating
+    CostDrivers         map[string]float64 // Driver ID -> Rating
+}
+
+// EstimateMaintenance calculates the annual maintenance effort of an existing system
+func (uc *COCOMOUseCase) EstimateMaintenance(input MaintenanceInput) (*domain.MaintenanceEstimate, error) {
+    // Validate input
+    if input.BaseSize <= 0 {
+        return nil, newValidationError("base size must be greater than 0")
+    }
+    if input.AnnualChangeTraffic < 0 || input.AnnualChangeTraffic > 1 {
+        return nil, newValidationError("annual change traffic must be between 0 and 1")
+    }
+    if err := validateRatings(input.ScaleFactors, input.CostDrivers); err != nil {
+        return nil, err
     }
 
-    // Calculate effort and other metrics
+    model, err := findModel(uc.cocomoRepo, input.ModelID, input.OwnerID)
+    if err != nil {
+        return nil, err
+    }
+
+    scaleFactors, costDrivers, err := resolveRatings(uc.cocomoRepo, input.ScaleFactors, input.CostDrivers)
+    if err != nil {
+        return nil, err
+    }
+
+    estimate := &domain.MaintenanceEstimate{
+        BaseSize:            input.BaseSize,
+        AnnualChangeTraffic: input.AnnualChangeTraffic,
+        Model:               model,
+        ScaleFactors:        scaleFactors,
+        CostDrivers:         costDrivers,
+    }
     estimate.CalculateEffort()
 
-    // Save estimate
-    if err := uc.cocomoRepo.SaveEstimate(estimate); err != nil {
+    return estimate, nil
+}
+
+// CalibrateInput represents input data for calibrating a COCOMO II model
+type CalibrateInput struct {
+    Points      []domain.CalibrationPoint
+    ScaleFactors map[string]float64 // Factor ID -> rating the completed projects shared
+    Save        bool   // Save the fitted coefficients as a new model
+    OwnerID     string // Organization the saved model belongs to
+    Name        string
+    Description string
+}
+
+// CalibrationResult represents the fitted coefficients and, when saved, the new model
+type CalibrationResult struct {
+    Calibration *domain.Calibration `json:"calibration"`
+    Model       *domain.COCOMOModel `json:"model,omitempty"`
+}
+
+// Calibrate fits the A and B coefficients to the actual effort of completed projects
+func (uc *COCOMOUseCase) Calibrate(input CalibrateInput) (*CalibrationResult, error) {
+    if input.Save && input.Name == "" {
+        return nil, newValidationError("a name is required to save the calibrated model")
+    }
+
+    if err := validateRatings(input.ScaleFactors, nil); err != nil {
         return nil, err
     }
+    scaleFactors, _, err := resolveRatings(uc.cocomoRepo, input.ScaleFactors, nil)
+    if err != nil {
+        return nil, err
+    }
+
+    calibration, err := domain.Calibrate(input.Points, scaleFactors)
+    if err != nil {
+        return nil, newValidationError(err.Error())
+    }
+    result := &CalibrationResult{Calibration: calibration}
+
+    if input.Save {
+        model := &domain.COCOMOModel{
+            OwnerID:     input.OwnerID,
+            Name:        input.Name,
+            Description: input.Description,
+            A:           calibration.A,
+            B:           calibration.BaseB,
+        }
+        if err := uc.cocomoRepo.SaveModel(model); err != nil {
+            return nil, err
+        }
+        uc.cache.clear()
+        result.Model = model
+    }
+
+    return result, nil
+}
+
+// COCOMO81Input represents input for an estimate using the original COCOMO (1981) model
+type COCOMO81Input struct {
+    Level            domain.COCOMO81Level
+    Mode             domain.COCOMO81Mode
+    KDSI             float64
+    CostDrivers      map[string]string  // Driver -> rating, e.g. "RELY": "high"
+    PhaseMultipliers map[string]float64 // Phase -> EAF, detailed COCOMO only
+}
+
+// EstimateCOCOMO81 calculates an estimate using the original COCOMO (1981) model
+func (uc *COCOMOUseCase) EstimateCOCOMO81(input COCOMO81Input) (*domain.COCOMO81Estimate, error) {
+    if input.KDSI <= 0 {
+        return nil, newValidationError("project size must be greater than 0")
+    }
+    model, ok := domain.COCOMO81ModelFor(input.Level, input.Mode)
+    if !ok {
+        return nil, newValidationError(fmt.Sprintf("unknown COCOMO 81 level %q or mode %q", input.Level, input.Mode))
+    }
+    for driver, rating := range input.CostDrivers {
+        if _, ok := domain.COCOMO81Multiplier(driver, rating); !ok {
+            return nil, newValidationError(fmt.Sprintf("invalid rating %q for cost driver %s", rating, driver))
+        }
+    }
+    if len(input.PhaseMultipliers) > 0 && input.Level != domain.COCOMO81Detailed {
+        return nil, newValidationError("phase multipliers require detailed COCOMO")
+    }
+    for phase, multiplier := range input.PhaseMultipliers {
+        if _, ok := domain.COCOMO81PhaseShares(input.Mode, input.KDSI)[phase]; !ok {
+            return nil, newValidationError(fmt.Sprintf("unknown phase %q", phase))
+        }
+        if multiplier <= 0 {
+            return nil, newValidationError(fmt.Sprintf("multiplier of phase %s must be greater than 0", phase))
+        }
+    }
+
+    estimate := &domain.COCOMO81Estimate{
+        KDSI:             input.KDSI,
+        Model:            model,
+        CostDrivers:      input.CostDrivers,
+        PhaseMultipliers: input.PhaseMultipliers,
+    }
+    estimate.Calculate()
+
+    return estimate, nil
+}
+
+// IncrementalInput represents input for a COCOMO II incremental development estimate
+type IncrementalInput struct {
+    ModelID      string
+    OwnerID      string                  // Organization of the caller, selects which models are visible
+    Increments   []float64               // Size of each increment
+    Adaptation   *domain.ReuseComponent  // Optional rework of carried code, defaults to domain.DefaultIncrementAdaptation
+    ScaleFactors map[string]float64      // Factor ID -> Rating
+    CostDrivers  map[string]float64      // Driver ID -> Rating
+}
+
+// EstimateIncremental calculates the effort and schedule of each increment and their totals
+func (uc *COCOMOUseCase) EstimateIncremental(input IncrementalInput) (*domain.IncrementalEstimate, error) {
+    // Validate input
+    if len(input.Increments) == 0 {
+        return nil, newValidationError("at least one increment is required")
+    }
+    for i, size := range input.Increments {
+        if size <= 0 {
+            return nil, newValidationError(fmt.Sprintf("size of increment %d must be greater than 0", i+1))
+        }
+    }
+    if err := validateRatings(input.ScaleFactors, input.CostDrivers); err != nil {
+        return nil, err
+    }
+
+    adaptation := domain.DefaultIncrementAdaptation
+    if input.Adaptation != nil {
+        adaptation = *input.Adaptation
+    }
+    if err := adaptation.Validate(); err != nil {
+        return nil, newValidationError(err.Error())
+    }
+
+    model, err := findModel(uc.cocomoRepo, input.ModelID, input.OwnerID)
+    if err != nil {
+        return nil, err
+    }
+
+    scaleFactors, costDrivers, err := resolveRatings(uc.cocomoRepo, input.ScaleFactors, input.CostDrivers)
+    if err != nil {
+        return nil, err
+    }
+
+    estimate := &domain.IncrementalEstimate{
+        Increments:   input.Increments,
+        Adaptation:   adaptation,
+        Model:        model,
+        ScaleFactors: scaleFactors,
+        CostDrivers:  costDrivers,
+    }
+    estimate.Calculate()
 
     return estimate, nil
 }
@@ -234,6 +515,16 @@ func (uc *COCOMOUseCase) GetEstimate(id string) (*domain.COCOMOEstimate, error)
     return uc.cocomoRepo.FindEstimateByID(id)
 }
 
+// GetEstimates retrieves all stored COCOMO II estimates
+func (uc *COCOMOUseCase) GetEstimates() ([]*domain.COCOMOEstimate, error) {
+    return uc.cocomoRepo.FindAllEstimates()
+}
+
+// DeleteEstimate deletes a stored COCOMO II estimate
+func (uc *COCOMOUseCase) DeleteEstimate(id string) error {
+    return uc.cocomoRepo.DeleteEstimate(id)
+}
+
 // UpdateRatingsInput represents input for updating scale factor and cost driver ratings
 type UpdateRatingsInput struct {
     EstimateID    string
@@ -243,6 +534,11 @@ type UpdateRatingsInput struct {
 
 // UpdateRatings updates the ratings of scale factors and cost drivers
 func (uc *COCOMOUseCase) UpdateRatings(input UpdateRatingsInput) (*domain.COCOMOEstimate, error) {
+    // Validate input
+    if err := validateRatings(input.ScaleFactors, input.CostDrivers); err != nil {
+        return nil, err
+    }
+
     estimate, err := uc.cocomoRepo.FindEstimateByID(input.EstimateID)
     if err != nil {
         return nil, err
@@ -262,7 +558,7 @@ func (uc *COCOMOUseCase) UpdateRatings(input UpdateRatingsInput) (*domain.COCOMO
     for id, rating := range input.CostDrivers {
         for i, cd := range estimate.CostDrivers {
             if cd.ID == id {
-                estimate.CostDrivers[i].Rating = rating
+                estimate.CostDrivers[i].SetRating(rating)
                 break
             }
         }
@@ -277,4 +573,239 @@ func (uc *COCOMOUseCase) UpdateRatings(input UpdateRatingsInput) (*domain.COCOMO
     }
 
     return estimate, nil
+}
+
+// validateEstimateInput checks the size, ratings, reuse and schedule parameters of an estimate
+func validateEstimateInput(input CreateEstimateInput) error {
+    if input.ProjectSize <= 0 {
+        return newValidationError("project size must be greater than 0")
+    }
+    if err := validateRatings(input.ScaleFactors, input.CostDrivers); err != nil {
+        return err
+    }
+    if err := validateReuseComponents(input.ReuseComponents); err != nil {
+        return err
+    }
+    if input.REVL < 0 {
+        return newValidationError("REVL must not be negative")
+    }
+    if input.TargetDurationTM < 0 {
+        return newValidationError("target duration must not be negative")
+    }
+    if input.PlannedTeamSize < 0 {
+        return newValidationError("planned team size must not be negative")
+    }
+    return validateEffectiveSize(input.ProjectSize, input.ReuseComponents, input.REVL)
+}
+
+// validateReuseComponents checks the size and adaptation parameters of each reuse component
+func validateReuseComponents(components []domain.ReuseComponent) error {
+    for _, rc := range components {
+        if err := rc.Validate(); err != nil {
+            return newValidationError(err.Error())
+        }
+    }
+    return nil
+}
+
+// validateEffectiveSize checks that the new size plus the reused code amounts to a positive size,
+// which the effort equation needs
+func validateEffectiveSize(size float64, components []domain.ReuseComponent, revl float64) error {
+    sized := domain.COCOMOEstimate{ProjectSize: size, ReuseComponents: components, REVL: revl}
+    if !(sized.EffectiveSize() > 0) {
+        return newValidationError("effective size including the reused code must be greater than 0")
+    }
+    return nil
+}
+
+// validateRatings checks that every scale factor and cost driver rating is within range
+func validateRatings(scaleFactors, costDrivers map[string]float64) error {
+    for id, rating := range scaleFactors {
+        if !domain.IsValidRating(rating) {
+            return newValidationError(fmt.Sprintf("rating %g of scale factor %s must be between %g and %g", rating, id, domain.MinRating, domain.MaxRating))
+        }
+    }
+    for id, rating := range costDrivers {
+        if !domain.IsValidRating(rating) {
+            return newValidationError(fmt.Sprintf("rating %g of cost driver %s must be between %g and %g", rating, id, domain.MinRating, domain.MaxRating))
+        }
+    }
+    return nil
+}
+
+// SensitivityCurve calculates the effort and duration of an estimate across all rating levels of one factor
+func (uc *COCOMOUseCase) SensitivityCurve(estimateID, factorID string) (*domain.SensitivityCurve, error) {
+    if factorID == "" {
+        return nil, newValidationError("factor ID is required")
+    }
+
+    estimate, err := uc.cocomoRepo.FindEstimateByID(estimateID)
+    if err != nil {
+        return nil, err
+    }
+
+    return estimate.SensitivityCurve(factorID)
+}
+
+// Trace recalculates a stored estimate, returning each step of the calculation for auditing
+func (uc *COCOMOUseCase) Trace(estimateID string) (*domain.CalculationTrace, error) {
+    estimate, err := uc.cocomoRepo.FindEstimateByID(estimateID)
+    if err != nil {
+        return nil, err
+    }
+
+    return estimate.Trace(), nil
+}
+
+// TradeoffCurve calculates the effort and staffing of an estimate's size across the schedules
+// from the strongest compression COCOMO II allows to the longest SCED describes
+func (uc *COCOMOUseCase) TradeoffCurve(estimateID string) (*domain.TradeoffCurve, error) {
+    estimate, err := uc.cocomoRepo.FindEstimateByID(estimateID)
+    if err != nil {
+        return nil, err
+    }
+
+    return estimate.TradeoffCurve(), nil
+}
+
+// RunMonteCarlo simulates a stored estimate, reporting the completed iterations to progress.
+// It stops with the error of ctx when ctx is done, such as when the client goes away.
+func (uc *COCOMOUseCase) RunMonteCarlo(ctx context.Context, estimateID string, iterations int, seed int64, progress func(completed int)) (*domain.MonteCarloResult, error) {
+    if iterations < 1 || iterations > domain.MaxMonteCarloIterations {
+        return nil, newValidationError(fmt.Sprintf("iterations must be between 1 and %d", domain.MaxMonteCarloIterations))
+    }
+    estimate, err := uc.cocomoRepo.FindEstimateByID(estimateID)
+    if err != nil {
+        return nil, err
+    }
+
+    return estimate.RunMonteCarloContext(ctx, iterations, seed, progress)
+}
+
+// DetailedResult generates the detailed result of an estimate using the current configuration,
+// distributing it across the phases of the profile and costing each phase at its rate from the rate card.
+// A profile without phases is the default one; phases missing from the role mix use the default split.
+func (uc *COCOMOUseCase) DetailedResult(estimate *domain.COCOMOEstimate, rates domain.RateCard, roles domain.RoleMix, phases domain.PhaseProfile) (*domain.COCOMODetailedResult, error) {
+    if unknown := rates.UnknownPhases(); len(unknown) > 0 {
+        return nil, newValidationError(fmt.Sprintf("unknown phases in rate card: %s", strings.Join(unknown, ", ")))
+    }
+    if rates.DefaultRate < 0 {
+        return nil, newValidationError("hourly rate must not be negative")
+    }
+    if rates.OverheadRate < 0 || rates.TaxRate < 0 {
+        return nil, newValidationError("overhead and tax rates must not be negative")
+    }
+    for phase, rate := range rates.PhaseRates {
+        if rate < 0 {
+            return nil, newValidationError(fmt.Sprintf("hourly rate of %s must not be negative", phase))
+        }
+    }
+    if err := roles.Validate(); err != nil {
+        return nil, newValidationError(err.Error())
+    }
+    if len(phases.Phases) > 0 {
+        if err := phases.Validate(); err != nil {
+            return nil, newValidationError(err.Error())
+        }
+    }
+    return estimate.GenerateDetailedResult(rates, roles.WithDefaults(), phases, uc.config.GetConfig()), nil
+}
+
+// MaxBatchSize is the upper bound of estimates accepted by a single batch calculation
+const MaxBatchSize = 100
+
+// DetailedInput represents input for a detailed COCOMO II result, as calculated for each item of a batch
+type DetailedInput struct {
+    Estimate             CreateEstimateInput
+    Rates                domain.RateCard
+    Roles                domain.RoleMix
+    Phases               domain.PhaseProfile
+    MonteCarloIterations int // Replaces the fixed ranges with simulated percentiles when greater than 0
+    MonteCarloSeed       int64
+}
+
+// BatchResult represents the detailed results of a batch, in the order of its inputs, and their roll-up
+type BatchResult struct {
+    Results []*domain.COCOMODetailedResult
+    Summary *domain.PortfolioSummary
+}
+
+// CalculateBatch calculates detailed results for several estimates concurrently on a bounded
+// pool of workers, without storing them. Results are in the order of the inputs. It is all or nothing:
+// when inputs fail, it returns the error of the first of them, naming its index, and no results.
+func (uc *COCOMOUseCase) CalculateBatch(inputs []DetailedInput) (*BatchResult, error) {
+    if len(inputs) == 0 {
+        return nil, newValidationError("at least one estimate is required")
+    }
+    if len(inputs) > MaxBatchSize {
+        return nil, newValidationError(fmt.Sprintf("at most %d estimates can be calculated at once", MaxBatchSize))
+    }
+
+    results := make([]*domain.COCOMODetailedResult, len(inputs))
+    errs := make([]error, len(inputs))
+    next := make(chan int)
+    var wg sync.WaitGroup
+    for w := 0; w < min(runtime.NumCPU(), len(inputs)); w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range next {
+                results[i], errs[i] = uc.detailedResult(inputs[i])
+            }
+        }()
+    }
+    for i := range inputs {
+        next <- i
+    }
+    close(next)
+    wg.Wait()
+
+    for i, err := range errs {
+        if err != nil {
+            return nil, fmt.Errorf("estimate %d: %w", i, err)
+        }
+    }
+    return &BatchResult{
+        Results: results,
+        Summary: domain.NewPortfolioSummary(results, uc.config.GetConfig().RiskThresholds()),
+    }, nil
+}
+
+// detailedResult calculates one item of a batch
+func (uc *COCOMOUseCase) detailedResult(input DetailedInput) (*domain.COCOMODetailedResult, error) {
+    if input.MonteCarloIterations < 0 || input.MonteCarloIterations > domain.MaxMonteCarloIterations {
+        return nil, newValidationError("monte carlo iterations are out of range")
+    }
+    estimate, err := uc.Calculate(input.Estimate)
+    if err != nil {
+        return nil, err
+    }
+    result, err := uc.DetailedResult(estimate, input.Rates, input.Roles, input.Phases)
+    if err != nil {
+        return nil, err
+    }
+    if input.MonteCarloIterations > 0 {
+        result.ApplyMonteCarlo(estimate.RunMonteCarlo(input.MonteCarloIterations, input.MonteCarloSeed))
+    }
+    return result, nil
+}
+
+// ScenarioPresets calculates an estimate under the optimistic, nominal and pessimistic presets.
+// Each scenario is costed when hourlyRate is greater than 0.
+func (uc *COCOMOUseCase) ScenarioPresets(id string, hourlyRate float64) (*domain.PresetComparison, error) {
+    estimate, err := uc.cocomoRepo.FindEstimateByID(id)
+    if err != nil {
+        return nil, err
+    }
+
+    comparison := &domain.PresetComparison{EstimateID: estimate.ID}
+    for _, preset := range domain.DefaultScenarioPresets {
+        comparison.Scenarios = append(comparison.Scenarios, estimate.CalculateScenario(preset))
+    }
+
+    if hourlyRate > 0 {
+        comparison.ApplyHourlyRate(hourlyRate, uc.config.GetConfig())
+    }
+
+    return comparison, nil
 }
\ No newline at end of file
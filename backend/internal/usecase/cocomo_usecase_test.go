@@ -0,0 +1,525 @@
+package usecase
+
+import (
+    "errors"
+    "math"
+    "strings"
+    "testing"
+
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/infrastructure/memory"
+)
+
+// newTestCOCOMOUseCase returns a COCOMOUseCase with the built-in models, scale factors and cost drivers
+func newTestCOCOMOUseCase(t *testing.T) *COCOMOUseCase {
+    t.Helper()
+    uc := NewCOCOMOUseCase(memory.NewInMemoryCOCOMORepository(), NewConfigUseCase())
+    for _, initialize := range []func() error{uc.InitializeDefaultModel, uc.InitializeScaleFactors, uc.InitializeCostDrivers} {
+        if err := initialize(); err != nil {
+            t.Fatal(err)
+        }
+    }
+    return uc
+}
+
+// nominalRatings rates every scale factor and cost driver Nominal
+func nominalRatings() (scaleFactors, costDrivers map[string]float64) {
+    scaleFactors = make(map[string]float64)
+    for _, t := range domain.ScaleFactorTypes {
+        scaleFactors[string(t)] = 2
+    }
+    costDrivers = make(map[string]float64)
+    for _, t := range domain.CostDriverTypes {
+        costDrivers[string(t)] = 2
+    }
+    return scaleFactors, costDrivers
+}
+
+// nominalInput returns a Post-Architecture input of the given size rated Nominal throughout
+func nominalInput(ksloc float64) CreateEstimateInput {
+    scaleFactors, costDrivers := nominalRatings()
+    return CreateEstimateInput{
+        ModelID:      domain.ModelPostArchitectureID,
+        ProjectSize:  ksloc,
+        ScaleFactors: scaleFactors,
+        CostDrivers:  costDrivers,
+    }
+}
+
+func TestScenarioPresets(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    estimate, err := uc.CreateEstimate(nominalInput(50))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    comparison, err := uc.ScenarioPresets(estimate.ID, 5000)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(comparison.Scenarios) != 3 {
+        t.Fatalf("got %d scenarios, want 3", len(comparison.Scenarios))
+    }
+
+    optimistic, nominal, pessimistic := comparison.Scenarios[0], comparison.Scenarios[1], comparison.Scenarios[2]
+    if optimistic.Preset != "optimistic" || nominal.Preset != "nominal" || pessimistic.Preset != "pessimistic" {
+        t.Errorf("presets = %s, %s, %s, want optimistic, nominal, pessimistic", optimistic.Preset, nominal.Preset, pessimistic.Preset)
+    }
+    if !approxEqual(nominal.EffortPM, estimate.EffortPM) {
+        t.Errorf("nominal EffortPM = %v, want the stored %v", nominal.EffortPM, estimate.EffortPM)
+    }
+    if !(optimistic.Cost < nominal.Cost && nominal.Cost < pessimistic.Cost) {
+        t.Errorf("cost optimistic/nominal/pessimistic = %v/%v/%v, want increasing", optimistic.Cost, nominal.Cost, pessimistic.Cost)
+    }
+    if want := nominal.EffortPM * domain.DefaultHoursPerPersonMonth * 5000; !approxEqual(nominal.Cost, want) {
+        t.Errorf("nominal cost = %v, want %v", nominal.Cost, want)
+    }
+
+    if _, err := uc.ScenarioPresets("unknown", 0); !errors.Is(err, ErrNotFound) {
+        t.Errorf("got %v, want ErrNotFound for an unknown estimate", err)
+    }
+}
+
+func TestCreateEstimateRatingBounds(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    rely := string(domain.CostDriverRELY)
+    prec := string(domain.ScaleFactorPREC)
+
+    tests := []struct {
+        name        string
+        scaleFactor float64
+        costDriver  float64
+        wantID      string // ID named in the error, empty when the ratings are valid
+    }{
+        {"minimum", domain.MinRating, domain.MinRating, ""},
+        {"maximum", domain.MaxRating, domain.MaxRating, ""},
+        {"scale factor below minimum", domain.MinRating - 1, 2, prec},
+        {"cost driver above maximum", 2, domain.MaxRating + 0.5, rely},
+        {"far out of range", 2, 99, rely},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            input := nominalInput(50)
+            input.ScaleFactors[prec] = tt.scaleFactor
+            input.CostDrivers[rely] = tt.costDriver
+
+            _, err := uc.CreateEstimate(input)
+            if tt.wantID == "" {
+                if err != nil {
+                    t.Errorf("got %v, want boundary ratings accepted", err)
+                }
+                return
+            }
+            if !errors.Is(err, ErrValidation) {
+                t.Fatalf("got %v, want a validation error", err)
+            }
+            if !strings.Contains(err.Error(), tt.wantID) {
+                t.Errorf("error %q does not name %s", err, tt.wantID)
+            }
+        })
+    }
+}
+
+func TestUpdateRatingsRejectsOutOfRange(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    estimate, err := uc.CreateEstimate(nominalInput(50))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    _, err = uc.UpdateRatings(UpdateRatingsInput{
+        EstimateID:  estimate.ID,
+        CostDrivers: map[string]float64{string(domain.CostDriverACAP): 6},
+    })
+    if !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want a validation error", err)
+    }
+}
+
+func TestCreateEstimateErrors(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+
+    if _, err := uc.CreateEstimate(nominalInput(0)); !errors.Is(err, ErrValidation) {
+        t.Errorf("size 0: got %v, want a validation error", err)
+    }
+
+    unknown := nominalInput(50)
+    unknown.ModelID = "unknown"
+    if _, err := uc.CreateEstimate(unknown); !errors.Is(err, ErrNotFound) {
+        t.Errorf("unknown model: got %v, want ErrNotFound", err)
+    }
+}
+
+func TestCreateEstimateAppliesREVL(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    tests := []struct {
+        revl float64
+        size float64
+    }{
+        {0, 50},
+        {25, 62.5},
+    }
+    for _, tt := range tests {
+        input := nominalInput(50)
+        input.REVL = tt.revl
+        estimate, err := uc.CreateEstimate(input)
+        if err != nil {
+            t.Fatal(err)
+        }
+        result, err := uc.DetailedResult(estimate, domain.RateCard{}, domain.RoleMix{}, domain.PhaseProfile{})
+        if err != nil {
+            t.Fatal(err)
+        }
+        if result.ProjectSize != 50 || !approxEqual(result.AdjustedSize, tt.size) {
+            t.Errorf("REVL %v: ProjectSize = %v, AdjustedSize = %v, want 50 and %v", tt.revl, result.ProjectSize, result.AdjustedSize, tt.size)
+        }
+
+        sized, err := uc.CreateEstimate(nominalInput(tt.size))
+        if err != nil {
+            t.Fatal(err)
+        }
+        if !approxEqual(estimate.EffortPM, sized.EffortPM) {
+            t.Errorf("REVL %v: EffortPM = %v, want %v as for %v KSLOC", tt.revl, estimate.EffortPM, sized.EffortPM, tt.size)
+        }
+    }
+}
+
+func TestSensitivityCurveIsMonotonic(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    estimate, err := uc.CreateEstimate(nominalInput(50))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // Effort falls as the analyst capability and the process maturity improve
+    var factorIDs []string
+    for _, cd := range estimate.CostDrivers {
+        if cd.Type == domain.CostDriverACAP {
+            factorIDs = append(factorIDs, cd.ID)
+        }
+    }
+    for _, sf := range estimate.ScaleFactors {
+        if sf.Type == domain.ScaleFactorPMAT {
+            factorIDs = append(factorIDs, sf.ID)
+        }
+    }
+    if len(factorIDs) != 2 {
+        t.Fatalf("found factors %v, want ACAP and PMAT", factorIDs)
+    }
+
+    for _, factorID := range factorIDs {
+        curve, err := uc.SensitivityCurve(estimate.ID, factorID)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if len(curve.Points) < 2 {
+            t.Fatalf("%s: got %d points, want one per rating level", curve.FactorName, len(curve.Points))
+        }
+        // Levels a driver does not define keep the multiplier of the nearest defined one
+        for i := 1; i < len(curve.Points); i++ {
+            if curve.Points[i].EffortPM > curve.Points[i-1].EffortPM {
+                t.Errorf("%s: effort %v at rating %v, want at most the %v at rating %v",
+                    curve.FactorName, curve.Points[i].EffortPM, curve.Points[i].Rating, curve.Points[i-1].EffortPM, curve.Points[i-1].Rating)
+            }
+        }
+        if first, last := curve.Points[0], curve.Points[len(curve.Points)-1]; last.EffortPM >= first.EffortPM {
+            t.Errorf("%s: effort %v at the best rating, want less than the %v at the worst", curve.FactorName, last.EffortPM, first.EffortPM)
+        }
+    }
+
+    if _, err := uc.SensitivityCurve(estimate.ID, "unknown"); !errors.Is(err, ErrNotFound) {
+        t.Errorf("got %v, want ErrNotFound for an unknown factor", err)
+    }
+}
+
+func TestCalibrateSavesModel(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    points := []domain.CalibrationPoint{
+        {Size: 10, ActualEffort: 3 * math.Pow(10, 1.1)},
+        {Size: 100, ActualEffort: 3 * math.Pow(100, 1.1)},
+    }
+
+    if _, err := uc.Calibrate(CalibrateInput{Points: points, Save: true}); !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want ErrValidation saving without a name", err)
+    }
+    if _, err := uc.Calibrate(CalibrateInput{Points: points[:1]}); !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want ErrValidation for a single project", err)
+    }
+
+    result, err := uc.Calibrate(CalibrateInput{Points: points, Save: true, Name: "calibrated", OwnerID: "org-1"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if result.Model == nil || !approxEqual(result.Model.A, 3) || !approxEqual(result.Model.B, 1.1) {
+        t.Fatalf("model = %+v, want A 3 and B 1.1 without scale factors", result.Model)
+    }
+    models, err := uc.GetModels("org-1")
+    if err != nil {
+        t.Fatal(err)
+    }
+    found := false
+    for _, model := range models {
+        found = found || model.ID == result.Model.ID
+    }
+    if !found {
+        t.Errorf("calibrated model %s not listed for its organization", result.Model.ID)
+    }
+}
+
+func TestInitializeDefaultModelIsIdempotent(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    for i := 0; i < 2; i++ {
+        if err := uc.InitializeDefaultModel(); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    models, err := uc.GetModels("")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(models) != 2 {
+        t.Errorf("got %d models, want the 2 built-in ones after repeated initialization", len(models))
+    }
+}
+
+func TestModelsAreIsolatedByOrganization(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    points := []domain.CalibrationPoint{{Size: 10, ActualEffort: 30}, {Size: 100, ActualEffort: 400}}
+    calibrated, err := uc.Calibrate(CalibrateInput{Points: points, Save: true, Name: "org-a model", OwnerID: "org-a"})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    for _, tt := range []struct {
+        owner string
+        count int
+    }{{"org-a", 3}, {"org-b", 2}, {"", 2}} {
+        models, err := uc.GetModels(tt.owner)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if len(models) != tt.count {
+            t.Errorf("owner %q: got %d models, want %d", tt.owner, len(models), tt.count)
+        }
+    }
+
+    input := nominalInput(50)
+    input.ModelID = calibrated.Model.ID
+    input.OwnerID = "org-b"
+    if _, err := uc.Calculate(input); !errors.Is(err, ErrNotFound) {
+        t.Errorf("got %v, want ErrNotFound using another organization's model", err)
+    }
+    input.OwnerID = "org-a"
+    if _, err := uc.Calculate(input); err != nil {
+        t.Errorf("using the organization's own model: %v", err)
+    }
+}
+
+func TestRepeatedLookupsSeedFactorsOnce(t *testing.T) {
+    repo := memory.NewInMemoryCOCOMORepository()
+    uc := NewCOCOMOUseCase(repo, NewConfigUseCase())
+
+    for i := 0; i < 2; i++ {
+        if _, err := uc.GetScaleFactors(); err != nil {
+            t.Fatal(err)
+        }
+        if _, err := uc.GetCostDrivers(); err != nil {
+            t.Fatal(err)
+        }
+        if err := uc.InitializeScaleFactors(); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    factors, err := repo.FindAllScaleFactors()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(factors) != 5 {
+        t.Errorf("got %d stored scale factors, want 5", len(factors))
+    }
+    drivers, err := repo.FindAllCostDrivers()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(drivers) != len(domain.CostDriverTypes) {
+        t.Errorf("got %d stored cost drivers, want %d", len(drivers), len(domain.CostDriverTypes))
+    }
+}
+
+// stubModelRepository serves a fixed model and fails the test on any saved estimate
+type stubModelRepository struct {
+    domain.COCOMORepository
+    t     *testing.T
+    model *domain.COCOMOModel
+}
+
+func (r *stubModelRepository) FindModelByID(id string) (*domain.COCOMOModel, error) {
+    if id != r.model.ID {
+        return nil, domain.ErrNotFound
+    }
+    return r.model, nil
+}
+
+func (r *stubModelRepository) SaveEstimate(*domain.COCOMOEstimate) error {
+    r.t.Error("Calculate saved an estimate")
+    return nil
+}
+
+func TestCalculateWithStubModel(t *testing.T) {
+    factors := newTestCOCOMOUseCase(t)
+    repo := &stubModelRepository{
+        COCOMORepository: factors.cocomoRepo,
+        t:                t,
+        model:            &domain.COCOMOModel{ID: "stub", A: 2, B: 1.0},
+    }
+    uc := NewCOCOMOUseCase(repo, NewConfigUseCase())
+
+    input := nominalInput(100)
+    input.ModelID = "stub"
+    estimate, err := uc.Calculate(input)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // Nominal cost drivers multiply by 1, so PM = A × Size^E
+    if want := 2 * math.Pow(100, estimate.ExponentB); !approxEqual(estimate.EffortPM, want) {
+        t.Errorf("EffortPM = %v, want %v", estimate.EffortPM, want)
+    }
+    if estimate.ExponentB <= repo.model.B {
+        t.Errorf("ExponentB = %v, want the scale factors added to B %v", estimate.ExponentB, repo.model.B)
+    }
+
+    input.ModelID = "missing"
+    if _, err := uc.Calculate(input); !errors.Is(err, domain.ErrNotFound) {
+        t.Errorf("got %v, want ErrNotFound for an unknown model", err)
+    }
+}
+
+func TestDoublingPlannedTeamBeyondThresholdRaisesEffort(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    threshold := uc.config.GetConfig().TeamOverheadSettings().Threshold
+
+    effortWith := func(planned float64) float64 {
+        input := nominalInput(100)
+        input.PlannedTeamSize = planned
+        estimate, err := uc.Calculate(input)
+        if err != nil {
+            t.Fatal(err)
+        }
+        return estimate.EffortPM
+    }
+
+    unplanned := effortWith(0)
+    if atThreshold := effortWith(threshold); atThreshold != unplanned {
+        t.Errorf("effort %v with a team at the threshold, want the unplanned %v", atThreshold, unplanned)
+    }
+    previous := unplanned
+    for _, planned := range []float64{2 * threshold, 4 * threshold} {
+        effort := effortWith(planned)
+        if effort <= previous {
+            t.Errorf("effort %v with a planned team of %v, want more than %v", effort, planned, previous)
+        }
+        previous = effort
+    }
+}
+
+// batchInputs returns nominal batch inputs of the given sizes, costed at a fixed hourly rate
+func batchInputs(sizes ...float64) []DetailedInput {
+    inputs := make([]DetailedInput, len(sizes))
+    for i, size := range sizes {
+        inputs[i] = DetailedInput{Estimate: nominalInput(size), Rates: domain.RateCard{DefaultRate: 5000}}
+    }
+    return inputs
+}
+
+func TestCalculateBatchKeepsInputOrder(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    sizes := []float64{80, 10, 45, 120, 5, 60, 30, 200, 15}
+
+    batch, err := uc.CalculateBatch(batchInputs(sizes...))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(batch.Results) != len(sizes) {
+        t.Fatalf("got %d results, want %d", len(batch.Results), len(sizes))
+    }
+    for i, size := range sizes {
+        estimate, err := uc.Calculate(nominalInput(size))
+        if err != nil {
+            t.Fatal(err)
+        }
+        if !approxEqual(batch.Results[i].AdjustedEffort, estimate.EffortPM) {
+            t.Errorf("result %d: effort %v, want the %v of size %v", i, batch.Results[i].AdjustedEffort, estimate.EffortPM, size)
+        }
+    }
+}
+
+func TestCalculateBatchSummaryIsSumOfResults(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+
+    batch, err := uc.CalculateBatch(batchInputs(20, 50, 100))
+    if err != nil {
+        t.Fatal(err)
+    }
+    var effort, cost, team, duration float64
+    for _, result := range batch.Results {
+        effort += result.AdjustedEffort
+        cost += result.CostEstimate.GrandTotal
+        team += result.TeamSize
+        duration = math.Max(duration, result.Duration)
+    }
+    summary := batch.Summary
+    if summary.ProjectCount != 3 {
+        t.Errorf("project count %d, want 3", summary.ProjectCount)
+    }
+    if !approxEqual(summary.TotalEffortPM, effort) || !approxEqual(summary.TotalCost, cost) || !approxEqual(summary.PeakTeamSize, team) {
+        t.Errorf("summary effort %v, cost %v and team %v, want the sums %v, %v and %v",
+            summary.TotalEffortPM, summary.TotalCost, summary.PeakTeamSize, effort, cost, team)
+    }
+    if summary.MaxDurationTM != duration {
+        t.Errorf("summary duration %v, want the longest %v", summary.MaxDurationTM, duration)
+    }
+}
+
+func TestCalculateBatchFailsOnFirstFailingInput(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+    inputs := batchInputs(20, 50, 100, 10)
+    inputs[1].Estimate.ModelID = "unknown"
+    inputs[3].Estimate.ProjectSize = 0
+
+    batch, err := uc.CalculateBatch(inputs)
+    if !errors.Is(err, ErrNotFound) {
+        t.Fatalf("got %v, want the ErrNotFound of estimate 1", err)
+    }
+    if !strings.HasPrefix(err.Error(), "estimate 1: ") {
+        t.Errorf("error %q does not name estimate 1", err)
+    }
+    if batch != nil {
+        t.Errorf("got results %+v with the error, want none", batch)
+    }
+}
+
+func TestCalculateRejectsInvalidReuseComponents(t *testing.T) {
+    uc := newTestCOCOMOUseCase(t)
+
+    // DM -100 on a 1 KSLOC project gave a negative effective size and a NaN effort
+    negativeDM := nominalInput(1)
+    negativeDM.ReuseComponents = []domain.ReuseComponent{{AdaptedSize: 10, DM: -100}}
+    outOfRangeSU := nominalInput(1)
+    outOfRangeSU.ReuseComponents = []domain.ReuseComponent{{AdaptedSize: 10, DM: 10, SU: 60}}
+    for name, input := range map[string]CreateEstimateInput{"negative DM": negativeDM, "SU above 50": outOfRangeSU} {
+        if _, err := uc.CreateEstimate(input); !errors.Is(err, ErrValidation) {
+            t.Errorf("%s: got %v, want ErrValidation", name, err)
+        }
+    }
+    if estimates, _ := uc.GetEstimates(); len(estimates) != 0 {
+        t.Errorf("got %d stored estimates, want none after rejected inputs", len(estimates))
+    }
+
+    reverse := ReverseInput{ModelID: domain.ModelPostArchitectureID, TargetDurationTM: 12, TeamSize: 5, ReuseComponents: negativeDM.ReuseComponents}
+    if _, err := uc.Reverse(reverse); !errors.Is(err, ErrValidation) {
+        t.Errorf("Reverse: got %v, want ErrValidation", err)
+    }
+}
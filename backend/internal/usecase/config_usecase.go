@@ -0,0 +1,91 @@
+package usecase
+
+import (
+    "sync"
+
+    "estimate-backend/internal/domain"
+)
+
+// ConfigUseCase holds the estimation configuration shared by the other use cases
+type ConfigUseCase struct {
+    mu     sync.RWMutex
+    config domain.EstimationConfig
+}
+
+// NewConfigUseCase creates a new ConfigUseCase with the default configuration
+func NewConfigUseCase() *ConfigUseCase {
+    return &ConfigUseCase{
+        config: domain.DefaultEstimationConfig(),
+    }
+}
+
+// GetConfig returns the current estimation configuration
+func (uc *ConfigUseCase) GetConfig() domain.EstimationConfig {
+    uc.mu.RLock()
+    defer uc.mu.RUnlock()
+    return uc.config
+}
+
+// UpdateConfig replaces the estimation configuration.
+// Unset risk thresholds and team overhead keep the current ones.
+func (uc *ConfigUseCase) UpdateConfig(config domain.EstimationConfig) (domain.EstimationConfig, error) {
+    if config.HoursPerPersonMonth <= 0 {
+        return domain.EstimationConfig{}, newValidationError("hours per person-month must be greater than 0")
+    }
+    if config.Risk != (domain.RiskConfig{}) {
+        if err := validateRiskConfig(config.Risk); err != nil {
+            return domain.EstimationConfig{}, err
+        }
+    }
+    if config.TeamOverhead != (domain.TeamOverheadConfig{}) {
+        if config.TeamOverhead.Threshold < 2 {
+            return domain.EstimationConfig{}, newValidationError("team overhead threshold must be at least 2")
+        }
+        if config.TeamOverhead.Rate < 0 {
+            return domain.EstimationConfig{}, newValidationError("team overhead rate must not be negative")
+        }
+    }
+
+    uc.mu.Lock()
+    defer uc.mu.Unlock()
+    if config.Risk == (domain.RiskConfig{}) {
+        config.Risk = uc.config.Risk
+    }
+    if config.TeamOverhead == (domain.TeamOverheadConfig{}) {
+        config.TeamOverhead = uc.config.TeamOverhead
+    }
+    uc.config = config
+    return uc.config, nil
+}
+
+// validateRiskConfig checks that the risk thresholds are usable
+func validateRiskConfig(risk domain.RiskConfig) error {
+    if !domain.IsValidRating(risk.ScaleFactorRating) {
+        return newValidationError("scale factor rating threshold must be between 0 and 5")
+    }
+    if risk.CostDriverValue <= 0 {
+        return newValidationError("cost driver value threshold must be greater than 0")
+    }
+    if risk.MediumRiskCount < 1 {
+        return newValidationError("medium risk count must be at least 1")
+    }
+    if risk.HighRiskCount < risk.MediumRiskCount {
+        return newValidationError("high risk count must not be less than the medium risk count")
+    }
+    if risk.LargeProjectSize <= 0 {
+        return newValidationError("large project size must be greater than 0")
+    }
+    if risk.LargeEffortPM <= 0 {
+        return newValidationError("large effort must be greater than 0")
+    }
+    if risk.LargeTeamSize <= 0 {
+        return newValidationError("large team size must be greater than 0")
+    }
+    if risk.MediumRiskScore <= 0 || risk.HighRiskScore > domain.MaxRiskScore {
+        return newValidationError("risk score thresholds must be between 0 and 100")
+    }
+    if risk.HighRiskScore < risk.MediumRiskScore {
+        return newValidationError("high risk score must not be less than the medium risk score")
+    }
+    return nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+    "errors"
+
+    "estimate-backend/internal/domain"
+)
+
+var (
+    // ErrValidation is matched by errors.Is for errors caused by invalid use case input
+    ErrValidation = errors.New("validation error")
+
+    // ErrNotFound is matched by errors.Is when a referenced entity does not exist
+    ErrNotFound = domain.ErrNotFound
+
+    // ErrConflict is matched by errors.Is when an update is based on an outdated version
+    ErrConflict = domain.ErrConflict
+)
+
+// validationError keeps the descriptive message while matching ErrValidation
+type validationError struct {
+    msg string
+}
+
+func (e *validationError) Error() string {
+    return e.msg
+}
+
+func (e *validationError) Is(target error) bool {
+    return target == ErrValidation
+}
+
+// newValidationError creates an error for invalid use case input
+func newValidationError(msg string) error {
+    return &validationError{msg: msg}
+}
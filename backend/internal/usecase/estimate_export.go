@@ -0,0 +1,271 @@
+package usecase
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "reflect"
+    "time"
+
+    "estimate-backend/internal/domain"
+)
+
+// EstimateExportFormat identifies estimate backup documents
+const EstimateExportFormat = "estimate-app/estimate"
+
+// EstimateExportSchemaVersion is the schema version of the documents ExportEstimate writes.
+// ImportEstimate upgrades documents of earlier versions to it through estimateExportMigrations.
+const EstimateExportSchemaVersion = 2
+
+// EstimateExport represents a self-contained backup of an estimate. The factors, the COCOMO II
+// model and the rated scale factors and cost drivers are part of the estimate; the processes its
+// tasks refer to are included as snapshots.
+type EstimateExport struct {
+    Format        string            `json:"format"`
+    SchemaVersion int               `json:"schemaVersion"`
+    ExportedAt    time.Time         `json:"exportedAt"`
+    Estimate      *domain.Estimate  `json:"estimate"`
+    Processes     []*domain.Process `json:"processes"`
+}
+
+// ExportEstimate builds the backup document of an estimate
+func (uc *EstimateUseCase) ExportEstimate(id string) (*EstimateExport, error) {
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+
+    doc := &EstimateExport{
+        Format:        EstimateExportFormat,
+        SchemaVersion: EstimateExportSchemaVersion,
+        ExportedAt:    time.Now(),
+        Estimate:      estimate,
+        Processes:     []*domain.Process{},
+    }
+    seen := make(map[string]bool)
+    for _, pe := range estimate.ProcessEstimates {
+        if pe.Process == nil || seen[pe.Process.ID] {
+            continue
+        }
+        seen[pe.Process.ID] = true
+
+        // The current process is what a recalculation reads; the copy in the estimate only
+        // stands in for a process deleted since
+        process, err := uc.processRepo.FindByID(pe.Process.ID)
+        if errors.Is(err, domain.ErrNotFound) {
+            process = pe.Process
+        } else if err != nil {
+            return nil, err
+        }
+        doc.Processes = append(doc.Processes, process)
+    }
+    return doc, nil
+}
+
+// estimateExportMigrations upgrade a document from the schema version of the key to the next one.
+// They work on the raw fields, so the document struct only ever describes the current schema.
+var estimateExportMigrations = map[int]func(doc map[string]json.RawMessage) error{
+    1: migrateEstimateExportV1,
+}
+
+// migrateEstimateExportV1 upgrades a version 1 document, which carried its version as "version"
+func migrateEstimateExportV1(doc map[string]json.RawMessage) error {
+    delete(doc, "version")
+    return nil
+}
+
+// DecodeEstimateExport reads a backup document of the current or an earlier schema version,
+// upgrading it to the current schema
+func DecodeEstimateExport(data []byte) (*EstimateExport, error) {
+    var fields map[string]json.RawMessage
+    if err := json.Unmarshal(data, &fields); err != nil {
+        return nil, newValidationError("document must be a JSON object")
+    }
+
+    var format string
+    if raw, ok := fields["format"]; ok {
+        json.Unmarshal(raw, &format)
+    }
+    if format != EstimateExportFormat {
+        return nil, newValidationError(fmt.Sprintf("document is not an estimate export: format must be %s", EstimateExportFormat))
+    }
+
+    version, err := estimateExportSchemaVersion(fields)
+    if err != nil {
+        return nil, err
+    }
+    switch {
+    case version < 1:
+        return nil, newValidationError("document schema version is required")
+    case version > EstimateExportSchemaVersion:
+        return nil, newValidationError(fmt.Sprintf("document schema version %d is newer than the supported version %d", version, EstimateExportSchemaVersion))
+    }
+    for ; version < EstimateExportSchemaVersion; version++ {
+        migrate, ok := estimateExportMigrations[version]
+        if !ok {
+            return nil, newValidationError(fmt.Sprintf("document schema version %d cannot be upgraded", version))
+        }
+        if err := migrate(fields); err != nil {
+            return nil, newValidationError(fmt.Sprintf("upgrading document schema version %d: %v", version, err))
+        }
+    }
+    fields["schemaVersion"], _ = json.Marshal(version)
+
+    upgraded, err := json.Marshal(fields)
+    if err != nil {
+        return nil, err
+    }
+    var doc EstimateExport
+    if err := json.Unmarshal(upgraded, &doc); err != nil {
+        return nil, newValidationError(fmt.Sprintf("document does not match schema version %d: %v", version, err))
+    }
+    return &doc, nil
+}
+
+// estimateExportSchemaVersion reads the schema version of a document,
+// 0 when it has none
+func estimateExportSchemaVersion(fields map[string]json.RawMessage) (int, error) {
+    raw, ok := fields["schemaVersion"]
+    if !ok {
+        // Version 1 documents name it "version"
+        if raw, ok = fields["version"]; !ok {
+            return 0, nil
+        }
+    }
+    var version int
+    if err := json.Unmarshal(raw, &version); err != nil {
+        return 0, newValidationError("document schema version must be an integer")
+    }
+    return version, nil
+}
+
+// ImportEstimateInput represents input data for restoring an estimate from a backup document
+type ImportEstimateInput struct {
+    Document []byte // JSON document of any supported schema version
+    OwnerID  string // Organization of the caller, owns a COCOMO II model the document brings along
+    Actor    string // Recorded in the audit trail
+}
+
+// ImportEstimate stores the estimate of a backup document as a new estimate and recalculates it.
+// Processes and the COCOMO II model are reused when identical ones exist, and otherwise saved
+// under new IDs; the estimate and its tasks always get new IDs.
+func (uc *EstimateUseCase) ImportEstimate(input ImportEstimateInput) (*domain.Estimate, error) {
+    doc, err := DecodeEstimateExport(input.Document)
+    if err != nil {
+        return nil, err
+    }
+    if doc.Estimate == nil {
+        return nil, newValidationError("document has no estimate")
+    }
+
+    estimate := doc.Estimate
+    processIDs, err := uc.importProcesses(doc.Processes)
+    if err != nil {
+        return nil, err
+    }
+    for i := range estimate.ProcessEstimates {
+        pe := &estimate.ProcessEstimates[i]
+        if pe.Process == nil {
+            return nil, newValidationError(fmt.Sprintf("process estimate %d has no process", i))
+        }
+        id, ok := processIDs[pe.Process.ID]
+        if !ok {
+            return nil, newValidationError(fmt.Sprintf("document has no snapshot of process %s", pe.Process.ID))
+        }
+        process, err := uc.processRepo.FindByID(id)
+        if err != nil {
+            return nil, err
+        }
+        pe.Process = process
+        for j := range pe.Tasks {
+            pe.Tasks[j].ProcessID = id
+        }
+    }
+    if estimate.COCOMOEstimate != nil {
+        if err := uc.importModel(estimate.COCOMOEstimate, input.OwnerID); err != nil {
+            return nil, err
+        }
+    }
+
+    estimate.ID = ""
+    estimate.DeletedAt = nil
+    if !estimate.Status.IsValid() {
+        estimate.Status = domain.EstimateStatusDraft
+    }
+    renewTaskIDs(estimate)
+    if err := estimate.ValidateReconciliation(); err != nil {
+        return nil, newValidationError(err.Error())
+    }
+    if err := estimate.CalculateTotalHours(uc.processRepo, uc.config.GetConfig()); err != nil {
+        return nil, err
+    }
+
+    now := time.Now()
+    if estimate.CreatedAt.IsZero() {
+        estimate.CreatedAt = now
+    }
+    estimate.UpdatedAt = now
+    if err := uc.estimateRepo.Save(estimate); err != nil {
+        return nil, err
+    }
+
+    uc.audit.record(input.Actor, domain.AuditEntityEstimate, estimate.ID, domain.AuditActionCreated, nil, estimateSummary(estimate))
+    return estimate, nil
+}
+
+// importProcesses maps the ID of each process snapshot to the ID of an identical stored process,
+// saving the snapshot under a new ID when there is none
+func (uc *EstimateUseCase) importProcesses(snapshots []*domain.Process) (map[string]string, error) {
+    ids := make(map[string]string)
+    for _, snapshot := range snapshots {
+        if snapshot == nil {
+            continue
+        }
+        existing, err := uc.processRepo.FindByID(snapshot.ID)
+        if err == nil && reflect.DeepEqual(existing, snapshot) {
+            ids[snapshot.ID] = existing.ID
+            continue
+        }
+        if err != nil && !errors.Is(err, domain.ErrNotFound) {
+            return nil, err
+        }
+
+        process := *snapshot
+        process.ID = ""
+        if err := uc.processRepo.Save(&process); err != nil {
+            return nil, err
+        }
+        ids[snapshot.ID] = process.ID
+    }
+    return ids, nil
+}
+
+// importModel points the COCOMO II data at a visible model with the coefficients of the snapshot,
+// saving a copy owned by the organization when there is none
+func (uc *EstimateUseCase) importModel(data *domain.COCOMOEstimate, ownerID string) error {
+    if data.Model == nil {
+        return newValidationError("COCOMO II data has no model")
+    }
+    snapshot := *data.Model
+    existing, err := findModel(uc.cocomoRepo, snapshot.ID, ownerID)
+    if err == nil && existing.A == snapshot.A && existing.B == snapshot.B {
+        data.Model = existing
+        return nil
+    }
+    if err != nil && !errors.Is(err, domain.ErrNotFound) {
+        return err
+    }
+
+    model := &domain.COCOMOModel{
+        OwnerID:     ownerID,
+        Name:        snapshot.Name,
+        Description: snapshot.Description,
+        A:           snapshot.A,
+        B:           snapshot.B,
+    }
+    if err := uc.cocomoRepo.SaveModel(model); err != nil {
+        return err
+    }
+    data.Model = model
+    return nil
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+
+    "estimate-backend/internal/domain"
+)
+
+// exportedEstimate creates an estimate over two processes with factors and COCOMO II data, the
+// implementation process customized so it matches no default process, and returns its backup document
+func exportedEstimate(t *testing.T, f *estimateFixture) (*domain.Estimate, []byte) {
+    t.Helper()
+    implementation := f.process(t, domain.ProcessImplementation)
+    implementation.Activities[1].BaseHours *= 3
+    if err := f.processes.Update(implementation); err != nil {
+        t.Fatal(err)
+    }
+
+    scaleFactors, costDrivers := nominalRatings()
+    estimate := f.create(t, CreateProjectEstimateInput{
+        ProjectName: "Project 1",
+        Tasks: []TaskInput{
+            f.task(t, domain.ProcessBasicDesign, 0),
+            f.task(t, domain.ProcessImplementation, 1),
+        },
+        GlobalFactors: []string{f.factorID(t, "セキュリティ要件厳格")},
+        COCOMOData: &COCOMOInput{
+            ModelID:      domain.ModelPostArchitectureID,
+            KSLOC:        10,
+            ScaleFactors: scaleFactors,
+            CostDrivers:  costDrivers,
+        },
+    })
+
+    doc, err := f.uc.ExportEstimate(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    data, err := json.Marshal(doc)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return estimate, data
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+    source := newEstimateFixture(t)
+    original, data := exportedEstimate(t, source)
+
+    // Import into another installation
+    target := newEstimateFixture(t)
+    imported, err := target.uc.ImportEstimate(ImportEstimateInput{Document: data, Actor: "tester"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !approxEqual(imported.TotalHours, original.TotalHours) {
+        t.Errorf("imported TotalHours %v, want the original %v", imported.TotalHours, original.TotalHours)
+    }
+    if imported.COCOMOResult == nil || !approxEqual(imported.COCOMOResult.TotalHours, original.COCOMOResult.TotalHours) {
+        t.Errorf("imported COCOMO II result %+v, want the original %+v", imported.COCOMOResult, original.COCOMOResult)
+    }
+
+    stored, err := target.uc.GetEstimate(imported.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !approxEqual(stored.TotalHours, original.TotalHours) {
+        t.Errorf("stored TotalHours %v, want the original %v", stored.TotalHours, original.TotalHours)
+    }
+    for _, pe := range stored.ProcessEstimates {
+        process, err := target.processes.FindByID(pe.Process.ID)
+        if err != nil {
+            t.Fatalf("imported estimate refers to process %s missing from the target: %v", pe.Process.ID, err)
+        }
+        for _, task := range pe.Tasks {
+            if task.ProcessID != process.ID {
+                t.Errorf("task %s refers to process %s, want the remapped %s", task.ID, task.ProcessID, process.ID)
+            }
+        }
+    }
+}
+
+func TestImportRemapsIDs(t *testing.T) {
+    f := newEstimateFixture(t)
+    original, data := exportedEstimate(t, f)
+    processes, _ := f.processes.FindAll()
+
+    // Importing into the same installation reuses the identical processes
+    imported, err := f.uc.ImportEstimate(ImportEstimateInput{Document: data, Actor: "tester"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if imported.ID == original.ID {
+        t.Errorf("imported estimate kept the ID %s, want a new one", original.ID)
+    }
+    originalTasks := make(map[string]bool)
+    for _, pe := range original.ProcessEstimates {
+        for _, task := range pe.Tasks {
+            originalTasks[task.ID] = true
+        }
+    }
+    for i, pe := range imported.ProcessEstimates {
+        if pe.Process.ID != original.ProcessEstimates[i].Process.ID {
+            t.Errorf("process %s saved again as %s, want the identical stored one reused", original.ProcessEstimates[i].Process.ID, pe.Process.ID)
+        }
+        for _, task := range pe.Tasks {
+            if originalTasks[task.ID] {
+                t.Errorf("imported task kept the ID %s, want a new one", task.ID)
+            }
+        }
+    }
+    if after, _ := f.processes.FindAll(); len(after) != len(processes) {
+        t.Errorf("got %d processes after the import, want the %d already stored", len(after), len(processes))
+    }
+}
+
+// documentWithVersion rewrites the schema version fields of a backup document
+func documentWithVersion(t *testing.T, data []byte, rewrite func(fields map[string]interface{})) []byte {
+    t.Helper()
+    var fields map[string]interface{}
+    if err := json.Unmarshal(data, &fields); err != nil {
+        t.Fatal(err)
+    }
+    rewrite(fields)
+    rewritten, err := json.Marshal(fields)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return rewritten
+}
+
+func TestImportUpgradesVersion1Document(t *testing.T) {
+    source := newEstimateFixture(t)
+    original, data := exportedEstimate(t, source)
+    // Version 1 documents carried their version as "version"
+    v1 := documentWithVersion(t, data, func(fields map[string]interface{}) {
+        delete(fields, "schemaVersion")
+        fields["version"] = 1
+    })
+
+    doc, err := DecodeEstimateExport(v1)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if doc.SchemaVersion != EstimateExportSchemaVersion {
+        t.Errorf("upgraded document has schema version %d, want %d", doc.SchemaVersion, EstimateExportSchemaVersion)
+    }
+
+    target := newEstimateFixture(t)
+    imported, err := target.uc.ImportEstimate(ImportEstimateInput{Document: v1, Actor: "tester"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !approxEqual(imported.TotalHours, original.TotalHours) {
+        t.Errorf("imported TotalHours %v, want the original %v", imported.TotalHours, original.TotalHours)
+    }
+}
+
+func TestImportRejectsUnsupportedVersions(t *testing.T) {
+    f := newEstimateFixture(t)
+    _, data := exportedEstimate(t, f)
+
+    tests := []struct {
+        name    string
+        rewrite func(fields map[string]interface{})
+    }{
+        {"future version", func(fields map[string]interface{}) { fields["schemaVersion"] = EstimateExportSchemaVersion + 1 }},
+        {"no version", func(fields map[string]interface{}) { delete(fields, "schemaVersion") }},
+        {"non-integer version", func(fields map[string]interface{}) { fields["schemaVersion"] = "2" }},
+        {"other format", func(fields map[string]interface{}) { fields["format"] = "other" }},
+    }
+    for _, tt := range tests {
+        doc := documentWithVersion(t, data, tt.rewrite)
+        if _, err := f.uc.ImportEstimate(ImportEstimateInput{Document: doc}); !errors.Is(err, ErrValidation) {
+            t.Errorf("%s: got %v, want ErrValidation", tt.name, err)
+        }
+    }
+}
@@ -0,0 +1,1255 @@
+package usecase
+
+import (
+    "errors"
+    "fmt"
+    "log/slog"
+    "sort"
+    "strings"
+    "time"
+
+    "estimate-backend/internal/domain"
+)
+
+// EstimateUseCase handles the business logic for project estimates
+type EstimateUseCase struct {
+    estimateRepo domain.EstimateRepository
+    processRepo  domain.ProcessRepository
+    factorRepo   domain.FactorRepository
+    taskRepo     domain.TaskRepository
+    cocomoRepo   domain.COCOMORepository
+    config       *ConfigUseCase
+
+    // Optional drift notification, see SetDriftNotification
+    subscriptionRepo domain.SubscriptionRepository
+    notifier         domain.Notifier
+    driftQueue       chan driftCheck
+
+    // Optional audit trail, see SetAuditLog
+    audit *AuditUseCase
+
+    // Background failures are logged here, slog.Default() unless set by SetLogger
+    logger *slog.Logger
+}
+
+// NewEstimateUseCase creates a new EstimateUseCase
+func NewEstimateUseCase(
+    estimateRepo domain.EstimateRepository,
+    processRepo domain.ProcessRepository,
+    factorRepo domain.FactorRepository,
+    taskRepo domain.TaskRepository,
+    cocomoRepo domain.COCOMORepository,
+    config *ConfigUseCase,
+) *EstimateUseCase {
+    return &EstimateUseCase{
+        estimateRepo: estimateRepo,
+        processRepo:  processRepo,
+        factorRepo:   factorRepo,
+        taskRepo:     taskRepo,
+        cocomoRepo:   cocomoRepo,
+        config:       config,
+    }
+}
+
+// driftQueueSize bounds the drift alerts waiting for delivery; alerts beyond it are dropped
+const driftQueueSize = 100
+
+// SetDriftNotification enables drift alerts for estimate updates.
+// Alerts are delivered in the background, one at a time, so slow webhooks don't hold up updates.
+func (uc *EstimateUseCase) SetDriftNotification(subscriptionRepo domain.SubscriptionRepository, notifier domain.Notifier) {
+    uc.subscriptionRepo = subscriptionRepo
+    uc.notifier = notifier
+    uc.driftQueue = make(chan driftCheck, driftQueueSize)
+    go uc.deliverDriftAlerts(uc.driftQueue)
+}
+
+// SetLogger sets the logger for failures of background work such as drift alert delivery
+func (uc *EstimateUseCase) SetLogger(logger *slog.Logger) {
+    uc.logger = logger
+}
+
+// log returns the logger set by SetLogger, or the default one
+func (uc *EstimateUseCase) log() *slog.Logger {
+    if uc.logger == nil {
+        return slog.Default()
+    }
+    return uc.logger
+}
+
+// SetAuditLog enables recording estimate changes in the audit trail
+func (uc *EstimateUseCase) SetAuditLog(audit *AuditUseCase) {
+    uc.audit = audit
+}
+
+// TaskInput represents input data for a task within an estimate
+type TaskInput struct {
+    ID            string   `json:"id,omitempty"` // Optional, lets dependencies refer to the task
+    ProcessID     string   `json:"processId" validate:"required"`
+    ActivityID    string   `json:"activityId"`
+    Name          string   `json:"name"`
+    Description   string   `json:"description"`
+    Complexity    int      `json:"complexity" validate:"omitempty,min=1,max=5"`
+    Scale         float64  `json:"scale" validate:"min=0"`
+    Dependencies  []string `json:"dependencies"`
+    CustomFactors []string `json:"customFactors"` // Factor IDs
+    Optimistic    float64  `json:"optimistic,omitempty" validate:"min=0"`
+    MostLikely    float64  `json:"mostLikely,omitempty" validate:"min=0"`
+    Pessimistic   float64  `json:"pessimistic,omitempty" validate:"min=0"`
+    StoryPoints   float64  `json:"storyPoints,omitempty" validate:"min=0"`
+    WorkType      string   `json:"workType,omitempty" validate:"omitempty,oneof=new change fix"`
+}
+
+// COCOMOInput represents the COCOMO II parameters attached to an estimate
+type COCOMOInput struct {
+    ModelID      string             `json:"modelId" validate:"required"`
+    KSLOC        float64            `json:"ksloc" validate:"gt=0"`
+    ScaleFactors map[string]float64 `json:"scaleFactors"` // Factor ID -> Rating
+    CostDrivers  map[string]float64 `json:"costDrivers"`  // Driver ID -> Rating
+    ReuseComponents []domain.ReuseComponent `json:"reuseComponents,omitempty"`
+    REVL         float64            `json:"revl,omitempty"` // Requirements volatility in percent
+    OwnerID      string             `json:"-"`              // Organization of the caller, selects which models are visible
+}
+
+// estimateInput converts the input into the parameters of a standalone COCOMO II estimate
+func (in *COCOMOInput) estimateInput() CreateEstimateInput {
+    return CreateEstimateInput{
+        ModelID:         in.ModelID,
+        OwnerID:         in.OwnerID,
+        ProjectSize:     in.KSLOC,
+        ScaleFactors:    in.ScaleFactors,
+        CostDrivers:     in.CostDrivers,
+        ReuseComponents: in.ReuseComponents,
+        REVL:            in.REVL,
+    }
+}
+
+// StoryPointInput represents the settings converting the story points of tasks into hours and sprints
+type StoryPointInput struct {
+    Velocity      float64 `json:"velocity" validate:"gt=0"`      // Story points per sprint
+    SprintWeeks   float64 `json:"sprintWeeks" validate:"gt=0"`   // Sprint length in weeks
+    HoursPerPoint float64 `json:"hoursPerPoint" validate:"gt=0"` // Effort hours per story point
+}
+
+// ReconciliationInput overrides how the method results are combined into the total hours
+type ReconciliationInput struct {
+    Mode    domain.ReconciliationMode            `json:"mode" validate:"omitempty,oneof=weighted activity_only cocomo_only custom"`
+    Weights map[domain.CalculationMethod]float64 `json:"weights,omitempty"` // Custom mode only, e.g. activity_based: 70, cocomo_based: 30
+}
+
+// reconciliationStrategy converts the input, the confidence weighting without one
+func (in *ReconciliationInput) reconciliationStrategy() domain.ReconciliationStrategy {
+    if in == nil {
+        return domain.ReconciliationStrategy{}
+    }
+    return domain.ReconciliationStrategy{Mode: in.Mode, Weights: in.Weights}
+}
+
+// CreateProjectEstimateInput represents input data for creating a project estimate
+type CreateProjectEstimateInput struct {
+    ProjectID     string
+    ProjectName   string
+    Tasks         []TaskInput
+    GlobalFactors []string // Factor IDs
+    ProcessFactors map[domain.ProcessCategory][]string // Process category -> factor IDs
+    COCOMOData    *COCOMOInput
+    UCPData       *UCPInput
+    StoryPointData *StoryPointInput
+    DeliverableBased bool // Also estimate from the deliverables of the selected activities
+    Reconciliation *ReconciliationInput // Optional, weights the methods by confidence without one
+    CreatedBy     string
+    Notes         string
+    Attributes    domain.ProjectAttributes
+    AccessibilityLevel    domain.AccessibilityLevel
+    LocalizationLanguages []string
+    Actor         string // Recorded in the audit trail, defaults to CreatedBy
+}
+
+// CalculateEstimate builds and calculates a project estimate without storing it
+func (uc *EstimateUseCase) CalculateEstimate(input CreateProjectEstimateInput) (*domain.Estimate, error) {
+    // Validate input
+    if input.ProjectID == "" {
+        return nil, newValidationError("project ID is required")
+    }
+
+    estimate := &domain.Estimate{
+        ProjectID:   input.ProjectID,
+        ProjectName: input.ProjectName,
+        Status:      domain.EstimateStatusDraft,
+        CreatedBy:   input.CreatedBy,
+        Notes:       input.Notes,
+        Attributes:  input.Attributes,
+        DeliverableBased:      input.DeliverableBased,
+        Reconciliation:        input.Reconciliation.reconciliationStrategy(),
+    }
+    if err := applyRequirements(estimate, input.AccessibilityLevel, input.LocalizationLanguages); err != nil {
+        return nil, err
+    }
+
+    if err := uc.applyInputs(estimate, input.Tasks, input.GlobalFactors, input.ProcessFactors, input.COCOMOData, input.UCPData, input.StoryPointData); err != nil {
+        return nil, err
+    }
+    if err := estimate.ValidateReconciliation(); err != nil {
+        return nil, newValidationError(err.Error())
+    }
+
+    if err := estimate.CalculateTotalHours(uc.processRepo, uc.config.GetConfig()); err != nil {
+        return nil, err
+    }
+
+    return estimate, nil
+}
+
+// CreateEstimate creates and calculates a new project estimate
+func (uc *EstimateUseCase) CreateEstimate(input CreateProjectEstimateInput) (*domain.Estimate, error) {
+    estimate, err := uc.CalculateEstimate(input)
+    if err != nil {
+        return nil, err
+    }
+
+    now := time.Now()
+    estimate.CreatedAt = now
+    estimate.UpdatedAt = now
+
+    if err := uc.estimateRepo.Save(estimate); err != nil {
+        return nil, err
+    }
+
+    actor := input.Actor
+    if actor == "" {
+        actor = input.CreatedBy
+    }
+    uc.audit.record(actor, domain.AuditEntityEstimate, estimate.ID, domain.AuditActionCreated, nil, estimateSummary(estimate))
+
+    return estimate, nil
+}
+
+// CloneEstimateInput represents input data for starting a new estimate from an existing one
+type CloneEstimateInput struct {
+    ID          string // Estimate to copy
+    ProjectID   string // Optional, defaults to the project of the copied estimate
+    ProjectName string
+    CreatedBy   string
+    Actor       string // Recorded in the audit trail, defaults to CreatedBy
+}
+
+// CloneEstimate copies the tasks, factors and sizing inputs of an estimate into a new draft.
+// Tasks get new IDs with their dependencies remapped; progress and actuals are not carried over.
+func (uc *EstimateUseCase) CloneEstimate(input CloneEstimateInput) (*domain.Estimate, error) {
+    if input.ProjectName == "" {
+        return nil, newValidationError("project name is required")
+    }
+
+    // The repository returns a deep copy, so the clone shares no state with the original
+    clone, err := uc.estimateRepo.FindByID(input.ID)
+    if err != nil {
+        return nil, err
+    }
+
+    clone.ID = ""
+    if input.ProjectID != "" {
+        clone.ProjectID = input.ProjectID
+    }
+    clone.ProjectName = input.ProjectName
+    clone.Status = domain.EstimateStatusDraft
+    clone.CreatedBy = input.CreatedBy
+    clone.CompletedDeliverables = nil
+    clone.ActualHours = 0
+    clone.DeletedAt = nil
+    renewTaskIDs(clone)
+
+    now := time.Now()
+    clone.CreatedAt = now
+    clone.UpdatedAt = now
+
+    if err := uc.estimateRepo.Save(clone); err != nil {
+        return nil, err
+    }
+
+    actor := input.Actor
+    if actor == "" {
+        actor = input.CreatedBy
+    }
+    uc.audit.record(actor, domain.AuditEntityEstimate, clone.ID, domain.AuditActionCreated, nil, estimateSummary(clone))
+
+    return clone, nil
+}
+
+// renewTaskIDs gives every task of the estimate a new ID and points the dependencies at the new IDs
+func renewTaskIDs(estimate *domain.Estimate) {
+    ids := make(map[string]string)
+    for i := range estimate.ProcessEstimates {
+        tasks := estimate.ProcessEstimates[i].Tasks
+        for j := range tasks {
+            id := domain.NewID()
+            ids[tasks[j].ID] = id
+            tasks[j].ID = id
+        }
+    }
+    for i := range estimate.ProcessEstimates {
+        tasks := estimate.ProcessEstimates[i].Tasks
+        for j := range tasks {
+            for k, dep := range tasks[j].Dependencies {
+                if id, ok := ids[dep]; ok {
+                    tasks[j].Dependencies[k] = id
+                }
+            }
+        }
+    }
+}
+
+// GetEstimate retrieves an estimate by ID
+func (uc *EstimateUseCase) GetEstimate(id string) (*domain.Estimate, error) {
+    return uc.estimateRepo.FindByID(id)
+}
+
+// GetEstimateVersions retrieves every saved version of an estimate, oldest first
+func (uc *EstimateUseCase) GetEstimateVersions(id string) ([]*domain.Estimate, error) {
+    return uc.estimateRepo.FindVersions(id)
+}
+
+// GetEstimateVersion retrieves a single saved version of an estimate
+func (uc *EstimateUseCase) GetEstimateVersion(id string, version int) (*domain.Estimate, error) {
+    return uc.estimateRepo.FindVersion(id, version)
+}
+
+// Page size limits of ListProjectEstimates
+const (
+    DefaultPageSize = 20
+    MaxPageSize     = 100
+)
+
+// EstimateListQuery represents the filter, paging and sort order of an estimate list.
+// Sort is createdAt (default) or totalHours, ascending unless prefixed with "-".
+type EstimateListQuery struct {
+    Statuses []domain.EstimateStatus // Any of these statuses; empty matches all
+    Page     int                     // 1-based, defaults to 1
+    PageSize int                     // Defaults to DefaultPageSize and is capped at MaxPageSize
+    Sort     string
+}
+
+// EstimatePage represents a single page of estimates
+type EstimatePage struct {
+    Items    []*domain.Estimate `json:"items"`
+    Page     int                `json:"page"`
+    PageSize int                `json:"pageSize"`
+    Total    int                `json:"total"` // Number of estimates across all pages
+}
+
+// GetProjectEstimates retrieves all estimates of a project
+func (uc *EstimateUseCase) GetProjectEstimates(projectID string) ([]*domain.Estimate, error) {
+    return uc.estimateRepo.FindByProjectID(projectID)
+}
+
+// ListEstimates retrieves a page of the estimates of all projects
+func (uc *EstimateUseCase) ListEstimates(query EstimateListQuery) (*EstimatePage, error) {
+    estimates, err := uc.estimateRepo.FindAll()
+    if err != nil {
+        return nil, err
+    }
+    return paginateEstimates(estimates, query)
+}
+
+// ListProjectEstimates retrieves a page of the estimates of a project
+func (uc *EstimateUseCase) ListProjectEstimates(projectID string, query EstimateListQuery) (*EstimatePage, error) {
+    estimates, err := uc.estimateRepo.FindByProjectID(projectID)
+    if err != nil {
+        return nil, err
+    }
+    return paginateEstimates(estimates, query)
+}
+
+// SearchEstimates retrieves a page of the estimates whose project name or notes contain text,
+// ignoring case. An empty text matches every estimate, so the search lists the most recent ones.
+// Results are newest first unless the query sets another order.
+func (uc *EstimateUseCase) SearchEstimates(text string, query EstimateListQuery) (*EstimatePage, error) {
+    estimates, err := uc.estimateRepo.FindAll()
+    if err != nil {
+        return nil, err
+    }
+
+    if text = strings.ToLower(strings.TrimSpace(text)); text != "" {
+        matches := []*domain.Estimate{}
+        for _, estimate := range estimates {
+            if strings.Contains(strings.ToLower(estimate.ProjectName), text) ||
+                strings.Contains(strings.ToLower(estimate.Notes), text) {
+                matches = append(matches, estimate)
+            }
+        }
+        estimates = matches
+    }
+
+    if query.Sort == "" {
+        query.Sort = "-createdAt"
+    }
+    return paginateEstimates(estimates, query)
+}
+
+// paginateEstimates filters, sorts and pages estimates. A page past the last one returns no items.
+func paginateEstimates(estimates []*domain.Estimate, query EstimateListQuery) (*EstimatePage, error) {
+    if query.Page == 0 {
+        query.Page = 1
+    }
+    if query.PageSize == 0 {
+        query.PageSize = DefaultPageSize
+    }
+    if query.Page < 0 || query.PageSize < 0 {
+        return nil, newValidationError("page and pageSize must be positive")
+    }
+    if query.PageSize > MaxPageSize {
+        query.PageSize = MaxPageSize
+    }
+
+    descending := strings.HasPrefix(query.Sort, "-")
+    var less func(a, b *domain.Estimate) bool
+    switch strings.TrimPrefix(query.Sort, "-") {
+    case "", "createdAt":
+        less = func(a, b *domain.Estimate) bool { return a.CreatedAt.Before(b.CreatedAt) }
+    case "totalHours":
+        less = func(a, b *domain.Estimate) bool { return a.TotalHours < b.TotalHours }
+    default:
+        return nil, newValidationError("sort must be createdAt or totalHours")
+    }
+
+    if len(query.Statuses) > 0 {
+        statuses := make(map[domain.EstimateStatus]bool, len(query.Statuses))
+        for _, status := range query.Statuses {
+            if !status.IsValid() {
+                return nil, newValidationError(fmt.Sprintf("unknown status %q", status))
+            }
+            statuses[status] = true
+        }
+        filtered := []*domain.Estimate{}
+        for _, estimate := range estimates {
+            if statuses[estimate.Status] {
+                filtered = append(filtered, estimate)
+            }
+        }
+        estimates = filtered
+    }
+
+    sort.SliceStable(estimates, func(i, j int) bool {
+        if descending {
+            return less(estimates[j], estimates[i])
+        }
+        return less(estimates[i], estimates[j])
+    })
+
+    page := &EstimatePage{
+        Items:    []*domain.Estimate{},
+        Page:     query.Page,
+        PageSize: query.PageSize,
+        Total:    len(estimates),
+    }
+    if start := (query.Page - 1) * query.PageSize; start < len(estimates) {
+        end := start + query.PageSize
+        if end > len(estimates) {
+            end = len(estimates)
+        }
+        page.Items = estimates[start:end]
+    }
+    return page, nil
+}
+
+// UpdateEstimateInput represents input data for updating an estimate
+type UpdateEstimateInput struct {
+    ID            string
+    Version       int // Version the update is based on; rejected with ErrConflict when outdated
+    Tasks         []TaskInput
+    GlobalFactors []string // Factor IDs
+    ProcessFactors map[domain.ProcessCategory][]string // Process category -> factor IDs
+    COCOMOData    *COCOMOInput
+    UCPData       *UCPInput
+    StoryPointData *StoryPointInput
+    DeliverableBased bool
+    Reconciliation *ReconciliationInput
+    Notes         string
+    CompletedDeliverables []string
+    Attributes    domain.ProjectAttributes
+    ActualHours   float64
+    AccessibilityLevel    domain.AccessibilityLevel
+    LocalizationLanguages []string
+    Actor         string // Recorded in the audit trail
+}
+
+// UpdateEstimate updates the inputs of an existing estimate and recalculates it
+func (uc *EstimateUseCase) UpdateEstimate(input UpdateEstimateInput) (*domain.Estimate, error) {
+    if input.Version <= 0 {
+        return nil, newValidationError("version is required")
+    }
+    estimate, err := uc.estimateRepo.FindByID(input.ID)
+    if err != nil {
+        return nil, err
+    }
+    if input.Version != estimate.Version {
+        return nil, fmt.Errorf("estimate %s: version %d is outdated, the current version is %d: %w", input.ID, input.Version, estimate.Version, ErrConflict)
+    }
+    before := estimateSummary(estimate)
+
+    if err := applyRequirements(estimate, input.AccessibilityLevel, input.LocalizationLanguages); err != nil {
+        return nil, err
+    }
+    if err := uc.applyInputs(estimate, input.Tasks, input.GlobalFactors, input.ProcessFactors, input.COCOMOData, input.UCPData, input.StoryPointData); err != nil {
+        return nil, err
+    }
+    estimate.DeliverableBased = input.DeliverableBased
+    estimate.Reconciliation = input.Reconciliation.reconciliationStrategy()
+    if err := estimate.ValidateReconciliation(); err != nil {
+        return nil, newValidationError(err.Error())
+    }
+    estimate.Notes = input.Notes
+    estimate.CompletedDeliverables = input.CompletedDeliverables
+    estimate.Attributes = input.Attributes
+    estimate.ActualHours = input.ActualHours
+
+    if err := estimate.CalculateTotalHours(uc.processRepo, uc.config.GetConfig()); err != nil {
+        return nil, err
+    }
+    estimate.UpdatedAt = time.Now()
+
+    if err := uc.estimateRepo.Update(estimate); err != nil {
+        return nil, err
+    }
+    uc.audit.record(input.Actor, domain.AuditEntityEstimate, estimate.ID, domain.AuditActionUpdated, before, estimateSummary(estimate))
+    uc.notifyDrift(estimate)
+
+    return estimate, nil
+}
+
+// DeleteEstimate soft-deletes an estimate; it can be brought back with RestoreEstimate
+func (uc *EstimateUseCase) DeleteEstimate(id, actor string) error {
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return err
+    }
+    if err := uc.estimateRepo.Delete(id); err != nil {
+        return err
+    }
+    uc.audit.record(actor, domain.AuditEntityEstimate, id, domain.AuditActionDeleted, estimateSummary(estimate), nil)
+    return nil
+}
+
+// RestoreEstimate restores a deleted estimate
+func (uc *EstimateUseCase) RestoreEstimate(id, actor string) (*domain.Estimate, error) {
+    if err := uc.estimateRepo.Restore(id); err != nil {
+        return nil, err
+    }
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+    uc.audit.record(actor, domain.AuditEntityEstimate, id, domain.AuditActionRestored, nil, estimateSummary(estimate))
+    return estimate, nil
+}
+
+// TransitionStatus moves an estimate to a new status, rejecting transitions that skip or
+// reverse the draft → completed → approved workflow. Only estimates with hours can be approved.
+func (uc *EstimateUseCase) TransitionStatus(id string, status domain.EstimateStatus, actor string) (*domain.Estimate, error) {
+    if !status.IsValid() {
+        return nil, newValidationError(fmt.Sprintf("unknown status %q", status))
+    }
+
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+    if !estimate.Status.CanTransitionTo(status) {
+        return nil, newValidationError(fmt.Sprintf("cannot change status from %s to %s", estimate.Status, status))
+    }
+    if status == domain.EstimateStatusApproved && estimate.TotalHours <= 0 {
+        return nil, newValidationError("cannot approve an estimate without hours")
+    }
+
+    before := estimateSummary(estimate)
+    estimate.Status = status
+    estimate.UpdatedAt = time.Now()
+    if err := uc.estimateRepo.Update(estimate); err != nil {
+        return nil, err
+    }
+    uc.audit.record(actor, domain.AuditEntityEstimate, id, domain.AuditActionUpdated, before, estimateSummary(estimate))
+    return estimate, nil
+}
+
+// GetDetailedEstimateResult recalculates an estimate and generates its detailed COCOMO II result
+func (uc *EstimateUseCase) GetDetailedEstimateResult(id string, hourlyRate float64) (*domain.Estimate, *domain.COCOMODetailedResult, error) {
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    if err := estimate.CalculateTotalHours(uc.processRepo, uc.config.GetConfig()); err != nil {
+        return nil, nil, err
+    }
+
+    var cocomoResult *domain.COCOMODetailedResult
+    if estimate.COCOMOEstimate != nil {
+        cocomoResult = estimate.COCOMOEstimate.GenerateDetailedResult(domain.FlatRateCard(hourlyRate), nil, domain.PhaseProfile{}, uc.config.GetConfig())
+    }
+
+    return estimate, cocomoResult, nil
+}
+
+// EstimateChange represents the change of an estimate's total caused by a recalculation
+type EstimateChange struct {
+    EstimateID    string  `json:"estimateId"`
+    PreviousHours float64 `json:"previousHours"`
+    CurrentHours  float64 `json:"currentHours"`
+    DiffHours     float64 `json:"diffHours"`
+}
+
+// RecalculationSummary represents the outcome of recalculating stored estimates
+type RecalculationSummary struct {
+    Recalculated int              `json:"recalculated"`
+    Changed      int              `json:"changed"`
+    Changes      []EstimateChange `json:"changes"`
+}
+
+// RecalculateEstimates re-resolves the factors of stored estimates, recalculates them and saves
+// those whose total changed. An empty projectID recalculates every estimate.
+func (uc *EstimateUseCase) RecalculateEstimates(projectID, actor string) (*RecalculationSummary, error) {
+    var estimates []*domain.Estimate
+    var err error
+    if projectID == "" {
+        estimates, err = uc.estimateRepo.FindAll()
+    } else {
+        estimates, err = uc.estimateRepo.FindByProjectID(projectID)
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    summary := &RecalculationSummary{Changes: []EstimateChange{}}
+    for _, estimate := range estimates {
+        change, err := uc.recalculate(estimate, actor)
+        if err != nil {
+            return nil, err
+        }
+        summary.Recalculated++
+
+        if change.DiffHours == 0 {
+            continue
+        }
+        summary.Changed++
+        summary.Changes = append(summary.Changes, change)
+    }
+
+    return summary, nil
+}
+
+// RecalculateEstimate re-resolves the factors of one stored estimate, recalculates it against the
+// current process and factor definitions and saves it when its total changed
+func (uc *EstimateUseCase) RecalculateEstimate(id, actor string) (*domain.Estimate, EstimateChange, error) {
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, EstimateChange{}, err
+    }
+
+    change, err := uc.recalculate(estimate, actor)
+    if err != nil {
+        return nil, EstimateChange{}, err
+    }
+    return estimate, change, nil
+}
+
+// recalculate refreshes the factors of an estimate and recalculates it, saving, auditing and
+// notifying drift subscribers when its total changed
+func (uc *EstimateUseCase) recalculate(estimate *domain.Estimate, actor string) (EstimateChange, error) {
+    previous := estimate.TotalHours
+    before := estimateSummary(estimate)
+
+    estimate.GlobalFactors = uc.refreshFactors(estimate.GlobalFactors)
+    for category, factors := range estimate.ProcessFactors {
+        estimate.ProcessFactors[category] = uc.refreshFactors(factors)
+    }
+    for i := range estimate.ProcessEstimates {
+        tasks := estimate.ProcessEstimates[i].Tasks
+        for j := range tasks {
+            tasks[j].CustomFactors = uc.refreshFactors(tasks[j].CustomFactors)
+        }
+    }
+
+    if err := estimate.CalculateTotalHours(uc.processRepo, uc.config.GetConfig()); err != nil {
+        return EstimateChange{}, err
+    }
+    change := EstimateChange{
+        EstimateID:    estimate.ID,
+        PreviousHours: previous,
+        CurrentHours:  estimate.TotalHours,
+        DiffHours:     estimate.TotalHours - previous,
+    }
+
+    if estimate.TotalHours == previous {
+        return change, nil
+    }
+    estimate.UpdatedAt = time.Now()
+    if err := uc.estimateRepo.Update(estimate); err != nil {
+        return EstimateChange{}, err
+    }
+    uc.audit.record(actor, domain.AuditEntityEstimate, estimate.ID, domain.AuditActionUpdated, before, estimateSummary(estimate))
+    uc.notifyDrift(estimate)
+
+    return change, nil
+}
+
+// refreshFactors replaces stored factor copies with their current version.
+// Factors that have since been deleted keep their stored values.
+func (uc *EstimateUseCase) refreshFactors(factors []domain.Factor) []domain.Factor {
+    for i, factor := range factors {
+        if current, err := uc.factorRepo.FindByID(factor.ID); err == nil {
+            factors[i] = *current
+        }
+    }
+    return factors
+}
+
+// CriticalPath calculates the longest chain of dependent tasks of an estimate
+func (uc *EstimateUseCase) CriticalPath(id string) (*domain.CriticalPathResult, error) {
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+
+    result, err := estimate.CalculateCriticalPath(uc.processRepo)
+    if errors.Is(err, domain.ErrInvalidDependencies) {
+        return nil, newValidationError(err.Error())
+    }
+    return result, err
+}
+
+// ProjectSchedule projects the delivery date of an estimate starting on a date.
+// The COCOMO II duration is used when available, the activity-based duration otherwise.
+func (uc *EstimateUseCase) ProjectSchedule(id string, start time.Time, calendar domain.TeamCalendar) (*domain.ProjectSchedule, error) {
+    if calendar.WorkingDaysPerWeek == 0 {
+        calendar.WorkingDaysPerWeek = domain.DefaultWorkingDaysPerWeek
+    }
+    if calendar.WorkingDaysPerWeek < 1 || calendar.WorkingDaysPerWeek > 7 {
+        return nil, newValidationError("working days per week must be between 1 and 7")
+    }
+
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+
+    var duration float64
+    switch {
+    case estimate.COCOMOEstimate != nil:
+        duration = estimate.COCOMOEstimate.DurationTM
+    case estimate.ActivityResult != nil:
+        duration = estimate.ActivityResult.DurationMonths
+    }
+
+    return domain.NewProjectSchedule(start, duration, calendar), nil
+}
+
+// CompareEstimates compares two estimates per process, in total and by their global factors
+func (uc *EstimateUseCase) CompareEstimates(id1, id2 string) (*domain.EstimateComparison, error) {
+    if id1 == "" || id2 == "" {
+        return nil, newValidationError("both estimate IDs are required")
+    }
+
+    estimate1, err := uc.estimateRepo.FindByID(id1)
+    if err != nil {
+        return nil, err
+    }
+    estimate2, err := uc.estimateRepo.FindByID(id2)
+    if err != nil {
+        return nil, err
+    }
+
+    return domain.CompareEstimates(estimate1, estimate2), nil
+}
+
+// MigrationEffort calculates the effort of a data-migration sub-project and attaches it to an estimate
+func (uc *EstimateUseCase) MigrationEffort(id string, spec domain.MigrationSpec, actor string) (*domain.MigrationEffort, error) {
+    // Validate input
+    if spec.SourceTables <= 0 {
+        return nil, newValidationError("source tables must be greater than 0")
+    }
+    if spec.RecordVolume < 0 {
+        return nil, newValidationError("record volume must not be negative")
+    }
+    if spec.TransformationComplexity < 1 || spec.TransformationComplexity > 5 {
+        return nil, newValidationError("transformation complexity must be between 1 and 5")
+    }
+
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+
+    before := estimateSummary(estimate)
+    migration := domain.CalculateMigrationEffort(spec)
+    estimate.SetAdditionalEffort(domain.AdditionalEffort{
+        Category: domain.AdditionalEffortMigration,
+        Name:     "データ移行",
+        Hours:    migration.TotalHours,
+    })
+
+    // Recalculate so the migration line is added to the total
+    if err := estimate.CalculateTotalHours(uc.processRepo, uc.config.GetConfig()); err != nil {
+        return nil, err
+    }
+    estimate.UpdatedAt = time.Now()
+
+    if err := uc.estimateRepo.Update(estimate); err != nil {
+        return nil, err
+    }
+    uc.audit.record(actor, domain.AuditEntityEstimate, id, domain.AuditActionUpdated, before, estimateSummary(estimate))
+    uc.notifyDrift(estimate)
+
+    return migration, nil
+}
+
+// PlanningMaturityConfidence derives the confidence of an estimate from the completed planning deliverables
+func (uc *EstimateUseCase) PlanningMaturityConfidence(id string) (float64, error) {
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return 0, err
+    }
+    return uc.planningConfidence(estimate)
+}
+
+// ProjectSummary aggregates the hours, cost, confidence and statuses of all estimates of a project.
+// A project without estimates yields a summary of zeros.
+func (uc *EstimateUseCase) ProjectSummary(projectID string, hourlyRate float64) (*domain.ProjectSummary, error) {
+    if hourlyRate < 0 {
+        return nil, newValidationError("hourly rate must not be negative")
+    }
+
+    estimates, err := uc.estimateRepo.FindByProjectID(projectID)
+    if err != nil {
+        return nil, err
+    }
+
+    summary := domain.NewProjectSummary(projectID, hourlyRate)
+    for _, estimate := range estimates {
+        confidence, err := uc.planningConfidence(estimate)
+        if err != nil {
+            return nil, err
+        }
+        summary.Add(estimate, confidence)
+    }
+    return summary, nil
+}
+
+// planningConfidence derives the planning maturity confidence from the completed deliverables of an estimate
+func (uc *EstimateUseCase) planningConfidence(estimate *domain.Estimate) (float64, error) {
+    var completion []float64
+    for _, category := range domain.PlanningProcesses {
+        process, err := uc.processRepo.FindByCategory(category)
+        if err != nil {
+            return 0, err
+        }
+
+        var total, completed int
+        for _, activity := range process.Activities {
+            for _, deliverable := range activity.Deliverables {
+                total++
+                if estimate.IsDeliverableCompleted(deliverable) {
+                    completed++
+                }
+            }
+        }
+
+        // A process without deliverables does not hold back the cone
+        ratio := 1.0
+        if total > 0 {
+            ratio = float64(completed) / float64(total)
+        }
+        completion = append(completion, ratio)
+    }
+
+    return domain.PlanningMaturityConfidence(completion), nil
+}
+
+// DeliverableChecklist lists the deliverables of the activities selected by an estimate
+func (uc *EstimateUseCase) DeliverableChecklist(id string) (*domain.DeliverableChecklist, error) {
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+    return estimate.DeliverableChecklist(uc.processRepo)
+}
+
+// EffortByWorkType aggregates the activity-based hours of an estimate per work type
+func (uc *EstimateUseCase) EffortByWorkType(id string) (map[string]float64, error) {
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+
+    byType, err := estimate.EffortByWorkType(uc.processRepo)
+    if err != nil {
+        return nil, err
+    }
+
+    result := make(map[string]float64, len(byType))
+    for workType, hours := range byType {
+        result[string(workType)] = hours
+    }
+
+    return result, nil
+}
+
+// AnalogyEstimate estimates a project from the actuals of the k most similar past estimates
+func (uc *EstimateUseCase) AnalogyEstimate(attributes domain.ProjectAttributes, k int) (*domain.AnalogyEstimate, error) {
+    if k <= 0 {
+        return nil, newValidationError("k must be greater than 0")
+    }
+
+    estimates, err := uc.estimateRepo.FindAll()
+    if err != nil {
+        return nil, err
+    }
+
+    result := domain.EstimateByAnalogy(attributes, estimates, k)
+    if len(result.Analogs) == 0 {
+        return nil, newValidationError("no completed estimates with actual hours to compare")
+    }
+
+    return result, nil
+}
+
+// triangulationAnalogs is the number of analogs used when triangulating an estimate
+const triangulationAnalogs = 3
+
+// TriangulateEstimate compares the COCOMO II, activity-based, use case points, deliverable-based and analogy estimates of an estimate
+func (uc *EstimateUseCase) TriangulateEstimate(id string, analogyAttributes domain.ProjectAttributes) (*domain.Triangulation, error) {
+    estimate, err := uc.estimateRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+
+    results, err := estimate.CalculateResults(uc.processRepo, uc.config.GetConfig())
+    if err != nil {
+        return nil, err
+    }
+
+    estimates, err := uc.estimateRepo.FindAll()
+    if err != nil {
+        return nil, err
+    }
+
+    // The analogy method is skipped when there are no past projects with actuals
+    var analogyResult *domain.CalculationResult
+    analogy := domain.EstimateByAnalogy(analogyAttributes, estimates, triangulationAnalogs)
+    if len(analogy.Analogs) > 0 {
+        analogyResult = analogy.ToCalculationResult(uc.config.GetConfig())
+    }
+
+    return domain.Triangulate(results.COCOMO, results.Activity, results.UCP, results.Deliverable, analogyResult), nil
+}
+
+// SubscribeInput represents input data for subscribing to drift alerts
+type SubscribeInput struct {
+    EstimateID       string
+    ThresholdPercent float64
+    NotifyURL        string
+}
+
+// Subscribe registers a drift alert subscription for an estimate
+func (uc *EstimateUseCase) Subscribe(input SubscribeInput) (*domain.DriftSubscription, error) {
+    if uc.subscriptionRepo == nil {
+        return nil, errors.New("drift notification is not configured")
+    }
+
+    // Validate input
+    if input.ThresholdPercent <= 0 {
+        return nil, newValidationError("threshold percent must be greater than 0")
+    }
+    if input.NotifyURL == "" {
+        return nil, newValidationError("notify URL is required")
+    }
+    if err := domain.ValidateNotifyURL(input.NotifyURL); err != nil {
+        return nil, newValidationError(err.Error())
+    }
+
+    estimate, err := uc.estimateRepo.FindByID(input.EstimateID)
+    if err != nil {
+        return nil, err
+    }
+
+    subscription := &domain.DriftSubscription{
+        EstimateID:        estimate.ID,
+        ThresholdPercent:  input.ThresholdPercent,
+        NotifyURL:         input.NotifyURL,
+        LastNotifiedHours: estimate.TotalHours,
+        CreatedAt:         time.Now(),
+    }
+
+    if err := uc.subscriptionRepo.Save(subscription); err != nil {
+        return nil, err
+    }
+
+    return subscription, nil
+}
+
+// GetSubscriptions retrieves the drift alert subscriptions of an estimate
+func (uc *EstimateUseCase) GetSubscriptions(estimateID string) ([]*domain.DriftSubscription, error) {
+    if uc.subscriptionRepo == nil {
+        return nil, errors.New("drift notification is not configured")
+    }
+    return uc.subscriptionRepo.FindByEstimateID(estimateID)
+}
+
+// Unsubscribe deletes a drift alert subscription
+func (uc *EstimateUseCase) Unsubscribe(id string) error {
+    if uc.subscriptionRepo == nil {
+        return errors.New("drift notification is not configured")
+    }
+    return uc.subscriptionRepo.Delete(id)
+}
+
+// driftCheck is an estimate total waiting to be checked against a subscription and delivered
+type driftCheck struct {
+    subscriptionID string
+    estimateID     string
+    totalHours     float64
+}
+
+// notifyDrift queues a notification for each subscription whose threshold the estimate has exceeded.
+// When the queue is full the alert is dropped; the baseline is kept so it fires again next time.
+func (uc *EstimateUseCase) notifyDrift(estimate *domain.Estimate) {
+    if uc.subscriptionRepo == nil || uc.notifier == nil {
+        return
+    }
+
+    subscriptions, err := uc.subscriptionRepo.FindByEstimateID(estimate.ID)
+    if err != nil {
+        return
+    }
+
+    for _, sub := range subscriptions {
+        if !sub.Exceeded(estimate.TotalHours) {
+            continue
+        }
+        select {
+        case uc.driftQueue <- driftCheck{subscriptionID: sub.ID, estimateID: estimate.ID, totalHours: estimate.TotalHours}:
+        default:
+        }
+    }
+}
+
+// deliverDriftAlerts sends the queued alerts. The subscription is read again before sending, so
+// alerts queued before an earlier one moved the baseline, or before unsubscribing, are skipped.
+// Delivery failures keep the baseline so the alert fires again on the next update.
+func (uc *EstimateUseCase) deliverDriftAlerts(queue <-chan driftCheck) {
+    for check := range queue {
+        sub, err := uc.subscriptionRepo.FindByID(check.subscriptionID)
+        if err != nil || !sub.Exceeded(check.totalHours) {
+            continue
+        }
+
+        alert := domain.DriftAlert{
+            SubscriptionID:   sub.ID,
+            EstimateID:       check.estimateID,
+            ThresholdPercent: sub.ThresholdPercent,
+            PreviousHours:    sub.LastNotifiedHours,
+            CurrentHours:     check.totalHours,
+            ChangePercent:    sub.ChangePercent(check.totalHours),
+        }
+        if err := uc.notifier.Notify(sub.NotifyURL, alert); err != nil {
+            uc.log().Error("drift alert delivery failed", slog.String("subscriptionId", sub.ID), slog.Any("error", err))
+            continue
+        }
+
+        sub.LastNotifiedHours = check.totalHours
+        if err := uc.subscriptionRepo.Update(sub); err != nil {
+            uc.log().Error("drift alert baseline update failed", slog.String("subscriptionId", sub.ID), slog.Any("error", err))
+        }
+    }
+}
+
+// applyRequirements sets the accessibility level and localization languages of an estimate,
+// replacing its localization effort line
+func applyRequirements(estimate *domain.Estimate, level domain.AccessibilityLevel, languages []string) error {
+    if !level.IsValid() {
+        return newValidationError("accessibility level must be one of A, AA or AAA")
+    }
+    estimate.AccessibilityLevel = level
+    estimate.LocalizationLanguages = languages
+    if len(languages) > 0 {
+        estimate.SetAdditionalEffort(domain.LocalizationEffort(languages))
+    } else {
+        estimate.RemoveAdditionalEffort(domain.AdditionalEffortLocalization)
+    }
+    return nil
+}
+
+// applyInputs resolves tasks, factors, COCOMO II, use case points and story point data and sets them on the estimate
+func (uc *EstimateUseCase) applyInputs(estimate *domain.Estimate, tasks []TaskInput, globalFactorIDs []string, processFactorIDs map[domain.ProcessCategory][]string, cocomoData *COCOMOInput, ucpData *UCPInput, storyPointData *StoryPointInput) error {
+    processEstimates, err := uc.buildProcessEstimates(tasks)
+    if err != nil {
+        return err
+    }
+
+    globalFactors, err := uc.resolveFactors(globalFactorIDs)
+    if err != nil {
+        return err
+    }
+
+    var processFactors map[domain.ProcessCategory][]domain.Factor
+    for category, ids := range processFactorIDs {
+        if !category.IsValid() {
+            return newValidationError(fmt.Sprintf("unknown process category %q", category))
+        }
+        factors, err := uc.resolveFactors(ids)
+        if err != nil {
+            return err
+        }
+        if processFactors == nil {
+            processFactors = make(map[domain.ProcessCategory][]domain.Factor)
+        }
+        processFactors[category] = factors
+    }
+
+    var cocomoEstimate *domain.COCOMOEstimate
+    if cocomoData != nil {
+        cocomoEstimate, err = uc.buildCOCOMOEstimate(cocomoData)
+        if err != nil {
+            return err
+        }
+    }
+
+    storyPoints, err := buildStoryPointEstimate(tasks, storyPointData)
+    if err != nil {
+        return err
+    }
+
+    var useCasePoints *domain.UseCasePoints
+    if ucpData != nil {
+        useCasePoints, err = buildUseCasePoints(ucpData)
+        if err != nil {
+            return err
+        }
+    }
+
+    estimate.ProcessEstimates = processEstimates
+    estimate.GlobalFactors = globalFactors
+    estimate.ProcessFactors = processFactors
+    estimate.COCOMOEstimate = cocomoEstimate
+    estimate.UseCasePoints = useCasePoints
+    estimate.StoryPoints = storyPoints
+
+    return nil
+}
+
+// buildProcessEstimates groups the task inputs by process, keeping the order of first appearance
+func (uc *EstimateUseCase) buildProcessEstimates(tasks []TaskInput) ([]domain.ProcessEstimate, error) {
+    var processEstimates []domain.ProcessEstimate
+    indexByProcess := make(map[string]int)
+
+    for i, ti := range tasks {
+        customFactors, err := uc.resolveFactors(ti.CustomFactors)
+        if err != nil {
+            return nil, err
+        }
+
+        switch domain.WorkType(ti.WorkType) {
+        case "", domain.WorkTypeNew, domain.WorkTypeChange, domain.WorkTypeFix:
+        default:
+            return nil, newValidationError("work type must be one of new, change or fix")
+        }
+
+        task := domain.Task{
+            ID:            ti.ID,
+            ProcessID:     ti.ProcessID,
+            ActivityID:    ti.ActivityID,
+            Name:          ti.Name,
+            Description:   ti.Description,
+            Complexity:    ti.Complexity,
+            Scale:         ti.Scale,
+            Dependencies:  ti.Dependencies,
+            CustomFactors: customFactors,
+            Optimistic:    ti.Optimistic,
+            MostLikely:    ti.MostLikely,
+            Pessimistic:   ti.Pessimistic,
+            StoryPoints:   ti.StoryPoints,
+            WorkType:      domain.WorkType(ti.WorkType),
+        }
+        if err := task.ValidateThreePointEstimate(); err != nil {
+            return nil, newValidationError(fmt.Sprintf("task %d (%s): %v", i, ti.Name, err))
+        }
+
+        idx, ok := indexByProcess[ti.ProcessID]
+        if !ok {
+            process, err := uc.processRepo.FindByID(ti.ProcessID)
+            if err != nil {
+                return nil, err
+            }
+            processEstimates = append(processEstimates, domain.ProcessEstimate{Process: process})
+            idx = len(processEstimates) - 1
+            indexByProcess[ti.ProcessID] = idx
+        }
+        // An unknown activity would silently contribute no base hours
+        if ti.ActivityID != "" && !processEstimates[idx].Process.HasActivity(ti.ActivityID) {
+            return nil, newValidationError(fmt.Sprintf("task %d (%s): activity %s is not part of process %s", i, ti.Name, ti.ActivityID, ti.ProcessID))
+        }
+        processEstimates[idx].Tasks = append(processEstimates[idx].Tasks, task)
+    }
+
+    return processEstimates, nil
+}
+
+// buildStoryPointEstimate validates the story point settings, which are required once a task is sized in points
+func buildStoryPointEstimate(tasks []TaskInput, input *StoryPointInput) (*domain.StoryPointEstimate, error) {
+    for _, ti := range tasks {
+        if ti.StoryPoints < 0 {
+            return nil, newValidationError("story points must not be negative")
+        }
+        if ti.StoryPoints > 0 && input == nil {
+            return nil, newValidationError("story point settings are required for tasks sized in story points")
+        }
+    }
+    if input == nil {
+        return nil, nil
+    }
+    if input.Velocity <= 0 || input.SprintWeeks <= 0 || input.HoursPerPoint <= 0 {
+        return nil, newValidationError("velocity, sprint length and hours per point must be greater than 0")
+    }
+
+    return &domain.StoryPointEstimate{
+        Velocity:      input.Velocity,
+        SprintWeeks:   input.SprintWeeks,
+        HoursPerPoint: input.HoursPerPoint,
+    }, nil
+}
+
+// resolveFactors loads the factors for the given IDs
+func (uc *EstimateUseCase) resolveFactors(ids []string) ([]domain.Factor, error) {
+    var factors []domain.Factor
+    for _, id := range ids {
+        factor, err := uc.factorRepo.FindByID(id)
+        if err != nil {
+            return nil, err
+        }
+        factors = append(factors, *factor)
+    }
+    return factors, nil
+}
+
+// buildCOCOMOEstimate creates the COCOMO II estimate attached to a project estimate,
+// validated and rated the same way as a standalone COCOMO II estimate
+func (uc *EstimateUseCase) buildCOCOMOEstimate(input *COCOMOInput) (*domain.COCOMOEstimate, error) {
+    if err := validateEstimateInput(input.estimateInput()); err != nil {
+        return nil, err
+    }
+
+    model, err := findModel(uc.cocomoRepo, input.ModelID, input.OwnerID)
+    if err != nil {
+        return nil, err
+    }
+    scaleFactors, costDrivers, err := resolveRatings(uc.cocomoRepo, input.ScaleFactors, input.CostDrivers)
+    if err != nil {
+        return nil, err
+    }
+
+    return &domain.COCOMOEstimate{
+        ProjectSize:  input.KSLOC,
+        Model:        model,
+        ScaleFactors: scaleFactors,
+        CostDrivers:  costDrivers,
+        ReuseComponents: input.ReuseComponents,
+        REVL:         input.REVL,
+    }, nil
+}
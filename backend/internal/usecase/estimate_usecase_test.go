@@ -0,0 +1,920 @@
+package usecase
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "log/slog"
+    "math"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/infrastructure/memory"
+)
+
+// estimateFixture is an EstimateUseCase backed by in-memory repositories seeded with the defaults
+type estimateFixture struct {
+    uc        *EstimateUseCase
+    estimates *memory.InMemoryEstimateRepository
+    processes *memory.InMemoryProcessRepository
+    factors   *memory.InMemoryFactorRepository
+    tasks     *memory.InMemoryTaskRepository
+    cocomo    *memory.InMemoryCOCOMORepository
+    config    *ConfigUseCase
+}
+
+func newEstimateFixture(t *testing.T) *estimateFixture {
+    t.Helper()
+    f := &estimateFixture{
+        estimates: memory.NewInMemoryEstimateRepository(),
+        processes: memory.NewInMemoryProcessRepository(),
+        factors:   memory.NewInMemoryFactorRepository(),
+        tasks:     memory.NewInMemoryTaskRepository(),
+        cocomo:    memory.NewInMemoryCOCOMORepository(),
+        config:    NewConfigUseCase(),
+    }
+    f.uc = NewEstimateUseCase(f.estimates, f.processes, f.factors, f.tasks, f.cocomo, f.config)
+
+    if err := NewProcessUseCase(f.processes).InitializeDefaultProcesses(); err != nil {
+        t.Fatal(err)
+    }
+    if err := NewFactorUseCase(f.factors).InitializeDefaultFactors(); err != nil {
+        t.Fatal(err)
+    }
+    cocomoUseCase := NewCOCOMOUseCase(f.cocomo, f.config)
+    for _, initialize := range []func() error{
+        cocomoUseCase.InitializeDefaultModel,
+        cocomoUseCase.InitializeScaleFactors,
+        cocomoUseCase.InitializeCostDrivers,
+    } {
+        if err := initialize(); err != nil {
+            t.Fatal(err)
+        }
+    }
+    return f
+}
+
+// process returns the default process of a category
+func (f *estimateFixture) process(t *testing.T, category domain.ProcessCategory) *domain.Process {
+    t.Helper()
+    process, err := f.processes.FindByCategory(category)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return process
+}
+
+// task returns a nominal task input for an activity of the default process of a category
+func (f *estimateFixture) task(t *testing.T, category domain.ProcessCategory, activity int) TaskInput {
+    t.Helper()
+    process := f.process(t, category)
+    return TaskInput{
+        ProcessID:  process.ID,
+        ActivityID: process.Activities[activity].ID,
+        Name:       process.Activities[activity].Name,
+        Complexity: 3,
+        Scale:      1,
+    }
+}
+
+// factorID returns the ID of the default factor with the given name
+func (f *estimateFixture) factorID(t *testing.T, name string) string {
+    t.Helper()
+    factors, err := f.factors.FindAll()
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, factor := range factors {
+        if factor.Name == name {
+            return factor.ID
+        }
+    }
+    t.Fatalf("factor %s not found", name)
+    return ""
+}
+
+// create creates an estimate, failing the test on error
+func (f *estimateFixture) create(t *testing.T, input CreateProjectEstimateInput) *domain.Estimate {
+    t.Helper()
+    if input.ProjectID == "" {
+        input.ProjectID = "project-1"
+    }
+    estimate, err := f.uc.CreateEstimate(input)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return estimate
+}
+
+func approxEqual(a, b float64) bool {
+    return math.Abs(a-b) <= 1e-9*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+func TestMigrationEffortAddsLineToTotal(t *testing.T) {
+    f := newEstimateFixture(t)
+    estimate := f.create(t, CreateProjectEstimateInput{
+        Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+    })
+
+    migration, err := f.uc.MigrationEffort(estimate.ID, domain.MigrationSpec{
+        SourceTables:             20,
+        RecordVolume:             1000000,
+        TransformationComplexity: 3,
+    }, "tester")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    stored, err := f.uc.GetEstimate(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if want := estimate.TotalHours + migration.TotalHours; !approxEqual(stored.TotalHours, want) {
+        t.Errorf("TotalHours = %v, want %v + %v", stored.TotalHours, estimate.TotalHours, migration.TotalHours)
+    }
+
+    // Sizing the migration again replaces the line instead of adding a second one
+    if _, err := f.uc.MigrationEffort(estimate.ID, domain.MigrationSpec{SourceTables: 20, RecordVolume: 1000000, TransformationComplexity: 3}, "tester"); err != nil {
+        t.Fatal(err)
+    }
+    stored, err = f.uc.GetEstimate(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(stored.AdditionalEfforts) != 1 {
+        t.Errorf("got %d additional effort lines, want 1", len(stored.AdditionalEfforts))
+    }
+}
+
+func TestMigrationEffortRejectsInvalidInput(t *testing.T) {
+    f := newEstimateFixture(t)
+    estimate := f.create(t, CreateProjectEstimateInput{
+        Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+    })
+
+    tests := []struct {
+        name string
+        spec domain.MigrationSpec
+    }{
+        {"no tables", domain.MigrationSpec{SourceTables: 0, TransformationComplexity: 3}},
+        {"negative volume", domain.MigrationSpec{SourceTables: 1, RecordVolume: -1, TransformationComplexity: 3}},
+        {"complexity below 1", domain.MigrationSpec{SourceTables: 1, TransformationComplexity: 0}},
+        {"complexity above 5", domain.MigrationSpec{SourceTables: 1, TransformationComplexity: 6}},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := f.uc.MigrationEffort(estimate.ID, tt.spec, "tester"); !errors.Is(err, ErrValidation) {
+                t.Errorf("got %v, want a validation error", err)
+            }
+        })
+    }
+
+    _, err := f.uc.MigrationEffort("unknown", domain.MigrationSpec{SourceTables: 1, TransformationComplexity: 3}, "tester")
+    if !errors.Is(err, ErrNotFound) {
+        t.Errorf("got %v, want ErrNotFound for an unknown estimate", err)
+    }
+}
+
+func TestPlanningConfidenceRisesWithRequirementDeliverables(t *testing.T) {
+    f := newEstimateFixture(t)
+    estimate := f.create(t, CreateProjectEstimateInput{
+        Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+    })
+
+    var deliverables []string
+    for _, activity := range f.process(t, domain.ProcessRequirementDefinition).Activities {
+        deliverables = append(deliverables, activity.Deliverables...)
+    }
+
+    previous, err := f.uc.PlanningMaturityConfidence(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // Complete the requirements deliverables one at a time
+    for i := range deliverables {
+        estimate, err = f.uc.UpdateEstimate(UpdateEstimateInput{
+            ID:                    estimate.ID,
+            Version:               estimate.Version,
+            Tasks:                 []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+            CompletedDeliverables: deliverables[:i+1],
+        })
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        confidence, err := f.uc.PlanningMaturityConfidence(estimate.ID)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if confidence <= previous {
+            t.Errorf("%d deliverables: confidence %v, want more than %v", i+1, confidence, previous)
+        }
+        previous = confidence
+    }
+
+    if !approxEqual(previous, 0.5) {
+        t.Errorf("confidence = %v, want 0.5 once the requirements are complete", previous)
+    }
+}
+
+// stubNotifier records the delivered drift alerts
+type stubNotifier struct {
+    alerts chan domain.DriftAlert
+    err    error // Returned after recording the alert
+}
+
+func (n *stubNotifier) Notify(url string, alert domain.DriftAlert) error {
+    n.alerts <- alert
+    return n.err
+}
+
+// logLines is a log output passing each written record on
+type logLines chan []byte
+
+func (l logLines) Write(p []byte) (int, error) {
+    l <- append([]byte(nil), p...)
+    return len(p), nil
+}
+
+// updateScale recalculates an estimate with an implementation task of the given scale
+func (f *estimateFixture) updateScale(t *testing.T, estimate *domain.Estimate, scale float64) *domain.Estimate {
+    t.Helper()
+    task := f.task(t, domain.ProcessImplementation, 1)
+    task.Scale = scale
+    updated, err := f.uc.UpdateEstimate(UpdateEstimateInput{ID: estimate.ID, Version: estimate.Version, Tasks: []TaskInput{task}})
+    if err != nil {
+        t.Fatal(err)
+    }
+    return updated
+}
+
+func TestDriftAlertFiresOnlyBeyondThreshold(t *testing.T) {
+    f := newEstimateFixture(t)
+    notifier := &stubNotifier{alerts: make(chan domain.DriftAlert, 10)}
+    f.uc.SetDriftNotification(memory.NewInMemorySubscriptionRepository(), notifier)
+
+    estimate := f.create(t, CreateProjectEstimateInput{
+        Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+    })
+    baseline := estimate.TotalHours
+    subscription, err := f.uc.Subscribe(SubscribeInput{EstimateID: estimate.ID, ThresholdPercent: 10, NotifyURL: "https://example.com/hook"})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // A 5% change stays within the threshold, doubling the task exceeds it. Alerts are
+    // delivered in order, so the first one received shows whether the small change fired.
+    estimate = f.updateScale(t, estimate, 1.05)
+    if change := (estimate.TotalHours - baseline) / baseline * 100; change <= 0 || change >= 10 {
+        t.Fatalf("small change = %v%%, want within the 10%% threshold", change)
+    }
+    estimate = f.updateScale(t, estimate, 2)
+
+    select {
+    case alert := <-notifier.alerts:
+        if alert.SubscriptionID != subscription.ID || alert.CurrentHours != estimate.TotalHours {
+            t.Errorf("alert = %+v, want subscription %s at %v hours", alert, subscription.ID, estimate.TotalHours)
+        }
+        if alert.PreviousHours != baseline {
+            t.Errorf("PreviousHours = %v, want the baseline %v", alert.PreviousHours, baseline)
+        }
+    case <-time.After(time.Second):
+        t.Fatal("no alert delivered after exceeding the threshold")
+    }
+}
+
+func TestDriftAlertFailureIsLogged(t *testing.T) {
+    f := newEstimateFixture(t)
+    notifier := &stubNotifier{alerts: make(chan domain.DriftAlert, 10), err: errors.New("webhook unreachable")}
+    lines := make(logLines, 10)
+    f.uc.SetLogger(slog.New(slog.NewJSONHandler(lines, nil)))
+    f.uc.SetDriftNotification(memory.NewInMemorySubscriptionRepository(), notifier)
+
+    estimate := f.create(t, CreateProjectEstimateInput{
+        Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+    })
+    subscription, err := f.uc.Subscribe(SubscribeInput{EstimateID: estimate.ID, ThresholdPercent: 10, NotifyURL: "https://example.com/hook"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    f.updateScale(t, estimate, 2)
+
+    select {
+    case line := <-lines:
+        var record map[string]interface{}
+        if err := json.Unmarshal(line, &record); err != nil {
+            t.Fatal(err)
+        }
+        if record["level"] != "ERROR" || record["subscriptionId"] != subscription.ID || record["error"] != "webhook unreachable" {
+            t.Errorf("log record %s, want an error for subscription %s", line, subscription.ID)
+        }
+    case <-time.After(time.Second):
+        t.Fatal("no log record for the failed delivery")
+    }
+}
+
+func TestEffortByWorkType(t *testing.T) {
+    f := newEstimateFixture(t)
+    newWork := f.task(t, domain.ProcessImplementation, 1)
+    fix := f.task(t, domain.ProcessImplementation, 1)
+    fix.WorkType = "fix"
+    estimate := f.create(t, CreateProjectEstimateInput{
+        Tasks:         []TaskInput{newWork, fix},
+        GlobalFactors: []string{f.factorID(t, "セキュリティ要件厳格")},
+    })
+
+    byWorkType, err := f.uc.EffortByWorkType(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    // The fix task is the same activity as the new one, adjusted by the fix multiplier
+    if want := byWorkType["new"] * domain.WorkTypeFix.Multiplier(); !approxEqual(byWorkType["fix"], want) {
+        t.Errorf("fix = %v hours, want %v", byWorkType["fix"], want)
+    }
+    var sum float64
+    for _, hours := range byWorkType {
+        sum += hours
+    }
+    if !approxEqual(sum, estimate.TotalHours) {
+        t.Errorf("buckets sum to %v, want the total %v", sum, estimate.TotalHours)
+    }
+
+    if _, err := f.uc.EffortByWorkType("unknown"); !errors.Is(err, ErrNotFound) {
+        t.Errorf("got %v, want ErrNotFound for an unknown estimate", err)
+    }
+}
+
+func TestCreateEstimateRejectsUnknownWorkType(t *testing.T) {
+    f := newEstimateFixture(t)
+    task := f.task(t, domain.ProcessImplementation, 1)
+    task.WorkType = "refactor"
+
+    _, err := f.uc.CreateEstimate(CreateProjectEstimateInput{ProjectID: "project-1", Tasks: []TaskInput{task}})
+    if !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want a validation error", err)
+    }
+}
+
+func TestCreateEstimateRejectsInvalidThreePointEstimate(t *testing.T) {
+    f := newEstimateFixture(t)
+    tests := []struct {
+        name                                string
+        optimistic, mostLikely, pessimistic float64
+    }{
+        {"reversed", 40, 10, 5},
+        {"partial", 0, 10, 0},
+        {"negative", -5, 10, 20},
+    }
+    for _, tt := range tests {
+        task := f.task(t, domain.ProcessImplementation, 1)
+        task.Optimistic, task.MostLikely, task.Pessimistic = tt.optimistic, tt.mostLikely, tt.pessimistic
+
+        _, err := f.uc.CreateEstimate(CreateProjectEstimateInput{ProjectID: "project-1", Tasks: []TaskInput{task}})
+        if !errors.Is(err, ErrValidation) {
+            t.Errorf("%s: got %v, want a validation error", tt.name, err)
+        }
+    }
+}
+
+// processHours returns the total hours of a process of an estimate
+func processHours(estimate *domain.Estimate, category domain.ProcessCategory) float64 {
+    for _, pe := range estimate.ProcessEstimates {
+        if pe.Process.Category == category {
+            return pe.TotalHours
+        }
+    }
+    return 0
+}
+
+func TestLocalizationAddsEffortPerLanguage(t *testing.T) {
+    f := newEstimateFixture(t)
+    tasks := []TaskInput{f.task(t, domain.ProcessImplementation, 1)}
+    plain := f.create(t, CreateProjectEstimateInput{Tasks: tasks})
+    localized := f.create(t, CreateProjectEstimateInput{Tasks: tasks, LocalizationLanguages: []string{"en", "zh", "ko"}})
+
+    if len(localized.AdditionalEfforts) != 1 || localized.AdditionalEfforts[0].Category != domain.AdditionalEffortLocalization {
+        t.Fatalf("additional efforts = %+v, want one localization line", localized.AdditionalEfforts)
+    }
+    want := 3 * domain.LocalizationHoursPerLanguage
+    if localized.AdditionalEfforts[0].Hours != want {
+        t.Errorf("localization = %v hours, want %v for three languages", localized.AdditionalEfforts[0].Hours, want)
+    }
+    if !approxEqual(localized.TotalHours-plain.TotalHours, want) {
+        t.Errorf("TotalHours grew by %v, want %v", localized.TotalHours-plain.TotalHours, want)
+    }
+}
+
+func TestAccessibilityRaisesUIProcessHours(t *testing.T) {
+    f := newEstimateFixture(t)
+    tasks := []TaskInput{f.task(t, domain.ProcessImplementation, 1), f.task(t, domain.ProcessBasicDesign, 0)}
+    plain := f.create(t, CreateProjectEstimateInput{Tasks: tasks})
+    accessible := f.create(t, CreateProjectEstimateInput{Tasks: tasks, AccessibilityLevel: domain.AccessibilityAAA})
+
+    ui := processHours(plain, domain.ProcessImplementation)
+    if want := ui * domain.AccessibilityAAA.Multiplier(); !approxEqual(processHours(accessible, domain.ProcessImplementation), want) {
+        t.Errorf("implementation = %v hours, want %v", processHours(accessible, domain.ProcessImplementation), want)
+    }
+    if processHours(accessible, domain.ProcessBasicDesign) != processHours(plain, domain.ProcessBasicDesign) {
+        t.Errorf("basic design = %v hours, want it unaffected", processHours(accessible, domain.ProcessBasicDesign))
+    }
+
+    _, err := f.uc.CreateEstimate(CreateProjectEstimateInput{ProjectID: "project-1", Tasks: tasks, AccessibilityLevel: "AAAA"})
+    if !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want a validation error for an unknown level", err)
+    }
+}
+
+func TestUpdateEstimateKeepsRequirementEffort(t *testing.T) {
+    f := newEstimateFixture(t)
+    tasks := []TaskInput{f.task(t, domain.ProcessImplementation, 1)}
+    estimate := f.create(t, CreateProjectEstimateInput{Tasks: tasks, AccessibilityLevel: domain.AccessibilityAA, LocalizationLanguages: []string{"en"}})
+
+    updated, err := f.uc.UpdateEstimate(UpdateEstimateInput{
+        ID:                    estimate.ID,
+        Version:               estimate.Version,
+        Tasks:                 tasks,
+        AccessibilityLevel:    domain.AccessibilityAA,
+        LocalizationLanguages: []string{"en"},
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !approxEqual(updated.TotalHours, estimate.TotalHours) {
+        t.Errorf("TotalHours = %v after an unchanged update, want %v", updated.TotalHours, estimate.TotalHours)
+    }
+
+    // Dropping the languages removes the localization line
+    updated, err = f.uc.UpdateEstimate(UpdateEstimateInput{ID: estimate.ID, Version: updated.Version, Tasks: tasks, AccessibilityLevel: domain.AccessibilityAA})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(updated.AdditionalEfforts) != 0 {
+        t.Errorf("additional efforts = %+v, want none without languages", updated.AdditionalEfforts)
+    }
+}
+
+func TestEstimateVersions(t *testing.T) {
+    f := newEstimateFixture(t)
+    v1 := f.create(t, CreateProjectEstimateInput{
+        Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+    })
+    v2 := f.updateScale(t, v1, 2)
+    v3 := f.updateScale(t, v2, 3)
+
+    versions, err := f.uc.GetEstimateVersions(v1.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(versions) != 3 {
+        t.Fatalf("got %d versions, want 3", len(versions))
+    }
+    for i, want := range []*domain.Estimate{v1, v2, v3} {
+        version, err := f.uc.GetEstimateVersion(v1.ID, i+1)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if version.Version != i+1 || version.TotalHours != want.TotalHours || versions[i].TotalHours != want.TotalHours {
+            t.Errorf("version %d: %v hours, want %v", i+1, version.TotalHours, want.TotalHours)
+        }
+    }
+
+    current, err := f.uc.GetEstimate(v1.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if current.Version != 3 || current.TotalHours != v3.TotalHours {
+        t.Errorf("current estimate is version %d with %v hours, want the latest", current.Version, current.TotalHours)
+    }
+
+    if _, err := f.uc.GetEstimateVersion(v1.ID, 4); !errors.Is(err, ErrNotFound) {
+        t.Errorf("version 4: got %v, want ErrNotFound", err)
+    }
+}
+
+func TestCreateEstimateKeepsBothMethodResults(t *testing.T) {
+    f := newEstimateFixture(t)
+    scaleFactors, costDrivers := nominalRatings()
+    estimate := f.create(t, CreateProjectEstimateInput{
+        Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+        COCOMOData: &COCOMOInput{
+            ModelID:      domain.ModelPostArchitectureID,
+            KSLOC:        10,
+            ScaleFactors: scaleFactors,
+            CostDrivers:  costDrivers,
+        },
+    })
+
+    if estimate.ActivityResult == nil || estimate.COCOMOResult == nil {
+        t.Fatalf("ActivityResult = %v, COCOMOResult = %v, want both", estimate.ActivityResult, estimate.COCOMOResult)
+    }
+    low := math.Min(estimate.ActivityResult.TotalHours, estimate.COCOMOResult.TotalHours)
+    high := math.Max(estimate.ActivityResult.TotalHours, estimate.COCOMOResult.TotalHours)
+    if low == high {
+        t.Fatalf("both methods give %v hours, want them apart", low)
+    }
+    if estimate.TotalHours <= low || estimate.TotalHours >= high {
+        t.Errorf("TotalHours = %v, want between the method results %v and %v", estimate.TotalHours, low, high)
+    }
+}
+
+func TestCreateEstimateMatchesStandaloneCOCOMOEstimate(t *testing.T) {
+    f := newEstimateFixture(t)
+    cocomo := NewCOCOMOUseCase(f.cocomo, f.config)
+    scaleFactors, costDrivers := nominalRatings()
+    scaleFactors[string(domain.ScaleFactorPREC)] = 4
+    costDrivers[string(domain.CostDriverRELY)] = 3
+    input := &COCOMOInput{ModelID: domain.ModelPostArchitectureID, KSLOC: 10, ScaleFactors: scaleFactors, CostDrivers: costDrivers, REVL: 10}
+
+    standalone, err := cocomo.Calculate(input.estimateInput())
+    if err != nil {
+        t.Fatal(err)
+    }
+    estimate := f.create(t, CreateProjectEstimateInput{Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)}, COCOMOData: input})
+    if estimate.COCOMOEstimate.EffortPM != standalone.EffortPM || estimate.COCOMOEstimate.ExponentB != standalone.ExponentB {
+        t.Errorf("EffortPM, ExponentB = %v, %v, want the standalone %v, %v",
+            estimate.COCOMOEstimate.EffortPM, estimate.COCOMOEstimate.ExponentB, standalone.EffortPM, standalone.ExponentB)
+    }
+
+    // Both reject the same invalid input
+    input.ScaleFactors[string(domain.ScaleFactorPREC)] = 6
+    if _, err := cocomo.Calculate(input.estimateInput()); !errors.Is(err, ErrValidation) {
+        t.Errorf("Calculate: got %v, want a validation error", err)
+    }
+    _, err = f.uc.CreateEstimate(CreateProjectEstimateInput{Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)}, COCOMOData: input})
+    if !errors.Is(err, ErrValidation) {
+        t.Errorf("CreateEstimate: got %v, want a validation error", err)
+    }
+}
+
+func TestRecalculateEstimatesAfterFactorEdit(t *testing.T) {
+    f := newEstimateFixture(t)
+    factorID := f.factorID(t, "セキュリティ要件厳格")
+    affected := f.create(t, CreateProjectEstimateInput{
+        Tasks:         []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+        GlobalFactors: []string{factorID},
+    })
+    unaffected := f.create(t, CreateProjectEstimateInput{
+        Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+    })
+
+    factor, err := f.factors.FindByID(factorID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    factor.Impact *= 2
+    if err := f.factors.Update(factor); err != nil {
+        t.Fatal(err)
+    }
+
+    summary, err := f.uc.RecalculateEstimates("", "tester")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if summary.Recalculated != 2 || summary.Changed != 1 {
+        t.Fatalf("summary = %+v, want 2 recalculated and 1 changed", summary)
+    }
+    if change := summary.Changes[0]; change.EstimateID != affected.ID || change.DiffHours <= 0 {
+        t.Errorf("change = %+v, want more hours for %s", change, affected.ID)
+    }
+
+    stored, err := f.uc.GetEstimate(affected.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if want := affected.TotalHours * 2; !approxEqual(stored.TotalHours, want) {
+        t.Errorf("affected TotalHours = %v, want %v after doubling the factor", stored.TotalHours, want)
+    }
+    stored, err = f.uc.GetEstimate(unaffected.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if stored.TotalHours != unaffected.TotalHours || stored.Version != unaffected.Version {
+        t.Errorf("unaffected estimate = %v hours at version %d, want the unchanged %v at version %d",
+            stored.TotalHours, stored.Version, unaffected.TotalHours, unaffected.Version)
+    }
+}
+
+// listedEstimates returns estimates created an hour apart, the later ones with fewer hours
+func listedEstimates(count int) []*domain.Estimate {
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    estimates := make([]*domain.Estimate, count)
+    for i := range estimates {
+        estimates[i] = &domain.Estimate{
+            ID:         fmt.Sprintf("estimate-%d", i+1),
+            TotalHours: float64(100 * (count - i)),
+            CreatedAt:  start.Add(time.Duration(i) * time.Hour),
+        }
+    }
+    return estimates
+}
+
+// itemIDs returns the IDs of the estimates of a page
+func itemIDs(page *EstimatePage) []string {
+    ids := []string{}
+    for _, estimate := range page.Items {
+        ids = append(ids, estimate.ID)
+    }
+    return ids
+}
+
+func TestPaginateEstimates(t *testing.T) {
+    tests := []struct {
+        name  string
+        query EstimateListQuery
+        want  []string
+    }{
+        {"first page", EstimateListQuery{Page: 1, PageSize: 2}, []string{"estimate-1", "estimate-2"}},
+        {"last page", EstimateListQuery{Page: 3, PageSize: 2}, []string{"estimate-5"}},
+        {"out of range", EstimateListQuery{Page: 4, PageSize: 2}, []string{}},
+        {"by total hours", EstimateListQuery{PageSize: 2, Sort: "totalHours"}, []string{"estimate-5", "estimate-4"}},
+        {"newest first", EstimateListQuery{PageSize: 2, Sort: "-createdAt"}, []string{"estimate-5", "estimate-4"}},
+    }
+    for _, tt := range tests {
+        page, err := paginateEstimates(listedEstimates(5), tt.query)
+        if err != nil {
+            t.Fatalf("%s: %v", tt.name, err)
+        }
+        if ids := itemIDs(page); !reflect.DeepEqual(ids, tt.want) {
+            t.Errorf("%s: items = %v, want %v", tt.name, ids, tt.want)
+        }
+        if page.Total != 5 {
+            t.Errorf("%s: Total = %d, want 5", tt.name, page.Total)
+        }
+    }
+}
+
+func TestPaginateEstimatesDefaultsAndLimits(t *testing.T) {
+    page, err := paginateEstimates(listedEstimates(3), EstimateListQuery{})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if page.Page != 1 || page.PageSize != DefaultPageSize || len(page.Items) != 3 {
+        t.Errorf("page %d of size %d with %d items, want page 1 of size %d with all 3", page.Page, page.PageSize, len(page.Items), DefaultPageSize)
+    }
+
+    page, err = paginateEstimates(listedEstimates(3), EstimateListQuery{PageSize: MaxPageSize + 1})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if page.PageSize != MaxPageSize {
+        t.Errorf("PageSize = %d, want the cap %d", page.PageSize, MaxPageSize)
+    }
+
+    for _, query := range []EstimateListQuery{{Page: -1}, {PageSize: -1}, {Sort: "name"}} {
+        if _, err := paginateEstimates(listedEstimates(3), query); !errors.Is(err, ErrValidation) {
+            t.Errorf("query %+v: got %v, want ErrValidation", query, err)
+        }
+    }
+}
+
+func TestListEstimatesFiltersByStatus(t *testing.T) {
+    f := newEstimateFixture(t)
+    input := CreateProjectEstimateInput{Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)}}
+    draft := f.create(t, input)
+    completed := f.create(t, input)
+    approved := f.create(t, input)
+    for _, step := range []struct {
+        id     string
+        status domain.EstimateStatus
+    }{
+        {completed.ID, domain.EstimateStatusCompleted},
+        {approved.ID, domain.EstimateStatusCompleted},
+        {approved.ID, domain.EstimateStatusApproved},
+    } {
+        if _, err := f.uc.TransitionStatus(step.id, step.status, "tester"); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    tests := []struct {
+        statuses []domain.EstimateStatus
+        want     []string
+    }{
+        {[]domain.EstimateStatus{domain.EstimateStatusApproved}, []string{approved.ID}},
+        {[]domain.EstimateStatus{domain.EstimateStatusDraft, domain.EstimateStatusCompleted}, []string{draft.ID, completed.ID}},
+        {nil, []string{draft.ID, completed.ID, approved.ID}},
+    }
+    for _, tt := range tests {
+        page, err := f.uc.ListEstimates(EstimateListQuery{Statuses: tt.statuses})
+        if err != nil {
+            t.Fatal(err)
+        }
+        if ids := itemIDs(page); !reflect.DeepEqual(ids, tt.want) {
+            t.Errorf("statuses %v: items = %v, want %v", tt.statuses, ids, tt.want)
+        }
+    }
+
+    if _, err := f.uc.ListEstimates(EstimateListQuery{Statuses: []domain.EstimateStatus{"archived"}}); !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want ErrValidation for an unknown status", err)
+    }
+}
+
+func TestTransitionStatus(t *testing.T) {
+    f := newEstimateFixture(t)
+    estimate := f.create(t, CreateProjectEstimateInput{Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)}})
+
+    for _, status := range []domain.EstimateStatus{
+        domain.EstimateStatusCompleted,
+        domain.EstimateStatusDraft,
+        domain.EstimateStatusCompleted,
+        domain.EstimateStatusApproved,
+    } {
+        updated, err := f.uc.TransitionStatus(estimate.ID, status, "tester")
+        if err != nil {
+            t.Fatalf("to %s: %v", status, err)
+        }
+        if updated.Status != status {
+            t.Errorf("Status = %s, want %s", updated.Status, status)
+        }
+    }
+
+    // An approved estimate is final
+    if _, err := f.uc.TransitionStatus(estimate.ID, domain.EstimateStatusDraft, "tester"); !errors.Is(err, ErrValidation) {
+        t.Errorf("approved -> draft: got %v, want ErrValidation", err)
+    }
+    stored, err := f.uc.GetEstimate(estimate.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if stored.Status != domain.EstimateStatusApproved {
+        t.Errorf("stored Status = %s, want it to stay approved", stored.Status)
+    }
+}
+
+func TestTransitionStatusRequiresHoursForApproval(t *testing.T) {
+    f := newEstimateFixture(t)
+    empty := &domain.Estimate{ProjectID: "project-1", Status: domain.EstimateStatusCompleted}
+    if err := f.estimates.Save(empty); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := f.uc.TransitionStatus(empty.ID, domain.EstimateStatusApproved, "tester"); !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want ErrValidation approving an estimate without hours", err)
+    }
+    if _, err := f.uc.TransitionStatus(empty.ID, "archived", "tester"); !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want ErrValidation for an unknown status", err)
+    }
+    if _, err := f.uc.TransitionStatus("unknown", domain.EstimateStatusCompleted, "tester"); !errors.Is(err, ErrNotFound) {
+        t.Errorf("got %v, want ErrNotFound for an unknown estimate", err)
+    }
+}
+
+func TestCreateEstimateReconcilesUseCasePoints(t *testing.T) {
+    f := newEstimateFixture(t)
+    estimate := f.create(t, CreateProjectEstimateInput{
+        Tasks: []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+        UCPData: &UCPInput{
+            Actors:   map[string]int{"complex": 2},
+            UseCases: map[string]int{"average": 10},
+        },
+    })
+
+    if estimate.ActivityResult == nil || estimate.UCPResult == nil {
+        t.Fatalf("ActivityResult = %v, UCPResult = %v, want both", estimate.ActivityResult, estimate.UCPResult)
+    }
+    low := math.Min(estimate.ActivityResult.TotalHours, estimate.UCPResult.TotalHours)
+    high := math.Max(estimate.ActivityResult.TotalHours, estimate.UCPResult.TotalHours)
+    if estimate.TotalHours <= low || estimate.TotalHours >= high {
+        t.Errorf("TotalHours = %v, want between the method results %v and %v", estimate.TotalHours, low, high)
+    }
+}
+
+func TestCloneEstimateIsIndependent(t *testing.T) {
+    f := newEstimateFixture(t)
+    original := f.create(t, CreateProjectEstimateInput{
+        ProjectName: "Original",
+        Tasks: []TaskInput{
+            f.task(t, domain.ProcessImplementation, 1),
+            f.task(t, domain.ProcessImplementation, 2),
+        },
+        GlobalFactors: []string{f.factorID(t, "セキュリティ要件厳格")},
+    })
+    before, err := f.uc.GetEstimate(original.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    clone, err := f.uc.CloneEstimate(CloneEstimateInput{ID: original.ID, ProjectName: "Clone"})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if clone.ID == original.ID || clone.ProjectName != "Clone" || clone.Status != domain.EstimateStatusDraft {
+        t.Fatalf("clone %s %q in %s, want a new draft named Clone", clone.ID, clone.ProjectName, clone.Status)
+    }
+    originalTasks, cloneTasks := before.ProcessEstimates[0].Tasks, clone.ProcessEstimates[0].Tasks
+    if len(cloneTasks) != len(originalTasks) || cloneTasks[0].ID == originalTasks[0].ID {
+        t.Fatalf("clone tasks %v, want copies of %v with new IDs", cloneTasks, originalTasks)
+    }
+
+    // Mutate the clone both in memory and in storage
+    clone.ProcessEstimates[0].Tasks[0].Name = "renamed"
+    clone.ProcessEstimates[0].Tasks[0].Scale = 99
+    clone.ProcessEstimates[0].Tasks = append(clone.ProcessEstimates[0].Tasks, domain.Task{Name: "extra"})
+    clone.GlobalFactors[0].Impact = 9
+    if err := f.estimates.Update(clone); err != nil {
+        t.Fatal(err)
+    }
+
+    after, err := f.uc.GetEstimate(original.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !reflect.DeepEqual(after.ProcessEstimates, before.ProcessEstimates) {
+        t.Errorf("original tasks changed by mutating the clone: %v", after.ProcessEstimates[0].Tasks)
+    }
+    if !reflect.DeepEqual(after.GlobalFactors, before.GlobalFactors) {
+        t.Errorf("original global factors changed by mutating the clone: %v", after.GlobalFactors)
+    }
+}
+
+func TestCreateEstimateChecksActivityMapping(t *testing.T) {
+    f := newEstimateFixture(t)
+    valid := f.task(t, domain.ProcessImplementation, 1)
+    foreign := f.task(t, domain.ProcessImplementation, 1)
+    foreign.Name = "misplaced"
+    foreign.ActivityID = f.process(t, domain.ProcessTesting).Activities[0].ID
+
+    estimate := f.create(t, CreateProjectEstimateInput{Tasks: []TaskInput{valid}})
+    if estimate.TotalHours <= 0 {
+        t.Errorf("TotalHours = %v, want hours from the mapped activity", estimate.TotalHours)
+    }
+
+    _, err := f.uc.CreateEstimate(CreateProjectEstimateInput{
+        ProjectID: "project-1",
+        Tasks:     []TaskInput{valid, foreign},
+    })
+    if !errors.Is(err, ErrValidation) {
+        t.Fatalf("got %v, want ErrValidation", err)
+    }
+    if !strings.Contains(err.Error(), "misplaced") || !strings.Contains(err.Error(), foreign.ActivityID) {
+        t.Errorf("error %q does not name the task and its activity", err)
+    }
+}
+
+func TestSearchEstimates(t *testing.T) {
+    f := newEstimateFixture(t)
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    for i, estimate := range []*domain.Estimate{
+        {ID: "shop", ProjectName: "Online Shop", Notes: "Includes the NIGHTLY batch import"},
+        {ID: "portal", ProjectName: "Customer portal", Notes: "Single sign-on"},
+        {ID: "batch", ProjectName: "Batch Redesign", Notes: ""},
+    } {
+        estimate.CreatedAt = start.Add(time.Duration(i) * time.Hour)
+        if err := f.estimates.Save(estimate); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    tests := []struct {
+        text string
+        want []string
+    }{
+        {"nightly", []string{"shop"}},               // Notes, different case
+        {"SIGN-ON", []string{"portal"}},             // Notes, different case
+        {"online shop", []string{"shop"}},           // Project name, different case
+        {"batch", []string{"batch", "shop"}},        // Name of one, notes of the other, newest first
+        {"  ", []string{"batch", "portal", "shop"}}, // Empty query lists the most recent
+        {"missing", []string{}},
+    }
+    for _, tt := range tests {
+        page, err := f.uc.SearchEstimates(tt.text, EstimateListQuery{})
+        if err != nil {
+            t.Fatal(err)
+        }
+        if got := itemIDs(page); !reflect.DeepEqual(got, tt.want) || page.Total != len(tt.want) {
+            t.Errorf("search %q: got %v of %d, want %v", tt.text, got, page.Total, tt.want)
+        }
+    }
+
+    page, err := f.uc.SearchEstimates("", EstimateListQuery{PageSize: 2, Page: 2})
+    if err != nil {
+        t.Fatal(err)
+    }
+    if got := itemIDs(page); !reflect.DeepEqual(got, []string{"shop"}) || page.Total != 3 {
+        t.Errorf("second page: got %v of %d, want [shop] of 3", got, page.Total)
+    }
+}
+
+func TestCreateEstimateRejectsInvalidReuseComponents(t *testing.T) {
+    f := newEstimateFixture(t)
+    scaleFactors, costDrivers := nominalRatings()
+
+    _, err := f.uc.CreateEstimate(CreateProjectEstimateInput{
+        ProjectID: "project-1",
+        Tasks:     []TaskInput{f.task(t, domain.ProcessImplementation, 1)},
+        COCOMOData: &COCOMOInput{
+            ModelID:         domain.ModelPostArchitectureID,
+            KSLOC:           1,
+            ScaleFactors:    scaleFactors,
+            CostDrivers:     costDrivers,
+            ReuseComponents: []domain.ReuseComponent{{AdaptedSize: 10, DM: -100}},
+        },
+    })
+    if !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want ErrValidation for a negative DM", err)
+    }
+}
@@ -1,13 +1,13 @@
 package usecase
 
 import (
-    "errors"
     "estimate-backend/internal/domain"
 )
 
 // FactorUseCase handles the business logic for estimation factors
 type FactorUseCase struct {
     factorRepo domain.FactorRepository
+    audit      *AuditUseCase // Optional, see SetAuditLog
 }
 
 // NewFactorUseCase creates a new FactorUseCase
@@ -17,6 +17,11 @@ func NewFactorUseCase(factorRepo domain.FactorRepository) *FactorUseCase {
     }
 }
 
+// SetAuditLog enables recording factor changes in the audit trail
+func (uc *FactorUseCase) SetAuditLog(audit *AuditUseCase) {
+    uc.audit = audit
+}
+
 // InitializeDefaultFactors creates the default set of estimation factors
 func (uc *FactorUseCase) InitializeDefaultFactors() error {
     defaultFactors := []domain.Factor{
@@ -116,16 +121,14 @@ type CreateFactorInput struct {
     Name        string
     Description string
     Impact      float64
+    Actor       string // Recorded in the audit trail
 }
 
 // CreateFactor creates a new estimation factor
 func (uc *FactorUseCase) CreateFactor(input CreateFactorInput) (*domain.Factor, error) {
     // Validate input
-    if input.Name == "" {
-        return nil, errors.New("factor name is required")
-    }
-    if input.Impact <= 0 {
-        return nil, errors.New("impact must be greater than 0")
+    if err := validateFactor(input.Name, input.Impact); err != nil {
+        return nil, err
     }
 
     factor := &domain.Factor{
@@ -138,6 +141,7 @@ func (uc *FactorUseCase) CreateFactor(input CreateFactorInput) (*domain.Factor,
     if err := uc.factorRepo.Save(factor); err != nil {
         return nil, err
     }
+    uc.audit.record(input.Actor, domain.AuditEntityFactor, factor.ID, domain.AuditActionCreated, nil, factorSummary(factor))
 
     return factor, nil
 }
@@ -149,14 +153,21 @@ type UpdateFactorInput struct {
     Name        string
     Description string
     Impact      float64
+    Actor       string // Recorded in the audit trail
 }
 
 // UpdateFactor updates an existing factor
 func (uc *FactorUseCase) UpdateFactor(input UpdateFactorInput) (*domain.Factor, error) {
+    // Validate input
+    if err := validateFactor(input.Name, input.Impact); err != nil {
+        return nil, err
+    }
+
     factor, err := uc.factorRepo.FindByID(input.ID)
     if err != nil {
         return nil, err
     }
+    before := factorSummary(factor)
 
     factor.Type = input.Type
     factor.Name = input.Name
@@ -166,6 +177,7 @@ func (uc *FactorUseCase) UpdateFactor(input UpdateFactorInput) (*domain.Factor,
     if err := uc.factorRepo.Update(factor); err != nil {
         return nil, err
     }
+    uc.audit.record(input.Actor, domain.AuditEntityFactor, factor.ID, domain.AuditActionUpdated, before, factorSummary(factor))
 
     return factor, nil
 }
@@ -181,6 +193,25 @@ func (uc *FactorUseCase) GetAllFactors() ([]*domain.Factor, error) {
 }
 
 // DeleteFactor deletes a factor by ID
-func (uc *FactorUseCase) DeleteFactor(id string) error {
-    return uc.factorRepo.Delete(id)
+func (uc *FactorUseCase) DeleteFactor(id, actor string) error {
+    factor, err := uc.factorRepo.FindByID(id)
+    if err != nil {
+        return err
+    }
+    if err := uc.factorRepo.Delete(id); err != nil {
+        return err
+    }
+    uc.audit.record(actor, domain.AuditEntityFactor, id, domain.AuditActionDeleted, factorSummary(factor), nil)
+    return nil
+}
+
+// validateFactor validates the editable fields of a factor
+func validateFactor(name string, impact float64) error {
+    if name == "" {
+        return newValidationError("factor name is required")
+    }
+    if impact <= 0 {
+        return newValidationError("impact must be greater than 0")
+    }
+    return nil
 }
\ No newline at end of file
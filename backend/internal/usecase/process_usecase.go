@@ -2,12 +2,17 @@ package usecase
 
 import (
     "errors"
+    "fmt"
+    "math"
+    "strings"
+
     "estimate-backend/internal/domain"
 )
 
 // ProcessUseCase handles the business logic for development processes
 type ProcessUseCase struct {
     processRepo domain.ProcessRepository
+    audit       *AuditUseCase // Optional, see SetAuditLog
 }
 
 // NewProcessUseCase creates a new ProcessUseCase
@@ -17,6 +22,11 @@ func NewProcessUseCase(processRepo domain.ProcessRepository) *ProcessUseCase {
     }
 }
 
+// SetAuditLog enables recording process and activity changes in the audit trail
+func (uc *ProcessUseCase) SetAuditLog(audit *AuditUseCase) {
+    uc.audit = audit
+}
+
 // InitializeDefaultProcesses creates the default set of development processes
 func (uc *ProcessUseCase) InitializeDefaultProcesses() error {
     defaultProcesses := []domain.Process{
@@ -228,16 +238,46 @@ func (uc *ProcessUseCase) GetAllProcesses() ([]*domain.Process, error) {
     return uc.processRepo.FindAll()
 }
 
-// UpdateProcess updates an existing process
-func (uc *ProcessUseCase) UpdateProcess(process *domain.Process) error {
+// UpdateProcess updates the editable fields of an existing process: name, description, complexity
+// curve and, unless nil, activities. The category and order are kept, the order changing only
+// through ReorderProcesses. process is updated to the saved process.
+func (uc *ProcessUseCase) UpdateProcess(process *domain.Process, actor string) error {
     if process.ID == "" {
         return errors.New("process ID is required")
     }
-    return uc.processRepo.Update(process)
+    if !process.ComplexityCurve.IsValid() {
+        return newValidationError(fmt.Sprintf("unknown complexity curve %q", process.ComplexityCurve))
+    }
+    for _, activity := range process.Activities {
+        if !activity.ComplexityCurve.IsValid() {
+            return newValidationError(fmt.Sprintf("unknown complexity curve %q", activity.ComplexityCurve))
+        }
+    }
+    current, err := uc.processRepo.FindByID(process.ID)
+    if err != nil {
+        return err
+    }
+
+    updated := *current
+    updated.Name = process.Name
+    updated.Description = process.Description
+    updated.ComplexityCurve = process.ComplexityCurve
+    if process.Activities != nil {
+        updated.Activities = process.Activities
+    }
+    if err := uc.processRepo.Update(&updated); err != nil {
+        return err
+    }
+    *process = updated
+    uc.audit.record(actor, domain.AuditEntityProcess, process.ID, domain.AuditActionUpdated, processSummary(current), processSummary(process))
+    return nil
 }
 
 // UpdateActivity updates an activity within a process
-func (uc *ProcessUseCase) UpdateActivity(processID string, activity domain.Activity) error {
+func (uc *ProcessUseCase) UpdateActivity(processID string, activity domain.Activity, actor string) error {
+    if !activity.ComplexityCurve.IsValid() {
+        return newValidationError(fmt.Sprintf("unknown complexity curve %q", activity.ComplexityCurve))
+    }
     process, err := uc.processRepo.FindByID(processID)
     if err != nil {
         return err
@@ -245,8 +285,10 @@ func (uc *ProcessUseCase) UpdateActivity(processID string, activity domain.Activ
 
     // Find and update the activity
     found := false
+    var before domain.Activity
     for i, act := range process.Activities {
         if act.ID == activity.ID {
+            before = act
             process.Activities[i] = activity
             found = true
             break
@@ -257,5 +299,276 @@ func (uc *ProcessUseCase) UpdateActivity(processID string, activity domain.Activ
         return errors.New("activity not found in process")
     }
 
-    return uc.processRepo.Update(process)
-}
\ No newline at end of file
+    if err := uc.processRepo.Update(process); err != nil {
+        return err
+    }
+    uc.audit.record(actor, domain.AuditEntityActivity, activity.ID, domain.AuditActionUpdated, activitySummary(processID, before), activitySummary(processID, activity))
+    return nil
+}
+// ActivityImportRow is a single activity row of a bulk import, matched by process and activity name
+type ActivityImportRow struct {
+    ProcessName  string
+    ActivityName string
+    BaseHours    float64
+    Deliverables []string
+}
+
+// ActivityImportResult reports the outcome of a bulk import
+type ActivityImportResult struct {
+    Updated   int      `json:"updated"`
+    Unchanged int      `json:"unchanged"`
+    Unknown   []string `json:"unknown"`
+}
+
+// ImportActivities updates the base hours and deliverables of matching activities.
+// Rows naming an unknown process or activity are reported instead of failing the import.
+// Every row is validated before any is applied, so an invalid row leaves all activities unchanged.
+func (uc *ProcessUseCase) ImportActivities(rows []ActivityImportRow, actor string) (*ActivityImportResult, error) {
+    for _, row := range rows {
+        if math.IsNaN(row.BaseHours) || math.IsInf(row.BaseHours, 0) || row.BaseHours < 0 {
+            return nil, newValidationError(fmt.Sprintf("activity %s/%s: base hours must be a non-negative number", row.ProcessName, row.ActivityName))
+        }
+    }
+
+    processes, err := uc.processRepo.FindAll()
+    if err != nil {
+        return nil, err
+    }
+    byName := make(map[string]*domain.Process, len(processes))
+    for _, process := range processes {
+        byName[process.Name] = process
+    }
+
+    result := &ActivityImportResult{Unknown: []string{}}
+    for _, row := range rows {
+        process, ok := byName[row.ProcessName]
+        if !ok {
+            result.Unknown = append(result.Unknown, row.ProcessName+"/"+row.ActivityName)
+            continue
+        }
+
+        var activity *domain.Activity
+        for i := range process.Activities {
+            if process.Activities[i].Name == row.ActivityName {
+                activity = &process.Activities[i]
+                break
+            }
+        }
+        if activity == nil {
+            result.Unknown = append(result.Unknown, row.ProcessName+"/"+row.ActivityName)
+            continue
+        }
+
+        if activity.BaseHours == row.BaseHours && equalStrings(activity.Deliverables, row.Deliverables) {
+            result.Unchanged++
+            continue
+        }
+
+        activity.BaseHours = row.BaseHours
+        activity.Deliverables = row.Deliverables
+        if err := uc.UpdateActivity(process.ID, *activity, actor); err != nil {
+            return nil, err
+        }
+        result.Updated++
+    }
+
+    return result, nil
+}
+
+// ReorderProcesses sets the display order of the processes to the order of ids, which must list every
+// process exactly once. The processes are saved all-or-nothing: those already saved are restored if
+// saving one fails.
+func (uc *ProcessUseCase) ReorderProcesses(ids []string, actor string) ([]*domain.Process, error) {
+    processes, err := uc.processRepo.FindAll()
+    if err != nil {
+        return nil, err
+    }
+
+    byID := make(map[string]*domain.Process, len(processes))
+    for _, process := range processes {
+        byID[process.ID] = process
+    }
+    seen := make(map[string]bool, len(ids))
+    for _, id := range ids {
+        if _, ok := byID[id]; !ok {
+            return nil, newValidationError(fmt.Sprintf("process %s not found", id))
+        }
+        if seen[id] {
+            return nil, newValidationError(fmt.Sprintf("process %s is listed more than once", id))
+        }
+        seen[id] = true
+    }
+    var missing []string
+    for _, process := range processes {
+        if !seen[process.ID] {
+            missing = append(missing, process.ID)
+        }
+    }
+    if len(missing) > 0 {
+        return nil, newValidationError(fmt.Sprintf("missing processes: %s", strings.Join(missing, ", ")))
+    }
+
+    // Save the processes whose order changes, restoring the saved ones if one fails
+    var saved []*domain.Process
+    var before []map[string]interface{}
+    var previousOrders []int
+    reordered := make([]*domain.Process, len(ids))
+    for i, id := range ids {
+        process := byID[id]
+        reordered[i] = process
+        if process.Order == i+1 {
+            continue
+        }
+        summary := processSummary(process)
+        previous := process.Order
+        process.Order = i + 1
+        if err := uc.processRepo.Update(process); err != nil {
+            for j, p := range saved {
+                p.Order = previousOrders[j]
+                if restoreErr := uc.processRepo.Update(p); restoreErr != nil {
+                    err = fmt.Errorf("%w; restoring process %s: %v", err, p.ID, restoreErr)
+                }
+            }
+            return nil, err
+        }
+        saved = append(saved, process)
+        before = append(before, summary)
+        previousOrders = append(previousOrders, previous)
+    }
+
+    for i, process := range saved {
+        uc.audit.record(actor, domain.AuditEntityProcess, process.ID, domain.AuditActionUpdated, before[i], processSummary(process))
+    }
+    return reordered, nil
+}
+
+// ActivityHoursUpdate sets the base hours of one activity in a bulk update
+type ActivityHoursUpdate struct {
+    ProcessID  string  `json:"processId"`
+    ActivityID string  `json:"activityId"`
+    BaseHours  float64 `json:"baseHours"`
+}
+
+// ActivityUpdateFailure reports why one update of a bulk update was rejected
+type ActivityUpdateFailure struct {
+    Index   int    // Position of the update in the request
+    Field   string // processId, activityId or baseHours
+    Message string
+}
+
+// BulkUpdateError is returned when updates of a bulk update fail validation; none of the updates is applied.
+// It matches ErrValidation.
+type BulkUpdateError struct {
+    Failures []ActivityUpdateFailure
+}
+
+func (e *BulkUpdateError) Error() string {
+    messages := make([]string, len(e.Failures))
+    for i, f := range e.Failures {
+        messages[i] = fmt.Sprintf("update %d: %s", f.Index, f.Message)
+    }
+    return strings.Join(messages, "; ")
+}
+
+func (e *BulkUpdateError) Is(target error) bool {
+    return target == ErrValidation
+}
+
+// ActivityBulkUpdateResult reports the outcome of a bulk update
+type ActivityBulkUpdateResult struct {
+    Updated   int `json:"updated"`
+    Unchanged int `json:"unchanged"`
+}
+
+// BulkUpdateActivityHours sets the base hours of several activities all-or-nothing: every update is
+// validated before any process is saved, and processes already saved are restored if saving fails.
+func (uc *ProcessUseCase) BulkUpdateActivityHours(updates []ActivityHoursUpdate, actor string) (*ActivityBulkUpdateResult, error) {
+    if len(updates) == 0 {
+        return nil, newValidationError("at least one update is required")
+    }
+
+    // Apply the updates to copies of the processes, collecting every failure
+    processes := make(map[string]*domain.Process)
+    originals := make(map[string]*domain.Process)
+    var order []string
+    var failures []ActivityUpdateFailure
+    befores := make([]domain.Activity, len(updates))
+    for i, update := range updates {
+        if update.BaseHours < 0 {
+            failures = append(failures, ActivityUpdateFailure{Index: i, Field: "baseHours", Message: "base hours must not be negative"})
+            continue
+        }
+
+        process, ok := processes[update.ProcessID]
+        if !ok {
+            found, err := uc.processRepo.FindByID(update.ProcessID)
+            if errors.Is(err, domain.ErrNotFound) {
+                failures = append(failures, ActivityUpdateFailure{Index: i, Field: "processId", Message: fmt.Sprintf("process %s not found", update.ProcessID)})
+                continue
+            } else if err != nil {
+                return nil, err
+            }
+            // A second copy keeps the stored state for the audit trail and for restoring
+            original, err := uc.processRepo.FindByID(update.ProcessID)
+            if err != nil {
+                return nil, err
+            }
+            process = found
+            processes[update.ProcessID] = process
+            originals[update.ProcessID] = original
+            order = append(order, update.ProcessID)
+        }
+
+        activity := process.Activity(update.ActivityID)
+        if activity == nil {
+            failures = append(failures, ActivityUpdateFailure{Index: i, Field: "activityId", Message: fmt.Sprintf("activity %s is not part of process %s", update.ActivityID, update.ProcessID)})
+            continue
+        }
+        // The same activity may be updated twice, so the stored activity is what it changes from
+        befores[i] = *originals[update.ProcessID].Activity(update.ActivityID)
+        activity.BaseHours = update.BaseHours
+    }
+    if len(failures) > 0 {
+        return nil, &BulkUpdateError{Failures: failures}
+    }
+
+    // Save the changed processes, restoring the saved ones if one fails
+    var saved []string
+    for _, id := range order {
+        if err := uc.processRepo.Update(processes[id]); err != nil {
+            for _, savedID := range saved {
+                if restoreErr := uc.processRepo.Update(originals[savedID]); restoreErr != nil {
+                    err = fmt.Errorf("%w; restoring process %s: %v", err, savedID, restoreErr)
+                }
+            }
+            return nil, err
+        }
+        saved = append(saved, id)
+    }
+
+    result := &ActivityBulkUpdateResult{}
+    for i, update := range updates {
+        if befores[i].BaseHours == update.BaseHours {
+            result.Unchanged++
+            continue
+        }
+        after := befores[i]
+        after.BaseHours = update.BaseHours
+        uc.audit.record(actor, domain.AuditEntityActivity, update.ActivityID, domain.AuditActionUpdated, activitySummary(update.ProcessID, befores[i]), activitySummary(update.ProcessID, after))
+        result.Updated++
+    }
+    return result, nil
+}
+
+// equalStrings reports whether two string slices have the same elements in order
+func equalStrings(a, b []string) bool {
+    if len(a) != len(b) {
+        return false
+    }
+    for i := range a {
+        if a[i] != b[i] {
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+    "errors"
+    "math"
+    "strings"
+    "testing"
+
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/infrastructure/memory"
+)
+
+// failingProcessRepository fails updates of one process, and restores of the others when failRestore is set
+type failingProcessRepository struct {
+    domain.ProcessRepository
+    failID      string
+    failRestore bool
+    updates     []string // IDs of the processes updated, in order
+}
+
+var errProcessStore = errors.New("process store unavailable")
+
+func (r *failingProcessRepository) Update(process *domain.Process) error {
+    r.updates = append(r.updates, process.ID)
+    if process.ID == r.failID {
+        return errProcessStore
+    }
+    if r.failRestore && len(r.updates) > 1 {
+        return errProcessStore
+    }
+    return r.ProcessRepository.Update(process)
+}
+
+// newBulkUpdateFixture returns a process use case over the default processes with an audit log
+func newBulkUpdateFixture(t *testing.T, repo domain.ProcessRepository) (*ProcessUseCase, *AuditUseCase) {
+    t.Helper()
+    uc := NewProcessUseCase(repo)
+    if err := uc.InitializeDefaultProcesses(); err != nil {
+        t.Fatal(err)
+    }
+    audit := NewAuditUseCase(memory.NewInMemoryAuditRepository())
+    uc.SetAuditLog(audit)
+    return uc, audit
+}
+
+// categoryProcess returns the stored process of a category
+func categoryProcess(t *testing.T, repo domain.ProcessRepository, category domain.ProcessCategory) *domain.Process {
+    t.Helper()
+    process, err := repo.FindByCategory(category)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return process
+}
+
+func TestBulkUpdateActivityHours(t *testing.T) {
+    repo := memory.NewInMemoryProcessRepository()
+    uc, _ := newBulkUpdateFixture(t, repo)
+    design := categoryProcess(t, repo, domain.ProcessBasicDesign)
+    implementation := categoryProcess(t, repo, domain.ProcessImplementation)
+
+    result, err := uc.BulkUpdateActivityHours([]ActivityHoursUpdate{
+        {ProcessID: design.ID, ActivityID: design.Activities[0].ID, BaseHours: 12},
+        {ProcessID: implementation.ID, ActivityID: implementation.Activities[0].ID, BaseHours: 34},
+        {ProcessID: implementation.ID, ActivityID: implementation.Activities[1].ID, BaseHours: implementation.Activities[1].BaseHours},
+    }, "tester")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if result.Updated != 2 || result.Unchanged != 1 {
+        t.Errorf("result %+v, want 2 updated and 1 unchanged", result)
+    }
+    if hours := categoryProcess(t, repo, domain.ProcessBasicDesign).Activities[0].BaseHours; hours != 12 {
+        t.Errorf("design activity has %v hours, want 12", hours)
+    }
+    if hours := categoryProcess(t, repo, domain.ProcessImplementation).Activities[0].BaseHours; hours != 34 {
+        t.Errorf("implementation activity has %v hours, want 34", hours)
+    }
+}
+
+func TestBulkUpdateActivityHoursRollsBackInvalidBatch(t *testing.T) {
+    repo := memory.NewInMemoryProcessRepository()
+    uc, _ := newBulkUpdateFixture(t, repo)
+    design := categoryProcess(t, repo, domain.ProcessBasicDesign)
+
+    _, err := uc.BulkUpdateActivityHours([]ActivityHoursUpdate{
+        {ProcessID: design.ID, ActivityID: design.Activities[0].ID, BaseHours: 12},
+        {ProcessID: design.ID, ActivityID: "missing", BaseHours: 5},
+        {ProcessID: design.ID, ActivityID: design.Activities[1].ID, BaseHours: -1},
+    }, "tester")
+
+    var bulkErr *BulkUpdateError
+    if !errors.As(err, &bulkErr) || !errors.Is(err, ErrValidation) {
+        t.Fatalf("got %v, want a BulkUpdateError matching ErrValidation", err)
+    }
+    if len(bulkErr.Failures) != 2 || bulkErr.Failures[0].Index != 1 || bulkErr.Failures[0].Field != "activityId" ||
+        bulkErr.Failures[1].Index != 2 || bulkErr.Failures[1].Field != "baseHours" {
+        t.Errorf("failures %+v, want the activity ID of update 1 and the hours of update 2", bulkErr.Failures)
+    }
+    if stored := categoryProcess(t, repo, domain.ProcessBasicDesign); stored.Activities[0].BaseHours != design.Activities[0].BaseHours {
+        t.Errorf("valid update applied: %v hours, want the original %v", stored.Activities[0].BaseHours, design.Activities[0].BaseHours)
+    }
+}
+
+func TestBulkUpdateActivityHoursRestoresOnSaveFailure(t *testing.T) {
+    memoryRepo := memory.NewInMemoryProcessRepository()
+    repo := &failingProcessRepository{ProcessRepository: memoryRepo}
+    uc, _ := newBulkUpdateFixture(t, repo)
+    design := categoryProcess(t, repo, domain.ProcessBasicDesign)
+    implementation := categoryProcess(t, repo, domain.ProcessImplementation)
+    repo.failID = implementation.ID
+
+    _, err := uc.BulkUpdateActivityHours([]ActivityHoursUpdate{
+        {ProcessID: design.ID, ActivityID: design.Activities[0].ID, BaseHours: 12},
+        {ProcessID: implementation.ID, ActivityID: implementation.Activities[0].ID, BaseHours: 34},
+    }, "tester")
+    if !errors.Is(err, errProcessStore) {
+        t.Fatalf("got %v, want the store error", err)
+    }
+
+    // The design process was saved first, then restored
+    if want := []string{design.ID, implementation.ID, design.ID}; len(repo.updates) != len(want) || repo.updates[2] != design.ID {
+        t.Errorf("updates %v, want %v", repo.updates, want)
+    }
+    if hours := categoryProcess(t, memoryRepo, domain.ProcessBasicDesign).Activities[0].BaseHours; hours != design.Activities[0].BaseHours {
+        t.Errorf("design activity has %v hours after the rollback, want the original %v", hours, design.Activities[0].BaseHours)
+    }
+}
+
+func TestBulkUpdateActivityHoursReportsFailedRestore(t *testing.T) {
+    repo := &failingProcessRepository{ProcessRepository: memory.NewInMemoryProcessRepository()}
+    uc, _ := newBulkUpdateFixture(t, repo)
+    design := categoryProcess(t, repo, domain.ProcessBasicDesign)
+    implementation := categoryProcess(t, repo, domain.ProcessImplementation)
+    repo.failID = implementation.ID
+    repo.failRestore = true
+
+    _, err := uc.BulkUpdateActivityHours([]ActivityHoursUpdate{
+        {ProcessID: design.ID, ActivityID: design.Activities[0].ID, BaseHours: 12},
+        {ProcessID: implementation.ID, ActivityID: implementation.Activities[0].ID, BaseHours: 34},
+    }, "tester")
+    if !errors.Is(err, errProcessStore) {
+        t.Fatalf("got %v, want the store error", err)
+    }
+    if want := "restoring process " + design.ID; !strings.Contains(err.Error(), want) {
+        t.Errorf("error %q does not report the failed restore of %s", err, design.ID)
+    }
+}
+
+func TestBulkUpdateActivityHoursAuditsFromStoredHours(t *testing.T) {
+    repo := memory.NewInMemoryProcessRepository()
+    uc, audit := newBulkUpdateFixture(t, repo)
+    design := categoryProcess(t, repo, domain.ProcessBasicDesign)
+    activity := design.Activities[0]
+
+    // The same activity twice: both entries change from the stored hours, the last update wins
+    _, err := uc.BulkUpdateActivityHours([]ActivityHoursUpdate{
+        {ProcessID: design.ID, ActivityID: activity.ID, BaseHours: 12},
+        {ProcessID: design.ID, ActivityID: activity.ID, BaseHours: 20},
+    }, "tester")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    entries, err := audit.GetAuditLogs(activity.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(entries) != 2 {
+        t.Fatalf("got %d audit entries, want 2", len(entries))
+    }
+    for i, after := range []float64{12, 20} {
+        if before := entries[i].Before["baseHours"]; before != activity.BaseHours {
+            t.Errorf("entry %d: before %v hours, want the stored %v", i, before, activity.BaseHours)
+        }
+        if got := entries[i].After["baseHours"]; got != after {
+            t.Errorf("entry %d: after %v hours, want %v", i, got, after)
+        }
+    }
+    if hours := categoryProcess(t, repo, domain.ProcessBasicDesign).Activities[0].BaseHours; hours != 20 {
+        t.Errorf("activity has %v hours, want the last update's 20", hours)
+    }
+}
+
+// processIDs returns the IDs of the stored processes in display order
+func processIDs(t *testing.T, uc *ProcessUseCase) []string {
+    t.Helper()
+    processes, err := uc.GetAllProcesses()
+    if err != nil {
+        t.Fatal(err)
+    }
+    ids := make([]string, len(processes))
+    for i, process := range processes {
+        ids[i] = process.ID
+    }
+    return ids
+}
+
+func TestReorderProcesses(t *testing.T) {
+    repo := memory.NewInMemoryProcessRepository()
+    uc, _ := newBulkUpdateFixture(t, repo)
+    ids := processIDs(t, uc)
+
+    // Reverse the display order
+    reversed := make([]string, len(ids))
+    for i, id := range ids {
+        reversed[len(ids)-1-i] = id
+    }
+    reordered, err := uc.ReorderProcesses(reversed, "tester")
+    if err != nil {
+        t.Fatal(err)
+    }
+    for i, process := range reordered {
+        if process.ID != reversed[i] || process.Order != i+1 {
+            t.Errorf("position %d: %s with order %d, want %s with order %d", i, process.ID, process.Order, reversed[i], i+1)
+        }
+    }
+    for i, id := range reversed {
+        stored, err := repo.FindByID(id)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if stored.Order != i+1 {
+            t.Errorf("stored process %s has order %d, want %d", id, stored.Order, i+1)
+        }
+    }
+}
+
+func TestReorderProcessesRejectsMissingProcess(t *testing.T) {
+    repo := memory.NewInMemoryProcessRepository()
+    uc, _ := newBulkUpdateFixture(t, repo)
+    ids := processIDs(t, uc)
+
+    // Swap the first two processes but leave out the last one
+    partial := append([]string{ids[1], ids[0]}, ids[2:len(ids)-1]...)
+    _, err := uc.ReorderProcesses(partial, "tester")
+    if !errors.Is(err, ErrValidation) {
+        t.Fatalf("got %v, want ErrValidation", err)
+    }
+    if missing := ids[len(ids)-1]; !strings.Contains(err.Error(), missing) {
+        t.Errorf("error %q does not name the missing process %s", err, missing)
+    }
+    if got := processIDs(t, uc); strings.Join(got, ",") != strings.Join(ids, ",") {
+        t.Errorf("order %v after a rejected reorder, want the original %v", got, ids)
+    }
+}
+
+func TestReorderProcessesRollsBackOnStoreError(t *testing.T) {
+    memoryRepo := memory.NewInMemoryProcessRepository()
+    repo := &failingProcessRepository{ProcessRepository: memoryRepo}
+    uc, _ := newBulkUpdateFixture(t, repo)
+    ids := processIDs(t, uc)
+    repo.failID = ids[0]
+
+    // Swapping the first two saves the second process, then fails on the first
+    swapped := append([]string{ids[1], ids[0]}, ids[2:]...)
+    if _, err := uc.ReorderProcesses(swapped, "tester"); !errors.Is(err, errProcessStore) {
+        t.Fatalf("got %v, want the store error", err)
+    }
+    if got := processIDs(t, uc); strings.Join(got, ",") != strings.Join(ids, ",") {
+        t.Errorf("order %v after the rollback, want the original %v", got, ids)
+    }
+}
+
+func TestReorderProcessesReportsFailedRestore(t *testing.T) {
+    repo := &failingProcessRepository{ProcessRepository: memory.NewInMemoryProcessRepository()}
+    uc, _ := newBulkUpdateFixture(t, repo)
+    ids := processIDs(t, uc)
+    repo.failID = ids[0]
+    repo.failRestore = true
+
+    swapped := append([]string{ids[1], ids[0]}, ids[2:]...)
+    _, err := uc.ReorderProcesses(swapped, "tester")
+    if !errors.Is(err, errProcessStore) {
+        t.Fatalf("got %v, want the store error", err)
+    }
+    if want := "restoring process " + ids[1]; !strings.Contains(err.Error(), want) {
+        t.Errorf("error %q does not report the failed restore of %s", err, ids[1])
+    }
+}
+
+func TestUpdateProcessNameKeepsCategoryAndOrder(t *testing.T) {
+    repo := memory.NewInMemoryProcessRepository()
+    uc, _ := newBulkUpdateFixture(t, repo)
+    design := categoryProcess(t, repo, domain.ProcessBasicDesign)
+
+    // Only the editable fields, as the controller builds the update
+    update := &domain.Process{ID: design.ID, Name: "外部設計", Description: design.Description}
+    if err := uc.UpdateProcess(update, "tester"); err != nil {
+        t.Fatal(err)
+    }
+
+    stored, err := repo.FindByID(design.ID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if stored.Name != "外部設計" {
+        t.Errorf("name %q, want the updated 外部設計", stored.Name)
+    }
+    if stored.Category != design.Category || stored.Order != design.Order {
+        t.Errorf("category %q and order %d, want the original %q and %d", stored.Category, stored.Order, design.Category, design.Order)
+    }
+    if len(stored.Activities) != len(design.Activities) {
+        t.Errorf("got %d activities, want the original %d", len(stored.Activities), len(design.Activities))
+    }
+}
+
+func TestImportActivitiesValidatesEveryRowFirst(t *testing.T) {
+    repo := memory.NewInMemoryProcessRepository()
+    uc, audit := newBulkUpdateFixture(t, repo)
+    design := categoryProcess(t, repo, domain.ProcessBasicDesign)
+    first, second := design.Activities[0], design.Activities[1]
+
+    for _, hours := range []float64{-1, math.NaN(), math.Inf(1)} {
+        // A valid change on the first row, the invalid hours mid-file, another valid row after them
+        _, err := uc.ImportActivities([]ActivityImportRow{
+            {ProcessName: design.Name, ActivityName: first.Name, BaseHours: first.BaseHours + 10},
+            {ProcessName: design.Name, ActivityName: second.Name, BaseHours: hours},
+            {ProcessName: design.Name, ActivityName: first.Name, BaseHours: first.BaseHours + 20},
+        }, "tester")
+        if !errors.Is(err, ErrValidation) {
+            t.Fatalf("hours %v: got %v, want ErrValidation", hours, err)
+        }
+
+        stored := categoryProcess(t, repo, domain.ProcessBasicDesign)
+        if stored.Activities[0].BaseHours != first.BaseHours || stored.Activities[1].BaseHours != second.BaseHours {
+            t.Errorf("hours %v: activities have %v and %v hours, want the original %v and %v",
+                hours, stored.Activities[0].BaseHours, stored.Activities[1].BaseHours, first.BaseHours, second.BaseHours)
+        }
+        if entries, _ := audit.GetAuditLogs(first.ID); len(entries) != 0 {
+            t.Errorf("hours %v: got %d audit entries, want none for a rejected import", hours, len(entries))
+        }
+    }
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+    "time"
+
+    "estimate-backend/internal/domain"
+)
+
+// TaskUseCase handles the business logic for development tasks
+type TaskUseCase struct {
+    taskRepo    domain.TaskRepository
+    processRepo domain.ProcessRepository
+    factorRepo  domain.FactorRepository
+}
+
+// NewTaskUseCase creates a new TaskUseCase
+func NewTaskUseCase(taskRepo domain.TaskRepository, processRepo domain.ProcessRepository, factorRepo domain.FactorRepository) *TaskUseCase {
+    return &TaskUseCase{
+        taskRepo:    taskRepo,
+        processRepo: processRepo,
+        factorRepo:  factorRepo,
+    }
+}
+
+// SaveTaskInput represents input data for creating or updating a task
+type SaveTaskInput struct {
+    ProcessID     string
+    ActivityID    string
+    Name          string
+    Description   string
+    Complexity    int
+    Scale         float64
+    Dependencies  []string
+    CustomFactors []string // Factor IDs
+}
+
+// CreateTask creates a new task
+func (uc *TaskUseCase) CreateTask(input SaveTaskInput) (*domain.Task, error) {
+    task := &domain.Task{}
+    if err := uc.applyInput(task, input); err != nil {
+        return nil, err
+    }
+
+    now := time.Now()
+    task.CreatedAt = now
+    task.UpdatedAt = now
+
+    if err := uc.taskRepo.Save(task); err != nil {
+        return nil, err
+    }
+
+    return task, nil
+}
+
+// UpdateTask updates an existing task
+func (uc *TaskUseCase) UpdateTask(id string, input SaveTaskInput) (*domain.Task, error) {
+    task, err := uc.taskRepo.FindByID(id)
+    if err != nil {
+        return nil, err
+    }
+
+    if err := uc.applyInput(task, input); err != nil {
+        return nil, err
+    }
+    task.UpdatedAt = time.Now()
+
+    if err := uc.taskRepo.Update(task); err != nil {
+        return nil, err
+    }
+
+    return task, nil
+}
+
+// GetTask retrieves a task by ID
+func (uc *TaskUseCase) GetTask(id string) (*domain.Task, error) {
+    return uc.taskRepo.FindByID(id)
+}
+
+// GetAllTasks retrieves all tasks
+func (uc *TaskUseCase) GetAllTasks() ([]*domain.Task, error) {
+    return uc.taskRepo.FindAll()
+}
+
+// GetTasksByProcess retrieves the tasks of a process
+func (uc *TaskUseCase) GetTasksByProcess(processID string) ([]*domain.Task, error) {
+    if _, err := uc.processRepo.FindByID(processID); err != nil {
+        return nil, err
+    }
+    return uc.taskRepo.FindByProcessID(processID)
+}
+
+// DeleteTask deletes a task by ID
+func (uc *TaskUseCase) DeleteTask(id string) error {
+    return uc.taskRepo.Delete(id)
+}
+
+// applyInput validates the input and sets it on the task
+func (uc *TaskUseCase) applyInput(task *domain.Task, input SaveTaskInput) error {
+    // Validate input
+    if input.Name == "" {
+        return newValidationError("task name is required")
+    }
+    if input.Complexity < 1 || input.Complexity > 5 {
+        return newValidationError("complexity must be between 1 and 5")
+    }
+    if input.Scale <= 0 {
+        return newValidationError("scale must be greater than 0")
+    }
+
+    if _, err := uc.processRepo.FindByID(input.ProcessID); err != nil {
+        return err
+    }
+
+    var customFactors []domain.Factor
+    for _, id := range input.CustomFactors {
+        factor, err := uc.factorRepo.FindByID(id)
+        if err != nil {
+            return err
+        }
+        customFactors = append(customFactors, *factor)
+    }
+
+    task.ProcessID = input.ProcessID
+    task.ActivityID = input.ActivityID
+    task.Name = input.Name
+    task.Description = input.Description
+    task.Complexity = input.Complexity
+    task.Scale = input.Scale
+    task.Dependencies = input.Dependencies
+    task.CustomFactors = customFactors
+
+    return nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+    "errors"
+    "testing"
+
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/infrastructure/memory"
+)
+
+// newTestTaskUseCase returns a TaskUseCase with the default processes and factors
+func newTestTaskUseCase(t *testing.T) (*TaskUseCase, *memory.InMemoryProcessRepository) {
+    t.Helper()
+    processes := memory.NewInMemoryProcessRepository()
+    factors := memory.NewInMemoryFactorRepository()
+    if err := NewProcessUseCase(processes).InitializeDefaultProcesses(); err != nil {
+        t.Fatal(err)
+    }
+    if err := NewFactorUseCase(factors).InitializeDefaultFactors(); err != nil {
+        t.Fatal(err)
+    }
+    return NewTaskUseCase(memory.NewInMemoryTaskRepository(), processes, factors), processes
+}
+
+// taskInput returns a valid input for an activity of the default process of a category
+func taskInput(t *testing.T, processes *memory.InMemoryProcessRepository, category domain.ProcessCategory) SaveTaskInput {
+    t.Helper()
+    process, err := processes.FindByCategory(category)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return SaveTaskInput{
+        ProcessID:  process.ID,
+        ActivityID: process.Activities[0].ID,
+        Name:       process.Activities[0].Name,
+        Complexity: 3,
+        Scale:      1,
+    }
+}
+
+func TestCreateTaskComplexityBoundary(t *testing.T) {
+    uc, processes := newTestTaskUseCase(t)
+
+    tests := []struct {
+        complexity int
+        valid      bool
+    }{
+        {0, false},
+        {1, true},
+        {5, true},
+        {6, false},
+    }
+    for _, tt := range tests {
+        input := taskInput(t, processes, domain.ProcessImplementation)
+        input.Complexity = tt.complexity
+
+        _, err := uc.CreateTask(input)
+        if tt.valid && err != nil {
+            t.Errorf("complexity %d: %v, want accepted", tt.complexity, err)
+        }
+        if !tt.valid && !errors.Is(err, ErrValidation) {
+            t.Errorf("complexity %d: got %v, want a validation error", tt.complexity, err)
+        }
+    }
+
+    input := taskInput(t, processes, domain.ProcessImplementation)
+    input.Scale = 0
+    if _, err := uc.CreateTask(input); !errors.Is(err, ErrValidation) {
+        t.Errorf("scale 0: got %v, want a validation error", err)
+    }
+}
+
+func TestGetTasksByProcess(t *testing.T) {
+    uc, processes := newTestTaskUseCase(t)
+
+    implementation := taskInput(t, processes, domain.ProcessImplementation)
+    for i := 0; i < 2; i++ {
+        if _, err := uc.CreateTask(implementation); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if _, err := uc.CreateTask(taskInput(t, processes, domain.ProcessTesting)); err != nil {
+        t.Fatal(err)
+    }
+
+    tasks, err := uc.GetTasksByProcess(implementation.ProcessID)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(tasks) != 2 {
+        t.Fatalf("got %d tasks, want the 2 of the implementation process", len(tasks))
+    }
+    for _, task := range tasks {
+        if task.ProcessID != implementation.ProcessID {
+            t.Errorf("task %s of process %s listed", task.ID, task.ProcessID)
+        }
+    }
+
+    delivery := taskInput(t, processes, domain.ProcessDelivery)
+    if tasks, err := uc.GetTasksByProcess(delivery.ProcessID); err != nil || len(tasks) != 0 {
+        t.Errorf("process without tasks: got %d tasks, %v, want none", len(tasks), err)
+    }
+    if _, err := uc.GetTasksByProcess("unknown"); !errors.Is(err, ErrNotFound) {
+        t.Errorf("unknown process: got %v, want ErrNotFound", err)
+    }
+}
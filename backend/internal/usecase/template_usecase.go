@@ -0,0 +1,157 @@
+package usecase
+
+import (
+    "fmt"
+    "time"
+
+    "estimate-backend/internal/domain"
+)
+
+// TemplateUseCase handles the business logic for estimate templates
+type TemplateUseCase struct {
+    templateRepo    domain.TemplateRepository
+    processRepo     domain.ProcessRepository
+    factorRepo      domain.FactorRepository
+    estimateUseCase *EstimateUseCase
+}
+
+// NewTemplateUseCase creates a new TemplateUseCase
+func NewTemplateUseCase(
+    templateRepo domain.TemplateRepository,
+    processRepo domain.ProcessRepository,
+    factorRepo domain.FactorRepository,
+    estimateUseCase *EstimateUseCase,
+) *TemplateUseCase {
+    return &TemplateUseCase{
+        templateRepo:    templateRepo,
+        processRepo:     processRepo,
+        factorRepo:      factorRepo,
+        estimateUseCase: estimateUseCase,
+    }
+}
+
+// TemplateTaskInput represents a task of a template
+type TemplateTaskInput struct {
+    ProcessID     string   `json:"processId" validate:"required"`
+    ActivityID    string   `json:"activityId"`
+    Name          string   `json:"name"`
+    Description   string   `json:"description"`
+    Complexity    int      `json:"complexity" validate:"omitempty,min=1,max=5"`
+    Scale         float64  `json:"scale" validate:"min=0"`
+    CustomFactors []string `json:"customFactors"` // Factor IDs
+}
+
+// CreateTemplateInput represents input data for creating a template
+type CreateTemplateInput struct {
+    Name          string
+    Description   string
+    Tasks         []TemplateTaskInput
+    GlobalFactors []string // Factor IDs
+    CreatedBy     string
+}
+
+// CreateTemplate validates the process, activity and factor selections and stores the template
+func (uc *TemplateUseCase) CreateTemplate(input CreateTemplateInput) (*domain.EstimateTemplate, error) {
+    if input.Name == "" {
+        return nil, newValidationError("template name is required")
+    }
+    if len(input.Tasks) == 0 {
+        return nil, newValidationError("a template needs at least one task")
+    }
+    if err := uc.checkFactors(input.GlobalFactors); err != nil {
+        return nil, err
+    }
+
+    template := &domain.EstimateTemplate{
+        Name:          input.Name,
+        Description:   input.Description,
+        GlobalFactors: input.GlobalFactors,
+        CreatedBy:     input.CreatedBy,
+        CreatedAt:     time.Now(),
+    }
+    for _, ti := range input.Tasks {
+        process, err := uc.processRepo.FindByID(ti.ProcessID)
+        if err != nil {
+            return nil, err
+        }
+        if ti.ActivityID != "" && !process.HasActivity(ti.ActivityID) {
+            return nil, newValidationError(fmt.Sprintf("activity %s is not part of process %s", ti.ActivityID, ti.ProcessID))
+        }
+        if err := uc.checkFactors(ti.CustomFactors); err != nil {
+            return nil, err
+        }
+        template.Tasks = append(template.Tasks, domain.TemplateTask{
+            ProcessID:     ti.ProcessID,
+            ActivityID:    ti.ActivityID,
+            Name:          ti.Name,
+            Description:   ti.Description,
+            Complexity:    ti.Complexity,
+            Scale:         ti.Scale,
+            CustomFactors: ti.CustomFactors,
+        })
+    }
+
+    if err := uc.templateRepo.Save(template); err != nil {
+        return nil, err
+    }
+    return template, nil
+}
+
+// checkFactors verifies that every factor ID refers to an existing factor
+func (uc *TemplateUseCase) checkFactors(ids []string) error {
+    for _, id := range ids {
+        if _, err := uc.factorRepo.FindByID(id); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// GetTemplates retrieves all templates
+func (uc *TemplateUseCase) GetTemplates() ([]*domain.EstimateTemplate, error) {
+    return uc.templateRepo.FindAll()
+}
+
+// InstantiateTemplateInput represents input data for creating an estimate from a template
+type InstantiateTemplateInput struct {
+    TemplateID  string
+    ProjectID   string
+    ProjectName string
+    CreatedBy   string
+    Actor       string // Recorded in the audit trail, defaults to CreatedBy
+}
+
+// InstantiateTemplate creates and calculates a new draft estimate for a project from a template
+func (uc *TemplateUseCase) InstantiateTemplate(input InstantiateTemplateInput) (*domain.Estimate, error) {
+    if input.ProjectID == "" || input.ProjectName == "" {
+        return nil, newValidationError("project ID and name are required")
+    }
+
+    template, err := uc.templateRepo.FindByID(input.TemplateID)
+    if err != nil {
+        return nil, err
+    }
+
+    tasks := make([]TaskInput, len(template.Tasks))
+    for i, task := range template.Tasks {
+        tasks[i] = TaskInput{
+            ProcessID:     task.ProcessID,
+            ActivityID:    task.ActivityID,
+            Name:          task.Name,
+            Description:   task.Description,
+            Complexity:    task.Complexity,
+            Scale:         task.Scale,
+            CustomFactors: task.CustomFactors,
+        }
+    }
+
+    return uc.estimateUseCase.CreateEstimate(CreateProjectEstimateInput{
+        ProjectID:     input.ProjectID,
+        ProjectName:   input.ProjectName,
+        Tasks:         tasks,
+        GlobalFactors: template.GlobalFactors,
+        CreatedBy:     input.CreatedBy,
+        Notes:         fmt.Sprintf("Created from template %s", template.Name),
+        Actor:         input.Actor,
+    })
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+    "errors"
+    "testing"
+
+    "estimate-backend/internal/domain"
+    "estimate-backend/internal/infrastructure/memory"
+)
+
+func TestInstantiateTemplateIntoTwoProjects(t *testing.T) {
+    f := newEstimateFixture(t)
+    uc := NewTemplateUseCase(memory.NewInMemoryTemplateRepository(), f.processes, f.factors, f.uc)
+    process := f.process(t, domain.ProcessImplementation)
+    factorID := f.factorID(t, "セキュリティ要件厳格")
+
+    template, err := uc.CreateTemplate(CreateTemplateInput{
+        Name: "Standard web app",
+        Tasks: []TemplateTaskInput{
+            {ProcessID: process.ID, ActivityID: process.Activities[1].ID, Name: "API", Complexity: 3, Scale: 1},
+            {ProcessID: process.ID, ActivityID: process.Activities[2].ID, Name: "Screens", Complexity: 2, Scale: 2},
+        },
+        GlobalFactors: []string{factorID},
+    })
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var estimates []*domain.Estimate
+    for _, projectID := range []string{"project-a", "project-b"} {
+        estimate, err := uc.InstantiateTemplate(InstantiateTemplateInput{
+            TemplateID:  template.ID,
+            ProjectID:   projectID,
+            ProjectName: projectID,
+        })
+        if err != nil {
+            t.Fatal(err)
+        }
+        estimates = append(estimates, estimate)
+    }
+
+    a, b := estimates[0], estimates[1]
+    if a.ID == b.ID || a.ProjectID != "project-a" || b.ProjectID != "project-b" {
+        t.Fatalf("estimates %s of %s and %s of %s, want distinct estimates of both projects", a.ID, a.ProjectID, b.ID, b.ProjectID)
+    }
+    for _, estimate := range estimates {
+        if estimate.Status != domain.EstimateStatusDraft {
+            t.Errorf("%s: status %s, want draft", estimate.ProjectID, estimate.Status)
+        }
+        if len(estimate.GlobalFactors) != 1 || estimate.GlobalFactors[0].ID != factorID {
+            t.Errorf("%s: global factors %v, want the template's factor", estimate.ProjectID, estimate.GlobalFactors)
+        }
+        if tasks := estimate.ProcessEstimates[0].Tasks; len(tasks) != 2 || tasks[0].Name != "API" || tasks[1].Name != "Screens" {
+            t.Errorf("%s: tasks %v, want the template's tasks", estimate.ProjectID, tasks)
+        }
+    }
+    if a.TotalHours == 0 || a.TotalHours != b.TotalHours {
+        t.Errorf("total hours %v and %v, want equal non-zero totals", a.TotalHours, b.TotalHours)
+    }
+    if a.ProcessEstimates[0].Tasks[0].ID == b.ProcessEstimates[0].Tasks[0].ID {
+        t.Error("both estimates share a task ID")
+    }
+}
+
+func TestCreateTemplateRejectsForeignActivity(t *testing.T) {
+    f := newEstimateFixture(t)
+    uc := NewTemplateUseCase(memory.NewInMemoryTemplateRepository(), f.processes, f.factors, f.uc)
+    implementation := f.process(t, domain.ProcessImplementation)
+    testProcess := f.process(t, domain.ProcessTesting)
+
+    _, err := uc.CreateTemplate(CreateTemplateInput{
+        Name:  "Mismatched",
+        Tasks: []TemplateTaskInput{{ProcessID: implementation.ID, ActivityID: testProcess.Activities[0].ID}},
+    })
+    if !errors.Is(err, ErrValidation) {
+        t.Errorf("got %v, want ErrValidation", err)
+    }
+}
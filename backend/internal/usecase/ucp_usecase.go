@@ -0,0 +1,112 @@
+package usecase
+
+import (
+    "fmt"
+
+    "estimate-backend/internal/domain"
+)
+
+// UCPUseCase handles the business logic for use case points estimation
+type UCPUseCase struct {
+    config *ConfigUseCase
+}
+
+// NewUCPUseCase creates a new UCPUseCase
+func NewUCPUseCase(config *ConfigUseCase) *UCPUseCase {
+    return &UCPUseCase{
+        config: config,
+    }
+}
+
+// UCPInput represents the use case points parameters of an estimate
+type UCPInput struct {
+    Actors               map[string]int     `json:"actors"`               // simple, average or complex -> count
+    UseCases             map[string]int     `json:"useCases"`             // simple, average or complex -> count
+    TechnicalFactors     map[string]float64 `json:"technicalFactors"`     // T1-T13 -> rating 0-5
+    EnvironmentalFactors map[string]float64 `json:"environmentalFactors"` // E1-E8 -> rating 0-5
+    HoursPerPoint        float64            `json:"hoursPerPoint,omitempty" validate:"min=0"`
+}
+
+// UCPResult represents a use case points estimate together with its calculation result
+type UCPResult struct {
+    UseCasePoints *domain.UseCasePoints     `json:"useCasePoints"`
+    Result        *domain.CalculationResult `json:"result"`
+}
+
+// Calculate calculates a use case points estimate without saving it
+func (uc *UCPUseCase) Calculate(input UCPInput) (*UCPResult, error) {
+    ucp, err := buildUseCasePoints(&input)
+    if err != nil {
+        return nil, err
+    }
+    ucp.Calculate()
+
+    return &UCPResult{
+        UseCasePoints: ucp,
+        Result:        ucp.ToCalculationResult(uc.config.GetConfig()),
+    }, nil
+}
+
+// buildUseCasePoints validates the input and creates the use case points estimate
+func buildUseCasePoints(input *UCPInput) (*domain.UseCasePoints, error) {
+    if input.HoursPerPoint < 0 {
+        return nil, newValidationError("hours per point must not be negative")
+    }
+
+    actors, err := ucpCounts("actor", input.Actors)
+    if err != nil {
+        return nil, err
+    }
+    useCases, err := ucpCounts("use case", input.UseCases)
+    if err != nil {
+        return nil, err
+    }
+    if len(useCases) == 0 {
+        return nil, newValidationError("at least one use case is required")
+    }
+
+    if err := validateUCPFactors("technical", input.TechnicalFactors, domain.UCPTechnicalWeights); err != nil {
+        return nil, err
+    }
+    if err := validateUCPFactors("environmental", input.EnvironmentalFactors, domain.UCPEnvironmentalWeights); err != nil {
+        return nil, err
+    }
+
+    return &domain.UseCasePoints{
+        Actors:               actors,
+        UseCases:             useCases,
+        TechnicalFactors:     input.TechnicalFactors,
+        EnvironmentalFactors: input.EnvironmentalFactors,
+        HoursPerPoint:        input.HoursPerPoint,
+    }, nil
+}
+
+// ucpCounts validates the counts per complexity, dropping zero counts
+func ucpCounts(kind string, counts map[string]int) (map[domain.UCPComplexity]int, error) {
+    result := make(map[domain.UCPComplexity]int)
+    for complexity, count := range counts {
+        if !domain.IsValidUCPComplexity(domain.UCPComplexity(complexity)) {
+            return nil, newValidationError(fmt.Sprintf("%s complexity must be simple, average or complex, got %q", kind, complexity))
+        }
+        if count < 0 {
+            return nil, newValidationError(fmt.Sprintf("number of %s %ss must not be negative", complexity, kind))
+        }
+        if count > 0 {
+            result[domain.UCPComplexity(complexity)] = count
+        }
+    }
+    return result, nil
+}
+
+// validateUCPFactors checks that every factor is known and rated between 0 and 5
+func validateUCPFactors(kind string, ratings map[string]float64, weights map[string]float64) error {
+    for factor, rating := range ratings {
+        if _, ok := weights[factor]; !ok {
+            return newValidationError(fmt.Sprintf("unknown %s factor %q", kind, factor))
+        }
+        if rating < 0 || rating > domain.MaxUCPFactorRating {
+            return newValidationError(fmt.Sprintf("rating of %s factor %s must be between 0 and 5", kind, factor))
+        }
+    }
+    return nil
+}